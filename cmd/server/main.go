@@ -16,6 +16,8 @@ import (
 	"github.com/bsrodrigue/appshare-backend/internal/handler"
 	"github.com/bsrodrigue/appshare-backend/internal/handler/middleware"
 	"github.com/bsrodrigue/appshare-backend/internal/logger"
+	"github.com/bsrodrigue/appshare-backend/internal/mailer"
+	"github.com/bsrodrigue/appshare-backend/internal/oidc"
 	"github.com/bsrodrigue/appshare-backend/internal/repository/postgres"
 	"github.com/bsrodrigue/appshare-backend/internal/service"
 	"github.com/bsrodrigue/appshare-backend/internal/storage"
@@ -95,10 +97,12 @@ func main() {
 
 	// JWT service
 	jwtConfig := auth.JWTConfig{
-		SecretKey:            cfg.JWTSecretKey,
-		AccessTokenDuration:  cfg.JWTAccessTokenDuration,
-		RefreshTokenDuration: cfg.JWTRefreshTokenDuration,
-		Issuer:               cfg.JWTIssuer,
+		SecretKey:             cfg.JWTSecretKey,
+		AccessTokenDuration:   cfg.JWTAccessTokenDuration,
+		RefreshTokenDuration:  cfg.JWTRefreshTokenDuration,
+		MFAChallengeDuration:  cfg.JWTMFAChallengeDuration,
+		ImpersonationDuration: cfg.JWTImpersonationDuration,
+		Issuer:                cfg.JWTIssuer,
 	}
 	jwtService := auth.NewJWTService(jwtConfig)
 	slog.Info("JWT configured",
@@ -123,28 +127,130 @@ func main() {
 		slog.Warn("Cloudflare R2 storage not configured (R2_ACCOUNT_ID missing)")
 	}
 
+	// ========== Mail ==========
+
+	var mailSvc mailer.Mailer
+	if cfg.SMTPHost != "" {
+		mailSvc = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+		slog.Info("SMTP mailer initialized", slog.String("host", cfg.SMTPHost))
+	} else {
+		mailSvc = mailer.NewLogMailer()
+		slog.Warn("SMTP not configured (SMTP_HOST missing), emails will only be logged")
+	}
+
+	// ========== Single Sign-On ==========
+
+	oidcProviders := make([]*oidc.Provider, 0, len(cfg.OIDCProviders))
+	for _, p := range cfg.OIDCProviders {
+		oidcProviders = append(oidcProviders, oidc.NewProvider(oidc.Config{
+			Name:           p.Name,
+			DisplayName:    p.DisplayName,
+			IssuerURL:      p.IssuerURL,
+			ClientID:       p.ClientID,
+			ClientSecret:   p.ClientSecret,
+			RedirectURL:    p.RedirectURL,
+			Scopes:         p.Scopes,
+			AllowedDomains: p.AllowedDomains,
+		}, nil))
+		slog.Info("OIDC provider configured", slog.String("provider", p.Name), slog.String("issuer", p.IssuerURL))
+	}
+
+	// ========== Password Policy ==========
+
+	passwordPolicy := &auth.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MaxLength:      cfg.PasswordMaxLength,
+		MinCharClasses: cfg.PasswordMinCharClasses,
+	}
+	if cfg.BreachedPasswordsFile != "" {
+		breached, err := auth.OpenBreachedPasswordFile(cfg.BreachedPasswordsFile)
+		if err != nil {
+			slog.Error("Failed to open breached passwords file", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		defer breached.Close()
+		passwordPolicy.Breached = breached
+		slog.Info("Breached password check enabled", slog.String("file", cfg.BreachedPasswordsFile))
+	}
+
 	// ========== Repositories ==========
 
 	userRepo := postgres.NewUserRepository(queries)
 	projectRepo := postgres.NewProjectRepository(queries)
+	orgRepo := postgres.NewOrganizationRepository(queries)
 	appRepo := postgres.NewApplicationRepository(queries)
 	releaseRepo := postgres.NewReleaseRepository(queries)
 	artifactRepo := postgres.NewArtifactRepository(queries)
+	passwordResetRepo := postgres.NewPasswordResetRepository(queries)
+	emailChangeRepo := postgres.NewEmailChangeRepository(queries)
+	accountRestoreRepo := postgres.NewAccountRestoreRepository(queries)
+	mfaRepo := postgres.NewMFARepository(queries)
+	identityRepo := postgres.NewIdentityRepository(queries)
+	oauthStateRepo := postgres.NewOAuthStateRepository(queries)
+	loginThrottleRepo := postgres.NewLoginThrottleRepository(queries)
+	adminAuditRepo := postgres.NewAdminAuditRepository(queries)
+	promotionRequestRepo := postgres.NewPromotionRequestRepository(queries)
+	channelRepo := postgres.NewChannelRepository(queries)
+	apiKeyRepo := postgres.NewAPIKeyRepository(queries)
+	releaseNoteRepo := postgres.NewReleaseNoteRepository(queries)
 
 	// ========== Services ==========
 
 	apkService := service.NewAPKService(storageSvc)
-	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userRepo, jwtService)
-	projectService := service.NewProjectService(projectRepo, userRepo, txManager)
-	appService := service.NewApplicationService(appRepo, projectRepo, releaseRepo, artifactRepo, apkService, txManager)
-	releaseService := service.NewReleaseService(apkService, releaseRepo, appRepo, projectRepo, artifactRepo, storageSvc, txManager)
+	userService := service.NewUserService(userRepo, passwordPolicy)
+	mfaService := service.NewMFAService(mfaRepo, userRepo, txManager, cfg.MFAIssuer)
+	loginProtectionService := service.NewLoginProtectionService(loginThrottleRepo, service.LoginProtectionConfig{
+		MaxFailures:      cfg.LoginMaxFailures,
+		MaxFailuresPerIP: cfg.LoginMaxFailuresPerIP,
+		BaseLockout:      cfg.LoginLockoutDuration,
+		MaxLockout:       cfg.LoginMaxLockoutDuration,
+		FailureWindow:    cfg.LoginFailureWindow,
+	})
+	authService := service.NewAuthService(userRepo, passwordResetRepo, emailChangeRepo, jwtService, mfaService, loginProtectionService, passwordPolicy, mailSvc, txManager, service.AuthConfig{
+		PasswordResetTokenTTL: cfg.PasswordResetTokenDuration,
+		PasswordResetURL:      cfg.PasswordResetURL,
+		EmailChangeTokenTTL:   cfg.EmailChangeTokenDuration,
+		EmailChangeURL:        cfg.EmailChangeURL,
+	})
+	orgService := service.NewOrganizationService(orgRepo, userRepo, txManager)
+	ssoService := service.NewSSOService(oidcProviders, oauthStateRepo, identityRepo, userRepo, authService, txManager)
+	avatarService := service.NewAvatarService(userRepo, storageSvc)
+	accountService := service.NewAccountService(
+		userRepo, projectRepo, orgRepo, appRepo, releaseRepo, identityRepo, mfaRepo, loginThrottleRepo,
+		passwordResetRepo, emailChangeRepo, accountRestoreRepo,
+		authService,
+		storageSvc, mailSvc, txManager,
+		service.AccountConfig{
+			DeletionGracePeriod: cfg.AccountDeletionGracePeriod,
+			ExportURLTTL:        cfg.DataExportURLDuration,
+			RestoreURL:          cfg.AccountRestoreURL,
+		},
+	)
+	adminService := service.NewAdminService(userRepo, adminAuditRepo, userService, accountService, orgService, authService, loginProtectionService, jwtService)
+	projectService := service.NewProjectService(projectRepo, userRepo, appRepo, releaseRepo, artifactRepo, orgService, txManager)
+	appService := service.NewApplicationService(appRepo, projectRepo, orgRepo, releaseRepo, artifactRepo, channelRepo, apkService, txManager)
+	releaseService := service.NewReleaseService(apkService, releaseRepo, appRepo, projectRepo, artifactRepo, channelRepo, storageSvc, txManager)
+	promotionService := service.NewPromotionService(releaseRepo, appRepo, projectRepo, artifactRepo, promotionRequestRepo, channelRepo, releaseNoteRepo, txManager)
+	channelService := service.NewChannelService(channelRepo, appRepo, projectRepo, releaseRepo, txManager)
 	artifactService := service.NewArtifactService(artifactRepo, releaseRepo, appRepo, projectRepo, storageSvc)
+	releaseNoteService := service.NewReleaseNoteService(releaseNoteRepo, releaseRepo, appRepo, projectRepo, channelRepo)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, appRepo, projectRepo)
+	updateService := service.NewUpdateService(apiKeyService, releaseService, releaseNoteService, releaseRepo, artifactRepo, channelRepo, storageSvc)
 	fileService := service.NewFileService(storageSvc)
+	trashService := service.NewTrashService(projectRepo, orgRepo, appRepo, releaseRepo, artifactRepo, storageSvc, txManager, service.TrashConfig{
+		Retention: cfg.TrashRetention,
+	})
+
+	// ========== Background Jobs ==========
+
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	go accountService.RunAnonymizer(jobsCtx, time.Hour)
+	go trashService.RunPurger(jobsCtx, time.Hour)
 
 	// ========== Auth Middleware ==========
 
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, userRepo)
 
 	// ========== Router ==========
 
@@ -159,6 +265,12 @@ func main() {
 			BearerFormat: "JWT",
 			Description:  "JWT access token. Get one from /auth/login or /auth/register",
 		},
+		"apiKey": {
+			Type:        "apiKey",
+			In:          "header",
+			Name:        "X-Api-Key",
+			Description: "Application API key, for client SDKs. Create one under /applications/{app_id}/api-keys",
+		},
 	}
 
 	api := humago.New(mux, humaConfig)
@@ -166,29 +278,53 @@ func main() {
 	// ========== Handlers ==========
 
 	systemHandler := handler.NewSystemHandler()
-	userHandler := handler.NewUserHandler(userService)
-	authHandler := handler.NewAuthHandler(authService)
+	userHandler := handler.NewUserHandler(userService, adminService)
+	authHandler := handler.NewAuthHandler(authService, userService, mfaService)
+	oidcHandler := handler.NewOIDCHandler(ssoService)
+	accountHandler := handler.NewAccountHandler(accountService)
+	avatarHandler := handler.NewAvatarHandler(avatarService)
+	adminHandler := handler.NewAdminHandler(adminService)
+	organizationHandler := handler.NewOrganizationHandler(orgService)
 	projectHandler := handler.NewProjectHandler(projectService)
 	applicationHandler := handler.NewApplicationHandler(appService)
 	releaseHandler := handler.NewReleaseHandler(releaseService)
+	releaseNoteHandler := handler.NewReleaseNoteHandler(releaseNoteService)
+	promotionHandler := handler.NewPromotionHandler(promotionService)
+	channelHandler := handler.NewChannelHandler(channelService)
 	artifactHandler := handler.NewArtifactHandler(artifactService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	updateHandler := handler.NewUpdateHandler(updateService)
 	fileHandler := handler.NewFileHandler(fileService)
+	trashHandler := handler.NewTrashHandler(trashService)
 
 	// Register all routes on the main API
 	systemHandler.Register(api)
 	authHandler.Register(api)
+	oidcHandler.Register(api)
+	accountHandler.Register(api)
+	updateHandler.Register(api)
 
 	// Sub-router for protected routes - This time we'll mount it correctly
 	protectedMux := http.NewServeMux()
 	protectedApi := humago.New(protectedMux, humaConfig)
 
 	authHandler.RegisterProtected(protectedApi)
+	accountHandler.RegisterProtected(protectedApi)
+	oidcHandler.RegisterProtected(protectedApi)
+	avatarHandler.Register(protectedApi)
 	userHandler.Register(protectedApi)
+	adminHandler.Register(protectedApi)
+	organizationHandler.Register(protectedApi)
 	projectHandler.Register(protectedApi)
 	applicationHandler.Register(protectedApi)
 	releaseHandler.Register(protectedApi)
+	releaseNoteHandler.Register(protectedApi)
+	promotionHandler.Register(protectedApi)
+	channelHandler.Register(protectedApi)
+	apiKeyHandler.Register(protectedApi)
 	artifactHandler.Register(protectedApi)
 	fileHandler.Register(protectedApi)
+	trashHandler.Register(protectedApi)
 
 	// The fix: use a catch-all route for protected routes to ensure path stripping/matching works correctly
 	mux.Handle("/", authMiddleware.RequireAuth(protectedMux))
@@ -196,7 +332,13 @@ func main() {
 	// ========== Apply Global Middleware ==========
 
 	loggingMiddleware := middleware.NewLoggingMiddleware(middleware.DefaultLoggingConfig())
+	clientIPMiddleware, err := middleware.NewClientIPMiddleware(cfg.TrustedProxies)
+	if err != nil {
+		slog.Error("Invalid trusted proxies", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 	var rootHandler http.Handler = mux
+	rootHandler = clientIPMiddleware.Handler(rootHandler)
 	rootHandler = loggingMiddleware.Handler(rootHandler)
 
 	// ========== Server ==========
@@ -216,6 +358,7 @@ func main() {
 		sig := <-sigChan
 
 		slog.Info("Shutting down server", slog.String("signal", sig.String()))
+		stopJobs()
 
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
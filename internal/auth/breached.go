@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// BreachedPasswordFile looks passwords up in a local copy of a breach corpus,
+// such as the Pwned Passwords SHA-1 list. The file holds one upper-case
+// hex SHA-1 hash per line, optionally followed by ":count", sorted by hash.
+//
+// Lookups binary-search the file on disk, so multi-gigabyte lists work
+// without being loaded into memory, and passwords never leave the server.
+type BreachedPasswordFile struct {
+	file *os.File
+	size int64
+}
+
+// OpenBreachedPasswordFile opens a sorted hash list for lookups.
+func OpenBreachedPasswordFile(path string) (*BreachedPasswordFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &BreachedPasswordFile{file: f, size: info.Size()}, nil
+}
+
+// Close releases the underlying file.
+func (b *BreachedPasswordFile) Close() error {
+	return b.file.Close()
+}
+
+// IsBreached reports whether the SHA-1 hash of the password is in the list.
+func (b *BreachedPasswordFile) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	target := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	// Invariant: if present, the target line starts within [lo, hi)
+	lo, hi := int64(0), b.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, line, err := b.lineFrom(mid)
+		if err != nil {
+			return false, err
+		}
+		if start >= hi {
+			// No line starts in [mid, hi)
+			hi = mid
+			continue
+		}
+
+		switch cmp := strings.Compare(lineHash(line), target); {
+		case cmp == 0:
+			return true, nil
+		case cmp < 0:
+			lo = start + int64(len(line))
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
+
+// lineFrom returns the first line starting at or after pos, including its newline.
+func (b *BreachedPasswordFile) lineFrom(pos int64) (int64, string, error) {
+	start := pos
+	if pos > 0 {
+		// Include the previous byte to know whether pos begins a line
+		start = pos - 1
+	}
+	r := bufio.NewReaderSize(io.NewSectionReader(b.file, start, b.size-start), 128)
+
+	if pos > 0 {
+		skipped, err := r.ReadString('\n')
+		if err == io.EOF {
+			return b.size, "", nil
+		}
+		if err != nil {
+			return 0, "", fmt.Errorf("read breached password list: %w", err)
+		}
+		start += int64(len(skipped))
+	}
+
+	line, err := r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, "", fmt.Errorf("read breached password list: %w", err)
+	}
+	if line == "" {
+		return b.size, "", nil
+	}
+	return start, line, nil
+}
+
+// lineHash extracts the upper-cased hash from a "HASH[:count]" line.
+func lineHash(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
@@ -17,8 +17,17 @@ const (
 
 // AuthenticatedUser represents the user data stored in context after authentication.
 type AuthenticatedUser struct {
-	ID    uuid.UUID
-	Email string
+	ID      uuid.UUID
+	Email   string
+	IsAdmin bool
+
+	// ImpersonatorID is the administrator acting as this user, nil for regular sessions.
+	ImpersonatorID *uuid.UUID
+}
+
+// IsImpersonated reports whether an administrator is acting as this user.
+func (u *AuthenticatedUser) IsImpersonated() bool {
+	return u.ImpersonatorID != nil
 }
 
 // UserFromContext extracts the authenticated user from context.
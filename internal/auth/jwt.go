@@ -9,12 +9,25 @@ import (
 	"github.com/google/uuid"
 )
 
-// TokenType distinguishes between access and refresh tokens.
+// TokenType distinguishes between access, refresh and MFA challenge tokens.
 type TokenType string
 
 const (
 	AccessToken  TokenType = "access"
 	RefreshToken TokenType = "refresh"
+
+	// MFAChallengeToken proves the password step of a login succeeded.
+	// It can only be exchanged for a TokenPair at /auth/mfa/verify.
+	MFAChallengeToken TokenType = "mfa_challenge"
+
+	// IdentityLinkToken carries an identity provider sign-in whose email matches an
+	// account that has not verified it. It can only be exchanged at /auth/oidc/link,
+	// together with the account's password, to link the identity.
+	IdentityLinkToken TokenType = "identity_link"
+
+	// ReauthenticationToken proves a user just signed in again at an identity provider
+	// linked to their account, standing in for the password they may not have.
+	ReauthenticationToken TokenType = "reauthentication"
 )
 
 // Claims represents the JWT claims for our tokens.
@@ -23,6 +36,19 @@ type Claims struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Email     string    `json:"email"`
 	TokenType TokenType `json:"token_type"`
+
+	// TokenVersion must match the user's current token version for refresh to succeed.
+	TokenVersion int32 `json:"token_version"`
+
+	// IsAdmin grants access to system administration endpoints.
+	IsAdmin bool `json:"is_admin,omitempty"`
+
+	// ImpersonatorID is set on access tokens an administrator obtained to act as this user.
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
+
+	// Provider and ProviderSubject identify the external identity of an identity link token.
+	Provider        string `json:"provider,omitempty"`
+	ProviderSubject string `json:"provider_subject,omitempty"`
 }
 
 // TokenPair contains both access and refresh tokens.
@@ -39,16 +65,22 @@ type JWTConfig struct {
 	SecretKey            string
 	AccessTokenDuration  time.Duration
 	RefreshTokenDuration time.Duration
-	Issuer               string
+	MFAChallengeDuration time.Duration
+
+	// ImpersonationDuration bounds support sessions; they cannot be refreshed.
+	ImpersonationDuration time.Duration
+	Issuer                string
 }
 
 // DefaultJWTConfig returns sensible defaults.
 func DefaultJWTConfig(secretKey string) JWTConfig {
 	return JWTConfig{
-		SecretKey:            secretKey,
-		AccessTokenDuration:  15 * time.Minute,   // Short-lived for security
-		RefreshTokenDuration: 7 * 24 * time.Hour, // 7 days
-		Issuer:               "appshare",
+		SecretKey:             secretKey,
+		AccessTokenDuration:   15 * time.Minute,   // Short-lived for security
+		RefreshTokenDuration:  7 * 24 * time.Hour, // 7 days
+		MFAChallengeDuration:  5 * time.Minute,
+		ImpersonationDuration: 30 * time.Minute,
+		Issuer:                "appshare",
 	}
 }
 
@@ -90,9 +122,12 @@ func (s *JWTService) GenerateTokenPair(user *domain.User) (*TokenPair, error) {
 // generateToken creates a single JWT token.
 func (s *JWTService) generateToken(user *domain.User, tokenType TokenType, now time.Time) (string, time.Time, error) {
 	var duration time.Duration
-	if tokenType == AccessToken {
+	switch tokenType {
+	case AccessToken:
 		duration = s.config.AccessTokenDuration
-	} else {
+	case MFAChallengeToken, ReauthenticationToken:
+		duration = s.config.MFAChallengeDuration
+	default:
 		duration = s.config.RefreshTokenDuration
 	}
 
@@ -106,11 +141,18 @@ func (s *JWTService) generateToken(user *domain.User, tokenType TokenType, now t
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			ID:        uuid.NewString(), // Unique token ID for potential revocation
 		},
-		UserID:    user.ID,
-		Email:     user.Email,
-		TokenType: tokenType,
+		UserID:       user.ID,
+		Email:        user.Email,
+		TokenType:    tokenType,
+		TokenVersion: user.TokenVersion,
+		IsAdmin:      user.IsAdmin,
 	}
 
+	return s.sign(claims, expiresAt)
+}
+
+// sign signs the claims with the configured secret.
+func (s *JWTService) sign(claims Claims, expiresAt time.Time) (string, time.Time, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err := token.SignedString([]byte(s.config.SecretKey))
 	if err != nil {
@@ -120,6 +162,109 @@ func (s *JWTService) generateToken(user *domain.User, tokenType TokenType, now t
 	return signedToken, expiresAt, nil
 }
 
+// GenerateMFAChallengeToken creates a short-lived token for the second login step.
+func (s *JWTService) GenerateMFAChallengeToken(user *domain.User) (string, time.Time, error) {
+	return s.generateToken(user, MFAChallengeToken, time.Now())
+}
+
+// GenerateReauthenticationToken creates a short-lived token confirming a fresh
+// single sign-on, accepted in place of the password by sensitive operations.
+func (s *JWTService) GenerateReauthenticationToken(user *domain.User) (string, time.Time, error) {
+	return s.generateToken(user, ReauthenticationToken, time.Now())
+}
+
+// GenerateImpersonationToken creates an access token to act as a user on behalf of an administrator.
+// No refresh token is issued: the session ends when the token expires.
+// The target's admin privileges are never carried over.
+func (s *JWTService) GenerateImpersonationToken(user *domain.User, impersonatorID uuid.UUID) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.config.ImpersonationDuration)
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			Issuer:    s.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+		},
+		UserID:         user.ID,
+		Email:          user.Email,
+		TokenType:      AccessToken,
+		TokenVersion:   user.TokenVersion,
+		ImpersonatorID: &impersonatorID,
+	}
+
+	return s.sign(claims, expiresAt)
+}
+
+// GenerateIdentityLinkToken creates a short-lived token to link an external identity
+// to a user once they confirm their password. It lasts as long as an MFA challenge.
+func (s *JWTService) GenerateIdentityLinkToken(user *domain.User, provider, subject string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.config.MFAChallengeDuration)
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			Issuer:    s.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+		},
+		UserID:          user.ID,
+		Email:           user.Email,
+		TokenType:       IdentityLinkToken,
+		TokenVersion:    user.TokenVersion,
+		Provider:        provider,
+		ProviderSubject: subject,
+	}
+
+	return s.sign(claims, expiresAt)
+}
+
+// ValidateIdentityLinkToken validates an identity link token and returns the claims.
+func (s *JWTService) ValidateIdentityLinkToken(tokenString string) (*Claims, error) {
+	claims, err := s.validateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != IdentityLinkToken {
+		return nil, domain.NewAppError(domain.CodeTokenInvalid, "invalid token type: expected identity link token")
+	}
+
+	return claims, nil
+}
+
+// ValidateMFAChallengeToken validates an MFA challenge token and returns the claims.
+func (s *JWTService) ValidateMFAChallengeToken(tokenString string) (*Claims, error) {
+	claims, err := s.validateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != MFAChallengeToken {
+		return nil, domain.NewAppError(domain.CodeTokenInvalid, "invalid token type: expected MFA challenge token")
+	}
+
+	return claims, nil
+}
+
+// ValidateReauthenticationToken validates a reauthentication token and returns the claims.
+func (s *JWTService) ValidateReauthenticationToken(tokenString string) (*Claims, error) {
+	claims, err := s.validateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != ReauthenticationToken {
+		return nil, domain.NewAppError(domain.CodeTokenInvalid, "invalid token type: expected reauthentication token")
+	}
+
+	return claims, nil
+}
+
 // ValidateAccessToken validates an access token and returns the claims.
 func (s *JWTService) ValidateAccessToken(tokenString string) (*Claims, error) {
 	claims, err := s.validateToken(tokenString)
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// opaqueTokenBytes is the entropy of opaque tokens (256 bits).
+const opaqueTokenBytes = 32
+
+// NewOpaqueToken generates a random URL-safe token and its SHA-256 hash.
+// The raw token is handed to the user; only the hash should be persisted.
+func NewOpaqueToken() (raw string, hash string, err error) {
+	b := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	raw = base64.RawURLEncoding.EncodeToString(b)
+	return raw, HashOpaqueToken(raw), nil
+}
+
+// HashOpaqueToken returns the hex-encoded SHA-256 hash of a raw opaque token.
+func HashOpaqueToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bcryptMaxBytes is the input length beyond which bcrypt silently ignores bytes.
+const bcryptMaxBytes = 72
+
+// BreachChecker reports whether a password appears in a corpus of breached passwords.
+type BreachChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy is the single source of truth for acceptable passwords.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+
+	// MaxLength is the maximum number of bytes, capped at bcrypt's 72-byte limit.
+	MaxLength int
+
+	// MinCharClasses is how many of lower-case, upper-case, digits and symbols are required.
+	MinCharClasses int
+
+	// Breached is optional; when set, known breached passwords are rejected.
+	Breached BreachChecker
+}
+
+// DefaultPasswordPolicy returns the policy used when nothing is configured.
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:      8,
+		MaxLength:      bcryptMaxBytes,
+		MinCharClasses: 2,
+	}
+}
+
+// ErrPasswordBreached is returned for passwords found in the breach corpus.
+var ErrPasswordBreached = errors.New("password has appeared in a data breach, choose another one")
+
+// Validate checks a password against the policy. Personal values such as the
+// username and email must not appear in the password.
+// The returned error message is suitable for end users.
+func (p *PasswordPolicy) Validate(password string, personal ...string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+
+	maxLength := p.MaxLength
+	if maxLength <= 0 || maxLength > bcryptMaxBytes {
+		maxLength = bcryptMaxBytes
+	}
+	if len(password) > maxLength {
+		return fmt.Errorf("password must be at most %d bytes", maxLength)
+	}
+
+	if classes := charClasses(password); classes < p.MinCharClasses {
+		return fmt.Errorf("password must mix at least %d of: lower-case letters, upper-case letters, digits, symbols", p.MinCharClasses)
+	}
+
+	lower := strings.ToLower(password)
+	for _, value := range personalTokens(personal) {
+		if strings.Contains(lower, value) {
+			return errors.New("password must not contain your username or email")
+		}
+	}
+
+	if p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			// Fail open: an unreadable corpus must not block every password change
+			slog.Warn("breached password check failed", slog.String("error", err.Error()))
+		} else if breached {
+			return ErrPasswordBreached
+		}
+	}
+
+	return nil
+}
+
+// charClasses counts the character classes used in s.
+func charClasses(s string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
+
+// personalTokens lower-cases personal values and splits emails so that the
+// local part is checked on its own. Very short values are ignored.
+func personalTokens(values []string) []string {
+	var tokens []string
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if local, _, ok := strings.Cut(v, "@"); ok {
+			tokens = append(tokens, v)
+			v = local
+		}
+		if len(v) >= 3 {
+			tokens = append(tokens, v)
+		}
+	}
+	return tokens
+}
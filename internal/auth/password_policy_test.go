@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	tests := []struct {
+		name     string
+		password string
+		personal []string
+		wantErr  bool
+	}{
+		{"valid", "correct-horse-7", nil, false},
+		{"too short", "ab1!", nil, true},
+		{"single class", "abcdefghij", nil, true},
+		{"over bcrypt limit", strings.Repeat("a1", 37), nil, true},
+		{"multi-byte characters count once", "pässwört1", nil, false},
+		{"contains username", "xJohnDoe42x", []string{"johndoe42"}, true},
+		{"contains email local part", "Alice.Smith#2024", []string{"alice.smith@example.com"}, true},
+		{"short personal values ignored", "zz-Secret-99", []string{"zz"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, tt.personal...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// writeHashList writes the SHA-1 hashes of the passwords as a sorted "HASH:count" file.
+func writeHashList(t *testing.T, passwords ...string) string {
+	t.Helper()
+	var lines []string
+	for i, p := range passwords {
+		sum := sha1.Sum([]byte(p))
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(sum[:]))+":"+strings.Repeat("9", i+1))
+	}
+	sort.Strings(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+	return path
+}
+
+func TestBreachedPasswordFile_IsBreached(t *testing.T) {
+	breached := []string{"password", "123456", "qwerty", "letmein", "Tr0ub4dor&3", "iloveyou", "dragon"}
+	list, err := OpenBreachedPasswordFile(writeHashList(t, breached...))
+	require.NoError(t, err)
+	defer list.Close()
+
+	for _, p := range breached {
+		found, err := list.IsBreached(p)
+		require.NoError(t, err)
+		assert.True(t, found, p)
+	}
+
+	for _, p := range []string{"correct-horse-7", "Password", "", "zzzzzz"} {
+		found, err := list.IsBreached(p)
+		require.NoError(t, err)
+		assert.False(t, found, p)
+	}
+}
+
+func TestBreachedPasswordFile_Empty(t *testing.T) {
+	list, err := OpenBreachedPasswordFile(writeHashList(t))
+	require.NoError(t, err)
+	defer list.Close()
+
+	found, err := list.IsBreached("password")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestPasswordPolicy_RejectsBreached(t *testing.T) {
+	list, err := OpenBreachedPasswordFile(writeHashList(t, "Summer2024!"))
+	require.NoError(t, err)
+	defer list.Close()
+
+	policy := DefaultPasswordPolicy()
+	policy.Breached = list
+
+	assert.ErrorIs(t, policy.Validate("Summer2024!"), ErrPasswordBreached)
+	assert.NoError(t, policy.Validate("Autumn2024!"))
+}
@@ -0,0 +1,133 @@
+// Package avatar validates and resizes user profile pictures and generates
+// initials placeholders for users without one.
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	// Registered decoders for accepted upload formats
+	_ "image/gif"
+	_ "image/jpeg"
+)
+
+const (
+	// MaxUploadBytes is the largest accepted upload.
+	MaxUploadBytes = 5 << 20
+
+	// MaxDimension bounds the width and height of an upload, checked before
+	// decoding so that small files cannot expand into huge bitmaps.
+	MaxDimension = 4096
+
+	// MinDimension is the smallest accepted width and height.
+	MinDimension = 64
+)
+
+// Sizes are the square renditions produced for every avatar, largest first.
+var Sizes = []int{256, 128, 64}
+
+var (
+	ErrUnsupportedFormat = errors.New("image must be a PNG, JPEG or GIF")
+	ErrTooLarge          = fmt.Errorf("image must be at most %d bytes and %dx%d pixels", MaxUploadBytes, MaxDimension, MaxDimension)
+	ErrTooSmall          = fmt.Errorf("image must be at least %dx%d pixels", MinDimension, MinDimension)
+)
+
+// Process validates an uploaded image, crops it to a centred square and
+// returns a PNG rendition for each of Sizes.
+func Process(data []byte) (map[int][]byte, error) {
+	if len(data) > MaxUploadBytes {
+		return nil, ErrTooLarge
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	switch format {
+	case "png", "jpeg", "gif":
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension {
+		return nil, ErrTooLarge
+	}
+	if cfg.Width < MinDimension || cfg.Height < MinDimension {
+		return nil, ErrTooSmall
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	square := cropSquare(src)
+
+	out := make(map[int][]byte, len(Sizes))
+	for _, size := range Sizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, resize(square, size)); err != nil {
+			return nil, err
+		}
+		out[size] = buf.Bytes()
+	}
+	return out, nil
+}
+
+// cropSquare returns the largest centred square of img as an RGBA image.
+func cropSquare(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), img, image.Pt(x0, y0), draw.Src)
+	return dst
+}
+
+// resize scales a square image to size×size by averaging the source pixels
+// covered by each destination pixel (nearest neighbour when enlarging).
+func resize(src *image.RGBA, size int) *image.RGBA {
+	n := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := span(dy, size, n)
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := span(dx, size, n)
+
+			var r, g, b, a, count uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					i := src.PixOffset(sx, sy)
+					r += uint32(src.Pix[i])
+					g += uint32(src.Pix[i+1])
+					b += uint32(src.Pix[i+2])
+					a += uint32(src.Pix[i+3])
+					count++
+				}
+			}
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: uint8(r / count),
+				G: uint8(g / count),
+				B: uint8(b / count),
+				A: uint8(a / count),
+			})
+		}
+	}
+	return dst
+}
+
+// span returns the source pixel range [lo, hi) covered by destination pixel d.
+func span(d, dstSize, srcSize int) (int, int) {
+	lo := d * srcSize / dstSize
+	hi := ((d+1)*srcSize + dstSize - 1) / dstSize
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, min(hi, srcSize)
+}
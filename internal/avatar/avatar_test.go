@@ -0,0 +1,110 @@
+package avatar
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int, fill func(x, y int) color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fill(x, y))
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("produces square renditions of every size", func(t *testing.T) {
+		// Red left half, blue right half; the centred square crop keeps both
+		data := encodePNG(t, 400, 200, func(x, y int) color.Color {
+			if x < 200 {
+				return color.RGBA{R: 255, A: 255}
+			}
+			return color.RGBA{B: 255, A: 255}
+		})
+
+		out, err := Process(data)
+		require.NoError(t, err)
+		require.Len(t, out, len(Sizes))
+
+		for _, size := range Sizes {
+			img, err := png.Decode(bytes.NewReader(out[size]))
+			require.NoError(t, err)
+			assert.Equal(t, size, img.Bounds().Dx())
+			assert.Equal(t, size, img.Bounds().Dy())
+
+			r, _, b, _ := img.At(0, size/2).RGBA()
+			assert.Equal(t, uint32(0xffff), r, "left edge is red")
+			assert.Zero(t, b)
+			r, _, b, _ = img.At(size-1, size/2).RGBA()
+			assert.Zero(t, r)
+			assert.Equal(t, uint32(0xffff), b, "right edge is blue")
+		}
+	})
+
+	t.Run("enlarges small images", func(t *testing.T) {
+		data := encodePNG(t, MinDimension, MinDimension, func(x, y int) color.Color {
+			return color.RGBA{G: 255, A: 255}
+		})
+
+		out, err := Process(data)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out[256]))
+		require.NoError(t, err)
+		assert.Equal(t, 256, img.Bounds().Dx())
+	})
+
+	t.Run("rejects non-images", func(t *testing.T) {
+		_, err := Process([]byte("not an image"))
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("rejects tiny images", func(t *testing.T) {
+		data := encodePNG(t, 10, 10, func(x, y int) color.Color { return color.White })
+		_, err := Process(data)
+		assert.ErrorIs(t, err, ErrTooSmall)
+	})
+
+	t.Run("rejects oversized dimensions before decoding", func(t *testing.T) {
+		data := encodePNG(t, MaxDimension+1, 1, func(x, y int) color.Color { return color.White })
+		_, err := Process(data)
+		assert.ErrorIs(t, err, ErrTooLarge)
+	})
+}
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		first, last, want string
+	}{
+		{"Jane", "Doe", "JD"},
+		{"élodie", "martin", "ÉM"},
+		{"  Ann", "", "A"},
+		{"", "", "?"},
+		{"'Bob", "-Lee", "BL"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Initials(tt.first, tt.last), "%q %q", tt.first, tt.last)
+	}
+}
+
+func TestInitialsSVG(t *testing.T) {
+	svg := string(InitialsSVG("<Jane>", "Doe", "seed"))
+	assert.Contains(t, svg, ">JD</text>")
+	assert.NotContains(t, svg, "<Jane>")
+
+	// The colour is stable per seed
+	assert.Equal(t, svg, string(InitialsSVG("<Jane>", "Doe", "seed")))
+	assert.True(t, strings.HasPrefix(InitialsDataURI("Jane", "Doe", "seed"), "data:image/svg+xml;base64,"))
+}
@@ -0,0 +1,55 @@
+package avatar
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// palette holds the background colours of initials placeholders.
+var palette = []string{
+	"#1abc9c", "#2ecc71", "#3498db", "#9b59b6", "#34495e",
+	"#16a085", "#27ae60", "#2980b9", "#8e44ad", "#e67e22",
+	"#e74c3c", "#d35400", "#c0392b", "#7f8c8d",
+}
+
+// Initials returns up to two upper-case initials for a name, or "?" when none can be derived.
+func Initials(firstName, lastName string) string {
+	var b strings.Builder
+	for _, part := range []string{firstName, lastName} {
+		for _, r := range strings.TrimSpace(part) {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				b.WriteRune(unicode.ToUpper(r))
+				break
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "?"
+	}
+	return b.String()
+}
+
+// InitialsSVG renders a square SVG placeholder with the user's initials.
+// The background colour is derived from seed so that it is stable per user.
+func InitialsSVG(firstName, lastName, seed string) []byte {
+	h := fnv.New32a()
+	h.Write([]byte(seed))
+	bg := palette[h.Sum32()%uint32(len(palette))]
+
+	return []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="256" height="256" viewBox="0 0 256 256">`+
+			`<rect width="256" height="256" fill="%s"/>`+
+			`<text x="50%%" y="50%%" dy=".35em" text-anchor="middle" fill="#ffffff" font-family="Helvetica, Arial, sans-serif" font-size="104">%s</text>`+
+			`</svg>`,
+		bg, html.EscapeString(Initials(firstName, lastName)),
+	))
+}
+
+// InitialsDataURI returns InitialsSVG as a data URI usable directly as an image source.
+func InitialsDataURI(firstName, lastName, seed string) string {
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(InitialsSVG(firstName, lastName, seed))
+}
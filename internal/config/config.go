@@ -4,13 +4,15 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
 	// Server
-	Port        string
-	Environment string // development, staging, production
+	Port           string
+	Environment    string   // development, staging, production
+	TrustedProxies []string // Addresses or CIDRs of the reverse proxies whose X-Forwarded-For is honoured
 
 	// Logging
 	LogLevel  string // debug, info, warn, error
@@ -20,10 +22,52 @@ type Config struct {
 	DatabaseURL string
 
 	// JWT
-	JWTSecretKey            string
-	JWTAccessTokenDuration  time.Duration
-	JWTRefreshTokenDuration time.Duration
-	JWTIssuer               string
+	JWTSecretKey             string
+	JWTAccessTokenDuration   time.Duration
+	JWTRefreshTokenDuration  time.Duration
+	JWTMFAChallengeDuration  time.Duration
+	JWTImpersonationDuration time.Duration
+	JWTIssuer                string
+
+	// Login brute-force protection
+	LoginMaxFailures        int // Per identifier, before lockout
+	LoginMaxFailuresPerIP   int // Per client IP, before lockout
+	LoginLockoutDuration    time.Duration
+	LoginMaxLockoutDuration time.Duration
+	LoginFailureWindow      time.Duration // Failures older than this are forgotten
+
+	// Password policy
+	PasswordMinLength      int
+	PasswordMaxLength      int // Bytes, bcrypt ignores anything past 72
+	PasswordMinCharClasses int
+	BreachedPasswordsFile  string // Sorted SHA-1 hash list, check disabled when empty
+
+	// Two-factor authentication
+	MFAIssuer string // Issuer label shown in authenticator apps
+
+	// Password reset
+	PasswordResetTokenDuration time.Duration
+	PasswordResetURL           string // Frontend page the emailed token is appended to
+	EmailChangeTokenDuration   time.Duration
+	EmailChangeURL             string // Frontend page the email change token is appended to
+
+	// Account deletion
+	AccountDeletionGracePeriod time.Duration // Deleted accounts can be restored until then
+	AccountRestoreURL          string        // Frontend page the restore token is appended to
+	DataExportURLDuration      time.Duration // Validity of data export download links
+
+	// Trash
+	TrashRetention time.Duration // Deleted applications, releases and artifacts are purged after this
+
+	// Mail (falls back to logging when SMTP_HOST is empty)
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// Single sign-on (OpenID Connect)
+	OIDCProviders []OIDCProviderConfig
 
 	// R2 Storage
 	R2AccountID       string
@@ -39,6 +83,7 @@ func Load() (*Config, error) {
 	// Server config
 	cfg.Port = getEnv("PORT", "8080")
 	cfg.Environment = getEnv("ENVIRONMENT", "development")
+	cfg.TrustedProxies = splitList(os.Getenv("TRUSTED_PROXIES"))
 
 	// Logging config - defaults based on environment
 	if cfg.Environment == "production" {
@@ -75,8 +120,54 @@ func Load() (*Config, error) {
 	// Token durations (with sensible defaults)
 	cfg.JWTAccessTokenDuration = getEnvAsDuration("JWT_ACCESS_TOKEN_MINUTES", 15*time.Minute)
 	cfg.JWTRefreshTokenDuration = getEnvAsDuration("JWT_REFRESH_TOKEN_DAYS", 7*24*time.Hour)
+	cfg.JWTMFAChallengeDuration = getEnvAsDuration("JWT_MFA_CHALLENGE_MINUTES", 5*time.Minute)
+	cfg.JWTImpersonationDuration = getEnvAsDuration("JWT_IMPERSONATION_MINUTES", 30*time.Minute)
 	cfg.JWTIssuer = getEnv("JWT_ISSUER", "appshare")
 
+	// Login protection config
+	cfg.LoginMaxFailures = getEnvAsInt("LOGIN_MAX_FAILURES", 5)
+	cfg.LoginMaxFailuresPerIP = getEnvAsInt("LOGIN_MAX_FAILURES_PER_IP", 20)
+	cfg.LoginLockoutDuration = getEnvAsDuration("LOGIN_LOCKOUT_MINUTES", time.Minute)
+	cfg.LoginMaxLockoutDuration = getEnvAsDuration("LOGIN_MAX_LOCKOUT_MINUTES", time.Hour)
+	cfg.LoginFailureWindow = getEnvAsDuration("LOGIN_FAILURE_WINDOW_HOURS", 24*time.Hour)
+
+	// Password policy config
+	cfg.PasswordMinLength = getEnvAsInt("PASSWORD_MIN_LENGTH", 8)
+	cfg.PasswordMaxLength = getEnvAsInt("PASSWORD_MAX_LENGTH", 72)
+	cfg.PasswordMinCharClasses = getEnvAsInt("PASSWORD_MIN_CHAR_CLASSES", 2)
+	cfg.BreachedPasswordsFile = os.Getenv("BREACHED_PASSWORDS_FILE")
+
+	// Two-factor authentication config
+	cfg.MFAIssuer = getEnv("MFA_ISSUER", "AppShare")
+
+	// Password reset config
+	cfg.PasswordResetTokenDuration = getEnvAsDuration("PASSWORD_RESET_TOKEN_MINUTES", 30*time.Minute)
+	cfg.PasswordResetURL = getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	cfg.EmailChangeTokenDuration = getEnvAsDuration("EMAIL_CHANGE_TOKEN_HOURS", 24*time.Hour)
+	cfg.EmailChangeURL = getEnv("EMAIL_CHANGE_URL", "http://localhost:3000/confirm-email")
+
+	// Account deletion
+	cfg.AccountDeletionGracePeriod = getEnvAsDuration("ACCOUNT_DELETION_GRACE_DAYS", 30*24*time.Hour)
+	cfg.AccountRestoreURL = getEnv("ACCOUNT_RESTORE_URL", "http://localhost:3000/restore-account")
+	cfg.DataExportURLDuration = getEnvAsDuration("DATA_EXPORT_URL_HOURS", 24*time.Hour)
+
+	// Trash config
+	cfg.TrashRetention = getEnvAsDuration("TRASH_RETENTION_DAYS", 30*24*time.Hour)
+
+	// Mail config
+	cfg.MailFrom = getEnv("MAIL_FROM", "AppShare <no-reply@appshare.local>")
+	cfg.SMTPHost = os.Getenv("SMTP_HOST")
+	cfg.SMTPPort = getEnv("SMTP_PORT", "587")
+	cfg.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	cfg.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+
+	// Single sign-on config
+	providers, err := loadOIDCProviders()
+	if err != nil {
+		return nil, err
+	}
+	cfg.OIDCProviders = providers
+
 	// R2 config
 	cfg.R2AccountID = os.Getenv("R2_ACCOUNT_ID")
 	cfg.R2AccessKeyID = os.Getenv("R2_ACCESS_KEY_ID")
@@ -103,6 +194,55 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// OIDCProviderConfig holds the settings of one OpenID Connect identity provider.
+type OIDCProviderConfig struct {
+	Name           string // Used in URLs, e.g. /auth/oidc/{name}/authorize
+	DisplayName    string
+	IssuerURL      string
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string
+	Scopes         []string
+	AllowedDomains []string // Email domains allowed to auto-provision accounts
+}
+
+// loadOIDCProviders reads the providers listed in OIDC_PROVIDERS.
+// Each provider NAME is configured through OIDC_<NAME>_* variables.
+func loadOIDCProviders() ([]OIDCProviderConfig, error) {
+	var providers []OIDCProviderConfig
+	for _, name := range splitList(os.Getenv("OIDC_PROVIDERS")) {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		p := OIDCProviderConfig{
+			Name:           name,
+			DisplayName:    getEnv(prefix+"DISPLAY_NAME", name),
+			IssuerURL:      os.Getenv(prefix + "ISSUER"),
+			ClientID:       os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret:   os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:    os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:         strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+			AllowedDomains: splitList(os.Getenv(prefix + "ALLOWED_DOMAINS")),
+		}
+		if p.IssuerURL == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return nil, fmt.Errorf("%sISSUER, %sCLIENT_ID and %sREDIRECT_URL are required", prefix, prefix, prefix)
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // buildDatabaseURL constructs a PostgreSQL connection URL from individual env vars.
 func buildDatabaseURL() (string, error) {
 	user := os.Getenv("POSTGRES_USER")
@@ -147,6 +287,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
@@ -11,6 +11,71 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveApplicationRelease = `-- name: ArchiveApplicationRelease :one
+UPDATE application_releases SET
+    status = 'archived',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND status IN ('published', 'withdrawn')
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
+`
+
+func (q *Queries) ArchiveApplicationRelease(ctx context.Context, id pgtype.UUID) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, archiveApplicationRelease, id)
+	var i ApplicationRelease
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VersionCode,
+		&i.VersionName,
+		&i.ReleaseNote,
+		&i.Environment,
+		&i.ApplicationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
+	)
+	return i, err
+}
+
+const checkMandatoryReleaseBetween = `-- name: CheckMandatoryReleaseBetween :one
+SELECT EXISTS (
+    SELECT 1 FROM application_releases
+    WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+        AND status = 'published' AND mandatory
+        AND version_code > $3::int
+        AND version_code <= $4::int
+)
+`
+
+type CheckMandatoryReleaseBetweenParams struct {
+	ApplicationID    pgtype.UUID `json:"application_id"`
+	Environment      string      `json:"environment"`
+	AfterVersionCode int32       `json:"after_version_code"`
+	UpToVersionCode  int32       `json:"up_to_version_code"`
+}
+
+// Whether a client updating past after_version_code up to up_to_version_code
+// skips a published mandatory release.
+func (q *Queries) CheckMandatoryReleaseBetween(ctx context.Context, arg CheckMandatoryReleaseBetweenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, checkMandatoryReleaseBetween,
+		arg.ApplicationID,
+		arg.Environment,
+		arg.AfterVersionCode,
+		arg.UpToVersionCode,
+	)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const checkReleaseExists = `-- name: CheckReleaseExists :one
 SELECT EXISTS (
     SELECT 1 FROM application_releases
@@ -19,9 +84,9 @@ SELECT EXISTS (
 `
 
 type CheckReleaseExistsParams struct {
-	ApplicationID pgtype.UUID        `json:"application_id"`
-	VersionCode   int32              `json:"version_code"`
-	Environment   ReleaseEnvironment `json:"environment"`
+	ApplicationID pgtype.UUID `json:"application_id"`
+	VersionCode   int32       `json:"version_code"`
+	Environment   string      `json:"environment"`
 }
 
 func (q *Queries) CheckReleaseExists(ctx context.Context, arg CheckReleaseExistsParams) (bool, error) {
@@ -38,19 +103,26 @@ INSERT INTO application_releases (
     version_name,
     release_note,
     environment,
-    application_id
+    application_id,
+    rollout_percentage,
+    mandatory,
+    status,
+    published_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $9 = 'published' THEN CURRENT_TIMESTAMP END
+) RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
 `
 
 type CreateApplicationReleaseParams struct {
-	Title         string             `json:"title"`
-	VersionCode   int32              `json:"version_code"`
-	VersionName   string             `json:"version_name"`
-	ReleaseNote   pgtype.Text        `json:"release_note"`
-	Environment   ReleaseEnvironment `json:"environment"`
-	ApplicationID pgtype.UUID        `json:"application_id"`
+	Title             string        `json:"title"`
+	VersionCode       int32         `json:"version_code"`
+	VersionName       string        `json:"version_name"`
+	ReleaseNote       pgtype.Text   `json:"release_note"`
+	Environment       string        `json:"environment"`
+	ApplicationID     pgtype.UUID   `json:"application_id"`
+	RolloutPercentage int32         `json:"rollout_percentage"`
+	Mandatory         bool          `json:"mandatory"`
+	Status            ReleaseStatus `json:"status"`
 }
 
 func (q *Queries) CreateApplicationRelease(ctx context.Context, arg CreateApplicationReleaseParams) (ApplicationRelease, error) {
@@ -61,6 +133,9 @@ func (q *Queries) CreateApplicationRelease(ctx context.Context, arg CreateApplic
 		arg.ReleaseNote,
 		arg.Environment,
 		arg.ApplicationID,
+		arg.RolloutPercentage,
+		arg.Mandatory,
+		arg.Status,
 	)
 	var i ApplicationRelease
 	err := row.Scan(
@@ -74,13 +149,22 @@ func (q *Queries) CreateApplicationRelease(ctx context.Context, arg CreateApplic
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
 
 const getApplicationReleaseByID = `-- name: GetApplicationReleaseByID :one
-SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at FROM application_releases 
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases
 WHERE id = $1 AND deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM applications a WHERE a.id = application_releases.application_id AND a.deleted_at IS NULL)
 `
 
 func (q *Queries) GetApplicationReleaseByID(ctx context.Context, id pgtype.UUID) (ApplicationRelease, error) {
@@ -97,22 +181,104 @@ func (q *Queries) GetApplicationReleaseByID(ctx context.Context, id pgtype.UUID)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
 
-const getLatestReleaseByEnvironment = `-- name: GetLatestReleaseByEnvironment :one
-SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at FROM application_releases 
+const getDeletedApplicationReleaseByID = `-- name: GetDeletedApplicationReleaseByID :one
+
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+// ============================================================================
+// Trash Queries
+// ============================================================================
+func (q *Queries) GetDeletedApplicationReleaseByID(ctx context.Context, id pgtype.UUID) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, getDeletedApplicationReleaseByID, id)
+	var i ApplicationRelease
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VersionCode,
+		&i.VersionName,
+		&i.ReleaseNote,
+		&i.Environment,
+		&i.ApplicationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
+	)
+	return i, err
+}
+
+const getHighestFullyRolledOutVersionCode = `-- name: GetHighestFullyRolledOutVersionCode :one
+SELECT COALESCE(MAX(version_code), 0)::int AS highest FROM application_releases
 WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+    AND status = 'published' AND rollout_percentage = 100 AND rollout_halted_at IS NULL
+`
+
+type GetHighestFullyRolledOutVersionCodeParams struct {
+	ApplicationID pgtype.UUID `json:"application_id"`
+	Environment   string      `json:"environment"`
+}
+
+// The newest published release of an environment offered to every client, or 0.
+func (q *Queries) GetHighestFullyRolledOutVersionCode(ctx context.Context, arg GetHighestFullyRolledOutVersionCodeParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getHighestFullyRolledOutVersionCode, arg.ApplicationID, arg.Environment)
+	var highest int32
+	err := row.Scan(&highest)
+	return highest, err
+}
+
+const getHighestVersionCode = `-- name: GetHighestVersionCode :one
+SELECT COALESCE(MAX(version_code), 0)::int AS highest FROM application_releases
+WHERE application_id = $1 AND environment = $2
+`
+
+type GetHighestVersionCodeParams struct {
+	ApplicationID pgtype.UUID `json:"application_id"`
+	Environment   string      `json:"environment"`
+}
+
+// Counts every release of the environment, deleted ones included: their builds
+// may already be installed, and their version codes stay taken until purged.
+func (q *Queries) GetHighestVersionCode(ctx context.Context, arg GetHighestVersionCodeParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getHighestVersionCode, arg.ApplicationID, arg.Environment)
+	var highest int32
+	err := row.Scan(&highest)
+	return highest, err
+}
+
+const getLatestReleaseByEnvironment = `-- name: GetLatestReleaseByEnvironment :one
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases 
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL AND status = 'published'
 ORDER BY version_code DESC
 LIMIT 1
 `
 
 type GetLatestReleaseByEnvironmentParams struct {
-	ApplicationID pgtype.UUID        `json:"application_id"`
-	Environment   ReleaseEnvironment `json:"environment"`
+	ApplicationID pgtype.UUID `json:"application_id"`
+	Environment   string      `json:"environment"`
 }
 
+// Only published releases count: rolling a channel back makes the previous release its latest.
 func (q *Queries) GetLatestReleaseByEnvironment(ctx context.Context, arg GetLatestReleaseByEnvironmentParams) (ApplicationRelease, error) {
 	row := q.db.QueryRow(ctx, getLatestReleaseByEnvironment, arg.ApplicationID, arg.Environment)
 	var i ApplicationRelease
@@ -127,6 +293,49 @@ func (q *Queries) GetLatestReleaseByEnvironment(ctx context.Context, arg GetLate
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
+	)
+	return i, err
+}
+
+const haltReleaseRollout = `-- name: HaltReleaseRollout :one
+UPDATE application_releases SET
+    rollout_halted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND status = 'published'
+    AND rollout_percentage < 100 AND rollout_halted_at IS NULL
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
+`
+
+func (q *Queries) HaltReleaseRollout(ctx context.Context, id pgtype.UUID) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, haltReleaseRollout, id)
+	var i ApplicationRelease
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VersionCode,
+		&i.VersionName,
+		&i.ReleaseNote,
+		&i.Environment,
+		&i.ApplicationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
@@ -140,14 +349,257 @@ func (q *Queries) HardDeleteApplicationRelease(ctx context.Context, id pgtype.UU
 	return err
 }
 
+const increaseReleaseRollout = `-- name: IncreaseReleaseRollout :one
+
+UPDATE application_releases SET
+    rollout_percentage = $1::int,
+    rollout_halted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2::uuid AND deleted_at IS NULL AND status = 'published'
+    AND (rollout_percentage < $1::int
+        OR (rollout_halted_at IS NOT NULL AND rollout_percentage <= $1::int))
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
+`
+
+type IncreaseReleaseRolloutParams struct {
+	RolloutPercentage int32       `json:"rollout_percentage"`
+	ID                pgtype.UUID `json:"id"`
+}
+
+// ============================================================================
+// Rollout Queries
+// ============================================================================
+// Rollouts only grow; increasing a halted rollout resumes it, possibly at the
+// percentage it was halted at.
+func (q *Queries) IncreaseReleaseRollout(ctx context.Context, arg IncreaseReleaseRolloutParams) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, increaseReleaseRollout, arg.RolloutPercentage, arg.ID)
+	var i ApplicationRelease
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VersionCode,
+		&i.VersionName,
+		&i.ReleaseNote,
+		&i.Environment,
+		&i.ApplicationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
+	)
+	return i, err
+}
+
+const listChangelogReleases = `-- name: ListChangelogReleases :many
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+    AND status IN ('published', 'archived')
+    AND version_code > $3::int
+    AND version_code <= $4::int
+ORDER BY version_code DESC
+LIMIT $5::int
+`
+
+type ListChangelogReleasesParams struct {
+	ApplicationID    pgtype.UUID `json:"application_id"`
+	Environment      string      `json:"environment"`
+	AfterVersionCode int32       `json:"after_version_code"`
+	UpToVersionCode  int32       `json:"up_to_version_code"`
+	MaxResults       int32       `json:"max_results"`
+}
+
+// The releases a client updating past after_version_code up to
+// up_to_version_code gets the changes of, newest first. Drafts and withdrawn
+// builds were never offered; archived ones were.
+func (q *Queries) ListChangelogReleases(ctx context.Context, arg ListChangelogReleasesParams) ([]ApplicationRelease, error) {
+	rows, err := q.db.Query(ctx, listChangelogReleases,
+		arg.ApplicationID,
+		arg.Environment,
+		arg.AfterVersionCode,
+		arg.UpToVersionCode,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApplicationRelease{}
+	for rows.Next() {
+		var i ApplicationRelease
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.VersionCode,
+			&i.VersionName,
+			&i.ReleaseNote,
+			&i.Environment,
+			&i.ApplicationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.WithdrawnAt,
+			&i.WithdrawnBy,
+			&i.WithdrawalReason,
+			&i.Status,
+			&i.PublishedAt,
+			&i.RolloutPercentage,
+			&i.RolloutHaltedAt,
+			&i.Mandatory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedReleasesByProject = `-- name: ListDeletedReleasesByProject :many
+SELECT r.id, r.title, r.version_code, r.version_name, r.release_note, r.environment, r.application_id, r.created_at, r.updated_at, r.deleted_at, r.withdrawn_at, r.withdrawn_by, r.withdrawal_reason, r.status, r.published_at, r.rollout_percentage, r.rollout_halted_at, r.mandatory FROM application_releases r
+JOIN applications a ON a.id = r.application_id
+WHERE a.project_id = $1::uuid AND r.deleted_at IS NOT NULL
+ORDER BY r.deleted_at DESC, r.id DESC
+LIMIT $2::int
+`
+
+type ListDeletedReleasesByProjectParams struct {
+	ProjectID  pgtype.UUID `json:"project_id"`
+	MaxResults int32       `json:"max_results"`
+}
+
+func (q *Queries) ListDeletedReleasesByProject(ctx context.Context, arg ListDeletedReleasesByProjectParams) ([]ApplicationRelease, error) {
+	rows, err := q.db.Query(ctx, listDeletedReleasesByProject, arg.ProjectID, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApplicationRelease{}
+	for rows.Next() {
+		var i ApplicationRelease
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.VersionCode,
+			&i.VersionName,
+			&i.ReleaseNote,
+			&i.Environment,
+			&i.ApplicationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.WithdrawnAt,
+			&i.WithdrawnBy,
+			&i.WithdrawalReason,
+			&i.Status,
+			&i.PublishedAt,
+			&i.RolloutPercentage,
+			&i.RolloutHaltedAt,
+			&i.Mandatory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableReleases = `-- name: ListPurgeableReleases :many
+SELECT r.id FROM application_releases r
+JOIN applications a ON a.id = r.application_id
+WHERE (r.deleted_at < $1::timestamp OR a.deleted_at < $1::timestamp)
+    AND NOT EXISTS (SELECT 1 FROM artifacts ar WHERE ar.release_id = r.id)
+LIMIT $2::int
+`
+
+type ListPurgeableReleasesParams struct {
+	DeletedBefore pgtype.Timestamp `json:"deleted_before"`
+	MaxResults    int32            `json:"max_results"`
+}
+
+// Releases deleted before the cutoff, or belonging to an application deleted
+// before it, whose artifacts have all been purged.
+func (q *Queries) ListPurgeableReleases(ctx context.Context, arg ListPurgeableReleasesParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listPurgeableReleases, arg.DeletedBefore, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReleasesByApplication = `-- name: ListReleasesByApplication :many
-SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at FROM application_releases 
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases
 WHERE application_id = $1 AND deleted_at IS NULL
-ORDER BY version_code DESC
+    AND ($2::release_status IS NULL OR status = $2::release_status)
+    AND ($3::bool OR status <> 'draft')
+    AND (
+        environment = $4::text
+        OR ($4::text IS NULL AND NOT EXISTS (
+            SELECT 1 FROM release_channels c
+            WHERE c.application_id = application_releases.application_id
+                AND c.name = application_releases.environment AND c.visibility = 'unlisted'
+        ))
+    )
+    AND (
+        $5::uuid IS NULL
+        OR ($6::bool AND (version_code, id) > ($7::int, $5::uuid))
+        OR (NOT $6::bool AND (version_code, id) < ($7::int, $5::uuid))
+    )
+ORDER BY
+    CASE WHEN $6::bool THEN version_code END ASC,
+    CASE WHEN $6::bool THEN id END ASC,
+    version_code DESC,
+    id DESC
+LIMIT $8::int
 `
 
-func (q *Queries) ListReleasesByApplication(ctx context.Context, applicationID pgtype.UUID) ([]ApplicationRelease, error) {
-	rows, err := q.db.Query(ctx, listReleasesByApplication, applicationID)
+type ListReleasesByApplicationParams struct {
+	ApplicationID    pgtype.UUID       `json:"application_id"`
+	Status           NullReleaseStatus `json:"status"`
+	IncludeDrafts    bool              `json:"include_drafts"`
+	Environment      pgtype.Text       `json:"environment"`
+	AfterID          pgtype.UUID       `json:"after_id"`
+	Ascending        bool              `json:"ascending"`
+	AfterVersionCode pgtype.Int4       `json:"after_version_code"`
+	MaxResults       int32             `json:"max_results"`
+}
+
+// Keyset pagination on (version_code, id); callers fetch one extra row to detect a next page.
+// Without a channel filter, unlisted channels are left out
+func (q *Queries) ListReleasesByApplication(ctx context.Context, arg ListReleasesByApplicationParams) ([]ApplicationRelease, error) {
+	rows, err := q.db.Query(ctx, listReleasesByApplication,
+		arg.ApplicationID,
+		arg.Status,
+		arg.IncludeDrafts,
+		arg.Environment,
+		arg.AfterID,
+		arg.Ascending,
+		arg.AfterVersionCode,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +618,14 @@ func (q *Queries) ListReleasesByApplication(ctx context.Context, applicationID p
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.WithdrawnAt,
+			&i.WithdrawnBy,
+			&i.WithdrawalReason,
+			&i.Status,
+			&i.PublishedAt,
+			&i.RolloutPercentage,
+			&i.RolloutHaltedAt,
+			&i.Mandatory,
 		); err != nil {
 			return nil, err
 		}
@@ -178,14 +638,14 @@ func (q *Queries) ListReleasesByApplication(ctx context.Context, applicationID p
 }
 
 const listReleasesByEnvironment = `-- name: ListReleasesByEnvironment :many
-SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at FROM application_releases 
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases 
 WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
 ORDER BY version_code DESC
 `
 
 type ListReleasesByEnvironmentParams struct {
-	ApplicationID pgtype.UUID        `json:"application_id"`
-	Environment   ReleaseEnvironment `json:"environment"`
+	ApplicationID pgtype.UUID `json:"application_id"`
+	Environment   string      `json:"environment"`
 }
 
 func (q *Queries) ListReleasesByEnvironment(ctx context.Context, arg ListReleasesByEnvironmentParams) ([]ApplicationRelease, error) {
@@ -208,6 +668,119 @@ func (q *Queries) ListReleasesByEnvironment(ctx context.Context, arg ListRelease
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.WithdrawnAt,
+			&i.WithdrawnBy,
+			&i.WithdrawalReason,
+			&i.Status,
+			&i.PublishedAt,
+			&i.RolloutPercentage,
+			&i.RolloutHaltedAt,
+			&i.Mandatory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleasesPastRetention = `-- name: ListReleasesPastRetention :many
+WITH ranked AS (
+    SELECT
+        r.id,
+        r.version_code,
+        r.created_at,
+        COALESCE((p.settings->'retention'->>'keep_last_releases')::int, 0) AS keep_last,
+        COALESCE((p.settings->'retention'->>'max_age_days')::int, 0) AS max_age_days,
+        ROW_NUMBER() OVER (PARTITION BY r.application_id, r.environment ORDER BY r.version_code DESC) AS position,
+        MAX(r.version_code) FILTER (WHERE r.status = 'published' AND r.rollout_percentage = 100 AND r.rollout_halted_at IS NULL)
+            OVER (PARTITION BY r.application_id, r.environment) AS served_floor
+    FROM application_releases r
+    JOIN applications a ON a.id = r.application_id AND a.deleted_at IS NULL
+    JOIN projects p ON p.id = a.project_id AND p.deleted_at IS NULL
+    WHERE r.deleted_at IS NULL AND r.status <> 'draft'
+)
+SELECT id FROM ranked
+WHERE (keep_last > 0 OR max_age_days > 0)
+    AND version_code < served_floor
+    AND (keep_last = 0 OR position > keep_last)
+    AND (max_age_days = 0 OR created_at < CURRENT_TIMESTAMP - make_interval(days => max_age_days))
+LIMIT $1::int
+`
+
+// Live releases their project's retention policy no longer keeps: past the
+// keep_last_releases newest of their environment and older than max_age_days,
+// whichever of the two are set. Drafts, and releases at or above the newest
+// one offered to every client of their environment, are always kept.
+func (q *Queries) ListReleasesPastRetention(ctx context.Context, maxResults int32) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listReleasesPastRetention, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRolloutCandidates = `-- name: ListRolloutCandidates :many
+SELECT id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory FROM application_releases
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL AND status = 'published'
+    AND version_code >= COALESCE((
+        SELECT MAX(full_r.version_code) FROM application_releases full_r
+        WHERE full_r.application_id = $1 AND full_r.environment = $2 AND full_r.deleted_at IS NULL
+            AND full_r.status = 'published' AND full_r.rollout_percentage = 100 AND full_r.rollout_halted_at IS NULL
+    ), 0)
+ORDER BY version_code DESC
+`
+
+type ListRolloutCandidatesParams struct {
+	ApplicationID pgtype.UUID `json:"application_id"`
+	Environment   string      `json:"environment"`
+}
+
+// The published releases of an environment, newest first, down to the newest
+// one fully rolled out: a client gets the first whose rollout includes it.
+func (q *Queries) ListRolloutCandidates(ctx context.Context, arg ListRolloutCandidatesParams) ([]ApplicationRelease, error) {
+	rows, err := q.db.Query(ctx, listRolloutCandidates, arg.ApplicationID, arg.Environment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApplicationRelease{}
+	for rows.Next() {
+		var i ApplicationRelease
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.VersionCode,
+			&i.VersionName,
+			&i.ReleaseNote,
+			&i.Environment,
+			&i.ApplicationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.WithdrawnAt,
+			&i.WithdrawnBy,
+			&i.WithdrawalReason,
+			&i.Status,
+			&i.PublishedAt,
+			&i.RolloutPercentage,
+			&i.RolloutHaltedAt,
+			&i.Mandatory,
 		); err != nil {
 			return nil, err
 		}
@@ -219,22 +792,61 @@ func (q *Queries) ListReleasesByEnvironment(ctx context.Context, arg ListRelease
 	return items, nil
 }
 
-const promoteRelease = `-- name: PromoteRelease :one
+const publishApplicationRelease = `-- name: PublishApplicationRelease :one
+
 UPDATE application_releases SET
-    environment = $2,
+    status = 'published',
+    published_at = COALESCE(published_at, CURRENT_TIMESTAMP),
+    withdrawn_at = NULL,
+    withdrawn_by = NULL,
+    withdrawal_reason = '',
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at
+WHERE id = $1 AND deleted_at IS NULL AND status IN ('draft', 'withdrawn')
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
 `
 
-type PromoteReleaseParams struct {
-	ID          pgtype.UUID        `json:"id"`
-	Environment ReleaseEnvironment `json:"environment"`
+// ============================================================================
+// Status Queries
+// Each only matches the statuses the transition starts from, so concurrent
+// transitions cannot both apply.
+// ============================================================================
+// Publishes a draft, or reinstates a withdrawn release.
+func (q *Queries) PublishApplicationRelease(ctx context.Context, id pgtype.UUID) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, publishApplicationRelease, id)
+	var i ApplicationRelease
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VersionCode,
+		&i.VersionName,
+		&i.ReleaseNote,
+		&i.Environment,
+		&i.ApplicationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
+	)
+	return i, err
 }
 
-// Change environment (e.g., development -> staging -> production)
-func (q *Queries) PromoteRelease(ctx context.Context, arg PromoteReleaseParams) (ApplicationRelease, error) {
-	row := q.db.QueryRow(ctx, promoteRelease, arg.ID, arg.Environment)
+const restoreApplicationRelease = `-- name: RestoreApplicationRelease :one
+UPDATE application_releases SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
+`
+
+func (q *Queries) RestoreApplicationRelease(ctx context.Context, id pgtype.UUID) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, restoreApplicationRelease, id)
 	var i ApplicationRelease
 	err := row.Scan(
 		&i.ID,
@@ -247,16 +859,61 @@ func (q *Queries) PromoteRelease(ctx context.Context, arg PromoteReleaseParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
 
+const restoreReleasesDeletedWithApplication = `-- name: RestoreReleasesDeletedWithApplication :exec
+UPDATE application_releases SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE application_id = $1::uuid AND deleted_at = $2::timestamp
+`
+
+type RestoreReleasesDeletedWithApplicationParams struct {
+	ApplicationID pgtype.UUID      `json:"application_id"`
+	DeletedAt     pgtype.Timestamp `json:"deleted_at"`
+}
+
+// Restores the releases that were deleted together with their application.
+func (q *Queries) RestoreReleasesDeletedWithApplication(ctx context.Context, arg RestoreReleasesDeletedWithApplicationParams) error {
+	_, err := q.db.Exec(ctx, restoreReleasesDeletedWithApplication, arg.ApplicationID, arg.DeletedAt)
+	return err
+}
+
+const restoreReleasesDeletedWithProject = `-- name: RestoreReleasesDeletedWithProject :exec
+UPDATE application_releases SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deleted_at = $1::timestamp
+    AND application_id IN (SELECT id FROM applications WHERE project_id = $2::uuid)
+`
+
+type RestoreReleasesDeletedWithProjectParams struct {
+	DeletedAt pgtype.Timestamp `json:"deleted_at"`
+	ProjectID pgtype.UUID      `json:"project_id"`
+}
+
+// Restores the releases that were deleted together with their project.
+func (q *Queries) RestoreReleasesDeletedWithProject(ctx context.Context, arg RestoreReleasesDeletedWithProjectParams) error {
+	_, err := q.db.Exec(ctx, restoreReleasesDeletedWithProject, arg.DeletedAt, arg.ProjectID)
+	return err
+}
+
 const softDeleteApplicationRelease = `-- name: SoftDeleteApplicationRelease :one
 
 UPDATE application_releases SET
     deleted_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
 `
 
 // ============================================================================
@@ -276,28 +933,68 @@ func (q *Queries) SoftDeleteApplicationRelease(ctx context.Context, id pgtype.UU
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
 
+const softDeleteReleasesByApplication = `-- name: SoftDeleteReleasesByApplication :exec
+UPDATE application_releases SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE application_id = $1 AND deleted_at IS NULL
+`
+
+// Cascades an application deletion; children share the parent's deleted_at so restoring can find them.
+func (q *Queries) SoftDeleteReleasesByApplication(ctx context.Context, applicationID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteReleasesByApplication, applicationID)
+	return err
+}
+
+const softDeleteReleasesByProject = `-- name: SoftDeleteReleasesByProject :exec
+UPDATE application_releases SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL
+    AND application_id IN (SELECT a.id FROM applications a WHERE a.project_id = $1::uuid)
+`
+
+// Cascades a project deletion.
+func (q *Queries) SoftDeleteReleasesByProject(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteReleasesByProject, projectID)
+	return err
+}
+
 const updateRelease = `-- name: UpdateRelease :one
 UPDATE application_releases SET
     title = $2,
     release_note = $3,
+    mandatory = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
 `
 
 type UpdateReleaseParams struct {
 	ID          pgtype.UUID `json:"id"`
 	Title       string      `json:"title"`
 	ReleaseNote pgtype.Text `json:"release_note"`
+	Mandatory   bool        `json:"mandatory"`
 }
 
-// Full update for title + release_note
+// Full update for title, release_note and the mandatory flag
 func (q *Queries) UpdateRelease(ctx context.Context, arg UpdateReleaseParams) (ApplicationRelease, error) {
-	row := q.db.QueryRow(ctx, updateRelease, arg.ID, arg.Title, arg.ReleaseNote)
+	row := q.db.QueryRow(ctx, updateRelease,
+		arg.ID,
+		arg.Title,
+		arg.ReleaseNote,
+		arg.Mandatory,
+	)
 	var i ApplicationRelease
 	err := row.Scan(
 		&i.ID,
@@ -310,6 +1007,14 @@ func (q *Queries) UpdateRelease(ctx context.Context, arg UpdateReleaseParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
@@ -319,7 +1024,7 @@ UPDATE application_releases SET
     release_note = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
 `
 
 type UpdateReleaseNoteParams struct {
@@ -341,6 +1046,14 @@ func (q *Queries) UpdateReleaseNote(ctx context.Context, arg UpdateReleaseNotePa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
@@ -351,7 +1064,7 @@ UPDATE application_releases SET
     title = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
 `
 
 type UpdateReleaseTitleParams struct {
@@ -376,6 +1089,57 @@ func (q *Queries) UpdateReleaseTitle(ctx context.Context, arg UpdateReleaseTitle
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
+	)
+	return i, err
+}
+
+const withdrawApplicationRelease = `-- name: WithdrawApplicationRelease :one
+UPDATE application_releases SET
+    status = 'withdrawn',
+    withdrawn_at = CURRENT_TIMESTAMP,
+    withdrawn_by = $2,
+    withdrawal_reason = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND status = 'published'
+RETURNING id, title, version_code, version_name, release_note, environment, application_id, created_at, updated_at, deleted_at, withdrawn_at, withdrawn_by, withdrawal_reason, status, published_at, rollout_percentage, rollout_halted_at, mandatory
+`
+
+type WithdrawApplicationReleaseParams struct {
+	ID               pgtype.UUID `json:"id"`
+	WithdrawnBy      pgtype.UUID `json:"withdrawn_by"`
+	WithdrawalReason string      `json:"withdrawal_reason"`
+}
+
+func (q *Queries) WithdrawApplicationRelease(ctx context.Context, arg WithdrawApplicationReleaseParams) (ApplicationRelease, error) {
+	row := q.db.QueryRow(ctx, withdrawApplicationRelease, arg.ID, arg.WithdrawnBy, arg.WithdrawalReason)
+	var i ApplicationRelease
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VersionCode,
+		&i.VersionName,
+		&i.ReleaseNote,
+		&i.Environment,
+		&i.ApplicationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WithdrawnAt,
+		&i.WithdrawnBy,
+		&i.WithdrawalReason,
+		&i.Status,
+		&i.PublishedAt,
+		&i.RolloutPercentage,
+		&i.RolloutHaltedAt,
+		&i.Mandatory,
 	)
 	return i, err
 }
@@ -51,8 +51,9 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 }
 
 const getApplicationByID = `-- name: GetApplicationByID :one
-SELECT id, title, package_name, description, project_id, created_at, updated_at, deleted_at FROM applications 
+SELECT id, title, package_name, description, project_id, created_at, updated_at, deleted_at FROM applications
 WHERE id = $1 AND deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = applications.project_id AND p.deleted_at IS NULL)
 `
 
 func (q *Queries) GetApplicationByID(ctx context.Context, id pgtype.UUID) (Application, error) {
@@ -92,6 +93,31 @@ func (q *Queries) GetApplicationByPackageName(ctx context.Context, packageName s
 	return i, err
 }
 
+const getDeletedApplicationByID = `-- name: GetDeletedApplicationByID :one
+
+SELECT id, title, package_name, description, project_id, created_at, updated_at, deleted_at FROM applications
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+// ============================================================================
+// Trash Queries
+// ============================================================================
+func (q *Queries) GetDeletedApplicationByID(ctx context.Context, id pgtype.UUID) (Application, error) {
+	row := q.db.QueryRow(ctx, getDeletedApplicationByID, id)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.PackageName,
+		&i.Description,
+		&i.ProjectID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const hardDeleteApplication = `-- name: HardDeleteApplication :exec
 DELETE FROM applications WHERE id = $1
 `
@@ -102,13 +128,84 @@ func (q *Queries) HardDeleteApplication(ctx context.Context, id pgtype.UUID) err
 }
 
 const listApplicationsByProject = `-- name: ListApplicationsByProject :many
-SELECT id, title, package_name, description, project_id, created_at, updated_at, deleted_at FROM applications 
+SELECT id, title, package_name, description, project_id, created_at, updated_at, deleted_at FROM applications
 WHERE project_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC
+    AND ($2::text IS NULL
+        OR title ILIKE '%' || $2::text || '%'
+        OR package_name ILIKE '%' || $2::text || '%')
+    AND (
+        $3::uuid IS NULL
+        OR ($4::bool AND (created_at, id) > ($5::timestamp, $3::uuid))
+        OR (NOT $4::bool AND (created_at, id) < ($5::timestamp, $3::uuid))
+    )
+ORDER BY
+    CASE WHEN $4::bool THEN created_at END ASC,
+    CASE WHEN $4::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $6::int
 `
 
-func (q *Queries) ListApplicationsByProject(ctx context.Context, projectID pgtype.UUID) ([]Application, error) {
-	rows, err := q.db.Query(ctx, listApplicationsByProject, projectID)
+type ListApplicationsByProjectParams struct {
+	ProjectID      pgtype.UUID      `json:"project_id"`
+	Search         pgtype.Text      `json:"search"`
+	AfterID        pgtype.UUID      `json:"after_id"`
+	Ascending      bool             `json:"ascending"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	MaxResults     int32            `json:"max_results"`
+}
+
+// Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+func (q *Queries) ListApplicationsByProject(ctx context.Context, arg ListApplicationsByProjectParams) ([]Application, error) {
+	rows, err := q.db.Query(ctx, listApplicationsByProject,
+		arg.ProjectID,
+		arg.Search,
+		arg.AfterID,
+		arg.Ascending,
+		arg.AfterCreatedAt,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Application{}
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.PackageName,
+			&i.Description,
+			&i.ProjectID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedApplicationsByProject = `-- name: ListDeletedApplicationsByProject :many
+SELECT id, title, package_name, description, project_id, created_at, updated_at, deleted_at FROM applications
+WHERE project_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT $2::int
+`
+
+type ListDeletedApplicationsByProjectParams struct {
+	ProjectID  pgtype.UUID `json:"project_id"`
+	MaxResults int32       `json:"max_results"`
+}
+
+func (q *Queries) ListDeletedApplicationsByProject(ctx context.Context, arg ListDeletedApplicationsByProjectParams) ([]Application, error) {
+	rows, err := q.db.Query(ctx, listDeletedApplicationsByProject, arg.ProjectID, arg.MaxResults)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +233,81 @@ func (q *Queries) ListApplicationsByProject(ctx context.Context, projectID pgtyp
 	return items, nil
 }
 
+const listPurgeableApplications = `-- name: ListPurgeableApplications :many
+SELECT id FROM applications
+WHERE deleted_at < $1::timestamp
+    AND NOT EXISTS (SELECT 1 FROM application_releases r WHERE r.application_id = applications.id)
+LIMIT $2::int
+`
+
+type ListPurgeableApplicationsParams struct {
+	DeletedBefore pgtype.Timestamp `json:"deleted_before"`
+	MaxResults    int32            `json:"max_results"`
+}
+
+// Applications deleted before the cutoff whose releases have all been purged.
+func (q *Queries) ListPurgeableApplications(ctx context.Context, arg ListPurgeableApplicationsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listPurgeableApplications, arg.DeletedBefore, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreApplication = `-- name: RestoreApplication :one
+UPDATE applications SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, package_name, description, project_id, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) RestoreApplication(ctx context.Context, id pgtype.UUID) (Application, error) {
+	row := q.db.QueryRow(ctx, restoreApplication, id)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.PackageName,
+		&i.Description,
+		&i.ProjectID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const restoreApplicationsDeletedWithProject = `-- name: RestoreApplicationsDeletedWithProject :exec
+UPDATE applications SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE project_id = $1::uuid AND deleted_at = $2::timestamp
+`
+
+type RestoreApplicationsDeletedWithProjectParams struct {
+	ProjectID pgtype.UUID      `json:"project_id"`
+	DeletedAt pgtype.Timestamp `json:"deleted_at"`
+}
+
+// Restores the applications that were deleted together with their project.
+func (q *Queries) RestoreApplicationsDeletedWithProject(ctx context.Context, arg RestoreApplicationsDeletedWithProjectParams) error {
+	_, err := q.db.Exec(ctx, restoreApplicationsDeletedWithProject, arg.ProjectID, arg.DeletedAt)
+	return err
+}
+
 const softDeleteApplication = `-- name: SoftDeleteApplication :one
 
 UPDATE applications SET
@@ -163,6 +335,18 @@ func (q *Queries) SoftDeleteApplication(ctx context.Context, id pgtype.UUID) (Ap
 	return i, err
 }
 
+const softDeleteApplicationsByProject = `-- name: SoftDeleteApplicationsByProject :exec
+UPDATE applications SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE project_id = $1 AND deleted_at IS NULL
+`
+
+// Cascades a project deletion; children share the parent's deleted_at so restoring can find them.
+func (q *Queries) SoftDeleteApplicationsByProject(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteApplicationsByProject, projectID)
+	return err
+}
+
 const updateApplication = `-- name: UpdateApplication :one
 UPDATE applications SET
     title = $2,
@@ -11,6 +11,56 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const checkReleaseHasVerifiedArtifact = `-- name: CheckReleaseHasVerifiedArtifact :one
+SELECT EXISTS (
+    SELECT 1 FROM artifacts
+    WHERE release_id = $1 AND deleted_at IS NULL AND verified_at IS NOT NULL
+)
+`
+
+func (q *Queries) CheckReleaseHasVerifiedArtifact(ctx context.Context, releaseID pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, checkReleaseHasVerifiedArtifact, releaseID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const copyArtifactsToRelease = `-- name: CopyArtifactsToRelease :exec
+INSERT INTO artifacts (file_url, sha256_hash, file_size, file_type, abi, release_id, verified_at)
+SELECT file_url, sha256_hash, file_size, file_type, abi, $1::uuid, verified_at
+FROM artifacts
+WHERE release_id = $2::uuid AND deleted_at IS NULL
+`
+
+type CopyArtifactsToReleaseParams struct {
+	TargetReleaseID pgtype.UUID `json:"target_release_id"`
+	SourceReleaseID pgtype.UUID `json:"source_release_id"`
+}
+
+// Promotion copies a release's live artifacts; the copies share the stored files.
+func (q *Queries) CopyArtifactsToRelease(ctx context.Context, arg CopyArtifactsToReleaseParams) error {
+	_, err := q.db.Exec(ctx, copyArtifactsToRelease, arg.TargetReleaseID, arg.SourceReleaseID)
+	return err
+}
+
+const countOtherArtifactsWithFileURL = `-- name: CountOtherArtifactsWithFileURL :one
+SELECT COUNT(*) FROM artifacts
+WHERE file_url = $1 AND id <> $2
+`
+
+type CountOtherArtifactsWithFileURLParams struct {
+	FileUrl string      `json:"file_url"`
+	ID      pgtype.UUID `json:"id"`
+}
+
+// Artifacts may share a stored file; it is only removed with the last of them.
+func (q *Queries) CountOtherArtifactsWithFileURL(ctx context.Context, arg CountOtherArtifactsWithFileURLParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOtherArtifactsWithFileURL, arg.FileUrl, arg.ID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createArtifact = `-- name: CreateArtifact :one
 INSERT INTO artifacts (
     file_url,
@@ -18,10 +68,11 @@ INSERT INTO artifacts (
     file_size,
     file_type,
     abi,
-    release_id
+    release_id,
+    verified_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6, CASE WHEN $7::bool THEN CURRENT_TIMESTAMP END
+) RETURNING id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at
 `
 
 type CreateArtifactParams struct {
@@ -31,6 +82,7 @@ type CreateArtifactParams struct {
 	FileType   string      `json:"file_type"`
 	Abi        pgtype.Text `json:"abi"`
 	ReleaseID  pgtype.UUID `json:"release_id"`
+	Verified   bool        `json:"verified"`
 }
 
 func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams) (Artifact, error) {
@@ -41,6 +93,7 @@ func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams)
 		arg.FileType,
 		arg.Abi,
 		arg.ReleaseID,
+		arg.Verified,
 	)
 	var i Artifact
 	err := row.Scan(
@@ -54,13 +107,15 @@ func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
 
 const getArtifactByID = `-- name: GetArtifactByID :one
-SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at FROM artifacts 
+SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at FROM artifacts
 WHERE id = $1 AND deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM application_releases r WHERE r.id = artifacts.release_id AND r.deleted_at IS NULL)
 `
 
 func (q *Queries) GetArtifactByID(ctx context.Context, id pgtype.UUID) (Artifact, error) {
@@ -77,12 +132,13 @@ func (q *Queries) GetArtifactByID(ctx context.Context, id pgtype.UUID) (Artifact
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
 
 const getArtifactByReleaseAndABI = `-- name: GetArtifactByReleaseAndABI :one
-SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at FROM artifacts 
+SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at FROM artifacts 
 WHERE release_id = $1 AND abi = $2 AND deleted_at IS NULL
 `
 
@@ -105,6 +161,74 @@ func (q *Queries) GetArtifactByReleaseAndABI(ctx context.Context, arg GetArtifac
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.VerifiedAt,
+	)
+	return i, err
+}
+
+const getArtifactForABI = `-- name: GetArtifactForABI :one
+SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at FROM artifacts
+WHERE release_id = $1::uuid AND deleted_at IS NULL
+    AND (abi = $2::text OR abi IS NULL OR abi = '' OR $2::text = '')
+ORDER BY
+    abi = $2::text DESC NULLS LAST,
+    (abi IS NULL OR abi = '') DESC,
+    verified_at IS NULL,
+    created_at DESC
+LIMIT 1
+`
+
+type GetArtifactForABIParams struct {
+	ReleaseID pgtype.UUID `json:"release_id"`
+	Abi       string      `json:"abi"`
+}
+
+// The artifact to install on a device: one built for its ABI, else a universal
+// one (without ABI), else any when the device did not tell its ABI. Verified
+// and newer artifacts come first.
+func (q *Queries) GetArtifactForABI(ctx context.Context, arg GetArtifactForABIParams) (Artifact, error) {
+	row := q.db.QueryRow(ctx, getArtifactForABI, arg.ReleaseID, arg.Abi)
+	var i Artifact
+	err := row.Scan(
+		&i.ID,
+		&i.FileUrl,
+		&i.Sha256Hash,
+		&i.FileSize,
+		&i.FileType,
+		&i.Abi,
+		&i.ReleaseID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.VerifiedAt,
+	)
+	return i, err
+}
+
+const getDeletedArtifactByID = `-- name: GetDeletedArtifactByID :one
+
+SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at FROM artifacts
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+// ============================================================================
+// Trash Queries
+// ============================================================================
+func (q *Queries) GetDeletedArtifactByID(ctx context.Context, id pgtype.UUID) (Artifact, error) {
+	row := q.db.QueryRow(ctx, getDeletedArtifactByID, id)
+	var i Artifact
+	err := row.Scan(
+		&i.ID,
+		&i.FileUrl,
+		&i.Sha256Hash,
+		&i.FileSize,
+		&i.FileType,
+		&i.Abi,
+		&i.ReleaseID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
@@ -119,12 +243,136 @@ func (q *Queries) HardDeleteArtifact(ctx context.Context, id pgtype.UUID) error
 }
 
 const listArtifactsByRelease = `-- name: ListArtifactsByRelease :many
-SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at FROM artifacts 
+SELECT id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at FROM artifacts
 WHERE release_id = $1 AND deleted_at IS NULL
+    AND ($2::text IS NULL OR abi = $2::text)
+    AND (
+        $3::uuid IS NULL
+        OR ($4::bool AND (created_at, id) > ($5::timestamp, $3::uuid))
+        OR (NOT $4::bool AND (created_at, id) < ($5::timestamp, $3::uuid))
+    )
+ORDER BY
+    CASE WHEN $4::bool THEN created_at END ASC,
+    CASE WHEN $4::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $6::int
+`
+
+type ListArtifactsByReleaseParams struct {
+	ReleaseID      pgtype.UUID      `json:"release_id"`
+	Abi            pgtype.Text      `json:"abi"`
+	AfterID        pgtype.UUID      `json:"after_id"`
+	Ascending      bool             `json:"ascending"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	MaxResults     int32            `json:"max_results"`
+}
+
+// Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+func (q *Queries) ListArtifactsByRelease(ctx context.Context, arg ListArtifactsByReleaseParams) ([]Artifact, error) {
+	rows, err := q.db.Query(ctx, listArtifactsByRelease,
+		arg.ReleaseID,
+		arg.Abi,
+		arg.AfterID,
+		arg.Ascending,
+		arg.AfterCreatedAt,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Artifact{}
+	for rows.Next() {
+		var i Artifact
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileUrl,
+			&i.Sha256Hash,
+			&i.FileSize,
+			&i.FileType,
+			&i.Abi,
+			&i.ReleaseID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.VerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedArtifactsByProject = `-- name: ListDeletedArtifactsByProject :many
+SELECT ar.id, ar.file_url, ar.sha256_hash, ar.file_size, ar.file_type, ar.abi, ar.release_id, ar.created_at, ar.updated_at, ar.deleted_at, ar.verified_at FROM artifacts ar
+JOIN application_releases r ON r.id = ar.release_id
+JOIN applications a ON a.id = r.application_id
+WHERE a.project_id = $1::uuid AND ar.deleted_at IS NOT NULL
+ORDER BY ar.deleted_at DESC, ar.id DESC
+LIMIT $2::int
+`
+
+type ListDeletedArtifactsByProjectParams struct {
+	ProjectID  pgtype.UUID `json:"project_id"`
+	MaxResults int32       `json:"max_results"`
+}
+
+func (q *Queries) ListDeletedArtifactsByProject(ctx context.Context, arg ListDeletedArtifactsByProjectParams) ([]Artifact, error) {
+	rows, err := q.db.Query(ctx, listDeletedArtifactsByProject, arg.ProjectID, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Artifact{}
+	for rows.Next() {
+		var i Artifact
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileUrl,
+			&i.Sha256Hash,
+			&i.FileSize,
+			&i.FileType,
+			&i.Abi,
+			&i.ReleaseID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.VerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableArtifacts = `-- name: ListPurgeableArtifacts :many
+SELECT ar.id, ar.file_url, ar.sha256_hash, ar.file_size, ar.file_type, ar.abi, ar.release_id, ar.created_at, ar.updated_at, ar.deleted_at, ar.verified_at FROM artifacts ar
+JOIN application_releases r ON r.id = ar.release_id
+JOIN applications a ON a.id = r.application_id
+WHERE ar.deleted_at < $1::timestamp
+    OR r.deleted_at < $1::timestamp
+    OR a.deleted_at < $1::timestamp
+LIMIT $2::int
 `
 
-func (q *Queries) ListArtifactsByRelease(ctx context.Context, releaseID pgtype.UUID) ([]Artifact, error) {
-	rows, err := q.db.Query(ctx, listArtifactsByRelease, releaseID)
+type ListPurgeableArtifactsParams struct {
+	DeletedBefore pgtype.Timestamp `json:"deleted_before"`
+	MaxResults    int32            `json:"max_results"`
+}
+
+// Artifacts deleted before the cutoff, or belonging to a release or an
+// application deleted before it.
+func (q *Queries) ListPurgeableArtifacts(ctx context.Context, arg ListPurgeableArtifactsParams) ([]Artifact, error) {
+	rows, err := q.db.Query(ctx, listPurgeableArtifacts, arg.DeletedBefore, arg.MaxResults)
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +391,7 @@ func (q *Queries) ListArtifactsByRelease(ctx context.Context, releaseID pgtype.U
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.VerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -154,11 +403,125 @@ func (q *Queries) ListArtifactsByRelease(ctx context.Context, releaseID pgtype.U
 	return items, nil
 }
 
+const markArtifactVerified = `-- name: MarkArtifactVerified :one
+UPDATE artifacts SET
+    verified_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at
+`
+
+func (q *Queries) MarkArtifactVerified(ctx context.Context, id pgtype.UUID) (Artifact, error) {
+	row := q.db.QueryRow(ctx, markArtifactVerified, id)
+	var i Artifact
+	err := row.Scan(
+		&i.ID,
+		&i.FileUrl,
+		&i.Sha256Hash,
+		&i.FileSize,
+		&i.FileType,
+		&i.Abi,
+		&i.ReleaseID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.VerifiedAt,
+	)
+	return i, err
+}
+
+const restoreArtifact = `-- name: RestoreArtifact :one
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at
+`
+
+func (q *Queries) RestoreArtifact(ctx context.Context, id pgtype.UUID) (Artifact, error) {
+	row := q.db.QueryRow(ctx, restoreArtifact, id)
+	var i Artifact
+	err := row.Scan(
+		&i.ID,
+		&i.FileUrl,
+		&i.Sha256Hash,
+		&i.FileSize,
+		&i.FileType,
+		&i.Abi,
+		&i.ReleaseID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.VerifiedAt,
+	)
+	return i, err
+}
+
+const restoreArtifactsDeletedWithApplication = `-- name: RestoreArtifactsDeletedWithApplication :exec
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deleted_at = $1::timestamp
+    AND release_id IN (SELECT id FROM application_releases WHERE application_id = $2::uuid)
+`
+
+type RestoreArtifactsDeletedWithApplicationParams struct {
+	DeletedAt     pgtype.Timestamp `json:"deleted_at"`
+	ApplicationID pgtype.UUID      `json:"application_id"`
+}
+
+// Restores the artifacts that were deleted together with their application.
+func (q *Queries) RestoreArtifactsDeletedWithApplication(ctx context.Context, arg RestoreArtifactsDeletedWithApplicationParams) error {
+	_, err := q.db.Exec(ctx, restoreArtifactsDeletedWithApplication, arg.DeletedAt, arg.ApplicationID)
+	return err
+}
+
+const restoreArtifactsDeletedWithProject = `-- name: RestoreArtifactsDeletedWithProject :exec
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deleted_at = $1::timestamp
+    AND release_id IN (
+        SELECT r.id FROM application_releases r
+        JOIN applications a ON a.id = r.application_id
+        WHERE a.project_id = $2::uuid
+    )
+`
+
+type RestoreArtifactsDeletedWithProjectParams struct {
+	DeletedAt pgtype.Timestamp `json:"deleted_at"`
+	ProjectID pgtype.UUID      `json:"project_id"`
+}
+
+// Restores the artifacts that were deleted together with their project.
+func (q *Queries) RestoreArtifactsDeletedWithProject(ctx context.Context, arg RestoreArtifactsDeletedWithProjectParams) error {
+	_, err := q.db.Exec(ctx, restoreArtifactsDeletedWithProject, arg.DeletedAt, arg.ProjectID)
+	return err
+}
+
+const restoreArtifactsDeletedWithRelease = `-- name: RestoreArtifactsDeletedWithRelease :exec
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE release_id = $1::uuid AND deleted_at = $2::timestamp
+`
+
+type RestoreArtifactsDeletedWithReleaseParams struct {
+	ReleaseID pgtype.UUID      `json:"release_id"`
+	DeletedAt pgtype.Timestamp `json:"deleted_at"`
+}
+
+// Restores the artifacts that were deleted together with their release.
+func (q *Queries) RestoreArtifactsDeletedWithRelease(ctx context.Context, arg RestoreArtifactsDeletedWithReleaseParams) error {
+	_, err := q.db.Exec(ctx, restoreArtifactsDeletedWithRelease, arg.ReleaseID, arg.DeletedAt)
+	return err
+}
+
 const softDeleteArtifact = `-- name: SoftDeleteArtifact :one
 UPDATE artifacts SET
     deleted_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at
+RETURNING id, file_url, sha256_hash, file_size, file_type, abi, release_id, created_at, updated_at, deleted_at, verified_at
 `
 
 func (q *Queries) SoftDeleteArtifact(ctx context.Context, id pgtype.UUID) (Artifact, error) {
@@ -175,6 +538,49 @@ func (q *Queries) SoftDeleteArtifact(ctx context.Context, id pgtype.UUID) (Artif
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
+
+const softDeleteArtifactsByApplication = `-- name: SoftDeleteArtifactsByApplication :exec
+UPDATE artifacts SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL
+    AND release_id IN (SELECT r.id FROM application_releases r WHERE r.application_id = $1::uuid)
+`
+
+// Cascades an application deletion.
+func (q *Queries) SoftDeleteArtifactsByApplication(ctx context.Context, applicationID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteArtifactsByApplication, applicationID)
+	return err
+}
+
+const softDeleteArtifactsByProject = `-- name: SoftDeleteArtifactsByProject :exec
+UPDATE artifacts SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL
+    AND release_id IN (
+        SELECT r.id FROM application_releases r
+        JOIN applications a ON a.id = r.application_id
+        WHERE a.project_id = $1::uuid
+    )
+`
+
+// Cascades a project deletion.
+func (q *Queries) SoftDeleteArtifactsByProject(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteArtifactsByProject, projectID)
+	return err
+}
+
+const softDeleteArtifactsByRelease = `-- name: SoftDeleteArtifactsByRelease :exec
+UPDATE artifacts SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE release_id = $1 AND deleted_at IS NULL
+`
+
+// Cascades a release deletion; children share the parent's deleted_at so restoring can find them.
+func (q *Queries) SoftDeleteArtifactsByRelease(ctx context.Context, releaseID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteArtifactsByRelease, releaseID)
+	return err
+}
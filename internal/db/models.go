@@ -55,47 +55,112 @@ func (ns NullProjectInviteStatus) Value() (driver.Value, error) {
 	return string(ns.ProjectInviteStatus), nil
 }
 
-type ReleaseEnvironment string
+type PromotionRequestStatus string
 
 const (
-	ReleaseEnvironmentDevelopment ReleaseEnvironment = "development"
-	ReleaseEnvironmentStaging     ReleaseEnvironment = "staging"
-	ReleaseEnvironmentProduction  ReleaseEnvironment = "production"
+	PromotionRequestStatusPending   PromotionRequestStatus = "pending"
+	PromotionRequestStatusApproved  PromotionRequestStatus = "approved"
+	PromotionRequestStatusRejected  PromotionRequestStatus = "rejected"
+	PromotionRequestStatusCancelled PromotionRequestStatus = "cancelled"
+	PromotionRequestStatusFailed    PromotionRequestStatus = "failed"
 )
 
-func (e *ReleaseEnvironment) Scan(src interface{}) error {
+func (e *PromotionRequestStatus) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = ReleaseEnvironment(s)
+		*e = PromotionRequestStatus(s)
 	case string:
-		*e = ReleaseEnvironment(s)
+		*e = PromotionRequestStatus(s)
 	default:
-		return fmt.Errorf("unsupported scan type for ReleaseEnvironment: %T", src)
+		return fmt.Errorf("unsupported scan type for PromotionRequestStatus: %T", src)
 	}
 	return nil
 }
 
-type NullReleaseEnvironment struct {
-	ReleaseEnvironment ReleaseEnvironment `json:"release_environment"`
-	Valid              bool               `json:"valid"` // Valid is true if ReleaseEnvironment is not NULL
+type NullPromotionRequestStatus struct {
+	PromotionRequestStatus PromotionRequestStatus `json:"promotion_request_status"`
+	Valid                  bool                   `json:"valid"` // Valid is true if PromotionRequestStatus is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullReleaseEnvironment) Scan(value interface{}) error {
+func (ns *NullPromotionRequestStatus) Scan(value interface{}) error {
 	if value == nil {
-		ns.ReleaseEnvironment, ns.Valid = "", false
+		ns.PromotionRequestStatus, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.ReleaseEnvironment.Scan(value)
+	return ns.PromotionRequestStatus.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullReleaseEnvironment) Value() (driver.Value, error) {
+func (ns NullPromotionRequestStatus) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.ReleaseEnvironment), nil
+	return string(ns.PromotionRequestStatus), nil
+}
+
+type ReleaseStatus string
+
+const (
+	ReleaseStatusDraft     ReleaseStatus = "draft"
+	ReleaseStatusPublished ReleaseStatus = "published"
+	ReleaseStatusWithdrawn ReleaseStatus = "withdrawn"
+	ReleaseStatusArchived  ReleaseStatus = "archived"
+)
+
+func (e *ReleaseStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReleaseStatus(s)
+	case string:
+		*e = ReleaseStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReleaseStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReleaseStatus struct {
+	ReleaseStatus ReleaseStatus `json:"release_status"`
+	Valid         bool          `json:"valid"` // Valid is true if ReleaseStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReleaseStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReleaseStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReleaseStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReleaseStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReleaseStatus), nil
+}
+
+type AccountRestoreToken struct {
+	ID        pgtype.UUID      `json:"id"`
+	TokenHash string           `json:"token_hash"`
+	UserID    pgtype.UUID      `json:"user_id"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type AdminAuditLog struct {
+	ID           pgtype.UUID      `json:"id"`
+	ActorID      pgtype.UUID      `json:"actor_id"`
+	Action       string           `json:"action"`
+	TargetUserID pgtype.UUID      `json:"target_user_id"`
+	Details      []byte           `json:"details"`
+	IpAddress    string           `json:"ip_address"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
 }
 
 type Application struct {
@@ -109,17 +174,37 @@ type Application struct {
 	DeletedAt   pgtype.Timestamp `json:"deleted_at"`
 }
 
+type ApplicationApiKey struct {
+	ID            pgtype.UUID      `json:"id"`
+	ApplicationID pgtype.UUID      `json:"application_id"`
+	Name          string           `json:"name"`
+	KeyPrefix     string           `json:"key_prefix"`
+	KeyHash       string           `json:"key_hash"`
+	CreatedBy     pgtype.UUID      `json:"created_by"`
+	LastUsedAt    pgtype.Timestamp `json:"last_used_at"`
+	RevokedAt     pgtype.Timestamp `json:"revoked_at"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
 type ApplicationRelease struct {
-	ID            pgtype.UUID        `json:"id"`
-	Title         string             `json:"title"`
-	VersionCode   int32              `json:"version_code"`
-	VersionName   string             `json:"version_name"`
-	ReleaseNote   pgtype.Text        `json:"release_note"`
-	Environment   ReleaseEnvironment `json:"environment"`
-	ApplicationID pgtype.UUID        `json:"application_id"`
-	CreatedAt     pgtype.Timestamp   `json:"created_at"`
-	UpdatedAt     pgtype.Timestamp   `json:"updated_at"`
-	DeletedAt     pgtype.Timestamp   `json:"deleted_at"`
+	ID                pgtype.UUID      `json:"id"`
+	Title             string           `json:"title"`
+	VersionCode       int32            `json:"version_code"`
+	VersionName       string           `json:"version_name"`
+	ReleaseNote       pgtype.Text      `json:"release_note"`
+	Environment       string           `json:"environment"`
+	ApplicationID     pgtype.UUID      `json:"application_id"`
+	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
+	DeletedAt         pgtype.Timestamp `json:"deleted_at"`
+	WithdrawnAt       pgtype.Timestamp `json:"withdrawn_at"`
+	WithdrawnBy       pgtype.UUID      `json:"withdrawn_by"`
+	WithdrawalReason  string           `json:"withdrawal_reason"`
+	Status            ReleaseStatus    `json:"status"`
+	PublishedAt       pgtype.Timestamp `json:"published_at"`
+	RolloutPercentage int32            `json:"rollout_percentage"`
+	RolloutHaltedAt   pgtype.Timestamp `json:"rollout_halted_at"`
+	Mandatory         bool             `json:"mandatory"`
 }
 
 type Artifact struct {
@@ -133,6 +218,33 @@ type Artifact struct {
 	CreatedAt  pgtype.Timestamp `json:"created_at"`
 	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
 	DeletedAt  pgtype.Timestamp `json:"deleted_at"`
+	VerifiedAt pgtype.Timestamp `json:"verified_at"`
+}
+
+type EmailChangeToken struct {
+	ID        pgtype.UUID      `json:"id"`
+	TokenHash string           `json:"token_hash"`
+	UserID    pgtype.UUID      `json:"user_id"`
+	NewEmail  string           `json:"new_email"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	ID         pgtype.UUID      `json:"id"`
+	Identifier string           `json:"identifier"`
+	IpAddress  string           `json:"ip_address"`
+	UserID     pgtype.UUID      `json:"user_id"`
+	Succeeded  bool             `json:"succeeded"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type LoginThrottle struct {
+	Key           string           `json:"key"`
+	FailureCount  int32            `json:"failure_count"`
+	LockedUntil   pgtype.Timestamp `json:"locked_until"`
+	LastFailureAt pgtype.Timestamp `json:"last_failure_at"`
 }
 
 type MembershipPermission struct {
@@ -140,6 +252,44 @@ type MembershipPermission struct {
 	PermissionID int32       `json:"permission_id"`
 }
 
+type OauthState struct {
+	State        string           `json:"state"`
+	Provider     string           `json:"provider"`
+	CodeVerifier string           `json:"code_verifier"`
+	Nonce        string           `json:"nonce"`
+	ExpiresAt    pgtype.Timestamp `json:"expires_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+}
+
+type Organization struct {
+	ID              pgtype.UUID      `json:"id"`
+	Name            string           `json:"name"`
+	PersonalOwnerID pgtype.UUID      `json:"personal_owner_id"`
+	MaxProjects     int32            `json:"max_projects"`
+	MaxMembers      int32            `json:"max_members"`
+	MaxApplications int32            `json:"max_applications"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	ID             pgtype.UUID      `json:"id"`
+	Role           string           `json:"role"`
+	OrganizationID pgtype.UUID      `json:"organization_id"`
+	UserID         pgtype.UUID      `json:"user_id"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+}
+
+type PasswordResetToken struct {
+	ID        pgtype.UUID      `json:"id"`
+	TokenHash string           `json:"token_hash"`
+	UserID    pgtype.UUID      `json:"user_id"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
 type Permission struct {
 	ID          int32       `json:"id"`
 	Key         string      `json:"key"`
@@ -147,13 +297,15 @@ type Permission struct {
 }
 
 type Project struct {
-	ID          pgtype.UUID      `json:"id"`
-	Title       string           `json:"title"`
-	Description string           `json:"description"`
-	OwnerID     pgtype.UUID      `json:"owner_id"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	DeletedAt   pgtype.Timestamp `json:"deleted_at"`
+	ID             pgtype.UUID      `json:"id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	OwnerID        pgtype.UUID      `json:"owner_id"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	DeletedAt      pgtype.Timestamp `json:"deleted_at"`
+	Settings       []byte           `json:"settings"`
+	OrganizationID pgtype.UUID      `json:"organization_id"`
 }
 
 type ProjectInvite struct {
@@ -176,17 +328,112 @@ type ProjectMembership struct {
 	DeletedAt pgtype.Timestamp `json:"deleted_at"`
 }
 
+type PromotionRequest struct {
+	ID                pgtype.UUID            `json:"id"`
+	ApplicationID     pgtype.UUID            `json:"application_id"`
+	ReleaseID         pgtype.UUID            `json:"release_id"`
+	FromEnvironment   string                 `json:"from_environment"`
+	ToEnvironment     string                 `json:"to_environment"`
+	RequestedBy       pgtype.UUID            `json:"requested_by"`
+	Note              string                 `json:"note"`
+	Status            PromotionRequestStatus `json:"status"`
+	RequiredApprovals int32                  `json:"required_approvals"`
+	ApproverRoles     []string               `json:"approver_roles"`
+	PromotedReleaseID pgtype.UUID            `json:"promoted_release_id"`
+	FailureReason     string                 `json:"failure_reason"`
+	ResolvedAt        pgtype.Timestamp       `json:"resolved_at"`
+	CreatedAt         pgtype.Timestamp       `json:"created_at"`
+	UpdatedAt         pgtype.Timestamp       `json:"updated_at"`
+	RolloutPercentage int32                  `json:"rollout_percentage"`
+}
+
+type PromotionReview struct {
+	ID         pgtype.UUID      `json:"id"`
+	RequestID  pgtype.UUID      `json:"request_id"`
+	ReviewerID pgtype.UUID      `json:"reviewer_id"`
+	Approved   bool             `json:"approved"`
+	Comment    string           `json:"comment"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type ReleaseChannel struct {
+	ID                      pgtype.UUID      `json:"id"`
+	ApplicationID           pgtype.UUID      `json:"application_id"`
+	Name                    string           `json:"name"`
+	Position                int32            `json:"position"`
+	Visibility              string           `json:"visibility"`
+	IsDefault               bool             `json:"is_default"`
+	CreatedAt               pgtype.Timestamp `json:"created_at"`
+	UpdatedAt               pgtype.Timestamp `json:"updated_at"`
+	MinSupportedVersionCode int32            `json:"min_supported_version_code"`
+}
+
+type ReleaseNote struct {
+	ReleaseID pgtype.UUID      `json:"release_id"`
+	Locale    string           `json:"locale"`
+	Note      string           `json:"note"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type ReleasePromotion struct {
+	ID              pgtype.UUID      `json:"id"`
+	ApplicationID   pgtype.UUID      `json:"application_id"`
+	SourceReleaseID pgtype.UUID      `json:"source_release_id"`
+	ReleaseID       pgtype.UUID      `json:"release_id"`
+	VersionCode     int32            `json:"version_code"`
+	VersionName     string           `json:"version_name"`
+	FromEnvironment string           `json:"from_environment"`
+	ToEnvironment   string           `json:"to_environment"`
+	ActorID         pgtype.UUID      `json:"actor_id"`
+	Note            string           `json:"note"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+}
+
 type User struct {
-	ID           pgtype.UUID      `json:"id"`
-	Email        string           `json:"email"`
-	Username     string           `json:"username"`
-	PhoneNumber  string           `json:"phone_number"`
-	PasswordHash string           `json:"password_hash"`
-	IsActive     bool             `json:"is_active"`
-	FirstName    string           `json:"first_name"`
-	LastName     string           `json:"last_name"`
+	ID              pgtype.UUID      `json:"id"`
+	Email           string           `json:"email"`
+	Username        string           `json:"username"`
+	PhoneNumber     pgtype.Text      `json:"phone_number"`
+	PasswordHash    string           `json:"password_hash"`
+	IsActive        bool             `json:"is_active"`
+	FirstName       string           `json:"first_name"`
+	LastName        string           `json:"last_name"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt     pgtype.Timestamp `json:"last_login_at"`
+	DeletedAt       pgtype.Timestamp `json:"deleted_at"`
+	TokenVersion    int32            `json:"token_version"`
+	IsAdmin         bool             `json:"is_admin"`
+	AnonymizedAt    pgtype.Timestamp `json:"anonymized_at"`
+	AvatarUrl       pgtype.Text      `json:"avatar_url"`
+	VerifiedEmail   pgtype.Text      `json:"verified_email"`
+	StoragePurgedAt pgtype.Timestamp `json:"storage_purged_at"`
+}
+
+type UserIdentity struct {
+	ID          pgtype.UUID      `json:"id"`
+	Provider    string           `json:"provider"`
+	Subject     string           `json:"subject"`
+	Email       string           `json:"email"`
+	UserID      pgtype.UUID      `json:"user_id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+}
+
+type UserMfa struct {
+	UserID       pgtype.UUID      `json:"user_id"`
+	Secret       string           `json:"secret"`
+	EnabledAt    pgtype.Timestamp `json:"enabled_at"`
+	LastUsedStep int64            `json:"last_used_step"`
 	CreatedAt    pgtype.Timestamp `json:"created_at"`
 	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
-	DeletedAt    pgtype.Timestamp `json:"deleted_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        pgtype.UUID      `json:"id"`
+	CodeHash  string           `json:"code_hash"`
+	UserID    pgtype.UUID      `json:"user_id"`
+	UsedAt    pgtype.Timestamp `json:"used_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
@@ -11,24 +11,43 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countProjectsByOrganization = `-- name: CountProjectsByOrganization :one
+SELECT COUNT(*) FROM projects
+WHERE organization_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountProjectsByOrganization(ctx context.Context, organizationID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjectsByOrganization, organizationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProject = `-- name: CreateProject :one
 INSERT INTO projects (
     title,
     description,
-    owner_id
+    owner_id,
+    organization_id
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at
+    $1, $2, $3, $4
+) RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
 `
 
 type CreateProjectParams struct {
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	OwnerID     pgtype.UUID `json:"owner_id"`
+	Title          string      `json:"title"`
+	Description    string      `json:"description"`
+	OwnerID        pgtype.UUID `json:"owner_id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
-	row := q.db.QueryRow(ctx, createProject, arg.Title, arg.Description, arg.OwnerID)
+	row := q.db.QueryRow(ctx, createProject,
+		arg.Title,
+		arg.Description,
+		arg.OwnerID,
+		arg.OrganizationID,
+	)
 	var i Project
 	err := row.Scan(
 		&i.ID,
@@ -38,12 +57,69 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getDeletedProjectByID = `-- name: GetDeletedProjectByID :one
+
+SELECT id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id FROM projects
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+// ============================================================================
+// Trash Queries
+// ============================================================================
+func (q *Queries) GetDeletedProjectByID(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, getDeletedProjectByID, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getProjectAccess = `-- name: GetProjectAccess :one
+SELECT
+    (array_agg(role ORDER BY rank))[1]::text AS role,
+    COALESCE(array_agg(DISTINCT permission ORDER BY permission) FILTER (WHERE permission IS NOT NULL), '{}')::text[] AS permissions
+FROM project_access
+WHERE project_id = $1::uuid AND user_id = $2::uuid
+`
+
+type GetProjectAccessParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetProjectAccessRow struct {
+	Role        pgtype.Text `json:"role"`
+	Permissions []string    `json:"permissions"`
+}
+
+// Effective role and permissions of a user in a project; role is NULL without access.
+func (q *Queries) GetProjectAccess(ctx context.Context, arg GetProjectAccessParams) (GetProjectAccessRow, error) {
+	row := q.db.QueryRow(ctx, getProjectAccess, arg.ProjectID, arg.UserID)
+	var i GetProjectAccessRow
+	err := row.Scan(
+		&i.Role,
+		&i.Permissions,
 	)
 	return i, err
 }
 
 const getProjectByID = `-- name: GetProjectByID :one
-SELECT id, title, description, owner_id, created_at, updated_at, deleted_at FROM projects 
+SELECT id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id FROM projects 
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -58,6 +134,8 @@ func (q *Queries) GetProjectByID(ctx context.Context, id pgtype.UUID) (Project,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -71,14 +149,93 @@ func (q *Queries) HardDeleteProject(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const listDeletedProjectsForUser = `-- name: ListDeletedProjectsForUser :many
+SELECT id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id FROM projects
+WHERE deleted_at IS NOT NULL
+    AND (
+        owner_id = $1::uuid
+        OR organization_id IN (
+            SELECT om.organization_id FROM organization_members om
+            WHERE om.user_id = $1::uuid AND om.role = 'owner'
+        )
+    )
+ORDER BY deleted_at DESC, id DESC
+LIMIT $2::int
+`
+
+type ListDeletedProjectsForUserParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	MaxResults int32       `json:"max_results"`
+}
+
+// Deleted projects the user could have deleted: the ones they own, and every
+// one of the organizations they own.
+func (q *Queries) ListDeletedProjectsForUser(ctx context.Context, arg ListDeletedProjectsForUserParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listDeletedProjectsForUser, arg.UserID, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Settings,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProjectsByOwner = `-- name: ListProjectsByOwner :many
-SELECT id, title, description, owner_id, created_at, updated_at, deleted_at FROM projects 
+SELECT id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id FROM projects
 WHERE owner_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC
+    AND ($2::text IS NULL OR title ILIKE '%' || $2::text || '%')
+    AND (
+        $3::uuid IS NULL
+        OR ($4::bool AND (created_at, id) > ($5::timestamp, $3::uuid))
+        OR (NOT $4::bool AND (created_at, id) < ($5::timestamp, $3::uuid))
+    )
+ORDER BY
+    CASE WHEN $4::bool THEN created_at END ASC,
+    CASE WHEN $4::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $6::int
 `
 
-func (q *Queries) ListProjectsByOwner(ctx context.Context, ownerID pgtype.UUID) ([]Project, error) {
-	rows, err := q.db.Query(ctx, listProjectsByOwner, ownerID)
+type ListProjectsByOwnerParams struct {
+	OwnerID        pgtype.UUID      `json:"owner_id"`
+	Search         pgtype.Text      `json:"search"`
+	AfterID        pgtype.UUID      `json:"after_id"`
+	Ascending      bool             `json:"ascending"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	MaxResults     int32            `json:"max_results"`
+}
+
+// Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+func (q *Queries) ListProjectsByOwner(ctx context.Context, arg ListProjectsByOwnerParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsByOwner,
+		arg.OwnerID,
+		arg.Search,
+		arg.AfterID,
+		arg.Ascending,
+		arg.AfterCreatedAt,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +251,8 @@ func (q *Queries) ListProjectsByOwner(ctx context.Context, ownerID pgtype.UUID)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Settings,
+			&i.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -105,12 +264,198 @@ func (q *Queries) ListProjectsByOwner(ctx context.Context, ownerID pgtype.UUID)
 	return items, nil
 }
 
+const listProjectsForUser = `-- name: ListProjectsForUser :many
+WITH access AS (
+    SELECT
+        pa.project_id,
+        (array_agg(pa.role ORDER BY pa.rank))[1] AS role,
+        COALESCE(array_agg(DISTINCT pa.permission ORDER BY pa.permission) FILTER (WHERE pa.permission IS NOT NULL), '{}') AS permissions
+    FROM project_access pa
+    WHERE pa.user_id = $1::uuid
+    GROUP BY pa.project_id
+)
+SELECT
+    p.id,
+    p.title,
+    p.description,
+    p.owner_id,
+    p.created_at,
+    p.updated_at,
+    p.settings,
+    p.organization_id,
+    a.role::text AS role,
+    a.permissions::text[] AS permissions,
+    (SELECT COUNT(*) FROM applications app WHERE app.project_id = p.id AND app.deleted_at IS NULL) AS application_count,
+    lr.id AS latest_release_id,
+    lr.application_id AS latest_release_application_id,
+    lr.version_name AS latest_release_version_name,
+    lr.version_code AS latest_release_version_code,
+    lr.created_at AS latest_release_created_at
+FROM access a
+JOIN projects p ON p.id = a.project_id
+LEFT JOIN LATERAL (
+    SELECT r.id, r.application_id, r.version_name, r.version_code, r.created_at
+    FROM application_releases r
+    JOIN applications app ON app.id = r.application_id AND app.deleted_at IS NULL
+    WHERE app.project_id = p.id AND r.environment = 'production' AND r.deleted_at IS NULL AND r.status = 'published'
+    ORDER BY r.created_at DESC, r.id DESC
+    LIMIT 1
+) lr ON true
+WHERE p.deleted_at IS NULL
+    AND ($2::text IS NULL OR p.title ILIKE '%' || $2::text || '%')
+    AND (
+        $3::uuid IS NULL
+        OR ($4::bool AND (p.created_at, p.id) > ($5::timestamp, $3::uuid))
+        OR (NOT $4::bool AND (p.created_at, p.id) < ($5::timestamp, $3::uuid))
+    )
+ORDER BY
+    CASE WHEN $4::bool THEN p.created_at END ASC,
+    CASE WHEN $4::bool THEN p.id END ASC,
+    p.created_at DESC,
+    p.id DESC
+LIMIT $6::int
+`
+
+type ListProjectsForUserParams struct {
+	UserID         pgtype.UUID      `json:"user_id"`
+	Search         pgtype.Text      `json:"search"`
+	AfterID        pgtype.UUID      `json:"after_id"`
+	Ascending      bool             `json:"ascending"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	MaxResults     int32            `json:"max_results"`
+}
+
+type ListProjectsForUserRow struct {
+	ID                         pgtype.UUID      `json:"id"`
+	Title                      string           `json:"title"`
+	Description                string           `json:"description"`
+	OwnerID                    pgtype.UUID      `json:"owner_id"`
+	CreatedAt                  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt                  pgtype.Timestamp `json:"updated_at"`
+	Settings                   []byte           `json:"settings"`
+	OrganizationID             pgtype.UUID      `json:"organization_id"`
+	Role                       string           `json:"role"`
+	Permissions                []string         `json:"permissions"`
+	ApplicationCount           int64            `json:"application_count"`
+	LatestReleaseID            pgtype.UUID      `json:"latest_release_id"`
+	LatestReleaseApplicationID pgtype.UUID      `json:"latest_release_application_id"`
+	LatestReleaseVersionName   pgtype.Text      `json:"latest_release_version_name"`
+	LatestReleaseVersionCode   pgtype.Int4      `json:"latest_release_version_code"`
+	LatestReleaseCreatedAt     pgtype.Timestamp `json:"latest_release_created_at"`
+}
+
+// Projects a user can access (owned, through a project membership or through their
+// organization), with their role, effective permissions, application count and latest
+// production release, in one round trip.
+// Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+func (q *Queries) ListProjectsForUser(ctx context.Context, arg ListProjectsForUserParams) ([]ListProjectsForUserRow, error) {
+	rows, err := q.db.Query(ctx, listProjectsForUser,
+		arg.UserID,
+		arg.Search,
+		arg.AfterID,
+		arg.Ascending,
+		arg.AfterCreatedAt,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectsForUserRow{}
+	for rows.Next() {
+		var i ListProjectsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Settings,
+			&i.OrganizationID,
+			&i.Role,
+			&i.Permissions,
+			&i.ApplicationCount,
+			&i.LatestReleaseID,
+			&i.LatestReleaseApplicationID,
+			&i.LatestReleaseVersionName,
+			&i.LatestReleaseVersionCode,
+			&i.LatestReleaseCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableProjects = `-- name: ListPurgeableProjects :many
+SELECT id FROM projects
+WHERE deleted_at < $1::timestamp
+    AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.project_id = projects.id)
+LIMIT $2::int
+`
+
+type ListPurgeableProjectsParams struct {
+	DeletedBefore pgtype.Timestamp `json:"deleted_before"`
+	MaxResults    int32            `json:"max_results"`
+}
+
+// Projects deleted before the cutoff whose applications have all been purged.
+func (q *Queries) ListPurgeableProjects(ctx context.Context, arg ListPurgeableProjectsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listPurgeableProjects, arg.DeletedBefore, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreProject = `-- name: RestoreProject :one
+UPDATE projects SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
+`
+
+func (q *Queries) RestoreProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, restoreProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
 const softDeleteProject = `-- name: SoftDeleteProject :one
 
 UPDATE projects SET
     deleted_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
 `
 
 // ============================================================================
@@ -127,6 +472,8 @@ func (q *Queries) SoftDeleteProject(ctx context.Context, id pgtype.UUID) (Projec
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -136,7 +483,7 @@ UPDATE projects SET
     owner_id = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
 `
 
 type TransferProjectOwnershipParams struct {
@@ -155,6 +502,8 @@ func (q *Queries) TransferProjectOwnership(ctx context.Context, arg TransferProj
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -165,7 +514,7 @@ UPDATE projects SET
     description = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
 `
 
 type UpdateProjectParams struct {
@@ -186,6 +535,8 @@ func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -195,7 +546,7 @@ UPDATE projects SET
     description = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
 `
 
 type UpdateProjectDescriptionParams struct {
@@ -214,6 +565,38 @@ func (q *Queries) UpdateProjectDescription(ctx context.Context, arg UpdateProjec
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const updateProjectSettings = `-- name: UpdateProjectSettings :one
+UPDATE projects SET
+    settings = $2::jsonb,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
+`
+
+type UpdateProjectSettingsParams struct {
+	ID       pgtype.UUID `json:"id"`
+	Settings []byte      `json:"settings"`
+}
+
+func (q *Queries) UpdateProjectSettings(ctx context.Context, arg UpdateProjectSettingsParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectSettings, arg.ID, arg.Settings)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -224,7 +607,7 @@ UPDATE projects SET
     title = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at
+RETURNING id, title, description, owner_id, created_at, updated_at, deleted_at, settings, organization_id
 `
 
 type UpdateProjectTitleParams struct {
@@ -246,6 +629,8 @@ func (q *Queries) UpdateProjectTitle(ctx context.Context, arg UpdateProjectTitle
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Settings,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -0,0 +1,24 @@
+-- name: CreateAccountRestoreToken :one
+INSERT INTO account_restore_tokens (
+    token_hash,
+    user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetAccountRestoreTokenByHash :one
+SELECT * FROM account_restore_tokens
+WHERE token_hash = $1;
+
+-- name: MarkAccountRestoreTokenUsed :one
+-- Guarded on used_at so that concurrent redemptions cannot both succeed.
+UPDATE account_restore_tokens SET
+    used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL
+RETURNING *;
+
+-- name: InvalidateUserAccountRestoreTokens :exec
+UPDATE account_restore_tokens SET
+    used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND used_at IS NULL;
@@ -0,0 +1,21 @@
+-- name: CreateAdminAuditLog :one
+INSERT INTO admin_audit_logs (
+    actor_id,
+    action,
+    target_user_id,
+    details,
+    ip_address
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListAdminAuditLogs :many
+SELECT * FROM admin_audit_logs
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: ListAdminAuditLogsByTarget :many
+SELECT * FROM admin_audit_logs
+WHERE target_user_id = $1
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_results)::int;
@@ -0,0 +1,33 @@
+-- name: CreateApplicationAPIKey :one
+INSERT INTO application_api_keys (
+    application_id,
+    name,
+    key_prefix,
+    key_hash,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetApplicationAPIKeyByHash :one
+-- Only live keys of live applications authenticate.
+SELECT k.* FROM application_api_keys k
+JOIN applications a ON a.id = k.application_id
+WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND a.deleted_at IS NULL;
+
+-- name: ListApplicationAPIKeys :many
+SELECT * FROM application_api_keys
+WHERE application_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokeApplicationAPIKey :one
+UPDATE application_api_keys SET
+    revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND application_id = $2 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: TouchApplicationAPIKey :exec
+-- Records key usage, at most once a minute to spare writes on busy keys.
+UPDATE application_api_keys SET
+    last_used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute');
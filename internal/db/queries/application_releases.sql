@@ -5,14 +5,19 @@ INSERT INTO application_releases (
     version_name,
     release_note,
     environment,
-    application_id
+    application_id,
+    rollout_percentage,
+    mandatory,
+    status,
+    published_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $9 = 'published' THEN CURRENT_TIMESTAMP END
 ) RETURNING *;
 
 -- name: GetApplicationReleaseByID :one
-SELECT * FROM application_releases 
-WHERE id = $1 AND deleted_at IS NULL;
+SELECT * FROM application_releases
+WHERE id = $1 AND deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM applications a WHERE a.id = application_releases.application_id AND a.deleted_at IS NULL);
 
 -- name: CheckReleaseExists :one
 SELECT EXISTS (
@@ -20,10 +25,38 @@ SELECT EXISTS (
     WHERE application_id = $1 AND version_code = $2 AND environment = $3 AND deleted_at IS NULL
 );
 
+-- name: GetHighestVersionCode :one
+-- Counts every release of the environment, deleted ones included: their builds
+-- may already be installed, and their version codes stay taken until purged.
+SELECT COALESCE(MAX(version_code), 0)::int AS highest FROM application_releases
+WHERE application_id = $1 AND environment = $2;
+
 -- name: ListReleasesByApplication :many
-SELECT * FROM application_releases 
+-- Keyset pagination on (version_code, id); callers fetch one extra row to detect a next page.
+SELECT * FROM application_releases
 WHERE application_id = $1 AND deleted_at IS NULL
-ORDER BY version_code DESC;
+    AND (sqlc.narg(status)::release_status IS NULL OR status = sqlc.narg(status)::release_status)
+    AND (sqlc.arg(include_drafts)::bool OR status <> 'draft')
+    AND (
+        environment = sqlc.narg(environment)::text
+        -- Without a channel filter, unlisted channels are left out
+        OR (sqlc.narg(environment)::text IS NULL AND NOT EXISTS (
+            SELECT 1 FROM release_channels c
+            WHERE c.application_id = application_releases.application_id
+                AND c.name = application_releases.environment AND c.visibility = 'unlisted'
+        ))
+    )
+    AND (
+        sqlc.narg(after_id)::uuid IS NULL
+        OR (sqlc.arg(ascending)::bool AND (version_code, id) > (sqlc.narg(after_version_code)::int, sqlc.narg(after_id)::uuid))
+        OR (NOT sqlc.arg(ascending)::bool AND (version_code, id) < (sqlc.narg(after_version_code)::int, sqlc.narg(after_id)::uuid))
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(ascending)::bool THEN version_code END ASC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN id END ASC,
+    version_code DESC,
+    id DESC
+LIMIT sqlc.arg(max_results)::int;
 
 -- name: ListReleasesByEnvironment :many
 SELECT * FROM application_releases 
@@ -31,11 +64,53 @@ WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
 ORDER BY version_code DESC;
 
 -- name: GetLatestReleaseByEnvironment :one
+-- Only published releases count: rolling a channel back makes the previous release its latest.
 SELECT * FROM application_releases 
-WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL AND status = 'published'
 ORDER BY version_code DESC
 LIMIT 1;
 
+-- name: ListRolloutCandidates :many
+-- The published releases of an environment, newest first, down to the newest
+-- one fully rolled out: a client gets the first whose rollout includes it.
+SELECT * FROM application_releases
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL AND status = 'published'
+    AND version_code >= COALESCE((
+        SELECT MAX(full_r.version_code) FROM application_releases full_r
+        WHERE full_r.application_id = $1 AND full_r.environment = $2 AND full_r.deleted_at IS NULL
+            AND full_r.status = 'published' AND full_r.rollout_percentage = 100 AND full_r.rollout_halted_at IS NULL
+    ), 0)
+ORDER BY version_code DESC;
+
+-- name: GetHighestFullyRolledOutVersionCode :one
+-- The newest published release of an environment offered to every client, or 0.
+SELECT COALESCE(MAX(version_code), 0)::int AS highest FROM application_releases
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+    AND status = 'published' AND rollout_percentage = 100 AND rollout_halted_at IS NULL;
+
+-- name: CheckMandatoryReleaseBetween :one
+-- Whether a client updating past after_version_code up to up_to_version_code
+-- skips a published mandatory release.
+SELECT EXISTS (
+    SELECT 1 FROM application_releases
+    WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+        AND status = 'published' AND mandatory
+        AND version_code > sqlc.arg(after_version_code)::int
+        AND version_code <= sqlc.arg(up_to_version_code)::int
+);
+
+-- name: ListChangelogReleases :many
+-- The releases a client updating past after_version_code up to
+-- up_to_version_code gets the changes of, newest first. Drafts and withdrawn
+-- builds were never offered; archived ones were.
+SELECT * FROM application_releases
+WHERE application_id = $1 AND environment = $2 AND deleted_at IS NULL
+    AND status IN ('published', 'archived')
+    AND version_code > sqlc.arg(after_version_code)::int
+    AND version_code <= sqlc.arg(up_to_version_code)::int
+ORDER BY version_code DESC
+LIMIT sqlc.arg(max_results)::int;
+
 -- ============================================================================
 -- Granular Update Queries
 -- ============================================================================
@@ -55,20 +130,72 @@ WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: UpdateRelease :one
--- Full update for title + release_note
+-- Full update for title, release_note and the mandatory flag
 UPDATE application_releases SET
     title = $2,
     release_note = $3,
+    mandatory = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
--- name: PromoteRelease :one
--- Change environment (e.g., development -> staging -> production)
+-- ============================================================================
+-- Status Queries
+-- Each only matches the statuses the transition starts from, so concurrent
+-- transitions cannot both apply.
+-- ============================================================================
+
+-- name: PublishApplicationRelease :one
+-- Publishes a draft, or reinstates a withdrawn release.
 UPDATE application_releases SET
-    environment = $2,
+    status = 'published',
+    published_at = COALESCE(published_at, CURRENT_TIMESTAMP),
+    withdrawn_at = NULL,
+    withdrawn_by = NULL,
+    withdrawal_reason = '',
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = $1 AND deleted_at IS NULL AND status IN ('draft', 'withdrawn')
+RETURNING *;
+
+-- name: WithdrawApplicationRelease :one
+UPDATE application_releases SET
+    status = 'withdrawn',
+    withdrawn_at = CURRENT_TIMESTAMP,
+    withdrawn_by = $2,
+    withdrawal_reason = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND status = 'published'
+RETURNING *;
+
+-- name: ArchiveApplicationRelease :one
+UPDATE application_releases SET
+    status = 'archived',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND status IN ('published', 'withdrawn')
+RETURNING *;
+
+-- ============================================================================
+-- Rollout Queries
+-- ============================================================================
+
+-- name: IncreaseReleaseRollout :one
+-- Rollouts only grow; increasing a halted rollout resumes it, possibly at the
+-- percentage it was halted at.
+UPDATE application_releases SET
+    rollout_percentage = sqlc.arg(rollout_percentage)::int,
+    rollout_halted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)::uuid AND deleted_at IS NULL AND status = 'published'
+    AND (rollout_percentage < sqlc.arg(rollout_percentage)::int
+        OR (rollout_halted_at IS NOT NULL AND rollout_percentage <= sqlc.arg(rollout_percentage)::int))
+RETURNING *;
+
+-- name: HaltReleaseRollout :one
+UPDATE application_releases SET
+    rollout_halted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND status = 'published'
+    AND rollout_percentage < 100 AND rollout_halted_at IS NULL
 RETURNING *;
 
 -- ============================================================================
@@ -81,5 +208,91 @@ UPDATE application_releases SET
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: SoftDeleteReleasesByApplication :exec
+-- Cascades an application deletion; children share the parent's deleted_at so restoring can find them.
+UPDATE application_releases SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE application_id = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeleteReleasesByProject :exec
+-- Cascades a project deletion.
+UPDATE application_releases SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL
+    AND application_id IN (SELECT a.id FROM applications a WHERE a.project_id = sqlc.arg(project_id)::uuid);
+
 -- name: HardDeleteApplicationRelease :exec
 DELETE FROM application_releases WHERE id = $1;
+
+-- ============================================================================
+-- Trash Queries
+-- ============================================================================
+
+-- name: GetDeletedApplicationReleaseByID :one
+SELECT * FROM application_releases
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedReleasesByProject :many
+SELECT r.* FROM application_releases r
+JOIN applications a ON a.id = r.application_id
+WHERE a.project_id = sqlc.arg(project_id)::uuid AND r.deleted_at IS NOT NULL
+ORDER BY r.deleted_at DESC, r.id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: RestoreApplicationRelease :one
+UPDATE application_releases SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: RestoreReleasesDeletedWithApplication :exec
+-- Restores the releases that were deleted together with their application.
+UPDATE application_releases SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE application_id = sqlc.arg(application_id)::uuid AND deleted_at = sqlc.arg(deleted_at)::timestamp;
+
+-- name: RestoreReleasesDeletedWithProject :exec
+-- Restores the releases that were deleted together with their project.
+UPDATE application_releases SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deleted_at = sqlc.arg(deleted_at)::timestamp
+    AND application_id IN (SELECT id FROM applications WHERE project_id = sqlc.arg(project_id)::uuid);
+
+-- name: ListReleasesPastRetention :many
+-- Live releases their project's retention policy no longer keeps: past the
+-- keep_last_releases newest of their environment and older than max_age_days,
+-- whichever of the two are set. Drafts, and releases at or above the newest
+-- one offered to every client of their environment, are always kept.
+WITH ranked AS (
+    SELECT
+        r.id,
+        r.version_code,
+        r.created_at,
+        COALESCE((p.settings->'retention'->>'keep_last_releases')::int, 0) AS keep_last,
+        COALESCE((p.settings->'retention'->>'max_age_days')::int, 0) AS max_age_days,
+        ROW_NUMBER() OVER (PARTITION BY r.application_id, r.environment ORDER BY r.version_code DESC) AS position,
+        MAX(r.version_code) FILTER (WHERE r.status = 'published' AND r.rollout_percentage = 100 AND r.rollout_halted_at IS NULL)
+            OVER (PARTITION BY r.application_id, r.environment) AS served_floor
+    FROM application_releases r
+    JOIN applications a ON a.id = r.application_id AND a.deleted_at IS NULL
+    JOIN projects p ON p.id = a.project_id AND p.deleted_at IS NULL
+    WHERE r.deleted_at IS NULL AND r.status <> 'draft'
+)
+SELECT id FROM ranked
+WHERE (keep_last > 0 OR max_age_days > 0)
+    AND version_code < served_floor
+    AND (keep_last = 0 OR position > keep_last)
+    AND (max_age_days = 0 OR created_at < CURRENT_TIMESTAMP - make_interval(days => max_age_days))
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: ListPurgeableReleases :many
+-- Releases deleted before the cutoff, or belonging to an application deleted
+-- before it, whose artifacts have all been purged.
+SELECT r.id FROM application_releases r
+JOIN applications a ON a.id = r.application_id
+WHERE (r.deleted_at < sqlc.arg(deleted_before)::timestamp OR a.deleted_at < sqlc.arg(deleted_before)::timestamp)
+    AND NOT EXISTS (SELECT 1 FROM artifacts ar WHERE ar.release_id = r.id)
+LIMIT sqlc.arg(max_results)::int;
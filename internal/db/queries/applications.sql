@@ -9,17 +9,32 @@ INSERT INTO applications (
 ) RETURNING *;
 
 -- name: GetApplicationByID :one
-SELECT * FROM applications 
-WHERE id = $1 AND deleted_at IS NULL;
+SELECT * FROM applications
+WHERE id = $1 AND deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = applications.project_id AND p.deleted_at IS NULL);
 
 -- name: GetApplicationByPackageName :one
 SELECT * FROM applications 
 WHERE package_name = $1 AND deleted_at IS NULL;
 
 -- name: ListApplicationsByProject :many
-SELECT * FROM applications 
+-- Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+SELECT * FROM applications
 WHERE project_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC;
+    AND (sqlc.narg(search)::text IS NULL
+        OR title ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR package_name ILIKE '%' || sqlc.narg(search)::text || '%')
+    AND (
+        sqlc.narg(after_id)::uuid IS NULL
+        OR (sqlc.arg(ascending)::bool AND (created_at, id) > (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+        OR (NOT sqlc.arg(ascending)::bool AND (created_at, id) < (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(ascending)::bool THEN created_at END ASC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg(max_results)::int;
 
 -- ============================================================================
 -- Granular Update Queries
@@ -58,5 +73,46 @@ UPDATE applications SET
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: SoftDeleteApplicationsByProject :exec
+-- Cascades a project deletion; children share the parent's deleted_at so restoring can find them.
+UPDATE applications SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE project_id = $1 AND deleted_at IS NULL;
+
 -- name: HardDeleteApplication :exec
 DELETE FROM applications WHERE id = $1;
+
+-- ============================================================================
+-- Trash Queries
+-- ============================================================================
+
+-- name: GetDeletedApplicationByID :one
+SELECT * FROM applications
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedApplicationsByProject :many
+SELECT * FROM applications
+WHERE project_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: RestoreApplication :one
+UPDATE applications SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: RestoreApplicationsDeletedWithProject :exec
+-- Restores the applications that were deleted together with their project.
+UPDATE applications SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE project_id = sqlc.arg(project_id)::uuid AND deleted_at = sqlc.arg(deleted_at)::timestamp;
+
+-- name: ListPurgeableApplications :many
+-- Applications deleted before the cutoff whose releases have all been purged.
+SELECT id FROM applications
+WHERE deleted_at < sqlc.arg(deleted_before)::timestamp
+    AND NOT EXISTS (SELECT 1 FROM application_releases r WHERE r.application_id = applications.id)
+LIMIT sqlc.arg(max_results)::int;
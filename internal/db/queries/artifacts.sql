@@ -5,28 +5,167 @@ INSERT INTO artifacts (
     file_size,
     file_type,
     abi,
-    release_id
+    release_id,
+    verified_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, CASE WHEN sqlc.arg(verified)::bool THEN CURRENT_TIMESTAMP END
 ) RETURNING *;
 
 -- name: GetArtifactByID :one
-SELECT * FROM artifacts 
-WHERE id = $1 AND deleted_at IS NULL;
+SELECT * FROM artifacts
+WHERE id = $1 AND deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM application_releases r WHERE r.id = artifacts.release_id AND r.deleted_at IS NULL);
 
 -- name: ListArtifactsByRelease :many
-SELECT * FROM artifacts 
-WHERE release_id = $1 AND deleted_at IS NULL;
+-- Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+SELECT * FROM artifacts
+WHERE release_id = $1 AND deleted_at IS NULL
+    AND (sqlc.narg(abi)::text IS NULL OR abi = sqlc.narg(abi)::text)
+    AND (
+        sqlc.narg(after_id)::uuid IS NULL
+        OR (sqlc.arg(ascending)::bool AND (created_at, id) > (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+        OR (NOT sqlc.arg(ascending)::bool AND (created_at, id) < (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(ascending)::bool THEN created_at END ASC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg(max_results)::int;
 
 -- name: GetArtifactByReleaseAndABI :one
 SELECT * FROM artifacts 
 WHERE release_id = $1 AND abi = $2 AND deleted_at IS NULL;
 
+-- name: GetArtifactForABI :one
+-- The artifact to install on a device: one built for its ABI, else a universal
+-- one (without ABI), else any when the device did not tell its ABI. Verified
+-- and newer artifacts come first.
+SELECT * FROM artifacts
+WHERE release_id = sqlc.arg(release_id)::uuid AND deleted_at IS NULL
+    AND (abi = sqlc.arg(abi)::text OR abi IS NULL OR abi = '' OR sqlc.arg(abi)::text = '')
+ORDER BY
+    abi = sqlc.arg(abi)::text DESC NULLS LAST,
+    (abi IS NULL OR abi = '') DESC,
+    verified_at IS NULL,
+    created_at DESC
+LIMIT 1;
+
+-- name: CopyArtifactsToRelease :exec
+-- Promotion copies a release's live artifacts; the copies share the stored files.
+INSERT INTO artifacts (file_url, sha256_hash, file_size, file_type, abi, release_id, verified_at)
+SELECT file_url, sha256_hash, file_size, file_type, abi, sqlc.arg(target_release_id)::uuid, verified_at
+FROM artifacts
+WHERE release_id = sqlc.arg(source_release_id)::uuid AND deleted_at IS NULL;
+
+-- name: MarkArtifactVerified :one
+UPDATE artifacts SET
+    verified_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CheckReleaseHasVerifiedArtifact :one
+SELECT EXISTS (
+    SELECT 1 FROM artifacts
+    WHERE release_id = $1 AND deleted_at IS NULL AND verified_at IS NOT NULL
+);
+
 -- name: SoftDeleteArtifact :one
 UPDATE artifacts SET
     deleted_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: SoftDeleteArtifactsByRelease :exec
+-- Cascades a release deletion; children share the parent's deleted_at so restoring can find them.
+UPDATE artifacts SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE release_id = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeleteArtifactsByApplication :exec
+-- Cascades an application deletion.
+UPDATE artifacts SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL
+    AND release_id IN (SELECT r.id FROM application_releases r WHERE r.application_id = sqlc.arg(application_id)::uuid);
+
+-- name: SoftDeleteArtifactsByProject :exec
+-- Cascades a project deletion.
+UPDATE artifacts SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL
+    AND release_id IN (
+        SELECT r.id FROM application_releases r
+        JOIN applications a ON a.id = r.application_id
+        WHERE a.project_id = sqlc.arg(project_id)::uuid
+    );
+
 -- name: HardDeleteArtifact :exec
 DELETE FROM artifacts WHERE id = $1;
+
+-- ============================================================================
+-- Trash Queries
+-- ============================================================================
+
+-- name: GetDeletedArtifactByID :one
+SELECT * FROM artifacts
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedArtifactsByProject :many
+SELECT ar.* FROM artifacts ar
+JOIN application_releases r ON r.id = ar.release_id
+JOIN applications a ON a.id = r.application_id
+WHERE a.project_id = sqlc.arg(project_id)::uuid AND ar.deleted_at IS NOT NULL
+ORDER BY ar.deleted_at DESC, ar.id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: RestoreArtifact :one
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: RestoreArtifactsDeletedWithRelease :exec
+-- Restores the artifacts that were deleted together with their release.
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE release_id = sqlc.arg(release_id)::uuid AND deleted_at = sqlc.arg(deleted_at)::timestamp;
+
+-- name: RestoreArtifactsDeletedWithApplication :exec
+-- Restores the artifacts that were deleted together with their application.
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deleted_at = sqlc.arg(deleted_at)::timestamp
+    AND release_id IN (SELECT id FROM application_releases WHERE application_id = sqlc.arg(application_id)::uuid);
+
+-- name: RestoreArtifactsDeletedWithProject :exec
+-- Restores the artifacts that were deleted together with their project.
+UPDATE artifacts SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deleted_at = sqlc.arg(deleted_at)::timestamp
+    AND release_id IN (
+        SELECT r.id FROM application_releases r
+        JOIN applications a ON a.id = r.application_id
+        WHERE a.project_id = sqlc.arg(project_id)::uuid
+    );
+
+-- name: ListPurgeableArtifacts :many
+-- Artifacts deleted before the cutoff, or belonging to a release or an
+-- application deleted before it.
+SELECT ar.* FROM artifacts ar
+JOIN application_releases r ON r.id = ar.release_id
+JOIN applications a ON a.id = r.application_id
+WHERE ar.deleted_at < sqlc.arg(deleted_before)::timestamp
+    OR r.deleted_at < sqlc.arg(deleted_before)::timestamp
+    OR a.deleted_at < sqlc.arg(deleted_before)::timestamp
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: CountOtherArtifactsWithFileURL :one
+-- Artifacts may share a stored file; it is only removed with the last of them.
+SELECT COUNT(*) FROM artifacts
+WHERE file_url = $1 AND id <> $2;
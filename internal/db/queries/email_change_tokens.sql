@@ -0,0 +1,26 @@
+-- name: CreateEmailChangeToken :one
+INSERT INTO email_change_tokens (
+    token_hash,
+    user_id,
+    new_email,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetEmailChangeTokenByHash :one
+SELECT * FROM email_change_tokens
+WHERE token_hash = $1;
+
+-- name: MarkEmailChangeTokenUsed :one
+-- Guarded on used_at so that concurrent confirmations cannot both succeed.
+UPDATE email_change_tokens SET
+    used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL
+RETURNING *;
+
+-- name: InvalidateUserEmailChangeTokens :exec
+-- Burns every pending change of a user (a new request supersedes older ones).
+UPDATE email_change_tokens SET
+    used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND used_at IS NULL;
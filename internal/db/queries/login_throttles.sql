@@ -0,0 +1,53 @@
+-- name: CreateLoginAttempt :exec
+INSERT INTO login_attempts (
+    identifier,
+    ip_address,
+    user_id,
+    succeeded
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: ListLoginAttemptsByIdentifier :many
+SELECT * FROM login_attempts
+WHERE identifier = $1
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: GetLoginThrottle :one
+SELECT * FROM login_throttles
+WHERE key = $1;
+
+-- name: RecordLoginThrottleFailure :one
+-- Failures older than the window no longer count: the counter starts over.
+INSERT INTO login_throttles (
+    key,
+    failure_count,
+    last_failure_at
+) VALUES (
+    $1, 1, CURRENT_TIMESTAMP
+)
+ON CONFLICT (key) DO UPDATE SET
+    failure_count = CASE
+        WHEN login_throttles.last_failure_at < sqlc.arg(window_start)::timestamp THEN 1
+        ELSE login_throttles.failure_count + 1
+    END,
+    last_failure_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: LockLoginThrottle :exec
+UPDATE login_throttles
+SET locked_until = $2
+WHERE key = $1;
+
+-- name: DeleteLoginThrottle :exec
+DELETE FROM login_throttles
+WHERE key = $1;
+
+-- name: ListLockedLoginThrottles :many
+SELECT * FROM login_throttles
+WHERE locked_until > CURRENT_TIMESTAMP
+ORDER BY locked_until DESC;
+
+-- name: DeleteLoginAttemptsByUser :exec
+DELETE FROM login_attempts WHERE user_id = $1;
@@ -0,0 +1,20 @@
+-- name: CreateOAuthState :one
+INSERT INTO oauth_states (
+    state,
+    provider,
+    code_verifier,
+    nonce,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ConsumeOAuthState :one
+-- Deleting on read makes every state single use.
+DELETE FROM oauth_states
+WHERE state = $1
+RETURNING *;
+
+-- name: DeleteExpiredOAuthStates :execrows
+DELETE FROM oauth_states
+WHERE expires_at < CURRENT_TIMESTAMP;
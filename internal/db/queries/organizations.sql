@@ -0,0 +1,126 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (
+    name,
+    personal_owner_id
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations
+WHERE id = $1;
+
+-- name: LockOrganization :one
+-- Locks the organization until the end of the transaction, so that quota
+-- checks and the inserts they guard are not interleaved.
+SELECT * FROM organizations
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetPersonalOrganization :one
+SELECT * FROM organizations
+WHERE personal_owner_id = $1;
+
+-- name: ListOrganizationsForUser :many
+SELECT o.*, om.role
+FROM organizations o
+JOIN organization_members om ON om.organization_id = o.id
+WHERE om.user_id = sqlc.arg(user_id)::uuid
+ORDER BY o.created_at, o.id;
+
+-- name: GetOrganizationUsage :one
+-- Current usage counted against the organization's quotas.
+SELECT
+    (SELECT COUNT(*) FROM projects p WHERE p.organization_id = sqlc.arg(organization_id)::uuid AND p.deleted_at IS NULL) AS project_count,
+    (SELECT COUNT(*) FROM organization_members om WHERE om.organization_id = sqlc.arg(organization_id)::uuid) AS member_count,
+    (SELECT COUNT(*) FROM applications a
+        JOIN projects p ON p.id = a.project_id AND p.deleted_at IS NULL
+        WHERE p.organization_id = sqlc.arg(organization_id)::uuid AND a.deleted_at IS NULL) AS application_count;
+
+-- name: UpdateOrganizationName :one
+UPDATE organizations SET
+    name = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrganizationQuotas :one
+UPDATE organizations SET
+    max_projects = $2,
+    max_members = $3,
+    max_applications = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- ============================================================================
+-- Members
+-- ============================================================================
+
+-- name: AddOrganizationMember :one
+INSERT INTO organization_members (
+    organization_id,
+    user_id,
+    role
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT om.*, u.username, u.email, u.first_name, u.last_name
+FROM organization_members om
+JOIN users u ON u.id = om.user_id
+WHERE om.organization_id = sqlc.arg(organization_id)::uuid
+ORDER BY om.created_at, om.id;
+
+-- name: UpdateOrganizationMemberRole :one
+UPDATE organization_members SET
+    role = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE organization_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: CountOrganizationOwners :one
+SELECT COUNT(*) FROM organization_members
+WHERE organization_id = $1 AND role = 'owner';
+
+-- name: GetOtherOrganizationOwner :one
+-- The longest-standing owner of an organization other than the given user.
+SELECT user_id FROM organization_members
+WHERE organization_id = $1 AND role = 'owner' AND user_id <> $2
+ORDER BY created_at, id
+LIMIT 1;
+
+-- name: ReassignOrganizationProjects :exec
+-- Hands the projects a user owns in an organization, trashed ones included,
+-- over to another user.
+UPDATE projects SET
+    owner_id = sqlc.arg(new_owner_id)::uuid,
+    updated_at = CURRENT_TIMESTAMP
+WHERE organization_id = sqlc.arg(organization_id)::uuid AND owner_id = sqlc.arg(previous_owner_id)::uuid;
+
+-- name: DeleteOrganizationMembershipsByUser :exec
+DELETE FROM organization_members
+WHERE user_id = $1;
+
+-- name: CountSoleOwnedOrganizationsWithProjects :one
+-- Organizations with live projects where the user is the only owner; deleting the
+-- account would leave those projects without anyone to manage them.
+SELECT COUNT(*) FROM organization_members om
+WHERE om.user_id = $1 AND om.role = 'owner'
+    AND NOT EXISTS (
+        SELECT 1 FROM organization_members other
+        WHERE other.organization_id = om.organization_id AND other.role = 'owner' AND other.user_id <> om.user_id
+    )
+    AND EXISTS (
+        SELECT 1 FROM projects p
+        WHERE p.organization_id = om.organization_id AND p.deleted_at IS NULL
+    );
@@ -0,0 +1,25 @@
+-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (
+    token_hash,
+    user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT * FROM password_reset_tokens
+WHERE token_hash = $1;
+
+-- name: MarkPasswordResetTokenUsed :one
+-- Guarded on used_at so that concurrent redemptions cannot both succeed.
+UPDATE password_reset_tokens SET
+    used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL
+RETURNING *;
+
+-- name: InvalidateUserPasswordResetTokens :exec
+-- Burns every outstanding token of a user (a new request supersedes older ones).
+UPDATE password_reset_tokens SET
+    used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND used_at IS NULL;
@@ -2,9 +2,10 @@
 INSERT INTO projects (
     title,
     description,
-    owner_id
+    owner_id,
+    organization_id
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: GetProjectByID :one
@@ -12,9 +13,88 @@ SELECT * FROM projects
 WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: ListProjectsByOwner :many
-SELECT * FROM projects 
+-- Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+SELECT * FROM projects
 WHERE owner_id = $1 AND deleted_at IS NULL
-ORDER BY created_at DESC;
+    AND (sqlc.narg(search)::text IS NULL OR title ILIKE '%' || sqlc.narg(search)::text || '%')
+    AND (
+        sqlc.narg(after_id)::uuid IS NULL
+        OR (sqlc.arg(ascending)::bool AND (created_at, id) > (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+        OR (NOT sqlc.arg(ascending)::bool AND (created_at, id) < (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(ascending)::bool THEN created_at END ASC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: ListProjectsForUser :many
+-- Projects a user can access (owned, through a project membership or through their
+-- organization), with their role, effective permissions, application count and latest
+-- production release, in one round trip.
+-- Keyset pagination on (created_at, id); callers fetch one extra row to detect a next page.
+WITH access AS (
+    SELECT
+        pa.project_id,
+        (array_agg(pa.role ORDER BY pa.rank))[1] AS role,
+        COALESCE(array_agg(DISTINCT pa.permission ORDER BY pa.permission) FILTER (WHERE pa.permission IS NOT NULL), '{}') AS permissions
+    FROM project_access pa
+    WHERE pa.user_id = sqlc.arg(user_id)::uuid
+    GROUP BY pa.project_id
+)
+SELECT
+    p.id,
+    p.title,
+    p.description,
+    p.owner_id,
+    p.created_at,
+    p.updated_at,
+    p.settings,
+    p.organization_id,
+    a.role::text AS role,
+    a.permissions::text[] AS permissions,
+    (SELECT COUNT(*) FROM applications app WHERE app.project_id = p.id AND app.deleted_at IS NULL) AS application_count,
+    lr.id AS latest_release_id,
+    lr.application_id AS latest_release_application_id,
+    lr.version_name AS latest_release_version_name,
+    lr.version_code AS latest_release_version_code,
+    lr.created_at AS latest_release_created_at
+FROM access a
+JOIN projects p ON p.id = a.project_id
+LEFT JOIN LATERAL (
+    SELECT r.id, r.application_id, r.version_name, r.version_code, r.created_at
+    FROM application_releases r
+    JOIN applications app ON app.id = r.application_id AND app.deleted_at IS NULL
+    WHERE app.project_id = p.id AND r.environment = 'production' AND r.deleted_at IS NULL AND r.status = 'published'
+    ORDER BY r.created_at DESC, r.id DESC
+    LIMIT 1
+) lr ON true
+WHERE p.deleted_at IS NULL
+    AND (sqlc.narg(search)::text IS NULL OR p.title ILIKE '%' || sqlc.narg(search)::text || '%')
+    AND (
+        sqlc.narg(after_id)::uuid IS NULL
+        OR (sqlc.arg(ascending)::bool AND (p.created_at, p.id) > (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+        OR (NOT sqlc.arg(ascending)::bool AND (p.created_at, p.id) < (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(ascending)::bool THEN p.created_at END ASC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN p.id END ASC,
+    p.created_at DESC,
+    p.id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: GetProjectAccess :one
+-- Effective role and permissions of a user in a project; role is NULL without access.
+SELECT
+    (array_agg(role ORDER BY rank))[1]::text AS role,
+    COALESCE(array_agg(DISTINCT permission ORDER BY permission) FILTER (WHERE permission IS NOT NULL), '{}')::text[] AS permissions
+FROM project_access
+WHERE project_id = sqlc.arg(project_id)::uuid AND user_id = sqlc.arg(user_id)::uuid;
+
+-- name: CountProjectsByOrganization :one
+SELECT COUNT(*) FROM projects
+WHERE organization_id = $1 AND deleted_at IS NULL;
 
 -- ============================================================================
 -- Granular Update Queries
@@ -43,6 +123,13 @@ UPDATE projects SET
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: UpdateProjectSettings :one
+UPDATE projects SET
+    settings = sqlc.arg(settings)::jsonb,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
 -- name: TransferProjectOwnership :one
 UPDATE projects SET
     owner_id = $2,
@@ -62,3 +149,40 @@ RETURNING *;
 
 -- name: HardDeleteProject :exec
 DELETE FROM projects WHERE id = $1;
+
+-- ============================================================================
+-- Trash Queries
+-- ============================================================================
+
+-- name: GetDeletedProjectByID :one
+SELECT * FROM projects
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedProjectsForUser :many
+-- Deleted projects the user could have deleted: the ones they own, and every
+-- one of the organizations they own.
+SELECT * FROM projects
+WHERE deleted_at IS NOT NULL
+    AND (
+        owner_id = sqlc.arg(user_id)::uuid
+        OR organization_id IN (
+            SELECT om.organization_id FROM organization_members om
+            WHERE om.user_id = sqlc.arg(user_id)::uuid AND om.role = 'owner'
+        )
+    )
+ORDER BY deleted_at DESC, id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: RestoreProject :one
+UPDATE projects SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: ListPurgeableProjects :many
+-- Projects deleted before the cutoff whose applications have all been purged.
+SELECT id FROM projects
+WHERE deleted_at < sqlc.arg(deleted_before)::timestamp
+    AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.project_id = projects.id)
+LIMIT sqlc.arg(max_results)::int;
@@ -0,0 +1,67 @@
+-- name: CreatePromotionRequest :one
+INSERT INTO promotion_requests (
+    application_id,
+    release_id,
+    from_environment,
+    to_environment,
+    requested_by,
+    note,
+    required_approvals,
+    approver_roles,
+    rollout_percentage
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetPromotionRequestByID :one
+SELECT * FROM promotion_requests
+WHERE id = $1;
+
+-- name: GetPromotionRequestByIDForUpdate :one
+-- Serializes reviews of a request.
+SELECT * FROM promotion_requests
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListPromotionRequestsByApplication :many
+SELECT * FROM promotion_requests
+WHERE application_id = $1
+    AND (sqlc.narg(status)::promotion_request_status IS NULL OR status = sqlc.narg(status)::promotion_request_status)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: ResolvePromotionRequest :one
+UPDATE promotion_requests SET
+    status = $2,
+    promoted_release_id = $3,
+    resolved_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: FailPromotionRequest :exec
+UPDATE promotion_requests SET
+    status = 'failed',
+    failure_reason = $2,
+    resolved_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending';
+
+-- ============================================================================
+-- Reviews
+-- ============================================================================
+
+-- name: CreatePromotionReview :one
+INSERT INTO promotion_reviews (
+    request_id,
+    reviewer_id,
+    approved,
+    comment
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListPromotionReviewsByRequest :many
+SELECT * FROM promotion_reviews
+WHERE request_id = $1
+ORDER BY created_at, id;
@@ -0,0 +1,63 @@
+-- name: CreateReleaseChannel :one
+INSERT INTO release_channels (
+    application_id,
+    name,
+    position,
+    visibility,
+    is_default
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListReleaseChannelsByApplication :many
+SELECT * FROM release_channels
+WHERE application_id = $1
+ORDER BY position, name;
+
+-- name: GetReleaseChannelByName :one
+SELECT * FROM release_channels
+WHERE application_id = $1 AND name = $2;
+
+-- name: LockReleaseChannel :one
+-- Locks a channel until the end of the transaction, so that changes to its
+-- minimum supported version and to its releases are checked one at a time.
+SELECT * FROM release_channels
+WHERE application_id = $1 AND name = $2
+FOR UPDATE;
+
+-- name: GetDefaultReleaseChannel :one
+SELECT * FROM release_channels
+WHERE application_id = $1 AND is_default;
+
+-- name: UpdateReleaseChannel :one
+UPDATE release_channels SET
+    position = $3,
+    visibility = $4,
+    min_supported_version_code = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE application_id = $1 AND name = $2
+RETURNING *;
+
+-- name: ClearDefaultReleaseChannel :exec
+UPDATE release_channels SET
+    is_default = FALSE,
+    updated_at = CURRENT_TIMESTAMP
+WHERE application_id = $1 AND is_default;
+
+-- name: SetDefaultReleaseChannel :one
+UPDATE release_channels SET
+    is_default = TRUE,
+    updated_at = CURRENT_TIMESTAMP
+WHERE application_id = $1 AND name = $2
+RETURNING *;
+
+-- name: CheckReleaseChannelInUse :one
+-- Trashed releases count too: they keep their channel until purged.
+SELECT EXISTS (
+    SELECT 1 FROM application_releases
+    WHERE application_id = $1 AND environment = $2
+);
+
+-- name: DeleteReleaseChannel :exec
+DELETE FROM release_channels
+WHERE application_id = $1 AND name = $2;
@@ -0,0 +1,33 @@
+-- name: UpsertReleaseNote :one
+INSERT INTO release_notes (
+    release_id,
+    locale,
+    note
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (release_id, locale) DO UPDATE SET
+    note = EXCLUDED.note,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListReleaseNotes :many
+SELECT * FROM release_notes
+WHERE release_id = $1
+ORDER BY locale;
+
+-- name: ListReleaseNotesForReleases :many
+-- The notes of several releases in any of the given locales, for changelogs.
+SELECT * FROM release_notes
+WHERE release_id = ANY(sqlc.arg(release_ids)::uuid[])
+    AND locale = ANY(sqlc.arg(locales)::text[]);
+
+-- name: DeleteReleaseNote :execrows
+DELETE FROM release_notes
+WHERE release_id = $1 AND locale = $2;
+
+-- name: CopyReleaseNotes :exec
+-- Gives a promoted release the translated notes of its source.
+INSERT INTO release_notes (release_id, locale, note)
+SELECT sqlc.arg(target_release_id)::uuid, locale, note FROM release_notes
+WHERE release_id = sqlc.arg(source_release_id)::uuid;
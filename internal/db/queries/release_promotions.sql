@@ -0,0 +1,33 @@
+-- name: CreateReleasePromotion :one
+INSERT INTO release_promotions (
+    application_id,
+    source_release_id,
+    release_id,
+    version_code,
+    version_name,
+    from_environment,
+    to_environment,
+    actor_id,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListReleasePromotionsByApplication :many
+SELECT * FROM release_promotions
+WHERE application_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: ListReleasePromotionsByRelease :many
+-- Promotions a release was copied from or into.
+SELECT * FROM release_promotions
+WHERE source_release_id = sqlc.arg(release_id)::uuid OR release_id = sqlc.arg(release_id)::uuid
+ORDER BY created_at DESC, id DESC;
+
+-- name: CheckReleasePromoted :one
+-- Whether a release was promoted from or into another, sharing its build.
+SELECT EXISTS (
+    SELECT 1 FROM release_promotions
+    WHERE source_release_id = $1 OR release_id = $1
+);
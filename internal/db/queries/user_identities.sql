@@ -0,0 +1,25 @@
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (
+    provider,
+    subject,
+    email,
+    user_id
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetUserIdentityByProviderSubject :one
+SELECT * FROM user_identities
+WHERE provider = $1 AND subject = $2;
+
+-- name: UpdateUserIdentityLogin :exec
+UPDATE user_identities SET
+    email = $2,
+    last_login_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: UserHasIdentities :one
+SELECT EXISTS (SELECT 1 FROM user_identities WHERE user_id = $1);
+
+-- name: DeleteUserIdentitiesByUser :exec
+DELETE FROM user_identities WHERE user_id = $1;
@@ -0,0 +1,60 @@
+-- name: UpsertUserMFASecret :one
+-- Starts (or restarts) an enrollment: the new secret stays disabled until confirmed.
+INSERT INTO user_mfa (
+    user_id,
+    secret
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    secret = EXCLUDED.secret,
+    enabled_at = NULL,
+    last_used_step = 0,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetUserMFA :one
+SELECT * FROM user_mfa
+WHERE user_id = $1;
+
+-- name: EnableUserMFA :one
+UPDATE user_mfa SET
+    enabled_at = CURRENT_TIMESTAMP,
+    last_used_step = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND enabled_at IS NULL
+RETURNING *;
+
+-- name: UpdateUserMFALastUsedStep :execrows
+-- Only moves forward, so a code (time step) can be accepted at most once.
+UPDATE user_mfa SET
+    last_used_step = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa WHERE user_id = $1;
+
+-- ============================================================================
+-- Recovery Codes
+-- ============================================================================
+
+-- name: CreateUserRecoveryCode :exec
+INSERT INTO user_recovery_codes (
+    code_hash,
+    user_id
+) VALUES (
+    $1, $2
+);
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes SET
+    used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedUserRecoveryCodes :one
+SELECT COUNT(*) FROM user_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = $1;
@@ -9,33 +9,47 @@ INSERT INTO users (
     last_name
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+) RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- name: GetUserByEmail :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE email = $1 AND deleted_at IS NULL;
 
 -- name: GetUserByUsername :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE username = $1 AND deleted_at IS NULL;
 
 -- name: GetUserByPhoneNumber :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE phone_number = $1 AND deleted_at IS NULL;
 
 -- name: GetUserByID :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: ListUsers :many
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
-FROM users 
-WHERE deleted_at IS NULL 
-ORDER BY created_at DESC;
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
+FROM users
+WHERE deleted_at IS NULL
+    AND (sqlc.narg(search)::text IS NULL
+        OR email ILIKE '%' || sqlc.narg(search)::text || '%'
+        OR username ILIKE '%' || sqlc.narg(search)::text || '%')
+    AND (sqlc.narg(is_active)::bool IS NULL OR is_active = sqlc.narg(is_active)::bool)
+    AND (
+        sqlc.narg(after_id)::uuid IS NULL
+        OR (sqlc.arg(ascending)::bool AND (created_at, id) > (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+        OR (NOT sqlc.arg(ascending)::bool AND (created_at, id) < (sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(ascending)::bool THEN created_at END ASC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg(max_results)::int;
 
 -- ============================================================================
 -- Authentication-specific queries (these DO return password_hash)
@@ -62,28 +76,28 @@ UPDATE users SET
     email = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- name: UpdateUserUsername :one
 UPDATE users SET
     username = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- name: UpdateUserPhoneNumber :one
 UPDATE users SET
     phone_number = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- name: UpdateUserPassword :one
 UPDATE users SET
     password_hash = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- name: UpdateUserProfile :one
 UPDATE users SET
@@ -91,30 +105,122 @@ UPDATE users SET
     last_name = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
+
+-- name: UpdateUserAvatar :one
+UPDATE users SET
+    avatar_url = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- name: UpdateUserActiveStatus :one
+-- Bumping token_version revokes every session, so the change applies at once.
 UPDATE users SET
     is_active = $2,
+    token_version = token_version + 1,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
+
+-- name: UpdateUserAdminStatus :one
+-- Bumping token_version revokes every session, so the change applies at once.
+UPDATE users SET
+    is_admin = $2,
+    token_version = token_version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
+
+-- name: MarkUserEmailVerified :exec
+-- Records that the user proved to own the address, if it is still their email.
+UPDATE users SET
+    verified_email = email
+WHERE id = $1 AND email = $2 AND deleted_at IS NULL;
+
+-- name: CheckUserEmailVerified :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE id = $1 AND verified_email = email AND deleted_at IS NULL
+);
 
 -- name: UpdateLastLogin :one
 UPDATE users SET
     last_login_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
+
+-- name: ResetUserPassword :one
+-- Sets a new password and bumps token_version, invalidating every issued token.
+UPDATE users SET
+    password_hash = $2,
+    token_version = token_version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
 
 -- ============================================================================
 -- Delete Queries
 -- ============================================================================
 
 -- name: SoftDeleteUser :one
+-- Starts the deletion grace period; bumping token_version revokes every session.
 UPDATE users SET
-    deleted_at = CURRENT_TIMESTAMP
+    deleted_at = CURRENT_TIMESTAMP,
+    token_version = token_version + 1
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at;
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
+
+-- name: RestoreUser :one
+-- Cancels a deletion that is still within its grace period.
+UPDATE users SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND deleted_at IS NOT NULL
+    AND anonymized_at IS NULL
+    AND deleted_at > sqlc.arg(grace_start)::timestamp
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url;
+
+-- name: ListUsersPendingAnonymization :many
+SELECT id FROM users
+WHERE deleted_at IS NOT NULL
+    AND anonymized_at IS NULL
+    AND deleted_at <= sqlc.arg(deleted_before)::timestamp
+ORDER BY deleted_at
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: AnonymizeUser :execrows
+-- Overwrites personal data of a deleted account so its identifiers can be reused.
+-- The row itself is kept for the foreign keys of content the user created.
+UPDATE users SET
+    email = 'deleted-' || id || '@deleted.invalid',
+    username = 'deleted-' || id,
+    phone_number = NULL,
+    password_hash = '',
+    first_name = '',
+    last_name = '',
+    is_active = FALSE,
+    is_admin = FALSE,
+    avatar_url = NULL,
+    verified_email = NULL,
+    token_version = token_version + 1,
+    anonymized_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL AND anonymized_at IS NULL;
+
+-- name: ListUsersPendingStoragePurge :many
+-- Anonymised accounts whose stored files have not been deleted yet, because the
+-- storage was unavailable when they were anonymised.
+SELECT id FROM users
+WHERE anonymized_at IS NOT NULL
+    AND storage_purged_at IS NULL
+ORDER BY anonymized_at
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: MarkUserStoragePurged :exec
+UPDATE users SET storage_purged_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND anonymized_at IS NOT NULL;
 
 -- name: HardDeleteUser :exec
 DELETE FROM users WHERE id = $1;
\ No newline at end of file
@@ -11,6 +11,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :execrows
+UPDATE users SET
+    email = 'deleted-' || id || '@deleted.invalid',
+    username = 'deleted-' || id,
+    phone_number = NULL,
+    password_hash = '',
+    first_name = '',
+    last_name = '',
+    is_active = FALSE,
+    is_admin = FALSE,
+    avatar_url = NULL,
+    verified_email = NULL,
+    token_version = token_version + 1,
+    anonymized_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL AND anonymized_at IS NULL
+`
+
+// Overwrites personal data of a deleted account so its identifiers can be reused.
+// The row itself is kept for the foreign keys of content the user created.
+func (q *Queries) AnonymizeUser(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const checkUserEmailVerified = `-- name: CheckUserEmailVerified :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE id = $1 AND verified_email = email AND deleted_at IS NULL
+)
+`
+
+func (q *Queries) CheckUserEmailVerified(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, checkUserEmailVerified, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     email,
@@ -22,30 +64,33 @@ INSERT INTO users (
     last_name
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+) RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type CreateUserParams struct {
-	Email        string `json:"email"`
-	Username     string `json:"username"`
-	PhoneNumber  string `json:"phone_number"`
-	PasswordHash string `json:"password_hash"`
-	IsActive     bool   `json:"is_active"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
+	Email        string      `json:"email"`
+	Username     string      `json:"username"`
+	PhoneNumber  pgtype.Text `json:"phone_number"`
+	PasswordHash string      `json:"password_hash"`
+	IsActive     bool        `json:"is_active"`
+	FirstName    string      `json:"first_name"`
+	LastName     string      `json:"last_name"`
 }
 
 type CreateUserRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
@@ -70,27 +115,33 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE email = $1 AND deleted_at IS NULL
 `
 
 type GetUserByEmailRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
@@ -107,27 +158,33 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE id = $1 AND deleted_at IS NULL
 `
 
 type GetUserByIDRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (GetUserByIDRow, error) {
@@ -144,30 +201,36 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (GetUserByIDR
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
 const getUserByPhoneNumber = `-- name: GetUserByPhoneNumber :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE phone_number = $1 AND deleted_at IS NULL
 `
 
 type GetUserByPhoneNumberRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
-}
-
-func (q *Queries) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (GetUserByPhoneNumberRow, error) {
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
+}
+
+func (q *Queries) GetUserByPhoneNumber(ctx context.Context, phoneNumber pgtype.Text) (GetUserByPhoneNumberRow, error) {
 	row := q.db.QueryRow(ctx, getUserByPhoneNumber, phoneNumber)
 	var i GetUserByPhoneNumberRow
 	err := row.Scan(
@@ -181,27 +244,33 @@ func (q *Queries) GetUserByPhoneNumber(ctx context.Context, phoneNumber string)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 FROM users 
 WHERE username = $1 AND deleted_at IS NULL
 `
 
 type GetUserByUsernameRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error) {
@@ -218,6 +287,9 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUs
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -288,27 +360,60 @@ func (q *Queries) HardDeleteUser(ctx context.Context, id pgtype.UUID) error {
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
-FROM users 
-WHERE deleted_at IS NULL 
-ORDER BY created_at DESC
+SELECT id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
+FROM users
+WHERE deleted_at IS NULL
+    AND ($1::text IS NULL
+        OR email ILIKE '%' || $1::text || '%'
+        OR username ILIKE '%' || $1::text || '%')
+    AND ($2::bool IS NULL OR is_active = $2::bool)
+    AND (
+        $3::uuid IS NULL
+        OR ($4::bool AND (created_at, id) > ($5::timestamp, $3::uuid))
+        OR (NOT $4::bool AND (created_at, id) < ($5::timestamp, $3::uuid))
+    )
+ORDER BY
+    CASE WHEN $4::bool THEN created_at END ASC,
+    CASE WHEN $4::bool THEN id END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $6::int
 `
 
+type ListUsersParams struct {
+	Search         pgtype.Text      `json:"search"`
+	IsActive       pgtype.Bool      `json:"is_active"`
+	AfterID        pgtype.UUID      `json:"after_id"`
+	Ascending      bool             `json:"ascending"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	MaxResults     int32            `json:"max_results"`
+}
+
 type ListUsersRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
-}
-
-func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
-	rows, err := q.db.Query(ctx, listUsers)
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Search,
+		arg.IsActive,
+		arg.AfterID,
+		arg.Ascending,
+		arg.AfterCreatedAt,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -327,6 +432,9 @@ func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLoginAt,
+			&i.TokenVersion,
+			&i.IsAdmin,
+			&i.AvatarUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -338,30 +446,232 @@ func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
 	return items, nil
 }
 
+const listUsersPendingAnonymization = `-- name: ListUsersPendingAnonymization :many
+SELECT id FROM users
+WHERE deleted_at IS NOT NULL
+    AND anonymized_at IS NULL
+    AND deleted_at <= $1::timestamp
+ORDER BY deleted_at
+LIMIT $2::int
+`
+
+type ListUsersPendingAnonymizationParams struct {
+	DeletedBefore pgtype.Timestamp `json:"deleted_before"`
+	MaxResults    int32            `json:"max_results"`
+}
+
+func (q *Queries) ListUsersPendingAnonymization(ctx context.Context, arg ListUsersPendingAnonymizationParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listUsersPendingAnonymization, arg.DeletedBefore, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersPendingStoragePurge = `-- name: ListUsersPendingStoragePurge :many
+SELECT id FROM users
+WHERE anonymized_at IS NOT NULL
+    AND storage_purged_at IS NULL
+ORDER BY anonymized_at
+LIMIT $1::int
+`
+
+// Anonymised accounts whose stored files have not been deleted yet, because the
+// storage was unavailable when they were anonymised.
+func (q *Queries) ListUsersPendingStoragePurge(ctx context.Context, maxResults int32) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listUsersPendingStoragePurge, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users SET
+    verified_email = email
+WHERE id = $1 AND email = $2 AND deleted_at IS NULL
+`
+
+type MarkUserEmailVerifiedParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Email string      `json:"email"`
+}
+
+// Records that the user proved to own the address, if it is still their email.
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error {
+	_, err := q.db.Exec(ctx, markUserEmailVerified, arg.ID, arg.Email)
+	return err
+}
+
+const markUserStoragePurged = `-- name: MarkUserStoragePurged :exec
+UPDATE users SET storage_purged_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND anonymized_at IS NOT NULL
+`
+
+func (q *Queries) MarkUserStoragePurged(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markUserStoragePurged, id)
+	return err
+}
+
+const resetUserPassword = `-- name: ResetUserPassword :one
+UPDATE users SET
+    password_hash = $2,
+    token_version = token_version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
+`
+
+type ResetUserPasswordParams struct {
+	ID           pgtype.UUID `json:"id"`
+	PasswordHash string      `json:"password_hash"`
+}
+
+type ResetUserPasswordRow struct {
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
+}
+
+// Sets a new password and bumps token_version, invalidating every issued token.
+func (q *Queries) ResetUserPassword(ctx context.Context, arg ResetUserPasswordParams) (ResetUserPasswordRow, error) {
+	row := q.db.QueryRow(ctx, resetUserPassword, arg.ID, arg.PasswordHash)
+	var i ResetUserPasswordRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.PhoneNumber,
+		&i.IsActive,
+		&i.FirstName,
+		&i.LastName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const restoreUser = `-- name: RestoreUser :one
+UPDATE users SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND deleted_at IS NOT NULL
+    AND anonymized_at IS NULL
+    AND deleted_at > $2::timestamp
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
+`
+
+type RestoreUserParams struct {
+	ID         pgtype.UUID      `json:"id"`
+	GraceStart pgtype.Timestamp `json:"grace_start"`
+}
+
+type RestoreUserRow struct {
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
+}
+
+// Cancels a deletion that is still within its grace period.
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (RestoreUserRow, error) {
+	row := q.db.QueryRow(ctx, restoreUser, arg.ID, arg.GraceStart)
+	var i RestoreUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.PhoneNumber,
+		&i.IsActive,
+		&i.FirstName,
+		&i.LastName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
 const softDeleteUser = `-- name: SoftDeleteUser :one
 
 UPDATE users SET
-    deleted_at = CURRENT_TIMESTAMP
+    deleted_at = CURRENT_TIMESTAMP,
+    token_version = token_version + 1
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type SoftDeleteUserRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 // ============================================================================
 // Delete Queries
 // ============================================================================
+// Starts the deletion grace period; bumping token_version revokes every session.
 func (q *Queries) SoftDeleteUser(ctx context.Context, id pgtype.UUID) (SoftDeleteUserRow, error) {
 	row := q.db.QueryRow(ctx, softDeleteUser, id)
 	var i SoftDeleteUserRow
@@ -376,6 +686,9 @@ func (q *Queries) SoftDeleteUser(ctx context.Context, id pgtype.UUID) (SoftDelet
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -384,20 +697,23 @@ const updateLastLogin = `-- name: UpdateLastLogin :one
 UPDATE users SET
     last_login_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateLastLoginRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) UpdateLastLogin(ctx context.Context, id pgtype.UUID) (UpdateLastLoginRow, error) {
@@ -414,6 +730,9 @@ func (q *Queries) UpdateLastLogin(ctx context.Context, id pgtype.UUID) (UpdateLa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -421,9 +740,10 @@ func (q *Queries) UpdateLastLogin(ctx context.Context, id pgtype.UUID) (UpdateLa
 const updateUserActiveStatus = `-- name: UpdateUserActiveStatus :one
 UPDATE users SET
     is_active = $2,
+    token_version = token_version + 1,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateUserActiveStatusParams struct {
@@ -432,18 +752,22 @@ type UpdateUserActiveStatusParams struct {
 }
 
 type UpdateUserActiveStatusRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
+// Bumping token_version revokes every session, so the change applies at once.
 func (q *Queries) UpdateUserActiveStatus(ctx context.Context, arg UpdateUserActiveStatusParams) (UpdateUserActiveStatusRow, error) {
 	row := q.db.QueryRow(ctx, updateUserActiveStatus, arg.ID, arg.IsActive)
 	var i UpdateUserActiveStatusRow
@@ -458,6 +782,111 @@ func (q *Queries) UpdateUserActiveStatus(ctx context.Context, arg UpdateUserActi
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const updateUserAdminStatus = `-- name: UpdateUserAdminStatus :one
+UPDATE users SET
+    is_admin = $2,
+    token_version = token_version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
+`
+
+type UpdateUserAdminStatusParams struct {
+	ID      pgtype.UUID `json:"id"`
+	IsAdmin bool        `json:"is_admin"`
+}
+
+type UpdateUserAdminStatusRow struct {
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
+}
+
+// Bumping token_version revokes every session, so the change applies at once.
+func (q *Queries) UpdateUserAdminStatus(ctx context.Context, arg UpdateUserAdminStatusParams) (UpdateUserAdminStatusRow, error) {
+	row := q.db.QueryRow(ctx, updateUserAdminStatus, arg.ID, arg.IsAdmin)
+	var i UpdateUserAdminStatusRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.PhoneNumber,
+		&i.IsActive,
+		&i.FirstName,
+		&i.LastName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
+	)
+	return i, err
+}
+
+const updateUserAvatar = `-- name: UpdateUserAvatar :one
+UPDATE users SET
+    avatar_url = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
+`
+
+type UpdateUserAvatarParams struct {
+	ID        pgtype.UUID `json:"id"`
+	AvatarUrl pgtype.Text `json:"avatar_url"`
+}
+
+type UpdateUserAvatarRow struct {
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
+}
+
+func (q *Queries) UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (UpdateUserAvatarRow, error) {
+	row := q.db.QueryRow(ctx, updateUserAvatar, arg.ID, arg.AvatarUrl)
+	var i UpdateUserAvatarRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.PhoneNumber,
+		&i.IsActive,
+		&i.FirstName,
+		&i.LastName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -468,7 +897,7 @@ UPDATE users SET
     email = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateUserEmailParams struct {
@@ -477,16 +906,19 @@ type UpdateUserEmailParams struct {
 }
 
 type UpdateUserEmailRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 // ============================================================================
@@ -506,6 +938,9 @@ func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -515,7 +950,7 @@ UPDATE users SET
     password_hash = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateUserPasswordParams struct {
@@ -524,16 +959,19 @@ type UpdateUserPasswordParams struct {
 }
 
 type UpdateUserPasswordRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (UpdateUserPasswordRow, error) {
@@ -550,6 +988,9 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -559,25 +1000,28 @@ UPDATE users SET
     phone_number = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateUserPhoneNumberParams struct {
 	ID          pgtype.UUID `json:"id"`
-	PhoneNumber string      `json:"phone_number"`
+	PhoneNumber pgtype.Text `json:"phone_number"`
 }
 
 type UpdateUserPhoneNumberRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) UpdateUserPhoneNumber(ctx context.Context, arg UpdateUserPhoneNumberParams) (UpdateUserPhoneNumberRow, error) {
@@ -594,6 +1038,9 @@ func (q *Queries) UpdateUserPhoneNumber(ctx context.Context, arg UpdateUserPhone
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -604,7 +1051,7 @@ UPDATE users SET
     last_name = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateUserProfileParams struct {
@@ -614,16 +1061,19 @@ type UpdateUserProfileParams struct {
 }
 
 type UpdateUserProfileRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UpdateUserProfileRow, error) {
@@ -640,6 +1090,9 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -649,7 +1102,7 @@ UPDATE users SET
     username = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at
+RETURNING id, email, username, phone_number, is_active, first_name, last_name, created_at, updated_at, last_login_at, token_version, is_admin, avatar_url
 `
 
 type UpdateUserUsernameParams struct {
@@ -658,16 +1111,19 @@ type UpdateUserUsernameParams struct {
 }
 
 type UpdateUserUsernameRow struct {
-	ID          pgtype.UUID      `json:"id"`
-	Email       string           `json:"email"`
-	Username    string           `json:"username"`
-	PhoneNumber string           `json:"phone_number"`
-	IsActive    bool             `json:"is_active"`
-	FirstName   string           `json:"first_name"`
-	LastName    string           `json:"last_name"`
-	CreatedAt   pgtype.Timestamp `json:"created_at"`
-	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
-	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
+	ID           pgtype.UUID      `json:"id"`
+	Email        string           `json:"email"`
+	Username     string           `json:"username"`
+	PhoneNumber  pgtype.Text      `json:"phone_number"`
+	IsActive     bool             `json:"is_active"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	LastLoginAt  pgtype.Timestamp `json:"last_login_at"`
+	TokenVersion int32            `json:"token_version"`
+	IsAdmin      bool             `json:"is_admin"`
+	AvatarUrl    pgtype.Text      `json:"avatar_url"`
 }
 
 func (q *Queries) UpdateUserUsername(ctx context.Context, arg UpdateUserUsernameParams) (UpdateUserUsernameRow, error) {
@@ -684,6 +1140,9 @@ func (q *Queries) UpdateUserUsername(ctx context.Context, arg UpdateUserUsername
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.TokenVersion,
+		&i.IsAdmin,
+		&i.AvatarUrl,
 	)
 	return i, err
 }
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountRestoreToken is a single-use grant to cancel an account deletion.
+// It is mailed when the deletion is requested and expires with the grace period.
+type AccountRestoreToken struct {
+	ID        uuid.UUID
+	TokenHash string
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsUsable reports whether the token can still be redeemed at the given time.
+func (t *AccountRestoreToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminAction identifies an audited administrative action.
+type AdminAction string
+
+const (
+	AdminActionActivateUser       AdminAction = "user.activate"
+	AdminActionDeactivateUser     AdminAction = "user.deactivate"
+	AdminActionGrantAdmin         AdminAction = "user.grant_admin"
+	AdminActionRevokeAdmin        AdminAction = "user.revoke_admin"
+	AdminActionCreateUser         AdminAction = "user.create"
+	AdminActionDeleteUser         AdminAction = "user.delete"
+	AdminActionUpdateProfile      AdminAction = "user.update_profile"
+	AdminActionForcePasswordReset AdminAction = "user.force_password_reset"
+	AdminActionImpersonate        AdminAction = "user.impersonate"
+	AdminActionUnlockLogin        AdminAction = "login.unlock"
+	AdminActionSetOrgQuotas       AdminAction = "organization.set_quotas"
+)
+
+// AdminAuditLog records an action taken by an administrator.
+type AdminAuditLog struct {
+	ID           uuid.UUID
+	ActorID      *uuid.UUID // nil once the administrator's account is deleted
+	Action       AdminAction
+	TargetUserID *uuid.UUID // nil for actions that do not target a user
+	Details      map[string]string
+	IPAddress    string
+	CreatedAt    time.Time
+}
+
+// CreateAdminAuditLogInput represents the data needed to record an administrative action.
+type CreateAdminAuditLogInput struct {
+	ActorID      uuid.UUID
+	Action       AdminAction
+	TargetUserID *uuid.UUID
+	Details      map[string]string
+	IPAddress    string
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every raw API key, so leaked keys are easy to spot.
+const APIKeyPrefix = "ask_"
+
+// MaxAPIKeyNameLength is the longest name an API key can have.
+const MaxAPIKeyNameLength = 100
+
+// apiKeyDisplayLength is how much of a raw key is kept to tell keys apart.
+const apiKeyDisplayLength = 12
+
+// APIKey lets the client SDK inside an application check for updates without a
+// user account. Keys are scoped to one application and read-only. Only the
+// SHA-256 hash of the key is persisted; the raw value is shown once on creation.
+type APIKey struct {
+	ID            uuid.UUID
+	ApplicationID uuid.UUID
+	Name          string
+	Prefix        string     // Start of the raw key
+	CreatedBy     *uuid.UUID // Nil once the creator's account is gone
+	LastUsedAt    *time.Time // Updated at most once a minute
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
+}
+
+// CreatedAPIKey is a new API key along with its raw value, which cannot be
+// retrieved again.
+type CreatedAPIKey struct {
+	Key *APIKey
+	Raw string
+}
+
+// CreateAPIKeyInput represents data needed to record a new API key.
+type CreateAPIKeyInput struct {
+	ApplicationID uuid.UUID
+	Name          string
+	Prefix        string
+	KeyHash       string
+	CreatedBy     uuid.UUID
+}
+
+// ValidateAPIKeyName checks the name given to an API key.
+func ValidateAPIKeyName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return NewValidationError("name", "is required")
+	}
+	if len(name) > MaxAPIKeyNameLength {
+		return NewValidationError("name", fmt.Sprintf("must be at most %d characters", MaxAPIKeyNameLength))
+	}
+	return nil
+}
+
+// APIKeyDisplayPrefix returns the part of a raw key kept to tell keys apart.
+func APIKeyDisplayPrefix(raw string) string {
+	if len(raw) <= apiKeyDisplayLength {
+		return raw
+	}
+	return raw[:apiKeyDisplayLength]
+}
+
+// LooksLikeAPIKey reports whether a raw value has the shape of an API key,
+// to skip the lookup for values that cannot be one.
+func LooksLikeAPIKey(raw string) bool {
+	return strings.HasPrefix(raw, APIKeyPrefix) && len(raw) > len(APIKeyPrefix)
+}
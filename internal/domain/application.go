@@ -15,6 +15,7 @@ type Application struct {
 	ProjectID   uuid.UUID
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   *time.Time // Set on applications in the trash
 }
 
 type ApplicationMetadata struct {
@@ -43,6 +44,7 @@ type CreateApplicationFromArtifactInput struct {
 	ProjectID   uuid.UUID
 	ArtifactURL string
 	Environment ReleaseEnvironment
+	ReleaseNote string // Default note of the initial release, in Markdown
 }
 
 // UpdateApplicationInput represents data needed to update an existing application.
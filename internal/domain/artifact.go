@@ -18,6 +18,10 @@ type Artifact struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Set once the server has hashed the stored file and found it matches
+	// the recorded hash and size. Releases need a verified artifact to be published.
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
 }
 
 // CreateArtifactInput represents data needed to record a new artifact.
@@ -28,6 +32,7 @@ type CreateArtifactInput struct {
 	FileType  string
 	ABI       *string
 	ReleaseID uuid.UUID
+	Verified  bool // Whether the server computed SHA256 and FileSize itself
 }
 
 // UploadURLResponse contains the signed URL and the storage path for the file.
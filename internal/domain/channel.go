@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bounds of release channels.
+const (
+	MaxChannelNameLength      = 40
+	MaxChannelsPerApplication = 20
+	MaxChannelPosition        = 1000
+)
+
+// channelNamePattern matches lowercase names made of dash-separated words, e.g. internal-qa.
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ChannelVisibility controls whether a channel's releases show up in release listings.
+type ChannelVisibility string
+
+const (
+	ChannelListed   ChannelVisibility = "listed"   // Shown in release listings
+	ChannelUnlisted ChannelVisibility = "unlisted" // Only listed when asked for by name
+)
+
+// ReleaseChannel is a channel releases of an application are published to,
+// e.g. development, beta or customer-acme.
+type ReleaseChannel struct {
+	ID            uuid.UUID
+	ApplicationID uuid.UUID
+	Name          ReleaseEnvironment
+	Position      int32 // Display order, lowest first
+	Visibility    ChannelVisibility
+	IsDefault     bool // Channel of uploads that name none
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Clients running a lower version code must update; 0 supports every build
+	MinSupportedVersionCode int32
+}
+
+// CreateReleaseChannelInput represents data needed to create a channel.
+type CreateReleaseChannelInput struct {
+	ApplicationID uuid.UUID
+	Name          ReleaseEnvironment
+	Position      int32
+	Visibility    ChannelVisibility
+	IsDefault     bool
+}
+
+// UpdateReleaseChannelInput represents updateable channel fields.
+type UpdateReleaseChannelInput struct {
+	Position   *int32 // nil means don't update
+	Visibility *ChannelVisibility
+	IsDefault  *bool // Only true is accepted: another channel must become the default instead
+
+	MinSupportedVersionCode *int32 // 0 supports every build
+}
+
+// Valid reports whether the name can be used for a channel.
+func (e ReleaseEnvironment) Valid() bool {
+	return len(e) <= MaxChannelNameLength && channelNamePattern.MatchString(string(e))
+}
+
+// invalidChannelName reports a malformed channel name.
+func invalidChannelName(field string) *ValidationError {
+	return NewValidationError(field, fmt.Sprintf("must be lowercase letters, digits and dashes, up to %d characters", MaxChannelNameLength))
+}
+
+// Validate checks the channel's fields.
+func (in CreateReleaseChannelInput) Validate() error {
+	if !in.Name.Valid() {
+		return invalidChannelName("name")
+	}
+	return validateChannelFields(in.Position, in.Visibility)
+}
+
+func validateChannelFields(position int32, visibility ChannelVisibility) error {
+	if position < 0 || position > MaxChannelPosition {
+		return NewValidationError("position", fmt.Sprintf("must be between 0 and %d", MaxChannelPosition))
+	}
+	switch visibility {
+	case ChannelListed, ChannelUnlisted:
+	default:
+		return NewValidationError("visibility", "must be listed or unlisted")
+	}
+	return nil
+}
+
+// Apply returns the channel with the provided fields replaced.
+func (in UpdateReleaseChannelInput) Apply(c ReleaseChannel) (ReleaseChannel, error) {
+	if in.Position != nil {
+		c.Position = *in.Position
+	}
+	if in.Visibility != nil {
+		c.Visibility = *in.Visibility
+	}
+	if in.IsDefault != nil {
+		if !*in.IsDefault && c.IsDefault {
+			return c, NewValidationError("is_default", "make another channel the default instead")
+		}
+		c.IsDefault = c.IsDefault || *in.IsDefault
+	}
+	if in.MinSupportedVersionCode != nil {
+		if *in.MinSupportedVersionCode < 0 {
+			return c, NewValidationError("min_supported_version_code", "must not be negative")
+		}
+		c.MinSupportedVersionCode = *in.MinSupportedVersionCode
+	}
+	return c, validateChannelFields(c.Position, c.Visibility)
+}
+
+// CheckMinSupportedVersion refuses a minimum supported version code above the
+// newest release of the channel offered to every client, given as
+// fullyRolledOut (0 when there is none): clients below the minimum would be
+// told to update with no update to install.
+func (c ReleaseChannel) CheckMinSupportedVersion(fullyRolledOut int32) error {
+	if c.MinSupportedVersionCode <= fullyRolledOut {
+		return nil
+	}
+	if fullyRolledOut == 0 {
+		return NewValidationError("min_supported_version_code", fmt.Sprintf("%s has no published release offered to every client yet", c.Name))
+	}
+	return NewValidationError("min_supported_version_code", fmt.Sprintf("must be at most %d, the newest release of %s offered to every client", fullyRolledOut, c.Name))
+}
+
+// CheckStillServed refuses a release change that would leave the newest
+// release of the channel offered to every client, given as fullyRolledOut,
+// below its minimum supported version code: clients below the minimum would
+// be told to update with no update to install.
+func (c ReleaseChannel) CheckStillServed(fullyRolledOut int32) error {
+	if fullyRolledOut >= c.MinSupportedVersionCode {
+		return nil
+	}
+	return NewAppError(CodeInvalidReleaseStatus, fmt.Sprintf("%s would have no release offered to every client at or above its minimum supported version code %d, lower it first", c.Name, c.MinSupportedVersionCode))
+}
+
+// Supports reports whether clients running the given version code may keep it.
+func (c ReleaseChannel) Supports(versionCode int32) bool {
+	return versionCode >= c.MinSupportedVersionCode
+}
+
+// DefaultChannelNames returns the channels of a new application: development,
+// staging and production, plus the project's default environment when it is
+// none of those.
+func DefaultChannelNames(defaultEnv ReleaseEnvironment) []ReleaseEnvironment {
+	names := []ReleaseEnvironment{EnvironmentDevelopment, EnvironmentStaging, EnvironmentProduction}
+	if !slices.Contains(names, defaultEnv) {
+		names = append(names, defaultEnv)
+	}
+	return names
+}
+
+// DefaultChannels returns the channels of a new application, see
+// DefaultChannelNames. Uploads default to the project's default environment.
+func DefaultChannels(appID uuid.UUID, defaultEnv ReleaseEnvironment) []CreateReleaseChannelInput {
+	names := DefaultChannelNames(defaultEnv)
+	channels := make([]CreateReleaseChannelInput, len(names))
+	for i, name := range names {
+		channels[i] = CreateReleaseChannelInput{
+			ApplicationID: appID,
+			Name:          name,
+			Position:      int32(i),
+			Visibility:    ChannelListed,
+			IsDefault:     name == defaultEnv,
+		}
+	}
+	return channels
+}
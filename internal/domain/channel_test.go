@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseChannel_MinSupportedVersion(t *testing.T) {
+	channel := ReleaseChannel{Name: EnvironmentProduction}
+	assert.True(t, channel.Supports(1))
+	assert.NoError(t, channel.CheckMinSupportedVersion(0))
+
+	channel.MinSupportedVersionCode = 12
+	assert.False(t, channel.Supports(11))
+	assert.True(t, channel.Supports(12))
+	assert.NoError(t, channel.CheckMinSupportedVersion(12))
+
+	var valErr *ValidationError
+	require.ErrorAs(t, channel.CheckMinSupportedVersion(11), &valErr)
+	assert.Equal(t, "min_supported_version_code", valErr.Field)
+	assert.Error(t, channel.CheckMinSupportedVersion(0), "a channel with no full rollout supports every build")
+
+	assert.NoError(t, channel.CheckStillServed(12))
+	assert.ErrorIs(t, channel.CheckStillServed(11), ErrReleaseStatus)
+	assert.NoError(t, ReleaseChannel{}.CheckStillServed(0), "a channel without a minimum needs no release")
+
+	negative := int32(-1)
+	_, err := UpdateReleaseChannelInput{MinSupportedVersionCode: &negative}.Apply(ReleaseChannel{Visibility: ChannelListed})
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailChangeToken is a single-use, time-limited confirmation of a new email address.
+// The address only replaces the user's email once the token mailed to it is redeemed.
+type EmailChangeToken struct {
+	ID        uuid.UUID
+	TokenHash string
+	UserID    uuid.UUID
+	NewEmail  string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsUsable reports whether the token can still be redeemed at the given time.
+func (t *EmailChangeToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
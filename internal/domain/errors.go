@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // ErrorCode represents machine-readable error codes for API clients.
 // These codes are stable and can be used for client-side logic.
@@ -19,16 +22,30 @@ const (
 	CodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
 	CodeTokenExpired       ErrorCode = "TOKEN_EXPIRED"
 	CodeTokenInvalid       ErrorCode = "TOKEN_INVALID"
+	CodeInvalidMFACode     ErrorCode = "INVALID_MFA_CODE"
+	CodeMFAAlreadyEnabled  ErrorCode = "MFA_ALREADY_ENABLED"
+	CodeMFANotEnabled      ErrorCode = "MFA_NOT_ENABLED"
+	CodeSSOFailed          ErrorCode = "SSO_FAILED"
+	CodeLinkRequired       ErrorCode = "IDENTITY_LINK_REQUIRED"
+	CodeAccountLocked      ErrorCode = "ACCOUNT_LOCKED"
+	CodeInvalidAPIKey      ErrorCode = "INVALID_API_KEY"
 
 	// Authorization errors
 	CodeForbidden        ErrorCode = "FORBIDDEN"
 	CodeInsufficientRole ErrorCode = "INSUFFICIENT_ROLE"
+	CodeDomainNotAllowed ErrorCode = "EMAIL_DOMAIN_NOT_ALLOWED"
 
 	// User-specific errors
 	CodeEmailExists    ErrorCode = "EMAIL_ALREADY_EXISTS"
 	CodeUsernameExists ErrorCode = "USERNAME_ALREADY_EXISTS"
 	CodePhoneExists    ErrorCode = "PHONE_ALREADY_EXISTS"
 	CodeUserInactive   ErrorCode = "USER_INACTIVE"
+	CodeOwnsProjects   ErrorCode = "ACCOUNT_OWNS_PROJECTS"
+
+	// Organization-specific errors
+	CodeOrganizationNotFound ErrorCode = "ORGANIZATION_NOT_FOUND"
+	CodeLastOwner            ErrorCode = "LAST_ORGANIZATION_OWNER"
+	CodeQuotaExceeded        ErrorCode = "QUOTA_EXCEEDED"
 
 	// Project-specific errors
 	CodeProjectNotFound ErrorCode = "PROJECT_NOT_FOUND"
@@ -37,11 +54,26 @@ const (
 	// Application-specific errors
 	CodeApplicationNotFound ErrorCode = "APPLICATION_NOT_FOUND"
 	CodePackageNameExists   ErrorCode = "PACKAGE_NAME_EXISTS"
+	CodeAPIKeyNotFound      ErrorCode = "API_KEY_NOT_FOUND"
 
 	// Release-specific errors
-	CodeReleaseNotFound    ErrorCode = "RELEASE_NOT_FOUND"
-	CodeReleaseExists      ErrorCode = "RELEASE_EXISTS"
-	CodeInvalidVersionCode ErrorCode = "INVALID_VERSION_CODE"
+	CodeReleaseNotFound      ErrorCode = "RELEASE_NOT_FOUND"
+	CodeReleaseExists        ErrorCode = "RELEASE_EXISTS"
+	CodeInvalidVersionCode   ErrorCode = "INVALID_VERSION_CODE"
+	CodeReleaseWithdrawn     ErrorCode = "RELEASE_WITHDRAWN"
+	CodeInvalidReleaseStatus ErrorCode = "INVALID_RELEASE_STATUS"
+	CodeArtifactMismatch     ErrorCode = "ARTIFACT_MISMATCH"
+	CodeReleasePromoted      ErrorCode = "RELEASE_PROMOTED"
+
+	// Channel errors
+	CodeChannelNotFound ErrorCode = "CHANNEL_NOT_FOUND"
+	CodeChannelInUse    ErrorCode = "CHANNEL_IN_USE"
+
+	// Promotion-specific errors
+	CodePromotionBlocked         ErrorCode = "PROMOTION_BLOCKED"
+	CodePromotionRequestNotFound ErrorCode = "PROMOTION_REQUEST_NOT_FOUND"
+	CodePromotionNotPending      ErrorCode = "PROMOTION_NOT_PENDING"
+	CodePromotionReviewed        ErrorCode = "PROMOTION_ALREADY_REVIEWED"
 )
 
 // AppError is the base error type for all domain errors.
@@ -99,15 +131,29 @@ var (
 	ErrInvalidCredentials = &AppError{Code: CodeInvalidCredentials, Message: "invalid credentials"}
 	ErrTokenExpired       = &AppError{Code: CodeTokenExpired, Message: "token has expired"}
 	ErrTokenInvalid       = &AppError{Code: CodeTokenInvalid, Message: "token is invalid"}
+	ErrInvalidMFACode     = &AppError{Code: CodeInvalidMFACode, Message: "invalid two-factor authentication code"}
+	ErrMFAAlreadyEnabled  = &AppError{Code: CodeMFAAlreadyEnabled, Message: "two-factor authentication is already enabled"}
+	ErrMFANotEnabled      = &AppError{Code: CodeMFANotEnabled, Message: "two-factor authentication is not enabled"}
+	ErrSSOFailed          = &AppError{Code: CodeSSOFailed, Message: "single sign-on failed"}
+	ErrLinkRequired       = &AppError{Code: CodeLinkRequired, Message: "an account with this email exists; sign in with its password to link the identity"}
+	ErrAccountLocked      = &AppError{Code: CodeAccountLocked, Message: "too many failed login attempts, try again later"}
+	ErrInvalidAPIKey      = &AppError{Code: CodeInvalidAPIKey, Message: "API key is missing, revoked or not valid for this application"}
 
 	// Authorization errors
-	ErrForbidden = &AppError{Code: CodeForbidden, Message: "you don't have permission to access this resource"}
+	ErrForbidden        = &AppError{Code: CodeForbidden, Message: "you don't have permission to access this resource"}
+	ErrInsufficientRole = &AppError{Code: CodeInsufficientRole, Message: "your role does not allow this action"}
+	ErrDomainNotAllowed = &AppError{Code: CodeDomainNotAllowed, Message: "accounts from this email domain cannot sign up with single sign-on"}
 
 	// User-specific errors
 	ErrEmailAlreadyExists    = &AppError{Code: CodeEmailExists, Message: "email already exists"}
 	ErrUsernameAlreadyExists = &AppError{Code: CodeUsernameExists, Message: "username already exists"}
 	ErrPhoneAlreadyExists    = &AppError{Code: CodePhoneExists, Message: "phone number already exists"}
 	ErrUserInactive          = &AppError{Code: CodeUserInactive, Message: "user account is inactive"}
+	ErrOwnsProjects          = &AppError{Code: CodeOwnsProjects, Message: "transfer or delete the projects of the organizations you solely own before deleting your account"}
+
+	// Organization-specific errors
+	ErrOrganizationNotFound = &AppError{Code: CodeOrganizationNotFound, Message: "organization not found"}
+	ErrLastOwner            = &AppError{Code: CodeLastOwner, Message: "an organization must keep at least one owner"}
 
 	// Project-specific errors
 	ErrProjectNotFound = &AppError{Code: CodeProjectNotFound, Message: "project not found"}
@@ -116,10 +162,26 @@ var (
 	// Application-specific errors
 	ErrApplicationNotFound = &AppError{Code: CodeApplicationNotFound, Message: "application not found"}
 	ErrPackageNameExists   = &AppError{Code: CodePackageNameExists, Message: "package name already exists"}
+	ErrAPIKeyNotFound      = &AppError{Code: CodeAPIKeyNotFound, Message: "API key not found or already revoked"}
 
 	// Release-specific errors
-	ErrReleaseNotFound = &AppError{Code: CodeReleaseNotFound, Message: "release not found"}
-	ErrReleaseExists   = &AppError{Code: CodeReleaseExists, Message: "release already exists"}
+	ErrReleaseNotFound  = &AppError{Code: CodeReleaseNotFound, Message: "release not found"}
+	ErrReleaseExists    = &AppError{Code: CodeReleaseExists, Message: "release already exists"}
+	ErrReleaseWithdrawn = &AppError{Code: CodeReleaseWithdrawn, Message: "release has been withdrawn"}
+	ErrReleaseStatus    = &AppError{Code: CodeInvalidReleaseStatus, Message: "the release's status does not allow this"}
+	ErrVersionCode      = &AppError{Code: CodeInvalidVersionCode, Message: "the version code is not allowed in this environment"}
+	ErrReleasePromoted  = &AppError{Code: CodeReleasePromoted, Message: "the build of a promoted release cannot change; create a new release instead"}
+
+	// Channel-specific errors
+	ErrChannelNotFound = &AppError{Code: CodeChannelNotFound, Message: "release channel not found"}
+	ErrChannelInUse    = &AppError{Code: CodeChannelInUse, Message: "release channel still holds releases, trashed ones included"}
+	ErrChannelExists   = &AppError{Code: CodeAlreadyExists, Message: "release channel already exists"}
+
+	// Promotion-specific errors
+	ErrPromotionRequestNotFound = &AppError{Code: CodePromotionRequestNotFound, Message: "promotion request not found"}
+	ErrPromotionNotPending      = &AppError{Code: CodePromotionNotPending, Message: "promotion request is no longer pending"}
+	ErrPromotionReviewed        = &AppError{Code: CodePromotionReviewed, Message: "you have already reviewed this promotion request"}
+	ErrSelfReview               = &AppError{Code: CodeForbidden, Message: "you cannot review your own promotion request"}
 )
 
 // ValidationError provides field-level validation error information.
@@ -146,6 +208,43 @@ func NewValidationError(field, message string) *ValidationError {
 	}
 }
 
+// LockoutError reports a temporary login lockout and when it ends.
+type LockoutError struct {
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return ErrAccountLocked.Message + " (locked until " + e.Until.UTC().Format(time.RFC3339) + ")"
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// NewLockoutError creates a lockout error ending at the given time.
+func NewLockoutError(until time.Time) *LockoutError {
+	return &LockoutError{Until: until}
+}
+
+// LinkRequiredError reports an identity provider sign-in that must be confirmed
+// with the password of the account holding its email before it is linked.
+type LinkRequiredError struct {
+	Token string
+}
+
+func (e *LinkRequiredError) Error() string {
+	return ErrLinkRequired.Message
+}
+
+func (e *LinkRequiredError) Unwrap() error {
+	return ErrLinkRequired
+}
+
+// NewLinkRequiredError creates a link required error carrying the link token.
+func NewLinkRequiredError(token string) *LinkRequiredError {
+	return &LinkRequiredError{Token: token}
+}
+
 // GetErrorCode extracts the error code from any error.
 // Returns CodeInternal if the error doesn't have a code.
 func GetErrorCode(err error) ErrorCode {
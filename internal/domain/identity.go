@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links an account at an external identity provider to a user.
+type UserIdentity struct {
+	ID          uuid.UUID
+	Provider    string // Configured provider name, e.g. "google"
+	Subject     string // Provider's stable user identifier ("sub" claim)
+	Email       string
+	UserID      uuid.UUID
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
+
+// CreateUserIdentityInput represents the data needed to link an external identity.
+type CreateUserIdentityInput struct {
+	Provider string
+	Subject  string
+	Email    string
+	UserID   uuid.UUID
+}
+
+// OAuthState is a pending single sign-on authorization request.
+// It binds the callback to the request that started it (CSRF), the ID token
+// to that request (nonce) and the code exchange to the client (PKCE).
+type OAuthState struct {
+	State        string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginAttempt is an audit record of a single login attempt.
+type LoginAttempt struct {
+	ID         uuid.UUID
+	Identifier string     // Email or username as submitted, lower-cased
+	IPAddress  string     // Client IP address
+	UserID     *uuid.UUID // nil if the identifier matched no account
+	Succeeded  bool
+	CreatedAt  time.Time
+}
+
+// CreateLoginAttemptInput represents the data needed to record a login attempt.
+type CreateLoginAttemptInput struct {
+	Identifier string
+	IPAddress  string
+	UserID     *uuid.UUID
+	Succeeded  bool
+}
+
+// LoginThrottle counts consecutive login failures for an identifier or a client IP.
+type LoginThrottle struct {
+	Key           string // "identifier:<value>", "user:<id>" or "ip:<value>"
+	FailureCount  int32
+	LockedUntil   *time.Time // nil if never locked
+	LastFailureAt time.Time
+}
+
+// IsLocked reports whether logins are refused at the given time.
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
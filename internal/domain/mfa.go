@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA holds a user's TOTP second factor.
+type UserMFA struct {
+	UserID       uuid.UUID
+	Secret       string     // base32 TOTP secret
+	EnabledAt    *time.Time // nil while enrollment is pending confirmation
+	LastUsedStep int64      // last accepted TOTP time step
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsEnabled reports whether the second factor has been confirmed.
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// MFAEnrollment is returned once, when a user starts TOTP enrollment.
+type MFAEnrollment struct {
+	Secret        string
+	URI           string   // otpauth:// URI for QR codes
+	RecoveryCodes []string // plain-text codes, only hashes are stored
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRole is the role of a member in an organization.
+type OrganizationRole string
+
+const (
+	OrgRoleOwner  OrganizationRole = "owner"  // Manages the organization, its members and every project
+	OrgRoleAdmin  OrganizationRole = "admin"  // Manages members and every project
+	OrgRoleMember OrganizationRole = "member" // Reads every project
+)
+
+// Valid reports whether the role is known.
+func (r OrganizationRole) Valid() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin || r == OrgRoleMember
+}
+
+// CanManage reports whether the role may manage members and create projects.
+func (r OrganizationRole) CanManage() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+// Organization owns projects on behalf of a team.
+// Every user gets a personal organization for the projects they create on their own.
+type Organization struct {
+	ID              uuid.UUID
+	Name            string
+	PersonalOwnerID *uuid.UUID // Set on personal organizations
+	Quotas          OrganizationQuotas
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IsPersonal reports whether this is a user's personal organization.
+func (o *Organization) IsPersonal() bool {
+	return o.PersonalOwnerID != nil
+}
+
+// OrganizationQuotas limits what an organization may hold, independently of billing.
+type OrganizationQuotas struct {
+	MaxProjects     int32
+	MaxMembers      int32
+	MaxApplications int32
+}
+
+// Organization name bounds.
+const (
+	MinOrganizationNameLength = 2
+	MaxOrganizationNameLength = 100
+)
+
+// ValidateOrganizationName checks the length of an organization name.
+func ValidateOrganizationName(name string) error {
+	if len(name) < MinOrganizationNameLength || len(name) > MaxOrganizationNameLength {
+		return NewValidationError("name", fmt.Sprintf("must be between %d and %d characters", MinOrganizationNameLength, MaxOrganizationNameLength))
+	}
+	return nil
+}
+
+// Validate checks that every quota is positive.
+func (q OrganizationQuotas) Validate() error {
+	if q.MaxProjects < 1 {
+		return NewValidationError("max_projects", "must be at least 1")
+	}
+	if q.MaxMembers < 1 {
+		return NewValidationError("max_members", "must be at least 1")
+	}
+	if q.MaxApplications < 1 {
+		return NewValidationError("max_applications", "must be at least 1")
+	}
+	return nil
+}
+
+// NewQuotaExceededError reports that an organization reached one of its quotas.
+func NewQuotaExceededError(resource string, limit int32) *AppError {
+	return NewAppError(CodeQuotaExceeded, fmt.Sprintf("the organization has reached its limit of %d %s", limit, resource))
+}
+
+// OrganizationUsage is what an organization currently holds.
+type OrganizationUsage struct {
+	Projects     int64
+	Members      int64
+	Applications int64
+}
+
+// OrganizationMembership is an organization as seen by one of its members.
+type OrganizationMembership struct {
+	Organization
+	Role OrganizationRole
+}
+
+// OrganizationMember is a user's membership in an organization.
+type OrganizationMember struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           OrganizationRole
+	Username       string
+	Email          string
+	FirstName      string
+	LastName       string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
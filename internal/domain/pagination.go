@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Page size limits shared by every list endpoint.
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Cursor is a keyset position in a listing.
+// Lists are ordered by (CreatedAt, ID) or (VersionCode, ID); the unused key stays zero.
+type Cursor struct {
+	CreatedAt   time.Time `json:"t,omitempty"`
+	VersionCode int32     `json:"v,omitempty"`
+	ID          uuid.UUID `json:"id"`
+}
+
+// Compare orders two cursors by their keys, then by ID.
+func (c Cursor) Compare(other Cursor) int {
+	if cmp := c.CreatedAt.Compare(other.CreatedAt); cmp != 0 {
+		return cmp
+	}
+	if c.VersionCode != other.VersionCode {
+		if c.VersionCode < other.VersionCode {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(c.ID[:], other.ID[:])
+}
+
+// Encode returns the opaque form of the cursor handed to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses an opaque cursor. An empty string means the first page.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, NewValidationError("cursor", "invalid cursor")
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == uuid.Nil {
+		return nil, NewValidationError("cursor", "invalid cursor")
+	}
+	return &c, nil
+}
+
+// PageRequest describes which slice of a listing to return.
+type PageRequest struct {
+	Limit     int32
+	After     *Cursor // Start after this position; nil for the first page
+	Ascending bool    // Oldest (or lowest version) first; newest first by default
+}
+
+// NewPageRequest validates the raw pagination parameters of a request.
+func NewPageRequest(limit int, cursor string, ascending bool) (PageRequest, error) {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return PageRequest{}, NewValidationError("limit", "limit cannot exceed 100")
+	}
+
+	after, err := DecodeCursor(cursor)
+	if err != nil {
+		return PageRequest{}, err
+	}
+
+	return PageRequest{Limit: int32(limit), After: after, Ascending: ascending}, nil
+}
+
+// Page is one slice of a listing.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string // Empty on the last page
+}
+
+// NewPage builds a page from up to Limit+1 fetched items; the extra item only
+// signals that another page follows.
+func NewPage[T any](items []T, limit int32, cursorOf func(T) Cursor) *Page[T] {
+	if limit > 0 && int32(len(items)) > limit {
+		items = items[:limit]
+		return &Page[T]{Items: items, NextCursor: cursorOf(items[len(items)-1]).Encode()}
+	}
+	return &Page[T]{Items: items}
+}
+
+// ========== Filters ==========
+
+// ProjectFilter narrows a project listing.
+type ProjectFilter struct {
+	Search string // Case-insensitive match on the title
+}
+
+// ApplicationFilter narrows an application listing.
+type ApplicationFilter struct {
+	Search string // Case-insensitive match on the title or package name
+}
+
+// ReleaseFilter narrows a release listing.
+type ReleaseFilter struct {
+	Environment   ReleaseEnvironment // Empty for every environment
+	Status        ReleaseStatus      // Empty for every status
+	IncludeDrafts bool               // Set for users who can upload to the project
+}
+
+// ArtifactFilter narrows an artifact listing.
+type ArtifactFilter struct {
+	ABI string // Empty for every ABI
+}
+
+// UserFilter narrows a user listing.
+type UserFilter struct {
+	Search   string // Case-insensitive match on the email or username
+	IsActive *bool  // nil for active and inactive users
+}
+
+// ========== Keyset Positions ==========
+
+// Cursor returns the listing position of the project.
+func (p *Project) Cursor() Cursor { return Cursor{CreatedAt: p.CreatedAt, ID: p.ID} }
+
+// Cursor returns the listing position of the application.
+func (a *Application) Cursor() Cursor { return Cursor{CreatedAt: a.CreatedAt, ID: a.ID} }
+
+// Cursor returns the listing position of the release.
+func (r *ApplicationRelease) Cursor() Cursor { return Cursor{VersionCode: r.VersionCode, ID: r.ID} }
+
+// Cursor returns the listing position of the artifact.
+func (a *Artifact) Cursor() Cursor { return Cursor{CreatedAt: a.CreatedAt, ID: a.ID} }
+
+// Cursor returns the listing position of the user.
+func (u *User) Cursor() Cursor { return Cursor{CreatedAt: u.CreatedAt, ID: u.ID} }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,19 +9,74 @@ import (
 
 // Project represents a project that can contain applications.
 type Project struct {
-	ID          uuid.UUID
-	Title       string
-	Description string
-	OwnerID     uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             uuid.UUID
+	Title          string
+	Description    string
+	OwnerID        uuid.UUID
+	OrganizationID uuid.UUID
+	Settings       ProjectSettings
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time // Set on projects in the trash
+}
+
+// ProjectRoleOwner is the role of a project's owner, who holds every permission.
+// Owners and admins of the project's organization get the same role as in the
+// organization; project members carry the role recorded on their membership.
+const ProjectRoleOwner = "owner"
+
+// Project permission keys.
+const (
+	PermissionProjectManage     = "project.manage"
+	PermissionApplicationCreate = "application.create"
+	PermissionApplicationUpdate = "application.update"
+	PermissionApplicationDelete = "application.delete"
+	PermissionPackageUpload     = "package.upload"
+	PermissionPackageDownload   = "package.download"
+	PermissionMemberInvite      = "member.invite"
+	PermissionMemberRemove      = "member.remove"
+)
+
+// ProjectAccess is what a user may do in a project.
+type ProjectAccess struct {
+	Role        string
+	Permissions []string
+}
+
+// IsOwner reports whether the user owns the project, directly or through its organization.
+func (a *ProjectAccess) IsOwner() bool {
+	return a.Role == ProjectRoleOwner
+}
+
+// Can reports whether the user holds a permission.
+func (a *ProjectAccess) Can(permission string) bool {
+	return slices.Contains(a.Permissions, permission)
+}
+
+// ProjectSummary is a project as listed for one of its owners or members.
+type ProjectSummary struct {
+	Project
+	Role                    string
+	Permissions             []string // Effective permission keys, e.g. application.create
+	ApplicationCount        int64
+	LatestProductionRelease *ReleaseSummary // nil when nothing was released to production
+}
+
+// ReleaseSummary identifies a release without its full details.
+type ReleaseSummary struct {
+	ID            uuid.UUID
+	ApplicationID uuid.UUID
+	VersionName   string
+	VersionCode   int32
+	CreatedAt     time.Time
 }
 
 // CreateProjectInput represents the data needed to create a new project.
 type CreateProjectInput struct {
-	Title       string
-	Description string
-	OwnerID     uuid.UUID
+	Title          string
+	Description    string
+	OwnerID        uuid.UUID
+	OrganizationID *uuid.UUID // nil for the owner's personal organization
 }
 
 // UpdateProjectInput represents updateable project fields.
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Bounds of the project settings.
+const (
+	MaxArtifactSizeLimit    = 4 << 30 // 4 GiB
+	MaxRetentionReleases    = 1000
+	MaxRetentionAgeDays     = 3650
+	MaxAllowedArtifactTypes = 20
+)
+
+// APKContentType is the MIME type of Android packages.
+const APKContentType = "application/vnd.android.package-archive"
+
+// ProjectSettings is the release policy of a project.
+// It is stored as a JSON document; keys missing from it keep their defaults.
+type ProjectSettings struct {
+	DefaultEnvironment   ReleaseEnvironment `json:"default_environment"`     // Default channel of new applications
+	RequireReleaseNote   bool               `json:"require_release_note"`    // Refuse releases without notes
+	AllowedArtifactTypes []string           `json:"allowed_artifact_types"`  // MIME types; empty allows any type
+	MaxArtifactSizeBytes int64              `json:"max_artifact_size_bytes"` // 0 means no limit
+	Retention            RetentionPolicy    `json:"retention"`
+	AllowShareLinks      bool               `json:"allow_share_links"` // Whether releases may be shared through public links
+	Pipeline             PromotionPipeline  `json:"pipeline"`
+	VersionCodePolicy    VersionCodePolicy  `json:"version_code_policy"` // How new version codes relate to the environment's
+	RequireSemver        bool               `json:"require_semver"`      // Refuse version names that are not semantic versions
+}
+
+// RetentionPolicy describes how long releases are kept. Zero values keep everything.
+// A release is moved to the trash once it is past the KeepLastReleases newest
+// of its application and environment and older than MaxAgeDays, whichever of
+// the two are set. Drafts, and releases at or above the newest one offered to
+// every client of their environment, are always kept.
+type RetentionPolicy struct {
+	KeepLastReleases int `json:"keep_last_releases"` // Per application and environment
+	MaxAgeDays       int `json:"max_age_days"`
+}
+
+// DefaultProjectSettings returns the settings of a new project.
+func DefaultProjectSettings() ProjectSettings {
+	return ProjectSettings{
+		DefaultEnvironment:   EnvironmentDevelopment,
+		AllowedArtifactTypes: []string{},
+		AllowShareLinks:      true,
+		Pipeline:             DefaultPromotionPipeline(),
+		VersionCodePolicy:    VersionCodeIncreasing,
+	}
+}
+
+// UpdateProjectSettingsInput represents updateable project settings.
+type UpdateProjectSettingsInput struct {
+	DefaultEnvironment   *ReleaseEnvironment // nil means don't update
+	RequireReleaseNote   *bool
+	AllowedArtifactTypes *[]string
+	MaxArtifactSizeBytes *int64
+	Retention            *RetentionPolicy
+	AllowShareLinks      *bool
+	Pipeline             *PromotionPipeline
+	VersionCodePolicy    *VersionCodePolicy
+	RequireSemver        *bool
+}
+
+// Apply returns the settings with the provided fields replaced.
+func (in UpdateProjectSettingsInput) Apply(s ProjectSettings) ProjectSettings {
+	if in.DefaultEnvironment != nil {
+		s.DefaultEnvironment = *in.DefaultEnvironment
+	}
+	if in.RequireReleaseNote != nil {
+		s.RequireReleaseNote = *in.RequireReleaseNote
+	}
+	if in.AllowedArtifactTypes != nil {
+		s.AllowedArtifactTypes = *in.AllowedArtifactTypes
+	}
+	if in.MaxArtifactSizeBytes != nil {
+		s.MaxArtifactSizeBytes = *in.MaxArtifactSizeBytes
+	}
+	if in.Retention != nil {
+		s.Retention = *in.Retention
+	}
+	if in.AllowShareLinks != nil {
+		s.AllowShareLinks = *in.AllowShareLinks
+	}
+	if in.Pipeline != nil {
+		s.Pipeline = *in.Pipeline
+	}
+	if in.VersionCodePolicy != nil {
+		s.VersionCodePolicy = *in.VersionCodePolicy
+	}
+	if in.RequireSemver != nil {
+		s.RequireSemver = *in.RequireSemver
+	}
+	return s
+}
+
+// Validate checks that the settings are consistent.
+func (s ProjectSettings) Validate() error {
+	if !s.DefaultEnvironment.Valid() {
+		return invalidChannelName("default_environment")
+	}
+
+	if len(s.AllowedArtifactTypes) > MaxAllowedArtifactTypes {
+		return NewValidationError("allowed_artifact_types", fmt.Sprintf("at most %d types are allowed", MaxAllowedArtifactTypes))
+	}
+	for _, t := range s.AllowedArtifactTypes {
+		if !strings.Contains(t, "/") || strings.TrimSpace(t) != t {
+			return NewValidationError("allowed_artifact_types", fmt.Sprintf("%q is not a MIME type", t))
+		}
+	}
+
+	if s.MaxArtifactSizeBytes < 0 || s.MaxArtifactSizeBytes > MaxArtifactSizeLimit {
+		return NewValidationError("max_artifact_size_bytes", fmt.Sprintf("must be between 0 and %d", MaxArtifactSizeLimit))
+	}
+	if s.Retention.KeepLastReleases < 0 || s.Retention.KeepLastReleases > MaxRetentionReleases {
+		return NewValidationError("retention.keep_last_releases", fmt.Sprintf("must be between 0 and %d", MaxRetentionReleases))
+	}
+	if s.Retention.MaxAgeDays < 0 || s.Retention.MaxAgeDays > MaxRetentionAgeDays {
+		return NewValidationError("retention.max_age_days", fmt.Sprintf("must be between 0 and %d", MaxRetentionAgeDays))
+	}
+	if !s.VersionCodePolicy.Valid() {
+		return NewValidationError("version_code_policy", "must be increasing or unique")
+	}
+	if err := s.Pipeline.Validate(); err != nil {
+		return err
+	}
+	if err := s.Pipeline.CheckUpload(s.DefaultEnvironment); err != nil {
+		return NewValidationError("default_environment", "uploads cannot default to a gated pipeline stage")
+	}
+	return nil
+}
+
+// EnvironmentFor returns the requested environment, or the project default when
+// none was given. Only applications being created use it; existing ones have
+// their own default channel.
+func (s ProjectSettings) EnvironmentFor(env ReleaseEnvironment) ReleaseEnvironment {
+	if env == "" {
+		return s.DefaultEnvironment
+	}
+	return env
+}
+
+// CheckReleaseNote enforces the release note requirement.
+func (s ProjectSettings) CheckReleaseNote(note string) error {
+	if s.RequireReleaseNote && strings.TrimSpace(note) == "" {
+		return NewValidationError("release_note", "this project requires a release note")
+	}
+	return nil
+}
+
+// CheckVersionName enforces semantic versioning when the project opted in.
+func (s ProjectSettings) CheckVersionName(name string) error {
+	if s.RequireSemver && !IsSemver(name) {
+		return NewValidationError("version_name", fmt.Sprintf("%q is not a semantic version (e.g. 1.4.0)", name))
+	}
+	return nil
+}
+
+// CheckArtifact enforces the artifact type and size limits.
+func (s ProjectSettings) CheckArtifact(fileType string, size int64) error {
+	if len(s.AllowedArtifactTypes) > 0 && !slices.Contains(s.AllowedArtifactTypes, fileType) {
+		return NewValidationError("file_type", fmt.Sprintf("artifact type %q is not allowed in this project", fileType))
+	}
+	if s.MaxArtifactSizeBytes > 0 && size > s.MaxArtifactSizeBytes {
+		return NewValidationError("file_size", fmt.Sprintf("artifact exceeds the project limit of %d bytes", s.MaxArtifactSizeBytes))
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bounds of the promotion pipeline.
+const (
+	MaxPipelineStages     = 10
+	MaxRequiredApprovals  = 10
+	MaxApproverRoles      = 10
+	MaxStageSoakHours     = 24 * 30
+	MaxApproverRoleLength = 64
+)
+
+// PromotionPipeline is the path releases follow between channels.
+// A release can only be promoted to the stage right after its own; an empty
+// pipeline allows any promotion. Stages name channels; an application lacking
+// a stage's channel cannot promote past that stage.
+type PromotionPipeline struct {
+	Stages []PipelineStage `json:"stages"`
+}
+
+// PipelineStage is an environment of the pipeline and the gate a release must
+// pass to enter it.
+type PipelineStage struct {
+	Environment       ReleaseEnvironment `json:"environment"`
+	RequiredApprovals int                `json:"required_approvals"` // 0 promotes right away
+	ApproverRoles     []string           `json:"approver_roles"`     // Project roles allowed to approve; empty allows any
+	MinSoakHours      int                `json:"min_soak_hours"`     // Time the release must have spent in the previous stage
+}
+
+// NewPromotionBlockedError reports a promotion the project's pipeline does not allow.
+func NewPromotionBlockedError(message string) *AppError {
+	return NewAppError(CodePromotionBlocked, message)
+}
+
+// DefaultPromotionPipeline returns the pipeline of a new project:
+// development, then staging, then production, without gates.
+func DefaultPromotionPipeline() PromotionPipeline {
+	return PromotionPipeline{Stages: []PipelineStage{
+		{Environment: EnvironmentDevelopment, ApproverRoles: []string{}},
+		{Environment: EnvironmentStaging, ApproverRoles: []string{}},
+		{Environment: EnvironmentProduction, ApproverRoles: []string{}},
+	}}
+}
+
+// Gated reports whether entering the stage needs more than a promotion request.
+func (s PipelineStage) Gated() bool {
+	return s.RequiredApprovals > 0 || s.MinSoakHours > 0
+}
+
+// CanApprove reports whether a project role may approve promotions into the stage.
+func (s PipelineStage) CanApprove(role string) bool {
+	return len(s.ApproverRoles) == 0 || slices.Contains(s.ApproverRoles, role)
+}
+
+// Validate checks that the pipeline is consistent.
+func (p PromotionPipeline) Validate() error {
+	if len(p.Stages) > MaxPipelineStages {
+		return NewValidationError("pipeline.stages", fmt.Sprintf("at most %d stages are allowed", MaxPipelineStages))
+	}
+
+	seen := make(map[ReleaseEnvironment]bool, len(p.Stages))
+	for i, stage := range p.Stages {
+		field := fmt.Sprintf("pipeline.stages[%d]", i)
+		if !stage.Environment.Valid() {
+			return invalidChannelName(field + ".environment")
+		}
+		if seen[stage.Environment] {
+			return NewValidationError(field+".environment", fmt.Sprintf("%s appears more than once", stage.Environment))
+		}
+		seen[stage.Environment] = true
+
+		if i == 0 && stage.Gated() {
+			return NewValidationError(field, "releases enter the pipeline at its first stage, which cannot be gated")
+		}
+		if stage.RequiredApprovals < 0 || stage.RequiredApprovals > MaxRequiredApprovals {
+			return NewValidationError(field+".required_approvals", fmt.Sprintf("must be between 0 and %d", MaxRequiredApprovals))
+		}
+		if stage.MinSoakHours < 0 || stage.MinSoakHours > MaxStageSoakHours {
+			return NewValidationError(field+".min_soak_hours", fmt.Sprintf("must be between 0 and %d", MaxStageSoakHours))
+		}
+		if len(stage.ApproverRoles) > MaxApproverRoles {
+			return NewValidationError(field+".approver_roles", fmt.Sprintf("at most %d roles are allowed", MaxApproverRoles))
+		}
+		for _, role := range stage.ApproverRoles {
+			if role == "" || strings.TrimSpace(role) != role || len(role) > MaxApproverRoleLength {
+				return NewValidationError(field+".approver_roles", fmt.Sprintf("%q is not a valid role", role))
+			}
+		}
+	}
+	return nil
+}
+
+// StageFor returns the stage gating a promotion between two channels, or an
+// error when the pipeline does not allow it. Channels outside the pipeline
+// (e.g. customer-acme) can be promoted into freely, but releases only enter
+// the pipeline at its first stage.
+func (p PromotionPipeline) StageFor(from, to ReleaseEnvironment) (PipelineStage, error) {
+	if len(p.Stages) == 0 {
+		return PipelineStage{Environment: to}, nil
+	}
+
+	fromIdx := p.index(from)
+	toIdx := p.index(to)
+	switch {
+	case toIdx < 0:
+		return PipelineStage{Environment: to}, nil
+	case fromIdx < 0 && toIdx != 0:
+		return PipelineStage{}, NewPromotionBlockedError(fmt.Sprintf("releases in %s can only enter the project's promotion pipeline at %s", from, p.Stages[0].Environment))
+	case fromIdx < 0:
+	case fromIdx == len(p.Stages)-1:
+		return PipelineStage{}, NewPromotionBlockedError(fmt.Sprintf("%s is the last stage of the project's promotion pipeline", from))
+	case toIdx != fromIdx+1:
+		return PipelineStage{}, NewPromotionBlockedError(fmt.Sprintf("releases in %s can only be promoted to %s", from, p.Stages[fromIdx+1].Environment))
+	}
+	return p.Stages[toIdx], nil
+}
+
+// CheckUpload refuses uploads straight into a gated stage, which would skip its gate.
+func (p PromotionPipeline) CheckUpload(env ReleaseEnvironment) error {
+	if i := p.index(env); i >= 0 && p.Stages[i].Gated() {
+		return NewPromotionBlockedError(fmt.Sprintf("releases reach %s through promotion only", env))
+	}
+	return nil
+}
+
+func (p PromotionPipeline) index(env ReleaseEnvironment) int {
+	return slices.IndexFunc(p.Stages, func(s PipelineStage) bool { return s.Environment == env })
+}
+
+// CheckSoak refuses the promotion of a release that entered its current
+// environment less than the stage's soak time ago.
+func (s PipelineStage) CheckSoak(from ReleaseEnvironment, enteredAt, now time.Time) error {
+	soak := time.Duration(s.MinSoakHours) * time.Hour
+	if left := enteredAt.Add(soak).Sub(now); left > 0 {
+		return NewPromotionBlockedError(fmt.Sprintf(
+			"releases must spend %dh in %s before promotion to %s, %s left",
+			s.MinSoakHours, from, s.Environment, left.Round(time.Minute),
+		))
+	}
+	return nil
+}
+
+// ========== Promotion Requests ==========
+
+// PromotionRequestStatus is the state of a promotion request.
+type PromotionRequestStatus string
+
+const (
+	PromotionPending   PromotionRequestStatus = "pending"
+	PromotionApproved  PromotionRequestStatus = "approved" // The release was promoted
+	PromotionRejected  PromotionRequestStatus = "rejected"
+	PromotionCancelled PromotionRequestStatus = "cancelled"
+	PromotionFailed    PromotionRequestStatus = "failed" // Approved, but the release could no longer be promoted
+)
+
+// PromotionRequest is a promotion waiting for approvals. The stage's gate is
+// copied on creation so later pipeline changes don't affect it.
+type PromotionRequest struct {
+	ID                uuid.UUID
+	ApplicationID     uuid.UUID
+	ReleaseID         uuid.UUID
+	FromEnvironment   ReleaseEnvironment
+	ToEnvironment     ReleaseEnvironment
+	RequestedBy       *uuid.UUID // Nil once the requester's account is gone
+	Note              string
+	Status            PromotionRequestStatus
+	RequiredApprovals int
+	ApproverRoles     []string
+	PromotedReleaseID *uuid.UUID // The copy, once approved
+	FailureReason     string     // Why the promotion failed, once failed
+	RolloutPercentage int32      // Rollout the copy starts at
+	ResolvedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Reviews           []*PromotionReview
+}
+
+// IsPromotionFailure reports whether an error raised while carrying out an
+// approved promotion means the release can no longer be promoted as requested,
+// as opposed to an error worth retrying.
+func IsPromotionFailure(err error) bool {
+	for _, target := range []error{ErrReleaseNotFound, ErrReleaseExists, ErrReleaseWithdrawn, ErrReleaseStatus, ErrVersionCode} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReview reports whether a project role may review the request.
+func (r *PromotionRequest) CanReview(role string) bool {
+	return len(r.ApproverRoles) == 0 || slices.Contains(r.ApproverRoles, role)
+}
+
+// Approvals counts the approving reviews.
+func (r *PromotionRequest) Approvals() int {
+	n := 0
+	for _, review := range r.Reviews {
+		if review.Approved {
+			n++
+		}
+	}
+	return n
+}
+
+// PromotionReview is an approval or rejection of a promotion request.
+type PromotionReview struct {
+	ID         uuid.UUID
+	RequestID  uuid.UUID
+	ReviewerID *uuid.UUID // Nil once the reviewer's account is gone
+	Approved   bool
+	Comment    string
+	CreatedAt  time.Time
+}
+
+// CreatePromotionRequestInput represents data needed to open a promotion request.
+type CreatePromotionRequestInput struct {
+	ApplicationID     uuid.UUID
+	ReleaseID         uuid.UUID
+	FromEnvironment   ReleaseEnvironment
+	ToEnvironment     ReleaseEnvironment
+	RequestedBy       uuid.UUID
+	Note              string
+	RequiredApprovals int
+	ApproverRoles     []string
+	RolloutPercentage int32
+}
+
+// PromotionResult is the outcome of a promotion: the promoted copy, or the
+// request waiting for approvals when the target stage is gated.
+type PromotionResult struct {
+	Release *ApplicationRelease
+	Request *PromotionRequest
+}
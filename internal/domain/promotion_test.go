@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPipeline() PromotionPipeline {
+	return PromotionPipeline{Stages: []PipelineStage{
+		{Environment: EnvironmentDevelopment},
+		{Environment: EnvironmentStaging, MinSoakHours: 24},
+		{Environment: EnvironmentProduction, RequiredApprovals: 2, ApproverRoles: []string{"owner", "admin"}},
+	}}
+}
+
+func TestPromotionPipeline_StageFor(t *testing.T) {
+	pipeline := testPipeline()
+
+	tests := []struct {
+		name    string
+		from    ReleaseEnvironment
+		to      ReleaseEnvironment
+		stage   ReleaseEnvironment
+		blocked bool
+	}{
+		{name: "next stage", from: EnvironmentDevelopment, to: EnvironmentStaging, stage: EnvironmentStaging},
+		{name: "next stage, gated", from: EnvironmentStaging, to: EnvironmentProduction, stage: EnvironmentProduction},
+		{name: "skipping a stage", from: EnvironmentDevelopment, to: EnvironmentProduction, blocked: true},
+		{name: "backwards", from: EnvironmentProduction, to: EnvironmentStaging, blocked: true},
+		{name: "past the last stage", from: EnvironmentProduction, to: "customer-acme", stage: "customer-acme"},
+		{name: "from the last stage into the pipeline", from: EnvironmentProduction, to: EnvironmentDevelopment, blocked: true},
+		{name: "off-pipeline into the first stage", from: "customer-acme", to: EnvironmentDevelopment, stage: EnvironmentDevelopment},
+		{name: "off-pipeline into a later stage", from: "customer-acme", to: EnvironmentStaging, blocked: true},
+		{name: "into an off-pipeline channel", from: EnvironmentStaging, to: "customer-acme", stage: "customer-acme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage, err := pipeline.StageFor(tt.from, tt.to)
+			if tt.blocked {
+				assert.ErrorIs(t, err, NewPromotionBlockedError(""))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stage, stage.Environment)
+		})
+	}
+
+	t.Run("gate of the stage entered", func(t *testing.T) {
+		stage, err := pipeline.StageFor(EnvironmentStaging, EnvironmentProduction)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stage.RequiredApprovals)
+		assert.Equal(t, []string{"owner", "admin"}, stage.ApproverRoles)
+	})
+
+	t.Run("empty pipeline", func(t *testing.T) {
+		stage, err := PromotionPipeline{}.StageFor(EnvironmentProduction, EnvironmentDevelopment)
+		require.NoError(t, err)
+		assert.Equal(t, PipelineStage{Environment: EnvironmentDevelopment}, stage)
+	})
+}
+
+func TestPromotionPipeline_CheckUpload(t *testing.T) {
+	pipeline := testPipeline()
+
+	for env, blocked := range map[ReleaseEnvironment]bool{
+		EnvironmentDevelopment: false,
+		EnvironmentStaging:     true, // Soak time
+		EnvironmentProduction:  true, // Approvals
+		"customer-acme":        false,
+	} {
+		err := pipeline.CheckUpload(env)
+		if blocked {
+			assert.ErrorIs(t, err, NewPromotionBlockedError(""), env)
+		} else {
+			assert.NoError(t, err, env)
+		}
+	}
+
+	assert.NoError(t, PromotionPipeline{}.CheckUpload(EnvironmentProduction))
+}
+
+func TestPipelineStage_CheckSoak(t *testing.T) {
+	stage := PipelineStage{Environment: EnvironmentProduction, MinSoakHours: 24}
+	entered := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		left    string
+		blocked bool
+	}{
+		{name: "just entered", now: entered, left: "24h0m0s", blocked: true},
+		{name: "soak time remaining", now: entered.Add(20*time.Hour + 30*time.Minute), left: "3h30m0s", blocked: true},
+		{name: "soak time over", now: entered.Add(24 * time.Hour)},
+		{name: "long since", now: entered.Add(72 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := stage.CheckSoak(EnvironmentStaging, entered, tt.now)
+			if !tt.blocked {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, NewPromotionBlockedError(""))
+			assert.Contains(t, err.Error(), tt.left+" left")
+		})
+	}
+
+	assert.NoError(t, PipelineStage{}.CheckSoak(EnvironmentStaging, entered, entered), "stages without soak time")
+}
+
+func TestPromotionRequest_CanReview(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []string
+		role  string
+		want  bool
+	}{
+		{name: "any role", roles: nil, role: "member", want: true},
+		{name: "listed role", roles: []string{"owner", "admin"}, role: "admin", want: true},
+		{name: "unlisted role", roles: []string{"owner", "admin"}, role: "member", want: false},
+		{name: "roles are case sensitive", roles: []string{"qa"}, role: "QA", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &PromotionRequest{ApproverRoles: tt.roles}
+			assert.Equal(t, tt.want, request.CanReview(tt.role))
+			assert.Equal(t, tt.want, PipelineStage{ApproverRoles: tt.roles}.CanApprove(tt.role))
+		})
+	}
+}
+
+func TestPromotionRequest_Approvals(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews []*PromotionReview
+		want    int
+	}{
+		{name: "no reviews", want: 0},
+		{name: "approvals only", reviews: []*PromotionReview{{Approved: true}, {Approved: true}}, want: 2},
+		{name: "rejections are not counted", reviews: []*PromotionReview{{Approved: true}, {Approved: false}}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, (&PromotionRequest{Reviews: tt.reviews}).Approvals())
+		})
+	}
+}
+
+func TestIsPromotionFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "target holds the version", err: NewAppError(CodeReleaseExists, "version code 42 is already in production"), want: true},
+		{name: "version code policy", err: ErrVersionCode, want: true},
+		{name: "release withdrawn", err: ErrReleaseWithdrawn, want: true},
+		{name: "release status", err: NewAppError(CodeInvalidReleaseStatus, "the release was archived since the request was opened"), want: true},
+		{name: "release gone", err: ErrReleaseNotFound, want: true},
+		{name: "wrapped", err: fmt.Errorf("promote: %w", ErrVersionCode), want: true},
+		{name: "internal error", err: WrapError(CodeInternal, "failed to promote release", errors.New("connection reset")), want: false},
+		{name: "already reviewed", err: ErrPromotionReviewed, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPromotionFailure(tt.err))
+		})
+	}
+}
@@ -1,20 +1,49 @@
 package domain
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// ReleaseEnvironment represents the environment of a release.
+// ReleaseEnvironment is the name of the release channel a release is published
+// to. Each application defines its own channels; see ReleaseChannel.
 type ReleaseEnvironment string
 
+// Channels every application starts with.
 const (
 	EnvironmentDevelopment ReleaseEnvironment = "development"
 	EnvironmentStaging     ReleaseEnvironment = "staging"
 	EnvironmentProduction  ReleaseEnvironment = "production"
 )
 
+// ReleaseStatus is the lifecycle stage of a release.
+type ReleaseStatus string
+
+const (
+	ReleaseDraft     ReleaseStatus = "draft"     // Being prepared; only visible to uploaders
+	ReleasePublished ReleaseStatus = "published" // Visible to testers; counts as its channel's latest
+	ReleaseWithdrawn ReleaseStatus = "withdrawn" // Pulled back, with a reason shown to downloaders
+	ReleaseArchived  ReleaseStatus = "archived"  // Kept for the record only
+)
+
+// releaseTransitions lists the statuses each status can move to.
+var releaseTransitions = map[ReleaseStatus][]ReleaseStatus{
+	ReleaseDraft:     {ReleasePublished},
+	ReleasePublished: {ReleaseWithdrawn, ReleaseArchived},
+	ReleaseWithdrawn: {ReleasePublished, ReleaseArchived},
+}
+
+// CheckTransition refuses a status change the release lifecycle does not allow.
+func (s ReleaseStatus) CheckTransition(to ReleaseStatus) error {
+	if !slices.Contains(releaseTransitions[s], to) {
+		return NewAppError(CodeInvalidReleaseStatus, fmt.Sprintf("a %s release cannot become %s", s, to))
+	}
+	return nil
+}
+
 // ApplicationRelease represents a specific version of an application.
 type ApplicationRelease struct {
 	ID            uuid.UUID
@@ -24,8 +53,23 @@ type ApplicationRelease struct {
 	ReleaseNote   string
 	Environment   ReleaseEnvironment
 	ApplicationID uuid.UUID
+	Status        ReleaseStatus
+	PublishedAt   *time.Time // First publication
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	DeletedAt     *time.Time // Set on releases in the trash
+
+	// Set on withdrawn releases, directly or by a rollback; they are kept
+	// but no longer count as their channel's latest release.
+	WithdrawnAt      *time.Time
+	WithdrawnBy      *uuid.UUID
+	WithdrawalReason string
+
+	// Share of clients offered the release while it is published
+	Rollout Rollout
+
+	// Clients offered the release, or updating past it, must install it
+	Mandatory bool
 }
 
 // CreateReleaseInput represents data needed to create a new release.
@@ -36,10 +80,49 @@ type CreateReleaseInput struct {
 	ReleaseNote   string
 	Environment   ReleaseEnvironment
 	ApplicationID uuid.UUID
+	Status        ReleaseStatus // Draft, or published for releases created from a verified artifact
+
+	RolloutPercentage int32 // 0 offers the release to every client
+	Mandatory         bool
+}
+
+// RollbackResult is the outcome of rolling a channel back.
+type RollbackResult struct {
+	Withdrawn *ApplicationRelease // The release taken out of the channel
+	Current   *ApplicationRelease // The channel's latest release from now on
 }
 
 // UpdateReleaseInput represents data needed to update an existing release.
 type UpdateReleaseInput struct {
 	Title       *string
 	ReleaseNote *string
+	Mandatory   *bool
+}
+
+// ReleasePromotion records the copy of a release into another environment.
+type ReleasePromotion struct {
+	ID              uuid.UUID
+	ApplicationID   uuid.UUID
+	SourceReleaseID *uuid.UUID // Nil once the promoted release is purged
+	ReleaseID       *uuid.UUID // The copy; nil once purged
+	VersionCode     int32
+	VersionName     string
+	FromEnvironment ReleaseEnvironment
+	ToEnvironment   ReleaseEnvironment
+	ActorID         *uuid.UUID // Nil once the actor's account is gone
+	Note            string
+	CreatedAt       time.Time
+}
+
+// CreateReleasePromotionInput represents data needed to record a promotion.
+type CreateReleasePromotionInput struct {
+	ApplicationID   uuid.UUID
+	SourceReleaseID uuid.UUID
+	ReleaseID       uuid.UUID
+	VersionCode     int32
+	VersionName     string
+	FromEnvironment ReleaseEnvironment
+	ToEnvironment   ReleaseEnvironment
+	ActorID         uuid.UUID
+	Note            string
 }
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bounds of release notes.
+const (
+	MaxReleaseNoteLength = 2000
+	MaxLocaleLength      = 35
+	MaxChangelogReleases = 100
+)
+
+// localePattern matches BCP 47 language tags such as fr, pt-BR or zh-Hant-TW.
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// ReleaseNote is the translation of a release's notes into a locale, in Markdown.
+// The release's own ReleaseNote is its default note.
+type ReleaseNote struct {
+	ReleaseID uuid.UUID
+	Locale    string
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// LocalizedNote is the note of a release shown to a reader.
+type LocalizedNote struct {
+	Note   string
+	Locale string // Empty for the release's default note
+}
+
+// NormalizeLocale checks a language tag and puts it in its usual case:
+// lowercase language, uppercase region and titlecase script, e.g. zh-Hant-TW.
+// Underscores, as in Android and Java locales, are taken for hyphens.
+func NormalizeLocale(locale string) (string, error) {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if len(locale) > MaxLocaleLength || !localePattern.MatchString(locale) {
+		return "", NewValidationError("locale", "must be a language tag such as fr or pt-BR")
+	}
+	subtags := strings.Split(locale, "-")
+	for i, s := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(s)
+		case len(s) == 2:
+			subtags[i] = strings.ToUpper(s)
+		case len(s) == 4:
+			subtags[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+		default:
+			subtags[i] = strings.ToLower(s)
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// LocaleFallbacks lists the locales whose notes suit a reader of the given
+// locale, most specific first: pt-BR falls back to pt.
+func LocaleFallbacks(locale string) []string {
+	var fallbacks []string
+	for locale != "" {
+		fallbacks = append(fallbacks, locale)
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return fallbacks
+}
+
+// ValidateReleaseNoteText checks the text of a translated note. Translations
+// are removed rather than emptied.
+func ValidateReleaseNoteText(note string) error {
+	if strings.TrimSpace(note) == "" {
+		return NewValidationError("note", "is required")
+	}
+	if len(note) > MaxReleaseNoteLength {
+		return NewValidationError("note", fmt.Sprintf("must be at most %d characters", MaxReleaseNoteLength))
+	}
+	return nil
+}
+
+// LocalizeNote picks the note of a release for a reader of the given locale
+// among translations, which may belong to other releases too. Without a
+// matching translation, the release's default note is used.
+func LocalizeNote(release *ApplicationRelease, notes []*ReleaseNote, locale string) LocalizedNote {
+	for _, candidate := range LocaleFallbacks(locale) {
+		for _, n := range notes {
+			if n.ReleaseID == release.ID && n.Locale == candidate {
+				return LocalizedNote{Note: n.Note, Locale: n.Locale}
+			}
+		}
+	}
+	return LocalizedNote{Note: release.ReleaseNote}
+}
+
+// ChangelogRange is the span of version codes a changelog covers: the
+// releases above From up to To, or every newer one when To is 0.
+type ChangelogRange struct {
+	From int32
+	To   int32
+}
+
+// Validate checks the range.
+func (r ChangelogRange) Validate() error {
+	if r.From < 0 {
+		return NewValidationError("from_version_code", "must not be negative")
+	}
+	if r.To != 0 && r.To <= r.From {
+		return NewValidationError("to_version_code", "must be greater than from_version_code")
+	}
+	return nil
+}
+
+// UpTo returns the highest version code covered.
+func (r ChangelogRange) UpTo() int32 {
+	if r.To == 0 {
+		return math.MaxInt32
+	}
+	return r.To
+}
+
+// ChangelogEntry is one release of a changelog.
+type ChangelogEntry struct {
+	Release *ApplicationRelease
+	Note    LocalizedNote
+}
+
+// Changelog gathers the notes of the releases a client gets the changes of
+// when updating across a range of version codes, newest first.
+type Changelog struct {
+	Environment ReleaseEnvironment
+	Range       ChangelogRange
+	Entries     []ChangelogEntry
+	Truncated   bool // Older releases were left out past MaxChangelogReleases
+}
+
+// Markdown joins the notes into one document, a section per release.
+// Releases without notes are listed with their version only.
+func (c *Changelog) Markdown() string {
+	var b strings.Builder
+	for i, e := range c.Entries {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "## %s (%d)", e.Release.VersionName, e.Release.VersionCode)
+		if note := strings.TrimSpace(e.Note.Note); note != "" {
+			b.WriteString("\n\n" + note)
+		}
+	}
+	return b.String()
+}
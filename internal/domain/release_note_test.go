@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	for in, want := range map[string]string{
+		"fr":         "fr",
+		"PT-br":      "pt-BR",
+		"pt_BR":      "pt-BR",
+		"zh-hant-tw": "zh-Hant-TW",
+		"es-419":     "es-419",
+	} {
+		got, err := NormalizeLocale(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "f", "french!", "fr--BR", "fr-"} {
+		_, err := NormalizeLocale(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	assert.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, LocaleFallbacks("zh-Hant-TW"))
+	assert.Equal(t, []string{"fr"}, LocaleFallbacks("fr"))
+	assert.Empty(t, LocaleFallbacks(""))
+}
+
+func TestLocalizeNote(t *testing.T) {
+	release := &ApplicationRelease{ID: uuid.New(), ReleaseNote: "Bug fixes"}
+	other := uuid.New()
+	notes := []*ReleaseNote{
+		{ReleaseID: other, Locale: "pt-BR", Note: "Outro"},
+		{ReleaseID: release.ID, Locale: "pt", Note: "Correções"},
+	}
+
+	assert.Equal(t, LocalizedNote{Note: "Correções", Locale: "pt"}, LocalizeNote(release, notes, "pt-BR"))
+	assert.Equal(t, LocalizedNote{Note: "Bug fixes"}, LocalizeNote(release, notes, "fr"))
+	assert.Equal(t, LocalizedNote{Note: "Bug fixes"}, LocalizeNote(release, notes, ""))
+}
+
+func TestChangelogRange(t *testing.T) {
+	assert.NoError(t, ChangelogRange{From: 10}.Validate())
+	assert.NoError(t, ChangelogRange{From: 10, To: 12}.Validate())
+	assert.Error(t, ChangelogRange{From: -1}.Validate())
+	assert.Error(t, ChangelogRange{From: 10, To: 10}.Validate())
+
+	assert.Equal(t, int32(12), ChangelogRange{From: 10, To: 12}.UpTo())
+	assert.Greater(t, ChangelogRange{From: 10}.UpTo(), int32(1<<30))
+}
+
+func TestChangelogMarkdown(t *testing.T) {
+	c := &Changelog{Entries: []ChangelogEntry{
+		{Release: &ApplicationRelease{VersionName: "1.2.0", VersionCode: 12}, Note: LocalizedNote{Note: "- New screen\n"}},
+		{Release: &ApplicationRelease{VersionName: "1.1.0", VersionCode: 11}},
+	}}
+	assert.Equal(t, "## 1.2.0 (12)\n\n- New screen\n\n## 1.1.0 (11)", c.Markdown())
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutBucket(t *testing.T) {
+	appID := uuid.New()
+
+	bucket := RolloutBucket("device-1", appID)
+	assert.Equal(t, bucket, RolloutBucket("device-1", appID), "buckets must be stable")
+
+	// Clients spread roughly evenly: about 10% of them fall in the first 10 buckets.
+	const clients = 10000
+	inFirstTenth := 0
+	for i := range clients {
+		b := RolloutBucket("device-"+strconv.Itoa(i), appID)
+		require.GreaterOrEqual(t, b, 0)
+		require.Less(t, b, RolloutBuckets)
+		if b < 10 {
+			inFirstTenth++
+		}
+	}
+	assert.InDelta(t, clients/10, inFirstTenth, clients/50)
+
+	// The same client is placed independently in each application.
+	differs := false
+	for i := 0; i < 20 && !differs; i++ {
+		differs = RolloutBucket("device-1", uuid.New()) != bucket
+	}
+	assert.True(t, differs)
+
+	// Anonymous clients only receive full rollouts.
+	assert.Equal(t, RolloutBuckets-1, RolloutBucket("", appID))
+}
+
+func TestRollout_Includes(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, Rollout{Percentage: 10}.Includes(9))
+	assert.False(t, Rollout{Percentage: 10}.Includes(10))
+	assert.True(t, Rollout{Percentage: FullRollout}.Includes(RolloutBuckets-1))
+	assert.False(t, Rollout{Percentage: 10, HaltedAt: &now}.Includes(0))
+}
+
+func TestRollout_CheckIncrease(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, Rollout{Percentage: 10}.CheckIncrease(50))
+	assert.NoError(t, Rollout{Percentage: 10}.CheckIncrease(FullRollout))
+	assert.Error(t, Rollout{Percentage: 10}.CheckIncrease(10))
+	assert.Error(t, Rollout{Percentage: 50}.CheckIncrease(10))
+	assert.Error(t, Rollout{Percentage: 10}.CheckIncrease(101))
+
+	// A halted rollout can resume where it stopped
+	assert.NoError(t, Rollout{Percentage: 10, HaltedAt: &now}.CheckIncrease(10))
+	assert.Error(t, Rollout{Percentage: 10, HaltedAt: &now}.CheckIncrease(5))
+}
+
+func TestRollout_CheckHalt(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, Rollout{Percentage: 10}.CheckHalt())
+	assert.Equal(t, CodeInvalidReleaseStatus, GetErrorCode(Rollout{Percentage: FullRollout}.CheckHalt()))
+	assert.Equal(t, CodeInvalidReleaseStatus, GetErrorCode(Rollout{Percentage: 10, HaltedAt: &now}.CheckHalt()))
+}
+
+func TestSelectRelease(t *testing.T) {
+	now := time.Now()
+	staged := &ApplicationRelease{VersionCode: 3, Rollout: Rollout{Percentage: 10}}
+	halted := &ApplicationRelease{VersionCode: 2, Rollout: Rollout{Percentage: 50, HaltedAt: &now}}
+	full := &ApplicationRelease{VersionCode: 1, Rollout: Rollout{Percentage: FullRollout}}
+	candidates := []*ApplicationRelease{staged, halted, full}
+
+	assert.Same(t, staged, SelectRelease(candidates, 5))
+	assert.Same(t, full, SelectRelease(candidates, 20), "halted rollouts are skipped")
+	assert.Same(t, full, SelectRelease(candidates, 99))
+	assert.Nil(t, SelectRelease([]*ApplicationRelease{staged}, 50))
+	assert.Nil(t, SelectRelease(nil, 0))
+}
@@ -0,0 +1,9 @@
+package domain
+
+// Trash is the soft-deleted content of a project, most recently deleted first.
+// Items can be restored until the purger removes them for good.
+type Trash struct {
+	Applications []*Application
+	Releases     []*ApplicationRelease
+	Artifacts    []*Artifact
+}
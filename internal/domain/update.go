@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UpdateCheckInput is what a client SDK reports about its installed build
+// when checking for updates.
+type UpdateCheckInput struct {
+	PackageName string
+	VersionCode int32              // Installed version code
+	Environment ReleaseEnvironment // Empty for the application's default channel
+	ABI         string             // Device ABI, e.g. arm64-v8a; empty accepts any artifact
+	DeviceID    string             // Stable device identifier, places the device in staged rollouts
+	Locale      string             // Language of the device, picks the release notes; empty for default notes
+}
+
+// UpdateInfo is the answer to an update check.
+type UpdateInfo struct {
+	Environment ReleaseEnvironment
+	Release     *ApplicationRelease // Nil when no newer build applies to the client
+	Artifact    *Artifact
+	Note        LocalizedNote // Notes of the release, in the client's locale when translated
+
+	// Signed link to the artifact, or its file URL when stored elsewhere
+	DownloadURL       string
+	DownloadExpiresAt *time.Time // Nil for links that do not expire
+
+	MinSupportedVersionCode int32 // Of the channel, 0 when every build is supported
+	Supported               bool  // Whether the installed build is at or above the minimum
+	// Whether the client must install the update: its build is no longer
+	// supported, or the update is or skips a mandatory release
+	Mandatory bool
+}
+
+// Available reports whether a newer build is offered to the client.
+func (u *UpdateInfo) Available() bool {
+	return u.Release != nil && u.Artifact != nil
+}
+
+// ETag identifies the answer for HTTP caching. It covers the release, notes
+// and artifact offered and whether the update is mandatory, but not the download
+// link, which is signed anew on every check, so it is a weak validator.
+func (u *UpdateInfo) ETag() string {
+	h := sha256.New()
+	h.Write([]byte(u.Environment))
+	fmt.Fprintf(h, ":%d:%t:%t", u.MinSupportedVersionCode, u.Supported, u.Mandatory)
+	if u.Available() {
+		h.Write([]byte(":" + u.Release.ID.String() + ":" + u.Release.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+		h.Write([]byte(":" + u.Artifact.ID.String() + ":" + u.Artifact.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+		h.Write([]byte(":" + u.Note.Locale + ":" + u.Note.Note))
+	} else {
+		h.Write([]byte(":none"))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
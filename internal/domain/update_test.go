@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateInfo_ETag(t *testing.T) {
+	now := time.Now()
+	release := &ApplicationRelease{ID: uuid.New(), UpdatedAt: now}
+	artifact := &Artifact{ID: uuid.New(), UpdatedAt: now}
+
+	none := &UpdateInfo{Environment: EnvironmentProduction}
+	offered := &UpdateInfo{Environment: EnvironmentProduction, Release: release, Artifact: artifact, DownloadURL: "https://a"}
+	assert.False(t, none.Available())
+	assert.True(t, offered.Available())
+	assert.NotEqual(t, none.ETag(), offered.ETag())
+	assert.NotEqual(t, none.ETag(), (&UpdateInfo{Environment: EnvironmentStaging}).ETag())
+
+	// Re-signed download links keep the ETag; release changes do not.
+	resigned := *offered
+	resigned.DownloadURL = "https://b"
+	assert.Equal(t, offered.ETag(), resigned.ETag())
+
+	changed := *release
+	changed.UpdatedAt = now.Add(time.Second)
+	assert.NotEqual(t, offered.ETag(), (&UpdateInfo{Environment: EnvironmentProduction, Release: &changed, Artifact: artifact}).ETag())
+}
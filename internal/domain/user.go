@@ -21,6 +21,15 @@ type User struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	LastLoginAt *time.Time // nil if never logged in
+
+	// TokenVersion is embedded in issued JWTs; bumping it revokes every session.
+	TokenVersion int32
+
+	// IsAdmin grants system-wide administration (user management, audit logs).
+	IsAdmin bool
+
+	// AvatarURL is the public URL of the largest avatar rendition, empty when none was uploaded.
+	AvatarURL string
 }
 
 // FullName returns the user's full name.
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// VersionCodePolicy sets how the version code of a new release must relate to
+// the version codes already used in its environment.
+type VersionCodePolicy string
+
+const (
+	// Above every version code used in the environment, as Android refuses to
+	// update an installed build to a lower version code.
+	VersionCodeIncreasing VersionCodePolicy = "increasing"
+	// Only unused in the environment, for projects shipping hotfixes of older lines.
+	VersionCodeUnique VersionCodePolicy = "unique"
+)
+
+// Valid checks that the policy is a known one.
+func (p VersionCodePolicy) Valid() bool {
+	return p == VersionCodeIncreasing || p == VersionCodeUnique
+}
+
+// CheckVersionCode checks a new version code against the highest one used in
+// the environment. Duplicates are refused by the release uniqueness checks.
+func (p VersionCodePolicy) CheckVersionCode(code, highest int32, env ReleaseEnvironment) error {
+	if p == VersionCodeUnique || code > highest {
+		return nil
+	}
+	return NewAppError(CodeInvalidVersionCode, fmt.Sprintf("version code %d must be greater than %d, the highest used in %s", code, highest, env))
+}
+
+// NextVersion is the version code CI should build next for an environment.
+type NextVersion struct {
+	Environment        ReleaseEnvironment
+	HighestVersionCode int32 // 0 when the environment has no release yet
+	NextVersionCode    int32
+}
+
+// NewNextVersion computes the next version code after the highest one used.
+func NewNextVersion(env ReleaseEnvironment, highest int32) *NextVersion {
+	return &NextVersion{Environment: env, HighestVersionCode: highest, NextVersionCode: highest + 1}
+}
+
+// semverPattern is the pattern recommended by the Semantic Versioning 2.0.0 spec.
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// IsSemver reports whether a version name is a semantic version, such as
+// 1.4.0, 2.0.0-beta.1 or 1.0.0+42. A leading "v" is not allowed.
+func IsSemver(name string) bool {
+	return semverPattern.MatchString(name)
+}
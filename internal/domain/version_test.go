@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSemver(t *testing.T) {
+	for _, name := range []string{"0.0.1", "1.4.0", "10.20.30", "2.0.0-beta.1", "1.0.0-rc.1+build.42", "1.0.0+20260101"} {
+		assert.True(t, IsSemver(name), name)
+	}
+	for _, name := range []string{"", "1", "1.4", "v1.4.0", "01.4.0", "1.4.0-", "1.4.0-01", "1.4.0+", "1.4.0.1", " 1.4.0"} {
+		assert.False(t, IsSemver(name), name)
+	}
+}
+
+func TestVersionCodePolicy_CheckVersionCode(t *testing.T) {
+	assert.NoError(t, VersionCodeIncreasing.CheckVersionCode(1, 0, EnvironmentProduction))
+	assert.NoError(t, VersionCodeIncreasing.CheckVersionCode(12, 11, EnvironmentProduction))
+
+	err := VersionCodeIncreasing.CheckVersionCode(11, 11, EnvironmentProduction)
+	assert.Equal(t, CodeInvalidVersionCode, GetErrorCode(err))
+	err = VersionCodeIncreasing.CheckVersionCode(3, 11, EnvironmentProduction)
+	assert.Equal(t, CodeInvalidVersionCode, GetErrorCode(err))
+
+	assert.NoError(t, VersionCodeUnique.CheckVersionCode(3, 11, EnvironmentProduction))
+}
+
+func TestProjectSettings_CheckVersionName(t *testing.T) {
+	settings := DefaultProjectSettings()
+	assert.NoError(t, settings.CheckVersionName("build-42"))
+
+	settings.RequireSemver = true
+	assert.NoError(t, settings.CheckVersionName("1.4.0"))
+	var valErr *ValidationError
+	require.ErrorAs(t, settings.CheckVersionName("build-42"), &valErr)
+	assert.Equal(t, "version_name", valErr.Field)
+}
+
+func TestNewNextVersion(t *testing.T) {
+	next := NewNextVersion(EnvironmentStaging, 0)
+	assert.Equal(t, int32(1), next.NextVersionCode)
+
+	next = NewNextVersion(EnvironmentStaging, 41)
+	assert.Equal(t, int32(41), next.HighestVersionCode)
+	assert.Equal(t, int32(42), next.NextVersionCode)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/handler/middleware"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// AccountHandler handles data export and account deletion HTTP requests.
+type AccountHandler struct {
+	accountService *service.AccountService
+}
+
+// NewAccountHandler creates a new AccountHandler.
+func NewAccountHandler(accountService *service.AccountService) *AccountHandler {
+	return &AccountHandler{accountService: accountService}
+}
+
+// Register registers public account routes with the API.
+func (h *AccountHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-account",
+		Method:      http.MethodPost,
+		Path:        "/auth/restore-account",
+		Summary:     "Restore Account",
+		Description: "Cancel an account deletion using the token emailed when it was requested. Only possible during the grace period.",
+		Tags:        []string{"Auth"},
+	}, h.restoreAccount)
+}
+
+// RegisterProtected registers account routes that require authentication.
+func (h *AccountHandler) RegisterProtected(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "export-account-data",
+		Method:      http.MethodPost,
+		Path:        "/auth/me/export",
+		Summary:     "Export Account Data",
+		Description: "Package the current user's profile and the projects they can access, with their role and releases metadata, as a ZIP archive and return a temporary download link.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.exportData)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-account",
+		Method:      http.MethodPost,
+		Path:        "/auth/me/delete",
+		Summary:     "Delete Account",
+		Description: "Delete the current user's account. Owned projects must be transferred or deleted first. Confirm with the current password; accounts with a linked SSO identity may instead send a reauthentication token from a fresh SSO sign-in or a two-factor code. A data export is produced beforehand and the account can be restored until the grace period ends, after which personal data is erased.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.deleteAccount)
+}
+
+// ========== Request/Response Types ==========
+
+// DataExportResponse represents a downloadable data export.
+type DataExportResponse struct {
+	DownloadURL string    `json:"download_url" doc:"Signed link to the ZIP archive"`
+	ExpiresAt   time.Time `json:"expires_at" doc:"When the download link stops working"`
+}
+
+// ExportDataOutput is the response for exporting account data.
+type ExportDataOutput struct {
+	Body ApiResponse[DataExportResponse]
+}
+
+// DeleteAccountInput is the request for deleting the current account.
+type DeleteAccountInput struct {
+	Body struct {
+		CurrentPassword string `json:"current_password,omitempty" doc:"Current password"`
+		ReauthToken     string `json:"reauth_token,omitempty" doc:"Token from a fresh SSO sign-in, for accounts with a linked identity"`
+		Code            string `json:"code,omitempty" doc:"Two-factor authentication code, for accounts with a linked identity"`
+	}
+}
+
+// AccountDeletionResponse represents a scheduled account deletion.
+type AccountDeletionResponse struct {
+	Export         DataExportResponse `json:"export"`
+	AnonymizeAfter time.Time          `json:"anonymize_after" doc:"End of the grace period; the account can be restored until then"`
+}
+
+// DeleteAccountOutput is the response for deleting the current account.
+type DeleteAccountOutput struct {
+	Body ApiResponse[AccountDeletionResponse]
+}
+
+// RestoreAccountInput is the request for restoring a deleted account.
+type RestoreAccountInput struct {
+	Body struct {
+		Token string `json:"token" required:"true" doc:"Restore token received by email"`
+	}
+}
+
+// RestoreAccountOutput is the response for restoring a deleted account.
+type RestoreAccountOutput struct {
+	Body ApiResponse[UserResponse]
+}
+
+// ========== Handlers ==========
+
+func (h *AccountHandler) exportData(ctx context.Context, input *struct{}) (*ExportDataOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	export, err := h.accountService.Export(ctx, authUser.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ExportDataOutput{
+		Body: ok("Data export created successfully", toDataExportResponse(export)),
+	}, nil
+}
+
+func (h *AccountHandler) deleteAccount(ctx context.Context, input *DeleteAccountInput) (*DeleteAccountOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+	if authUser.IsImpersonated() {
+		return nil, mapDomainError(domain.NewAppError(domain.CodeForbidden, "account cannot be deleted while impersonating"))
+	}
+
+	deletion, err := h.accountService.RequestDeletion(ctx, authUser.ID, service.DeletionConfirmation{
+		Password:    input.Body.CurrentPassword,
+		ReauthToken: input.Body.ReauthToken,
+		Code:        input.Body.Code,
+	}, middleware.ClientIP(ctx))
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &DeleteAccountOutput{
+		Body: ok("Account deleted successfully", AccountDeletionResponse{
+			Export:         toDataExportResponse(deletion.Export),
+			AnonymizeAfter: deletion.AnonymizeAfter,
+		}),
+	}, nil
+}
+
+func (h *AccountHandler) restoreAccount(ctx context.Context, input *RestoreAccountInput) (*RestoreAccountOutput, error) {
+	user, err := h.accountService.Restore(ctx, input.Body.Token)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RestoreAccountOutput{
+		Body: ok("Account restored successfully", toUserResponse(user)),
+	}, nil
+}
+
+// toDataExportResponse converts a data export to an API response.
+func toDataExportResponse(e *service.DataExport) DataExportResponse {
+	return DataExportResponse{
+		DownloadURL: e.DownloadURL,
+		ExpiresAt:   e.ExpiresAt,
+	}
+}
@@ -0,0 +1,466 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// AdminHandler handles system administration HTTP requests.
+// Every route requires an administrator (see adminActor).
+type AdminHandler struct {
+	adminService *service.AdminService
+}
+
+// NewAdminHandler creates a new AdminHandler.
+func NewAdminHandler(adminService *service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// Register registers all admin routes with the API.
+func (h *AdminHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-activate-user",
+		Method:      http.MethodPost,
+		Path:        "/admin/users/{id}/activate",
+		Summary:     "Activate User",
+		Description: "Re-enable a deactivated user account.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.activateUser)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-deactivate-user",
+		Method:      http.MethodPost,
+		Path:        "/admin/users/{id}/deactivate",
+		Summary:     "Deactivate User",
+		Description: "Disable a user account. The user can no longer log in or refresh tokens.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.deactivateUser)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-set-user-admin",
+		Method:      http.MethodPut,
+		Path:        "/admin/users/{id}/admin",
+		Summary:     "Set Administrator",
+		Description: "Grant or revoke system administration rights. Takes effect on the user's next token refresh.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.setAdmin)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-force-password-reset",
+		Method:      http.MethodPost,
+		Path:        "/admin/users/{id}/force-password-reset",
+		Summary:     "Force Password Reset",
+		Description: "Disable the user's password, revoke all their sessions and email them a reset link.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.forcePasswordReset)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-impersonate-user",
+		Method:      http.MethodPost,
+		Path:        "/admin/users/{id}/impersonate",
+		Summary:     "Impersonate User",
+		Description: "Get a short-lived, non-refreshable access token to act as a user for support. The session is recorded in the audit log.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.impersonateUser)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-list-audit-logs",
+		Method:      http.MethodGet,
+		Path:        "/admin/audit-logs",
+		Summary:     "List Audit Logs",
+		Description: "Retrieve the most recent administrative actions, optionally about a single user.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listAuditLogs)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-list-login-locks",
+		Method:      http.MethodGet,
+		Path:        "/admin/login-locks",
+		Summary:     "List Login Lockouts",
+		Description: "Retrieve identifiers and client IPs currently locked after repeated failed logins.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listLoginLocks)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-unlock-login",
+		Method:      http.MethodPost,
+		Path:        "/admin/login-locks/unlock",
+		Summary:     "Unlock Login",
+		Description: "Lift a login lockout and reset its failure counter.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.unlockLogin)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-list-login-attempts",
+		Method:      http.MethodGet,
+		Path:        "/admin/login-attempts",
+		Summary:     "List Login Attempts",
+		Description: "Retrieve the most recent login attempts for an email or username.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listLoginAttempts)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "admin-set-organization-quotas",
+		Method:      http.MethodPut,
+		Path:        "/admin/organizations/{id}/quotas",
+		Summary:     "Set Organization Quotas",
+		Description: "Replace the project, member and application limits of an organization.",
+		Tags:        []string{"Admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.setOrganizationQuotas)
+}
+
+// ============================================================================
+// Request/Response Types
+// ============================================================================
+
+// AdminUserInput is the request for an action on a user.
+type AdminUserInput struct {
+	ID uuid.UUID `path:"id" doc:"User ID"`
+}
+
+// AdminUserOutput is the response for an action returning a user.
+type AdminUserOutput struct {
+	Body ApiResponse[UserResponse]
+}
+
+// SetAdminInput is the request for granting or revoking administration rights.
+type SetAdminInput struct {
+	ID   uuid.UUID `path:"id" doc:"User ID"`
+	Body struct {
+		IsAdmin bool `json:"is_admin" doc:"Whether the user is an administrator"`
+	}
+}
+
+// ForcePasswordResetOutput is the response for forcing a password reset.
+type ForcePasswordResetOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// ImpersonateInput is the request for impersonating a user.
+type ImpersonateInput struct {
+	ID   uuid.UUID `path:"id" doc:"User ID"`
+	Body struct {
+		Reason string `json:"reason" required:"true" minLength:"3" maxLength:"500" doc:"Why the session is needed (e.g. support ticket)"`
+	}
+}
+
+// ImpersonationResponse represents a support session.
+type ImpersonationResponse struct {
+	User        UserResponse `json:"user"`
+	AccessToken string       `json:"access_token" doc:"Access token acting as the user; cannot be refreshed"`
+	ExpiresAt   time.Time    `json:"expires_at" doc:"Access token expiration time"`
+	TokenType   string       `json:"token_type" doc:"Token type (always 'Bearer')"`
+}
+
+// ImpersonateOutput is the response for impersonating a user.
+type ImpersonateOutput struct {
+	Body ApiResponse[ImpersonationResponse]
+}
+
+// ListAuditLogsInput is the request for listing audit logs.
+type ListAuditLogsInput struct {
+	UserID string `query:"user_id" doc:"Only entries about this user (UUID)"`
+	Limit  int32  `query:"limit" default:"50" minimum:"1" maximum:"500" doc:"Maximum number of entries"`
+}
+
+// AuditLogResponse represents an audit entry in API responses.
+type AuditLogResponse struct {
+	ID           string            `json:"id"`
+	ActorID      *string           `json:"actor_id"`
+	Action       string            `json:"action"`
+	TargetUserID *string           `json:"target_user_id,omitempty"`
+	Details      map[string]string `json:"details"`
+	IPAddress    string            `json:"ip_address"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// ListAuditLogsOutput is the response for listing audit logs.
+type ListAuditLogsOutput struct {
+	Body ApiResponse[[]AuditLogResponse]
+}
+
+// LoginLockResponse represents a locked identifier or client IP.
+type LoginLockResponse struct {
+	Key          string     `json:"key" doc:"Lock key, 'identifier:<email or username>', 'user:<user ID>' or 'ip:<address>'"`
+	FailureCount int32      `json:"failure_count"`
+	LockedUntil  *time.Time `json:"locked_until"`
+}
+
+// ListLoginLocksOutput is the response for listing lockouts.
+type ListLoginLocksOutput struct {
+	Body ApiResponse[[]LoginLockResponse]
+}
+
+// UnlockLoginInput is the request for lifting a lockout.
+type UnlockLoginInput struct {
+	Body struct {
+		Key string `json:"key" required:"true" doc:"Lock key as listed by /admin/login-locks"`
+	}
+}
+
+// UnlockLoginOutput is the response for lifting a lockout.
+type UnlockLoginOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// ListLoginAttemptsInput is the request for listing login attempts.
+type ListLoginAttemptsInput struct {
+	Identifier string `query:"identifier" required:"true" doc:"Email or username"`
+	Limit      int32  `query:"limit" default:"50" minimum:"1" maximum:"500" doc:"Maximum number of attempts"`
+}
+
+// LoginAttemptResponse represents a login attempt in API responses.
+type LoginAttemptResponse struct {
+	Identifier string    `json:"identifier"`
+	IPAddress  string    `json:"ip_address"`
+	UserID     *string   `json:"user_id,omitempty"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListLoginAttemptsOutput is the response for listing login attempts.
+type ListLoginAttemptsOutput struct {
+	Body ApiResponse[[]LoginAttemptResponse]
+}
+
+// SetOrganizationQuotasInput is the request for replacing an organization's quotas.
+type SetOrganizationQuotasInput struct {
+	ID   uuid.UUID `path:"id" doc:"Organization ID"`
+	Body OrganizationQuotasBody
+}
+
+// ============================================================================
+// Handlers
+// ============================================================================
+
+func (h *AdminHandler) activateUser(ctx context.Context, input *AdminUserInput) (*AdminUserOutput, error) {
+	return h.setActive(ctx, input.ID, true)
+}
+
+func (h *AdminHandler) deactivateUser(ctx context.Context, input *AdminUserInput) (*AdminUserOutput, error) {
+	return h.setActive(ctx, input.ID, false)
+}
+
+func (h *AdminHandler) setActive(ctx context.Context, id uuid.UUID, active bool) (*AdminUserOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.adminService.SetActive(ctx, actor, id, active)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	message := "User activated successfully"
+	if !active {
+		message = "User deactivated successfully"
+	}
+	return &AdminUserOutput{
+		Body: ok(message, toUserResponse(user)),
+	}, nil
+}
+
+func (h *AdminHandler) setAdmin(ctx context.Context, input *SetAdminInput) (*AdminUserOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.adminService.SetAdmin(ctx, actor, input.ID, input.Body.IsAdmin)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &AdminUserOutput{
+		Body: ok("Administrator status updated successfully", toUserResponse(user)),
+	}, nil
+}
+
+func (h *AdminHandler) forcePasswordReset(ctx context.Context, input *AdminUserInput) (*ForcePasswordResetOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.adminService.ForcePasswordReset(ctx, actor, input.ID); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ForcePasswordResetOutput{
+		Body: ok("Password reset and sessions revoked", emptyData{}),
+	}, nil
+}
+
+func (h *AdminHandler) impersonateUser(ctx context.Context, input *ImpersonateInput) (*ImpersonateOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := h.adminService.Impersonate(ctx, actor, input.ID, input.Body.Reason)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ImpersonateOutput{
+		Body: ok("Impersonation session started", ImpersonationResponse{
+			User:        toUserResponse(session.User),
+			AccessToken: session.AccessToken,
+			ExpiresAt:   session.ExpiresAt,
+			TokenType:   "Bearer",
+		}),
+	}, nil
+}
+
+func (h *AdminHandler) listAuditLogs(ctx context.Context, input *ListAuditLogsInput) (*ListAuditLogsOutput, error) {
+	if _, err := adminActor(ctx); err != nil {
+		return nil, err
+	}
+
+	var target *uuid.UUID
+	if input.UserID != "" {
+		id, err := uuid.Parse(input.UserID)
+		if err != nil {
+			return nil, huma.Error400BadRequest("invalid user ID format")
+		}
+		target = &id
+	}
+
+	logs, err := h.adminService.ListAuditLogs(ctx, target, input.Limit)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	response := make([]AuditLogResponse, len(logs))
+	for i, l := range logs {
+		response[i] = toAuditLogResponse(l)
+	}
+
+	return &ListAuditLogsOutput{
+		Body: ok("Audit logs retrieved successfully", response),
+	}, nil
+}
+
+func (h *AdminHandler) listLoginLocks(ctx context.Context, input *struct{}) (*ListLoginLocksOutput, error) {
+	if _, err := adminActor(ctx); err != nil {
+		return nil, err
+	}
+
+	locks, err := h.adminService.ListLockedLogins(ctx)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	response := make([]LoginLockResponse, len(locks))
+	for i, l := range locks {
+		response[i] = LoginLockResponse{
+			Key:          l.Key,
+			FailureCount: l.FailureCount,
+			LockedUntil:  l.LockedUntil,
+		}
+	}
+
+	return &ListLoginLocksOutput{
+		Body: ok("Login lockouts retrieved successfully", response),
+	}, nil
+}
+
+func (h *AdminHandler) unlockLogin(ctx context.Context, input *UnlockLoginInput) (*UnlockLoginOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.adminService.UnlockLogin(ctx, actor, input.Body.Key); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &UnlockLoginOutput{
+		Body: ok("Login unlocked successfully", emptyData{}),
+	}, nil
+}
+
+func (h *AdminHandler) listLoginAttempts(ctx context.Context, input *ListLoginAttemptsInput) (*ListLoginAttemptsOutput, error) {
+	if _, err := adminActor(ctx); err != nil {
+		return nil, err
+	}
+
+	attempts, err := h.adminService.ListLoginAttempts(ctx, input.Identifier, input.Limit)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	response := make([]LoginAttemptResponse, len(attempts))
+	for i, a := range attempts {
+		response[i] = LoginAttemptResponse{
+			Identifier: a.Identifier,
+			IPAddress:  a.IPAddress,
+			UserID:     uuidPtrToString(a.UserID),
+			Succeeded:  a.Succeeded,
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+
+	return &ListLoginAttemptsOutput{
+		Body: ok("Login attempts retrieved successfully", response),
+	}, nil
+}
+
+// toAuditLogResponse converts a domain audit entry to an API response.
+func toAuditLogResponse(l *domain.AdminAuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:           l.ID.String(),
+		ActorID:      uuidPtrToString(l.ActorID),
+		Action:       string(l.Action),
+		TargetUserID: uuidPtrToString(l.TargetUserID),
+		Details:      l.Details,
+		IPAddress:    l.IPAddress,
+		CreatedAt:    l.CreatedAt,
+	}
+}
+
+// uuidPtrToString formats an optional UUID.
+func uuidPtrToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func (h *AdminHandler) setOrganizationQuotas(ctx context.Context, input *SetOrganizationQuotasInput) (*OrganizationOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := h.adminService.SetOrganizationQuotas(ctx, actor, input.ID, domain.OrganizationQuotas{
+		MaxProjects:     input.Body.MaxProjects,
+		MaxMembers:      input.Body.MaxMembers,
+		MaxApplications: input.Body.MaxApplications,
+	})
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &OrganizationOutput{
+		Body: ok("Organization quotas updated successfully", toOrganizationResponse(org)),
+	}, nil
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler handles application API key HTTP requests.
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler.
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// Register registers API key routes with the API.
+func (h *APIKeyHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-api-key",
+		Method:      http.MethodPost,
+		Path:        "/applications/{app_id}/api-keys",
+		Summary:     "Create API Key",
+		Description: "Issue an API key for the client SDK embedded in an application to check for updates. The key is returned once and cannot be retrieved again.",
+		Tags:        []string{"API Keys"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.createAPIKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-api-keys",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/api-keys",
+		Summary:     "List API Keys",
+		Description: "List the API keys of an application, revoked ones included, newest first.",
+		Tags:        []string{"API Keys"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listAPIKeys)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-api-key",
+		Method:      http.MethodDelete,
+		Path:        "/applications/{app_id}/api-keys/{id}",
+		Summary:     "Revoke API Key",
+		Description: "Revoke an API key. Update checks using it are refused from then on.",
+		Tags:        []string{"API Keys"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.revokeAPIKey)
+}
+
+// ========== Request/Response Types ==========
+
+// APIKeyResponse represents an API key in API responses, without its raw value.
+type APIKeyResponse struct {
+	ID            uuid.UUID  `json:"id" doc:"API key unique ID"`
+	ApplicationID uuid.UUID  `json:"application_id" doc:"Application the key is scoped to"`
+	Name          string     `json:"name" doc:"Name telling keys apart"`
+	Prefix        string     `json:"prefix" doc:"Start of the key, to recognize it"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty" doc:"User who created the key"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty" doc:"Last use of the key, to the minute"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty" doc:"Set on revoked keys"`
+	CreatedAt     time.Time  `json:"created_at" doc:"Creation timestamp"`
+}
+
+// CreatedAPIKeyResponse is a new API key along with its raw value.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" doc:"The API key, shown only once. Clients send it in the X-Api-Key header"`
+}
+
+// CreateAPIKeyInput is the request for creating an API key.
+type CreateAPIKeyInput struct {
+	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+	Body  struct {
+		Name string `json:"name" required:"true" minLength:"1" maxLength:"100" doc:"Name telling keys apart (e.g. Android SDK)"`
+	}
+}
+
+// CreateAPIKeyOutput is the response for creating an API key.
+type CreateAPIKeyOutput struct {
+	Body ApiResponse[CreatedAPIKeyResponse]
+}
+
+// ListAPIKeysInput is the request for listing API keys.
+type ListAPIKeysInput struct {
+	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+}
+
+// ListAPIKeysOutput is the response for listing API keys.
+type ListAPIKeysOutput struct {
+	Body ApiResponse[[]APIKeyResponse]
+}
+
+// RevokeAPIKeyInput is the request for revoking an API key.
+type RevokeAPIKeyInput struct {
+	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+	ID    uuid.UUID `path:"id" doc:"API key ID"`
+}
+
+// RevokeAPIKeyOutput is the response for revoking an API key.
+type RevokeAPIKeyOutput struct {
+	Body ApiResponse[APIKeyResponse]
+}
+
+// ========== Handlers ==========
+
+func (h *APIKeyHandler) createAPIKey(ctx context.Context, input *CreateAPIKeyInput) (*CreateAPIKeyOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	key, err := h.apiKeyService.Create(ctx, authUser.ID, input.AppID, input.Body.Name)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &CreateAPIKeyOutput{
+		Body: created("API key created successfully", CreatedAPIKeyResponse{
+			APIKeyResponse: toAPIKeyResponse(key.Key),
+			Key:            key.Raw,
+		}),
+	}, nil
+}
+
+func (h *APIKeyHandler) listAPIKeys(ctx context.Context, input *ListAPIKeysInput) (*ListAPIKeysOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	keys, err := h.apiKeyService.List(ctx, authUser.ID, input.AppID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	res := make([]APIKeyResponse, len(keys))
+	for i, k := range keys {
+		res[i] = toAPIKeyResponse(k)
+	}
+
+	return &ListAPIKeysOutput{
+		Body: ok("API keys retrieved successfully", res),
+	}, nil
+}
+
+func (h *APIKeyHandler) revokeAPIKey(ctx context.Context, input *RevokeAPIKeyInput) (*RevokeAPIKeyOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	key, err := h.apiKeyService.Revoke(ctx, authUser.ID, input.AppID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RevokeAPIKeyOutput{
+		Body: ok("API key revoked successfully", toAPIKeyResponse(key)),
+	}, nil
+}
+
+// ========== Helpers ==========
+
+func toAPIKeyResponse(k *domain.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:            k.ID,
+		ApplicationID: k.ApplicationID,
+		Name:          k.Name,
+		Prefix:        k.Prefix,
+		CreatedBy:     k.CreatedBy,
+		LastUsedAt:    k.LastUsedAt,
+		RevokedAt:     k.RevokedAt,
+		CreatedAt:     k.CreatedAt,
+	}
+}
@@ -90,13 +90,14 @@ func (h *ApplicationHandler) Register(api huma.API) {
 
 // ApplicationResponse represents an application in API responses.
 type ApplicationResponse struct {
-	ID          uuid.UUID `json:"id" doc:"Application unique ID"`
-	Title       string    `json:"title" doc:"Application title"`
-	PackageName string    `json:"package_name" doc:"Unique package name (e.g. com.example.app)"`
-	Description string    `json:"description" doc:"Application description"`
-	ProjectID   uuid.UUID `json:"project_id" doc:"ID of the parent project"`
-	CreatedAt   time.Time `json:"created_at" doc:"Creation timestamp"`
-	UpdatedAt   time.Time `json:"updated_at" doc:"Last update timestamp"`
+	ID          uuid.UUID  `json:"id" doc:"Application unique ID"`
+	Title       string     `json:"title" doc:"Application title"`
+	PackageName string     `json:"package_name" doc:"Unique package name (e.g. com.example.app)"`
+	Description string     `json:"description" doc:"Application description"`
+	ProjectID   uuid.UUID  `json:"project_id" doc:"ID of the parent project"`
+	CreatedAt   time.Time  `json:"created_at" doc:"Creation timestamp"`
+	UpdatedAt   time.Time  `json:"updated_at" doc:"Last update timestamp"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" doc:"Deletion timestamp, set on applications in the trash"`
 }
 
 // CreateApplicationInput is the request for creating an application.
@@ -151,6 +152,9 @@ type GetApplicationOutput struct {
 // ListApplicationsInput is the request for listing applications.
 type ListApplicationsInput struct {
 	ProjectID uuid.UUID `path:"project_id" doc:"Project ID"`
+	PageQuery
+	Sort   string `query:"sort" enum:"-created_at,created_at" default:"-created_at" doc:"Sort order, newest first by default"`
+	Search string `query:"q" maxLength:"100" doc:"Only return applications whose title or package name contains this text"`
 }
 
 // ListApplicationsOutput is the response for listing applications.
@@ -164,7 +168,8 @@ type CreateApplicationFromBinaryInput struct {
 		ProjectID   uuid.UUID                 `json:"project_id" required:"true" doc:"Project ID"`
 		Title       string                    `json:"title" required:"true" minLength:"3" maxLength:"100" doc:"Application title"`
 		ArtifactURL string                    `json:"artifact_url" required:"true" doc:"URL of the artifact in storage"`
-		Environment domain.ReleaseEnvironment `json:"environment" required:"true" doc:"Environment (e.g. production, development, testing)"`
+		Environment domain.ReleaseEnvironment `json:"environment,omitempty" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Channel of the initial release, one of development, staging, production or the project's default environment; the latter when omitted"`
+		ReleaseNote string                    `json:"release_note,omitempty" maxLength:"2000" doc:"Notes of the initial release, in Markdown"`
 	}
 }
 
@@ -248,18 +253,23 @@ func (h *ApplicationHandler) getApplication(ctx context.Context, input *GetAppli
 }
 
 func (h *ApplicationHandler) listApplications(ctx context.Context, input *ListApplicationsInput) (*ListApplicationsOutput, error) {
-	apps, err := h.appService.ListByProject(ctx, input.ProjectID)
+	page, err := pageRequest(input.PageQuery, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := h.appService.ListByProject(ctx, input.ProjectID, domain.ApplicationFilter{Search: input.Search}, page)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
-	responses := make([]ApplicationResponse, len(apps))
-	for i, app := range apps {
+	responses := make([]ApplicationResponse, len(apps.Items))
+	for i, app := range apps.Items {
 		responses[i] = toApplicationResponse(app)
 	}
 
 	return &ListApplicationsOutput{
-		Body: ok("Applications retrieved successfully", responses),
+		Body: okPage("Applications retrieved successfully", responses, apps.NextCursor),
 	}, nil
 }
 
@@ -274,6 +284,7 @@ func (h *ApplicationHandler) createApplicationFromBinary(ctx context.Context, in
 		Title:       input.Body.Title,
 		ArtifactURL: input.Body.ArtifactURL,
 		Environment: input.Body.Environment,
+		ReleaseNote: input.Body.ReleaseNote,
 	})
 	if err != nil {
 		return nil, mapDomainError(err)
@@ -295,5 +306,6 @@ func toApplicationResponse(app *domain.Application) ApplicationResponse {
 		ProjectID:   app.ProjectID,
 		CreatedAt:   app.CreatedAt,
 		UpdatedAt:   app.UpdatedAt,
+		DeletedAt:   app.DeletedAt,
 	}
 }
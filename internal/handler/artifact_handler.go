@@ -38,7 +38,7 @@ func (h *ArtifactHandler) Register(api huma.API) {
 		Method:      http.MethodPost,
 		Path:        "/artifacts",
 		Summary:     "Create Artifact",
-		Description: "Record a new artifact in the database after it has been uploaded to storage.",
+		Description: "Record a new artifact in the database after it has been uploaded to storage. Releases promoted from or into another cannot get new artifacts.",
 		Tags:        []string{"Artifacts"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.createArtifact)
@@ -52,6 +52,16 @@ func (h *ArtifactHandler) Register(api huma.API) {
 		Tags:        []string{"Artifacts"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.listByRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-artifact",
+		Method:      http.MethodPost,
+		Path:        "/artifacts/{id}/verify",
+		Summary:     "Verify Artifact",
+		Description: "Hash the stored file of an artifact and mark the artifact verified if it matches the recorded SHA256 and size. A release needs a verified artifact to be published.",
+		Tags:        []string{"Artifacts"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.verifyArtifact)
 }
 
 // ========== Request/Response Types ==========
@@ -84,12 +94,23 @@ type CreateArtifactOutput struct {
 
 type ListArtifactsInput struct {
 	ReleaseID uuid.UUID `path:"release_id" doc:"Release ID"`
+	PageQuery
+	Sort string `query:"sort" enum:"-created_at,created_at" default:"-created_at" doc:"Sort order, newest first by default"`
+	ABI  string `query:"abi" maxLength:"256" doc:"Only return artifacts built for this ABI (e.g. arm64-v8a)"`
 }
 
 type ListArtifactsOutput struct {
 	Body ApiResponse[[]domain.Artifact]
 }
 
+type VerifyArtifactInput struct {
+	ID uuid.UUID `path:"id" doc:"Artifact ID"`
+}
+
+type VerifyArtifactOutput struct {
+	Body ApiResponse[domain.Artifact]
+}
+
 // ========== Handlers ==========
 
 func (h *ArtifactHandler) getUploadURL(ctx context.Context, input *GetUploadURLInput) (*GetUploadURLOutput, error) {
@@ -137,18 +158,39 @@ func (h *ArtifactHandler) listByRelease(ctx context.Context, input *ListArtifact
 		return nil, mapDomainError(domain.ErrUnauthorized)
 	}
 
-	artifacts, err := h.artifactService.ListByRelease(ctx, authUser.ID, input.ReleaseID)
+	page, err := pageRequest(input.PageQuery, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := h.artifactService.ListByRelease(ctx, authUser.ID, input.ReleaseID, domain.ArtifactFilter{ABI: input.ABI}, page)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
 	// Convert pointer slice to value slice for response
-	result := make([]domain.Artifact, len(artifacts))
-	for i, a := range artifacts {
+	result := make([]domain.Artifact, len(artifacts.Items))
+	for i, a := range artifacts.Items {
 		result[i] = *a
 	}
 
 	return &ListArtifactsOutput{
-		Body: ok("Artifacts retrieved successfully", result),
+		Body: okPage("Artifacts retrieved successfully", result, artifacts.NextCursor),
+	}, nil
+}
+
+func (h *ArtifactHandler) verifyArtifact(ctx context.Context, input *VerifyArtifactInput) (*VerifyArtifactOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	artifact, err := h.artifactService.Verify(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &VerifyArtifactOutput{
+		Body: ok("Artifact verified successfully", *artifact),
 	}, nil
 }
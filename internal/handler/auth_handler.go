@@ -7,6 +7,7 @@ import (
 
 	"github.com/bsrodrigue/appshare-backend/internal/auth"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/handler/middleware"
 	"github.com/bsrodrigue/appshare-backend/internal/service"
 	"github.com/danielgtaylor/huma/v2"
 )
@@ -14,11 +15,17 @@ import (
 // AuthHandler handles authentication-related HTTP requests.
 type AuthHandler struct {
 	authService *service.AuthService
+	userService *service.UserService
+	mfaService  *service.MFAService
 }
 
 // NewAuthHandler creates a new AuthHandler.
-func NewAuthHandler(authService *service.AuthService) *AuthHandler {
-	return &AuthHandler{authService: authService}
+func NewAuthHandler(authService *service.AuthService, userService *service.UserService, mfaService *service.MFAService) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+		userService: userService,
+		mfaService:  mfaService,
+	}
 }
 
 // Register registers all auth routes with the API.
@@ -29,7 +36,7 @@ func (h *AuthHandler) Register(api huma.API) {
 		Method:      http.MethodPost,
 		Path:        "/auth/login",
 		Summary:     "Login",
-		Description: "Authenticate with email/username and password. Returns access and refresh tokens.",
+		Description: "Authenticate with email/username and password. Returns access and refresh tokens, or an MFA challenge to complete at /auth/mfa/verify when two-factor authentication is enabled. Repeated failures temporarily lock the account or client IP (429 ACCOUNT_LOCKED with Retry-After).",
 		Tags:        []string{"Auth"},
 	}, h.login)
 
@@ -50,6 +57,42 @@ func (h *AuthHandler) Register(api huma.API) {
 		Description: "Exchange a valid refresh token for new access and refresh tokens.",
 		Tags:        []string{"Auth"},
 	}, h.refreshToken)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "forgot-password",
+		Method:      http.MethodPost,
+		Path:        "/auth/forgot-password",
+		Summary:     "Forgot Password",
+		Description: "Email a single-use password reset link. Always succeeds, whether or not the address is registered.",
+		Tags:        []string{"Auth"},
+	}, h.forgotPassword)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reset-password",
+		Method:      http.MethodPost,
+		Path:        "/auth/reset-password",
+		Summary:     "Reset Password",
+		Description: "Set a new password using a reset token. All existing sessions are revoked.",
+		Tags:        []string{"Auth"},
+	}, h.resetPassword)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "confirm-email-change",
+		Method:      http.MethodPost,
+		Path:        "/auth/confirm-email",
+		Summary:     "Confirm Email Change",
+		Description: "Apply a pending email change using the token sent to the new address.",
+		Tags:        []string{"Auth"},
+	}, h.confirmEmailChange)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-mfa",
+		Method:      http.MethodPost,
+		Path:        "/auth/mfa/verify",
+		Summary:     "Verify MFA",
+		Description: "Complete a login by exchanging an MFA challenge and a TOTP or recovery code for access and refresh tokens.",
+		Tags:        []string{"Auth"},
+	}, h.verifyMFA)
 }
 
 func (h *AuthHandler) RegisterProtected(api huma.API) {
@@ -63,6 +106,46 @@ func (h *AuthHandler) RegisterProtected(api huma.API) {
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.getCurrentUser)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "update-current-user-profile",
+		Method:      http.MethodPatch,
+		Path:        "/auth/me/profile",
+		Summary:     "Update Profile",
+		Description: "Update the current user's first and last name.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.updateProfile)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-current-user-username",
+		Method:      http.MethodPatch,
+		Path:        "/auth/me/username",
+		Summary:     "Update Username",
+		Description: "Change the current user's username. The new username must not be taken.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.updateUsername)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-current-user-phone-number",
+		Method:      http.MethodPatch,
+		Path:        "/auth/me/phone-number",
+		Summary:     "Update Phone Number",
+		Description: "Change the current user's phone number. The new number must not be registered to another account.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.updatePhoneNumber)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "request-email-change",
+		Method:      http.MethodPatch,
+		Path:        "/auth/me/email",
+		Summary:     "Change Email",
+		Description: "Request an email change. A confirmation link is sent to the new address, which only replaces the current one once confirmed.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.requestEmailChange)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "change-password",
 		Method:      http.MethodPost,
@@ -72,6 +155,46 @@ func (h *AuthHandler) RegisterProtected(api huma.API) {
 		Tags:        []string{"Auth"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.changePassword)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-mfa-status",
+		Method:      http.MethodGet,
+		Path:        "/auth/mfa",
+		Summary:     "Get MFA Status",
+		Description: "Get the two-factor authentication state of the current user.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.getMFAStatus)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "enroll-mfa",
+		Method:      http.MethodPost,
+		Path:        "/auth/mfa/enroll",
+		Summary:     "Enroll MFA",
+		Description: "Start TOTP enrollment. Returns the secret, an otpauth URI and single-use recovery codes, shown only once. Enrollment must be confirmed with a code.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.enrollMFA)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "confirm-mfa",
+		Method:      http.MethodPost,
+		Path:        "/auth/mfa/confirm",
+		Summary:     "Confirm MFA",
+		Description: "Activate two-factor authentication with a code from the authenticator app.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.confirmMFA)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "disable-mfa",
+		Method:      http.MethodPost,
+		Path:        "/auth/mfa/disable",
+		Summary:     "Disable MFA",
+		Description: "Disable two-factor authentication. Requires the password and a TOTP or recovery code.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.disableMFA)
 }
 
 // ========== Request/Response Types ==========
@@ -93,10 +216,19 @@ type LoginInput struct {
 	}
 }
 
+// MFAChallengeResponse represents a pending second authentication step.
+type MFAChallengeResponse struct {
+	Token     string    `json:"token" doc:"Challenge token to send to /auth/mfa/verify"`
+	ExpiresAt time.Time `json:"expires_at" doc:"Challenge expiration time"`
+}
+
 // LoginResponse represents the login response data.
+// Either user and tokens, or mfa_challenge is set.
 type LoginResponse struct {
-	User   UserResponse  `json:"user"`
-	Tokens TokenResponse `json:"tokens"`
+	MFARequired  bool                  `json:"mfa_required" doc:"Whether a second factor is needed to complete the login"`
+	User         *UserResponse         `json:"user,omitempty"`
+	Tokens       *TokenResponse        `json:"tokens,omitempty"`
+	MFAChallenge *MFAChallengeResponse `json:"mfa_challenge,omitempty"`
 }
 
 // LoginOutput is the response for login.
@@ -110,7 +242,7 @@ type RegisterInput struct {
 		Email       string `json:"email" required:"true" doc:"Email address"`
 		Username    string `json:"username" required:"true" minLength:"3" maxLength:"30" doc:"Username"`
 		PhoneNumber string `json:"phone_number" required:"true" doc:"Phone number with country code"`
-		Password    string `json:"password" required:"true" minLength:"8" doc:"Password (min 8 characters)"`
+		Password    string `json:"password" required:"true" doc:"Password (must satisfy the password policy)"`
 		FirstName   string `json:"first_name" required:"true" doc:"First name"`
 		LastName    string `json:"last_name" required:"true" doc:"Last name"`
 	}
@@ -153,7 +285,7 @@ type GetCurrentUserOutput struct {
 type ChangePasswordInput struct {
 	Body struct {
 		CurrentPassword string `json:"current_password" required:"true" doc:"Current password"`
-		NewPassword     string `json:"new_password" required:"true" minLength:"8" doc:"New password (min 8 characters)"`
+		NewPassword     string `json:"new_password" required:"true" doc:"New password (must satisfy the password policy)"`
 	}
 }
 
@@ -162,22 +294,165 @@ type ChangePasswordOutput struct {
 	Body ApiResponse[emptyData]
 }
 
+// ForgotPasswordInput is the request for a password reset email.
+type ForgotPasswordInput struct {
+	Body struct {
+		Email string `json:"email" required:"true" format:"email" doc:"Email address of the account"`
+	}
+}
+
+// ForgotPasswordOutput is the response for a password reset email.
+type ForgotPasswordOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// ResetPasswordInput is the request for resetting a password.
+type ResetPasswordInput struct {
+	Body struct {
+		Token       string `json:"token" required:"true" doc:"Reset token received by email"`
+		NewPassword string `json:"new_password" required:"true" doc:"New password (must satisfy the password policy)"`
+	}
+}
+
+// ResetPasswordOutput is the response for resetting a password.
+type ResetPasswordOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// ConfirmEmailChangeInput is the request for confirming an email change.
+type ConfirmEmailChangeInput struct {
+	Body struct {
+		Token string `json:"token" required:"true" doc:"Confirmation token received at the new address"`
+	}
+}
+
+// ConfirmEmailChangeOutput is the response for confirming an email change.
+type ConfirmEmailChangeOutput struct {
+	Body ApiResponse[UserResponse]
+}
+
+// UpdateCurrentUserProfileInput is the request for updating the current user's profile.
+type UpdateCurrentUserProfileInput struct {
+	Body struct {
+		FirstName string `json:"first_name" required:"true" doc:"New first name"`
+		LastName  string `json:"last_name" required:"true" doc:"New last name"`
+	}
+}
+
+// UpdateUsernameInput is the request for changing the current user's username.
+type UpdateUsernameInput struct {
+	Body struct {
+		Username string `json:"username" required:"true" minLength:"3" maxLength:"30" doc:"New username"`
+	}
+}
+
+// UpdatePhoneNumberInput is the request for changing the current user's phone number.
+type UpdatePhoneNumberInput struct {
+	Body struct {
+		PhoneNumber string `json:"phone_number" required:"true" minLength:"1" doc:"New phone number with country code"`
+	}
+}
+
+// UpdateCurrentUserOutput is the response for the current user's profile updates.
+type UpdateCurrentUserOutput struct {
+	Body ApiResponse[UserResponse]
+}
+
+// RequestEmailChangeInput is the request for changing the current user's email.
+type RequestEmailChangeInput struct {
+	Body struct {
+		NewEmail        string `json:"new_email" required:"true" format:"email" doc:"New email address"`
+		CurrentPassword string `json:"current_password" required:"true" doc:"Current password"`
+	}
+}
+
+// RequestEmailChangeOutput is the response for requesting an email change.
+type RequestEmailChangeOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// VerifyMFAInput is the request for completing a login with a second factor.
+type VerifyMFAInput struct {
+	Body struct {
+		MFAToken string `json:"mfa_token" required:"true" doc:"Challenge token returned by /auth/login"`
+		Code     string `json:"code" required:"true" doc:"6-digit TOTP code or a recovery code"`
+	}
+}
+
+// MFAStatusResponse represents the two-factor state of a user.
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+// GetMFAStatusOutput is the response for getting the two-factor state.
+type GetMFAStatusOutput struct {
+	Body ApiResponse[MFAStatusResponse]
+}
+
+// MFAEnrollmentResponse represents a started TOTP enrollment.
+type MFAEnrollmentResponse struct {
+	Secret        string   `json:"secret" doc:"Base32 TOTP secret for manual entry"`
+	URI           string   `json:"otpauth_uri" doc:"otpauth:// URI to render as a QR code"`
+	RecoveryCodes []string `json:"recovery_codes" doc:"Single-use recovery codes, shown only once"`
+}
+
+// EnrollMFAOutput is the response for starting TOTP enrollment.
+type EnrollMFAOutput struct {
+	Body ApiResponse[MFAEnrollmentResponse]
+}
+
+// ConfirmMFAInput is the request for activating two-factor authentication.
+type ConfirmMFAInput struct {
+	Body struct {
+		Code string `json:"code" required:"true" doc:"6-digit TOTP code"`
+	}
+}
+
+// ConfirmMFAOutput is the response for activating two-factor authentication.
+type ConfirmMFAOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// DisableMFAInput is the request for disabling two-factor authentication.
+type DisableMFAInput struct {
+	Body struct {
+		Password string `json:"password" required:"true" doc:"Current password"`
+		Code     string `json:"code" required:"true" doc:"6-digit TOTP code or a recovery code"`
+	}
+}
+
+// DisableMFAOutput is the response for disabling two-factor authentication.
+type DisableMFAOutput struct {
+	Body ApiResponse[emptyData]
+}
+
 // ========== Handlers ==========
 
 func (h *AuthHandler) login(ctx context.Context, input *LoginInput) (*LoginOutput, error) {
 	result, err := h.authService.Login(ctx, service.LoginInput{
-		Email:    input.Body.Email,
-		Password: input.Body.Password,
+		Email:     input.Body.Email,
+		Password:  input.Body.Password,
+		IPAddress: middleware.ClientIP(ctx),
 	})
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
 	return &LoginOutput{
-		Body: ok("Login successful", LoginResponse{
-			User:   toUserResponse(result.User),
-			Tokens: toTokenResponse(result.Tokens),
-		}),
+		Body: ok("Login successful", toLoginResponse(result)),
+	}, nil
+}
+
+func (h *AuthHandler) verifyMFA(ctx context.Context, input *VerifyMFAInput) (*LoginOutput, error) {
+	result, err := h.authService.VerifyMFA(ctx, input.Body.MFAToken, input.Body.Code, middleware.ClientIP(ctx))
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &LoginOutput{
+		Body: ok("Login successful", toLoginResponse(result)),
 	}, nil
 }
 
@@ -215,6 +490,26 @@ func (h *AuthHandler) refreshToken(ctx context.Context, input *RefreshTokenInput
 	}, nil
 }
 
+func (h *AuthHandler) forgotPassword(ctx context.Context, input *ForgotPasswordInput) (*ForgotPasswordOutput, error) {
+	if err := h.authService.ForgotPassword(ctx, input.Body.Email); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ForgotPasswordOutput{
+		Body: ok("If the address is registered, a reset link has been sent", emptyData{}),
+	}, nil
+}
+
+func (h *AuthHandler) resetPassword(ctx context.Context, input *ResetPasswordInput) (*ResetPasswordOutput, error) {
+	if err := h.authService.ResetPassword(ctx, input.Body.Token, input.Body.NewPassword); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ResetPasswordOutput{
+		Body: ok("Password reset successfully", emptyData{}),
+	}, nil
+}
+
 func (h *AuthHandler) getCurrentUser(ctx context.Context, input *struct{}) (*GetCurrentUserOutput, error) {
 	authUser := auth.UserFromContext(ctx)
 	if authUser == nil {
@@ -247,8 +542,187 @@ func (h *AuthHandler) changePassword(ctx context.Context, input *ChangePasswordI
 	}, nil
 }
 
+func (h *AuthHandler) updateProfile(ctx context.Context, input *UpdateCurrentUserProfileInput) (*UpdateCurrentUserOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	user, err := h.userService.UpdateProfile(ctx, authUser.ID, input.Body.FirstName, input.Body.LastName)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &UpdateCurrentUserOutput{
+		Body: ok("Profile updated successfully", toUserResponse(user)),
+	}, nil
+}
+
+func (h *AuthHandler) updateUsername(ctx context.Context, input *UpdateUsernameInput) (*UpdateCurrentUserOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	user, err := h.userService.UpdateUsername(ctx, authUser.ID, input.Body.Username)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &UpdateCurrentUserOutput{
+		Body: ok("Username updated successfully", toUserResponse(user)),
+	}, nil
+}
+
+func (h *AuthHandler) updatePhoneNumber(ctx context.Context, input *UpdatePhoneNumberInput) (*UpdateCurrentUserOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	user, err := h.userService.UpdatePhoneNumber(ctx, authUser.ID, input.Body.PhoneNumber)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &UpdateCurrentUserOutput{
+		Body: ok("Phone number updated successfully", toUserResponse(user)),
+	}, nil
+}
+
+func (h *AuthHandler) requestEmailChange(ctx context.Context, input *RequestEmailChangeInput) (*RequestEmailChangeOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+	// Support sessions must not be able to take over the account
+	if authUser.IsImpersonated() {
+		return nil, mapDomainError(domain.NewAppError(domain.CodeForbidden, "email cannot be changed while impersonating"))
+	}
+
+	err := h.authService.RequestEmailChange(ctx, authUser.ID, input.Body.CurrentPassword, input.Body.NewEmail)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RequestEmailChangeOutput{
+		Body: ok("Confirmation email sent to the new address", emptyData{}),
+	}, nil
+}
+
+func (h *AuthHandler) confirmEmailChange(ctx context.Context, input *ConfirmEmailChangeInput) (*ConfirmEmailChangeOutput, error) {
+	user, err := h.authService.ConfirmEmailChange(ctx, input.Body.Token)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ConfirmEmailChangeOutput{
+		Body: ok("Email changed successfully", toUserResponse(user)),
+	}, nil
+}
+
+func (h *AuthHandler) getMFAStatus(ctx context.Context, input *struct{}) (*GetMFAStatusOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	status, err := h.mfaService.GetStatus(ctx, authUser.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &GetMFAStatusOutput{
+		Body: ok("Two-factor status retrieved successfully", MFAStatusResponse{
+			Enabled:                status.Enabled,
+			EnabledAt:              status.EnabledAt,
+			RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+		}),
+	}, nil
+}
+
+func (h *AuthHandler) enrollMFA(ctx context.Context, input *struct{}) (*EnrollMFAOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+	// Support sessions must not be able to change the account's second factor
+	if authUser.IsImpersonated() {
+		return nil, mapDomainError(domain.NewAppError(domain.CodeForbidden, "two-factor authentication cannot be enrolled while impersonating"))
+	}
+
+	enrollment, err := h.mfaService.Enroll(ctx, authUser.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &EnrollMFAOutput{
+		Body: ok("Two-factor enrollment started", MFAEnrollmentResponse{
+			Secret:        enrollment.Secret,
+			URI:           enrollment.URI,
+			RecoveryCodes: enrollment.RecoveryCodes,
+		}),
+	}, nil
+}
+
+func (h *AuthHandler) confirmMFA(ctx context.Context, input *ConfirmMFAInput) (*ConfirmMFAOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+	if authUser.IsImpersonated() {
+		return nil, mapDomainError(domain.NewAppError(domain.CodeForbidden, "two-factor authentication cannot be enrolled while impersonating"))
+	}
+
+	if err := h.mfaService.Confirm(ctx, authUser.ID, input.Body.Code); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ConfirmMFAOutput{
+		Body: ok("Two-factor authentication enabled", emptyData{}),
+	}, nil
+}
+
+func (h *AuthHandler) disableMFA(ctx context.Context, input *DisableMFAInput) (*DisableMFAOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+	// Support sessions must not be able to change the account's second factor
+	if authUser.IsImpersonated() {
+		return nil, mapDomainError(domain.NewAppError(domain.CodeForbidden, "two-factor authentication cannot be disabled while impersonating"))
+	}
+
+	if err := h.mfaService.Disable(ctx, authUser.ID, input.Body.Password, input.Body.Code); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &DisableMFAOutput{
+		Body: ok("Two-factor authentication disabled", emptyData{}),
+	}, nil
+}
+
 // ========== Helper Functions ==========
 
+func toLoginResponse(result *service.LoginResult) LoginResponse {
+	if result.MFAChallenge != nil {
+		return LoginResponse{
+			MFARequired: true,
+			MFAChallenge: &MFAChallengeResponse{
+				Token:     result.MFAChallenge.Token,
+				ExpiresAt: result.MFAChallenge.ExpiresAt,
+			},
+		}
+	}
+
+	user := toUserResponse(result.User)
+	tokens := toTokenResponse(result.Tokens)
+	return LoginResponse{
+		User:   &user,
+		Tokens: &tokens,
+	}
+}
+
 func toTokenResponse(tokens *auth.TokenPair) TokenResponse {
 	return TokenResponse{
 		AccessToken:           tokens.AccessToken,
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// AvatarHandler handles profile picture HTTP requests.
+type AvatarHandler struct {
+	avatarService *service.AvatarService
+}
+
+// NewAvatarHandler creates a new AvatarHandler.
+func NewAvatarHandler(avatarService *service.AvatarService) *AvatarHandler {
+	return &AvatarHandler{avatarService: avatarService}
+}
+
+// Register registers avatar routes with the API.
+func (h *AvatarHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-avatar-upload-url",
+		Method:      http.MethodPost,
+		Path:        "/auth/me/avatar/upload-url",
+		Summary:     "Get Avatar Upload URL",
+		Description: "Generate a signed URL for uploading a new profile picture (PNG, JPEG or GIF, at most 5 MB). Call Publish Avatar once the upload has completed.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.getUploadURL)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-avatar",
+		Method:      http.MethodPost,
+		Path:        "/auth/me/avatar",
+		Summary:     "Publish Avatar",
+		Description: "Validate the uploaded picture, crop it to a square and publish it in 256, 128 and 64 pixel sizes.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.publishAvatar)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-avatar",
+		Method:      http.MethodDelete,
+		Path:        "/auth/me/avatar",
+		Summary:     "Delete Avatar",
+		Description: "Remove the current profile picture. Responses fall back to an initials placeholder.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.deleteAvatar)
+}
+
+// ========== Request/Response Types ==========
+
+// AvatarUploadURLOutput is the response for requesting an avatar upload URL.
+type AvatarUploadURLOutput struct {
+	Body ApiResponse[domain.UploadURLResponse]
+}
+
+// AvatarOutput is the response for avatar changes.
+type AvatarOutput struct {
+	Body ApiResponse[UserResponse]
+}
+
+// ========== Handlers ==========
+
+func (h *AvatarHandler) getUploadURL(ctx context.Context, input *struct{}) (*AvatarUploadURLOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	res, err := h.avatarService.GetUploadURL(ctx, authUser.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &AvatarUploadURLOutput{
+		Body: ok("Upload URL generated successfully", *res),
+	}, nil
+}
+
+func (h *AvatarHandler) publishAvatar(ctx context.Context, input *struct{}) (*AvatarOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	user, err := h.avatarService.Publish(ctx, authUser.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &AvatarOutput{
+		Body: ok("Avatar updated successfully", toUserResponse(user)),
+	}, nil
+}
+
+func (h *AvatarHandler) deleteAvatar(ctx context.Context, input *struct{}) (*AvatarOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	user, err := h.avatarService.Remove(ctx, authUser.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &AvatarOutput{
+		Body: ok("Avatar removed successfully", toUserResponse(user)),
+	}, nil
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// ChannelHandler handles release channel HTTP requests.
+type ChannelHandler struct {
+	channelService *service.ChannelService
+}
+
+// NewChannelHandler creates a new ChannelHandler.
+func NewChannelHandler(channelService *service.ChannelService) *ChannelHandler {
+	return &ChannelHandler{channelService: channelService}
+}
+
+// Register registers channel routes with the API.
+func (h *ChannelHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-channels",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/channels",
+		Summary:     "List Channels",
+		Description: "List the release channels of an application in display order, unlisted ones included.",
+		Tags:        []string{"Channels"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listChannels)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-channel",
+		Method:      http.MethodPost,
+		Path:        "/applications/{app_id}/channels",
+		Summary:     "Create Channel",
+		Description: "Add a release channel (e.g. beta, internal-qa, customer-acme) to an application. Releases are uploaded and promoted to channels by name.",
+		Tags:        []string{"Channels"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.createChannel)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-channel",
+		Method:      http.MethodPatch,
+		Path:        "/applications/{app_id}/channels/{name}",
+		Summary:     "Update Channel",
+		Description: "Change a channel's position or visibility, make it the default channel of uploads, or set the minimum version code its clients must run.",
+		Tags:        []string{"Channels"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.updateChannel)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-channel",
+		Method:      http.MethodDelete,
+		Path:        "/applications/{app_id}/channels/{name}",
+		Summary:     "Delete Channel",
+		Description: "Remove a channel. The default channel and channels holding releases, trashed ones included, cannot be removed.",
+		Tags:        []string{"Channels"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.deleteChannel)
+}
+
+// ========== Request/Response Types ==========
+
+// ChannelResponse represents a release channel in API responses.
+type ChannelResponse struct {
+	Name       domain.ReleaseEnvironment `json:"name" doc:"Channel name, used as the environment of releases"`
+	Position   int32                     `json:"position" doc:"Display order, lowest first"`
+	Visibility domain.ChannelVisibility  `json:"visibility" enum:"listed,unlisted" doc:"Unlisted channels are left out of release listings that don't ask for them"`
+	IsDefault  bool                      `json:"is_default" doc:"Whether uploads naming no environment go to this channel"`
+	CreatedAt  time.Time                 `json:"created_at" doc:"Creation timestamp"`
+	UpdatedAt  time.Time                 `json:"updated_at" doc:"Last update timestamp"`
+
+	MinSupportedVersionCode int32 `json:"min_supported_version_code" doc:"Clients running a lower version code are told to update; 0 supports every build"`
+}
+
+// ListChannelsInput is the request for listing channels.
+type ListChannelsInput struct {
+	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+}
+
+// ListChannelsOutput is the response for listing channels.
+type ListChannelsOutput struct {
+	Body ApiResponse[[]ChannelResponse]
+}
+
+// CreateChannelInput is the request for creating a channel.
+type CreateChannelInput struct {
+	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+	Body  struct {
+		Name       domain.ReleaseEnvironment `json:"name" required:"true" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Channel name: lowercase letters, digits and dashes"`
+		Position   int32                     `json:"position,omitempty" minimum:"0" maximum:"1000" doc:"Display order, lowest first"`
+		Visibility domain.ChannelVisibility  `json:"visibility,omitempty" enum:"listed,unlisted" default:"listed" doc:"Unlisted channels are left out of release listings that don't ask for them"`
+		IsDefault  bool                      `json:"is_default,omitempty" doc:"Make this the default channel of uploads"`
+	}
+}
+
+// ChannelOutput is the response for a single channel.
+type ChannelOutput struct {
+	Body ApiResponse[ChannelResponse]
+}
+
+// UpdateChannelInput is the request for updating a channel.
+type UpdateChannelInput struct {
+	AppID uuid.UUID                 `path:"app_id" doc:"Application ID"`
+	Name  domain.ReleaseEnvironment `path:"name" doc:"Channel name"`
+	Body  struct {
+		Position   *int32                    `json:"position,omitempty" minimum:"0" maximum:"1000" doc:"Display order, lowest first"`
+		Visibility *domain.ChannelVisibility `json:"visibility,omitempty" enum:"listed,unlisted" doc:"Unlisted channels are left out of release listings that don't ask for them"`
+		IsDefault  *bool                     `json:"is_default,omitempty" doc:"Only true: make this the default channel of uploads"`
+
+		MinSupportedVersionCode *int32 `json:"min_supported_version_code,omitempty" minimum:"0" doc:"Clients running a lower version code are told to update; 0 supports every build. At most the newest release of the channel offered to every client"`
+	}
+}
+
+// DeleteChannelInput is the request for deleting a channel.
+type DeleteChannelInput struct {
+	AppID uuid.UUID                 `path:"app_id" doc:"Application ID"`
+	Name  domain.ReleaseEnvironment `path:"name" doc:"Channel name"`
+}
+
+// DeleteChannelOutput is the response for deleting a channel.
+type DeleteChannelOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// ========== Handlers ==========
+
+func (h *ChannelHandler) listChannels(ctx context.Context, input *ListChannelsInput) (*ListChannelsOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	channels, err := h.channelService.List(ctx, authUser.ID, input.AppID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	res := make([]ChannelResponse, len(channels))
+	for i, c := range channels {
+		res[i] = toChannelResponse(c)
+	}
+
+	return &ListChannelsOutput{
+		Body: ok("Channels retrieved successfully", res),
+	}, nil
+}
+
+func (h *ChannelHandler) createChannel(ctx context.Context, input *CreateChannelInput) (*ChannelOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	channel, err := h.channelService.Create(ctx, authUser.ID, domain.CreateReleaseChannelInput{
+		ApplicationID: input.AppID,
+		Name:          input.Body.Name,
+		Position:      input.Body.Position,
+		Visibility:    input.Body.Visibility,
+		IsDefault:     input.Body.IsDefault,
+	})
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ChannelOutput{
+		Body: created("Channel created successfully", toChannelResponse(channel)),
+	}, nil
+}
+
+func (h *ChannelHandler) updateChannel(ctx context.Context, input *UpdateChannelInput) (*ChannelOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	channel, err := h.channelService.Update(ctx, authUser.ID, input.AppID, input.Name, domain.UpdateReleaseChannelInput{
+		Position:   input.Body.Position,
+		Visibility: input.Body.Visibility,
+		IsDefault:  input.Body.IsDefault,
+
+		MinSupportedVersionCode: input.Body.MinSupportedVersionCode,
+	})
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ChannelOutput{
+		Body: ok("Channel updated successfully", toChannelResponse(channel)),
+	}, nil
+}
+
+func (h *ChannelHandler) deleteChannel(ctx context.Context, input *DeleteChannelInput) (*DeleteChannelOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	if err := h.channelService.Delete(ctx, authUser.ID, input.AppID, input.Name); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &DeleteChannelOutput{
+		Body: ok("Channel deleted successfully", emptyData{}),
+	}, nil
+}
+
+// ========== Helpers ==========
+
+func toChannelResponse(c *domain.ReleaseChannel) ChannelResponse {
+	return ChannelResponse{
+		Name:       c.Name,
+		Position:   c.Position,
+		Visibility: c.Visibility,
+		IsDefault:  c.IsDefault,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+
+		MinSupportedVersionCode: c.MinSupportedVersionCode,
+	}
+}
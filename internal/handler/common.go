@@ -7,24 +7,51 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/handler/middleware"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
 	"github.com/danielgtaylor/huma/v2"
 )
 
 // ApiResponse is the standard response wrapper for all endpoints.
 // Clients can use the 'code' field for programmatic error handling.
 type ApiResponse[T any] struct {
-	Status    int              `json:"status" doc:"HTTP status code"`
-	ErrorCode domain.ErrorCode `json:"code,omitempty" doc:"Machine-readable error code for client-side handling"`
-	Message   string           `json:"message" doc:"Brief description of the response"`
-	Data      T                `json:"data" doc:"The actual response payload"`
+	Status     int              `json:"status" doc:"HTTP status code"`
+	ErrorCode  domain.ErrorCode `json:"code,omitempty" doc:"Machine-readable error code for client-side handling"`
+	Message    string           `json:"message" doc:"Brief description of the response"`
+	Data       T                `json:"data" doc:"The actual response payload"`
+	NextCursor string           `json:"next_cursor,omitempty" doc:"Cursor of the next page, absent on the last page of a list"`
 }
 
+// PageQuery holds the pagination parameters shared by list endpoints.
+type PageQuery struct {
+	Limit  int    `query:"limit" minimum:"1" maximum:"100" default:"20" doc:"Maximum number of items to return"`
+	Cursor string `query:"cursor" doc:"Opaque cursor taken from the next_cursor of the previous page"`
+}
+
+// pageRequest converts pagination parameters to a domain page request.
+// Sort values are a field name, prefixed with '-' for descending order.
+func pageRequest(q PageQuery, sort string) (domain.PageRequest, error) {
+	page, err := domain.NewPageRequest(q.Limit, q.Cursor, !strings.HasPrefix(sort, "-"))
+	if err != nil {
+		return domain.PageRequest{}, mapDomainError(err)
+	}
+	return page, nil
+}
+
+// identityLinkTokenHeader carries the token of an IDENTITY_LINK_REQUIRED error.
+const identityLinkTokenHeader = "X-Identity-Link-Token"
+
 // ErrorDetail provides additional error information for clients.
 // It implements the error interface so it can be passed to huma error functions.
 type ErrorDetail struct {
@@ -61,25 +88,45 @@ func mapDomainError(err error) error {
 		return huma.Error422UnprocessableEntity(valErr.Message, detail)
 	}
 
+	// Lockouts tell clients when to retry
+	var lockErr *domain.LockoutError
+	if errors.As(err, &lockErr) {
+		retryAfter := int(time.Until(lockErr.Until).Seconds()) + 1
+		headers := http.Header{}
+		headers.Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+		return huma.ErrorWithHeaders(huma.Error429TooManyRequests(message, detail), headers)
+	}
+
+	// Identity links hand clients the token to confirm them with
+	var linkErr *domain.LinkRequiredError
+	if errors.As(err, &linkErr) {
+		headers := http.Header{}
+		headers.Set(identityLinkTokenHeader, linkErr.Token)
+		return huma.ErrorWithHeaders(huma.Error409Conflict(message, detail), headers)
+	}
+
 	// Check for specific domain errors and map to HTTP status
 	var appErr *domain.AppError
 	if errors.As(err, &appErr) {
 		switch appErr.Code {
-		case domain.CodeNotFound, domain.CodeProjectNotFound, domain.CodeApplicationNotFound, domain.CodeReleaseNotFound:
+		case domain.CodeNotFound, domain.CodeProjectNotFound, domain.CodeApplicationNotFound, domain.CodeReleaseNotFound, domain.CodeOrganizationNotFound, domain.CodePromotionRequestNotFound, domain.CodeChannelNotFound, domain.CodeAPIKeyNotFound:
 			return huma.Error404NotFound(message, detail)
 
-		case domain.CodeEmailExists, domain.CodeUsernameExists, domain.CodePhoneExists, domain.CodeAlreadyExists, domain.CodePackageNameExists, domain.CodeReleaseExists:
+		case domain.CodeEmailExists, domain.CodeUsernameExists, domain.CodePhoneExists, domain.CodeAlreadyExists, domain.CodePackageNameExists, domain.CodeReleaseExists, domain.CodeMFAAlreadyEnabled, domain.CodeOwnsProjects, domain.CodeLastOwner, domain.CodePromotionBlocked, domain.CodePromotionNotPending, domain.CodePromotionReviewed, domain.CodeChannelInUse, domain.CodeReleaseWithdrawn, domain.CodeInvalidReleaseStatus, domain.CodeInvalidVersionCode, domain.CodeReleasePromoted:
 			return huma.Error409Conflict(message, detail)
 
-		case domain.CodeInvalidCredentials, domain.CodeUnauthorized, domain.CodeTokenExpired, domain.CodeTokenInvalid:
+		case domain.CodeInvalidCredentials, domain.CodeUnauthorized, domain.CodeTokenExpired, domain.CodeTokenInvalid, domain.CodeInvalidMFACode, domain.CodeSSOFailed, domain.CodeInvalidAPIKey:
 			return huma.Error401Unauthorized(message, detail)
 
-		case domain.CodeUserInactive, domain.CodeForbidden, domain.CodeNotProjectOwner, domain.CodeInsufficientRole:
+		case domain.CodeUserInactive, domain.CodeForbidden, domain.CodeNotProjectOwner, domain.CodeInsufficientRole, domain.CodeDomainNotAllowed, domain.CodeQuotaExceeded:
 			return huma.Error403Forbidden(message, detail)
 
-		case domain.CodeInvalidInput, domain.CodeValidation:
+		case domain.CodeInvalidInput, domain.CodeValidation, domain.CodeMFANotEnabled, domain.CodeArtifactMismatch:
 			return huma.Error400BadRequest(message, detail)
 
+		case domain.CodeAccountLocked:
+			return huma.Error429TooManyRequests(message, detail)
+
 		case domain.CodeInternal:
 			return huma.Error500InternalServerError(message, detail)
 		}
@@ -93,6 +140,16 @@ func mapDomainError(err error) error {
 	})
 }
 
+// adminActor returns the authenticated administrator for audit purposes.
+// Impersonation sessions never carry administration rights.
+func adminActor(ctx context.Context) (service.AdminActor, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil || !user.IsAdmin || user.IsImpersonated() {
+		return service.AdminActor{}, mapDomainError(domain.NewAppError(domain.CodeForbidden, "administrator access required"))
+	}
+	return service.AdminActor{ID: user.ID, IPAddress: middleware.ClientIP(ctx)}, nil
+}
+
 // successResponse creates a standard success response.
 func successResponse[T any](status int, message string, data T) ApiResponse[T] {
 	return ApiResponse[T]{
@@ -110,6 +167,13 @@ func ok[T any](message string, data T) ApiResponse[T] {
 	return successResponse(http.StatusOK, message, data)
 }
 
+// okPage creates a 200 OK response for one page of a list.
+func okPage[T any](message string, data T, nextCursor string) ApiResponse[T] {
+	resp := successResponse(http.StatusOK, message, data)
+	resp.NextCursor = nextCursor
+	return resp
+}
+
 // created creates a 201 Created response.
 func created[T any](message string, data T) ApiResponse[T] {
 	return successResponse(http.StatusCreated, message, data)
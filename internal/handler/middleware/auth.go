@@ -2,20 +2,30 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/bsrodrigue/appshare-backend/internal/auth"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
 )
 
+// UserLookup retrieves the current state of a token's user.
+type UserLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
 // AuthMiddleware handles JWT authentication for protected routes.
 type AuthMiddleware struct {
 	jwtService *auth.JWTService
+	users      UserLookup
 }
 
 // NewAuthMiddleware creates a new auth middleware.
-func NewAuthMiddleware(jwtService *auth.JWTService) *AuthMiddleware {
-	return &AuthMiddleware{jwtService: jwtService}
+func NewAuthMiddleware(jwtService *auth.JWTService, users UserLookup) *AuthMiddleware {
+	return &AuthMiddleware{jwtService: jwtService, users: users}
 }
 
 // RequireAuth returns a middleware that requires a valid JWT token.
@@ -35,12 +45,17 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			writeUnauthorized(w, err)
 			return
 		}
+		authUser, err := m.authenticate(r.Context(), claims)
+		if err != nil {
+			if domain.GetErrorCode(err) == domain.CodeInternal {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			writeUnauthorized(w, err)
+			return
+		}
 
 		// Add user to context
-		authUser := &auth.AuthenticatedUser{
-			ID:    claims.UserID,
-			Email: claims.Email,
-		}
 		ctx := auth.ContextWithUser(r.Context(), authUser)
 
 		// Call next handler with updated context
@@ -74,26 +89,61 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r)
 			return
 		}
+		authUser, err := m.authenticate(r.Context(), claims)
+		if err != nil {
+			// Revoked session - continue without user
+			next.ServeHTTP(w, r)
+			return
+		}
 
 		// Valid token - add user to context
-		authUser := &auth.AuthenticatedUser{
-			ID:    claims.UserID,
-			Email: claims.Email,
-		}
 		ctx := auth.ContextWithUser(r.Context(), authUser)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// authenticate checks an access token against its user's current state, so
+// that revoking sessions (e.g. on password reset) or deactivating the user
+// takes effect on access tokens at once rather than when they expire.
+// Administration rights are those the user holds now, never more than the
+// token was issued with.
+func (m *AuthMiddleware) authenticate(ctx context.Context, claims *auth.Claims) (*auth.AuthenticatedUser, error) {
+	user, err := m.users.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTokenInvalid
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+	if claims.TokenVersion != user.TokenVersion {
+		return nil, domain.ErrTokenInvalid
+	}
+
+	return &auth.AuthenticatedUser{
+		ID:             claims.UserID,
+		Email:          claims.Email,
+		IsAdmin:        claims.IsAdmin && user.IsAdmin,
+		ImpersonatorID: claims.ImpersonatorID,
+	}, nil
+}
+
 // writeUnauthorized writes a 401 response with proper JSON format.
 func writeUnauthorized(w http.ResponseWriter, err error) {
+	writeError(w, http.StatusUnauthorized, err)
+}
+
+// writeError writes an error response with proper JSON format.
+func writeError(w http.ResponseWriter, status int, err error) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
+	w.WriteHeader(status)
 
 	code := domain.GetErrorCode(err)
 	message := domain.GetErrorMessage(err)
 
 	// Write JSON error response
-	response := `{"status":401,"code":"` + string(code) + `","message":"` + message + `"}`
+	response := `{"status":` + strconv.Itoa(status) + `,"code":"` + string(code) + `","message":"` + message + `"}`
 	w.Write([]byte(response))
 }
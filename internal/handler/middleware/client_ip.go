@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// clientIPKey is the context key for the client IP address.
+type clientIPKey struct{}
+
+// ClientIP retrieves the client IP address from context.
+func ClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// ClientIPMiddleware resolves the client IP address once per request.
+type ClientIPMiddleware struct {
+	// trustedProxies are the reverse proxies whose X-Forwarded-For and X-Real-IP
+	// headers are honoured. Headers from any other peer are ignored, otherwise
+	// clients could spoof their address.
+	trustedProxies []netip.Prefix
+}
+
+// NewClientIPMiddleware creates a new client IP middleware trusting the
+// proxy headers set by the given addresses or CIDRs.
+func NewClientIPMiddleware(trustedProxies []string) (*ClientIPMiddleware, error) {
+	m := &ClientIPMiddleware{}
+	for _, proxy := range trustedProxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		m.trustedProxies = append(m.trustedProxies, prefix.Masked())
+	}
+	return m, nil
+}
+
+// Handler returns the client IP middleware handler.
+func (m *ClientIPMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPKey{}, m.resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// resolve returns the most trustworthy client address available.
+func (m *ClientIPMiddleware) resolve(r *http.Request) string {
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		peer = host
+	}
+	if !m.trusted(peer) {
+		return peer
+	}
+
+	// Each proxy appends the address it received the request from, so walk
+	// X-Forwarded-For from the right: the first untrusted hop is the client.
+	// Entries left of it were written by the client and may be forged.
+	client := ""
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = addr.Unmap().String()
+		if !m.trusted(client) {
+			return client
+		}
+	}
+	if client != "" {
+		// Every hop is a trusted proxy
+		return client
+	}
+
+	if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return addr.Unmap().String()
+	}
+	return peer
+}
+
+// trusted reports whether an address belongs to a trusted proxy.
+func (m *ClientIPMiddleware) trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range m.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIPMiddleware_Resolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		trusted    []string
+		remoteAddr string
+		forwarded  []string // X-Forwarded-For header lines
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:51234",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed X-Forwarded-For without trusted proxies",
+			remoteAddr: "203.0.113.7:51234",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed X-Forwarded-For from an untrusted peer",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "203.0.113.7:51234",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed X-Real-IP from an untrusted peer",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "203.0.113.7:51234",
+			realIP:     "198.51.100.1",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "client behind a trusted proxy",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:443",
+			forwarded:  []string{"203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "forged entry prepended by the client",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:443",
+			forwarded:  []string{"198.51.100.1, 203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "chain of trusted proxies",
+			trusted:    []string{"10.0.0.0/8", "192.0.2.10"},
+			remoteAddr: "10.0.0.2:443",
+			forwarded:  []string{"198.51.100.1, 203.0.113.7, 192.0.2.10", "10.1.2.3"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "garbage stops the walk",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:443",
+			forwarded:  []string{"203.0.113.7, not-an-ip, 10.1.2.3"},
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "X-Real-IP from a trusted proxy",
+			trusted:    []string{"10.0.0.2"},
+			remoteAddr: "10.0.0.2:443",
+			realIP:     "203.0.113.7",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy without headers",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:443",
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "IPv6",
+			trusted:    []string{"2001:db8::/32"},
+			remoteAddr: "[2001:db8::1]:443",
+			forwarded:  []string{"2001:db8:ffff::9, 2a00:1450::1"},
+			want:       "2a00:1450::1",
+		},
+		{
+			name:       "IPv4-mapped IPv6 proxy",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "[::ffff:10.0.0.2]:443",
+			forwarded:  []string{"203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewClientIPMiddleware(tt.trusted)
+			require.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, line := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", line)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			var got string
+			m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r.Context())
+			})).ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewClientIPMiddleware_InvalidProxy(t *testing.T) {
+	_, err := NewClientIPMiddleware([]string{"10.0.0.0/8", "proxy.internal"})
+	assert.ErrorContains(t, err, `invalid trusted proxy "proxy.internal"`)
+}
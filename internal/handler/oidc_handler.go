@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/handler/middleware"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// OIDCHandler handles single sign-on through OpenID Connect providers.
+type OIDCHandler struct {
+	ssoService *service.SSOService
+}
+
+// NewOIDCHandler creates a new OIDCHandler.
+func NewOIDCHandler(ssoService *service.SSOService) *OIDCHandler {
+	return &OIDCHandler{
+		ssoService: ssoService,
+	}
+}
+
+// Register registers all single sign-on routes with the API.
+// These routes are public: they are how a user obtains tokens.
+func (h *OIDCHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-oidc-providers",
+		Method:      http.MethodGet,
+		Path:        "/auth/oidc/providers",
+		Summary:     "List SSO Providers",
+		Description: "List the identity providers available for single sign-on.",
+		Tags:        []string{"Auth"},
+	}, h.listProviders)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "authorize-oidc",
+		Method:      http.MethodGet,
+		Path:        "/auth/oidc/{provider}/authorize",
+		Summary:     "Start SSO Login",
+		Description: "Get the provider URL to redirect the user to. The provider sends the user back to the configured redirect URL with a code and state.",
+		Tags:        []string{"Auth"},
+	}, h.authorize)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "oidc-callback",
+		Method:      http.MethodPost,
+		Path:        "/auth/oidc/{provider}/callback",
+		Summary:     "Complete SSO Login",
+		Description: "Exchange the code and state returned by the provider for tokens. Accounts with a verified email are linked, and accounts are provisioned for allowed email domains. An existing account whose email was never verified fails with 409 IDENTITY_LINK_REQUIRED and an X-Identity-Link-Token header to pass to the link endpoint.",
+		Tags:        []string{"Auth"},
+	}, h.callback)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "link-oidc-identity",
+		Method:      http.MethodPost,
+		Path:        "/auth/oidc/link",
+		Summary:     "Link SSO Identity",
+		Description: "Confirm the account password, and two-factor code when enabled, to link the identity of a refused SSO login and log in.",
+		Tags:        []string{"Auth"},
+	}, h.link)
+}
+
+// RegisterProtected registers single sign-on routes that require authentication.
+func (h *OIDCHandler) RegisterProtected(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "reauthenticate-oidc",
+		Method:      http.MethodPost,
+		Path:        "/auth/oidc/{provider}/reauthenticate",
+		Summary:     "Reauthenticate With SSO",
+		Description: "Complete a sign-in started with Start SSO Login at a provider linked to the current user, instead of the callback. Returns a short-lived token that confirms sensitive operations, such as deleting the account, in place of the password.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.reauthenticate)
+}
+
+// ============================================================================
+// Request/Response Types
+// ============================================================================
+
+// OIDCProviderResponse represents an identity provider in API responses.
+type OIDCProviderResponse struct {
+	Name        string `json:"name" doc:"Provider identifier used in URLs"`
+	DisplayName string `json:"display_name" doc:"Human-readable provider name"`
+}
+
+// ListOIDCProvidersOutput is the response for listing providers.
+type ListOIDCProvidersOutput struct {
+	Body ApiResponse[[]OIDCProviderResponse]
+}
+
+// AuthorizeOIDCInput is the request for starting a single sign-on.
+type AuthorizeOIDCInput struct {
+	Provider string `path:"provider" doc:"Provider identifier"`
+}
+
+// OIDCAuthorizationResponse represents where to send the user to sign in.
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string    `json:"authorization_url" doc:"Provider URL to redirect the user to"`
+	State            string    `json:"state" doc:"Opaque state the provider echoes back to the callback"`
+	ExpiresAt        time.Time `json:"expires_at" doc:"Deadline to complete the sign-in"`
+}
+
+// AuthorizeOIDCOutput is the response for starting a single sign-on.
+type AuthorizeOIDCOutput struct {
+	Body ApiResponse[OIDCAuthorizationResponse]
+}
+
+// OIDCCallbackInput is the request for completing a single sign-on.
+type OIDCCallbackInput struct {
+	Provider string `path:"provider" doc:"Provider identifier"`
+	Body     struct {
+		Code  string `json:"code" required:"true" doc:"Authorization code returned by the provider"`
+		State string `json:"state" required:"true" doc:"State returned by the provider"`
+	}
+}
+
+// LinkOIDCIdentityInput is the request for linking an identity to an existing account.
+type LinkOIDCIdentityInput struct {
+	Body struct {
+		LinkToken string `json:"link_token" required:"true" doc:"Token from the X-Identity-Link-Token header of the refused callback"`
+		Password  string `json:"password" required:"true" doc:"Password of the existing account"`
+		Code      string `json:"code,omitempty" doc:"Two-factor authentication code, when enabled"`
+	}
+}
+
+// OIDCReauthenticationResponse represents a confirmed fresh sign-in.
+type OIDCReauthenticationResponse struct {
+	ReauthToken string    `json:"reauth_token" doc:"Token to send with the sensitive operation"`
+	ExpiresAt   time.Time `json:"expires_at" doc:"When the token stops being accepted"`
+}
+
+// ReauthenticateOIDCOutput is the response for reauthenticating with a provider.
+type ReauthenticateOIDCOutput struct {
+	Body ApiResponse[OIDCReauthenticationResponse]
+}
+
+// ============================================================================
+// Handlers
+// ============================================================================
+
+func (h *OIDCHandler) listProviders(ctx context.Context, input *struct{}) (*ListOIDCProvidersOutput, error) {
+	providers := h.ssoService.ListProviders()
+
+	response := make([]OIDCProviderResponse, len(providers))
+	for i, p := range providers {
+		response[i] = OIDCProviderResponse{
+			Name:        p.Name,
+			DisplayName: p.DisplayName,
+		}
+	}
+
+	return &ListOIDCProvidersOutput{
+		Body: ok("Providers retrieved", response),
+	}, nil
+}
+
+func (h *OIDCHandler) authorize(ctx context.Context, input *AuthorizeOIDCInput) (*AuthorizeOIDCOutput, error) {
+	authz, err := h.ssoService.Authorize(ctx, input.Provider)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &AuthorizeOIDCOutput{
+		Body: ok("Authorization URL created", OIDCAuthorizationResponse{
+			AuthorizationURL: authz.AuthorizationURL,
+			State:            authz.State,
+			ExpiresAt:        authz.ExpiresAt,
+		}),
+	}, nil
+}
+
+func (h *OIDCHandler) callback(ctx context.Context, input *OIDCCallbackInput) (*LoginOutput, error) {
+	result, err := h.ssoService.Callback(ctx, input.Provider, input.Body.Code, input.Body.State)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &LoginOutput{
+		Body: ok("Login successful", toLoginResponse(result)),
+	}, nil
+}
+
+func (h *OIDCHandler) link(ctx context.Context, input *LinkOIDCIdentityInput) (*LoginOutput, error) {
+	result, err := h.ssoService.Link(ctx, input.Body.LinkToken, input.Body.Password, input.Body.Code, middleware.ClientIP(ctx))
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &LoginOutput{
+		Body: ok("Identity linked", toLoginResponse(result)),
+	}, nil
+}
+
+func (h *OIDCHandler) reauthenticate(ctx context.Context, input *OIDCCallbackInput) (*ReauthenticateOIDCOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	result, err := h.ssoService.Reauthenticate(ctx, authUser.ID, input.Provider, input.Body.Code, input.Body.State)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReauthenticateOIDCOutput{
+		Body: ok("Reauthentication successful", OIDCReauthenticationResponse{
+			ReauthToken: result.Token,
+			ExpiresAt:   result.ExpiresAt,
+		}),
+	}, nil
+}
@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// OrganizationHandler handles organization-related HTTP requests.
+type OrganizationHandler struct {
+	orgService *service.OrganizationService
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler.
+func NewOrganizationHandler(orgService *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{orgService: orgService}
+}
+
+// Register registers all organization routes with the API.
+// All organization routes require authentication.
+func (h *OrganizationHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-my-organizations",
+		Method:      http.MethodGet,
+		Path:        "/organizations",
+		Summary:     "List My Organizations",
+		Description: "Retrieve the organizations the authenticated user belongs to, with their role. Includes their personal organization.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listMyOrganizations)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-organization",
+		Method:      http.MethodPost,
+		Path:        "/organizations",
+		Summary:     "Create Organization",
+		Description: "Create an organization. The authenticated user becomes its owner.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.createOrganization)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-organization",
+		Method:      http.MethodGet,
+		Path:        "/organizations/{id}",
+		Summary:     "Get Organization",
+		Description: "Retrieve an organization with its quotas and current usage. Only members can see it.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.getOrganization)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-organization",
+		Method:      http.MethodPatch,
+		Path:        "/organizations/{id}",
+		Summary:     "Update Organization",
+		Description: "Rename an organization. Only owners and admins can update it.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.updateOrganization)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-organization-members",
+		Method:      http.MethodGet,
+		Path:        "/organizations/{id}/members",
+		Summary:     "List Organization Members",
+		Description: "Retrieve the members of an organization. Only members can see them.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listMembers)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "add-organization-member",
+		Method:      http.MethodPost,
+		Path:        "/organizations/{id}/members",
+		Summary:     "Add Organization Member",
+		Description: "Add a user to an organization. Owners and admins can add members; only owners can add owners. " +
+			"Owners and admins get every permission on the organization's projects, members can download packages.",
+		Tags:     []string{"Organizations"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, h.addMember)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-organization-member",
+		Method:      http.MethodPatch,
+		Path:        "/organizations/{id}/members/{user_id}",
+		Summary:     "Update Organization Member",
+		Description: "Change a member's role. Only owners can grant or take away the owner role, and the last owner cannot be demoted. Members demoted to member hand the projects they own over to an owner.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.updateMember)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-organization-member",
+		Method:      http.MethodDelete,
+		Path:        "/organizations/{id}/members/{user_id}",
+		Summary:     "Remove Organization Member",
+		Description: "Remove a member from an organization, or leave it. The last owner cannot leave, and nobody can leave their personal organization. The projects the member owns are handed over to an owner.",
+		Tags:        []string{"Organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.removeMember)
+}
+
+// ========== Request/Response Types ==========
+
+// OrganizationResponse represents an organization in API responses.
+type OrganizationResponse struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	IsPersonal bool                       `json:"is_personal" doc:"Whether this is a user's personal organization"`
+	Role       string                     `json:"role,omitempty" doc:"Caller's role in the organization: owner, admin or member"`
+	Quotas     OrganizationQuotasBody     `json:"quotas"`
+	Usage      *OrganizationUsageResponse `json:"usage,omitempty"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+}
+
+// OrganizationQuotasBody represents the limits of an organization.
+type OrganizationQuotasBody struct {
+	MaxProjects     int32 `json:"max_projects" minimum:"1" doc:"Maximum number of projects"`
+	MaxMembers      int32 `json:"max_members" minimum:"1" doc:"Maximum number of members"`
+	MaxApplications int32 `json:"max_applications" minimum:"1" doc:"Maximum number of applications across all projects"`
+}
+
+// OrganizationUsageResponse represents what an organization currently holds.
+type OrganizationUsageResponse struct {
+	Projects     int64 `json:"projects"`
+	Members      int64 `json:"members"`
+	Applications int64 `json:"applications"`
+}
+
+// toOrganizationResponse converts a domain organization to an API response.
+func toOrganizationResponse(o *domain.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:         o.ID.String(),
+		Name:       o.Name,
+		IsPersonal: o.IsPersonal(),
+		Quotas: OrganizationQuotasBody{
+			MaxProjects:     o.Quotas.MaxProjects,
+			MaxMembers:      o.Quotas.MaxMembers,
+			MaxApplications: o.Quotas.MaxApplications,
+		},
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+// toOrganizationMembershipResponse converts a domain organization membership to an API response.
+func toOrganizationMembershipResponse(m *domain.OrganizationMembership) OrganizationResponse {
+	resp := toOrganizationResponse(&m.Organization)
+	resp.Role = string(m.Role)
+	return resp
+}
+
+// OrganizationMemberResponse represents an organization member in API responses.
+type OrganizationMemberResponse struct {
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	Username  string    `json:"username,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	FirstName string    `json:"first_name,omitempty"`
+	LastName  string    `json:"last_name,omitempty"`
+	CreatedAt time.Time `json:"created_at" doc:"When the user joined"`
+}
+
+// toOrganizationMemberResponse converts a domain organization member to an API response.
+func toOrganizationMemberResponse(m *domain.OrganizationMember) OrganizationMemberResponse {
+	return OrganizationMemberResponse{
+		UserID:    m.UserID.String(),
+		Role:      string(m.Role),
+		Username:  m.Username,
+		Email:     m.Email,
+		FirstName: m.FirstName,
+		LastName:  m.LastName,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ListMyOrganizationsOutput is the response for listing the user's organizations.
+type ListMyOrganizationsOutput struct {
+	Body ApiResponse[[]OrganizationResponse]
+}
+
+// CreateOrganizationInput is the request for creating an organization.
+type CreateOrganizationInput struct {
+	Body struct {
+		Name string `json:"name" required:"true" minLength:"2" maxLength:"100" doc:"Organization name"`
+	}
+}
+
+// OrganizationInput is the request for an action on an organization.
+type OrganizationInput struct {
+	ID uuid.UUID `path:"id" doc:"Organization ID"`
+}
+
+// UpdateOrganizationInput is the request for renaming an organization.
+type UpdateOrganizationInput struct {
+	ID   uuid.UUID `path:"id" doc:"Organization ID"`
+	Body struct {
+		Name string `json:"name" required:"true" minLength:"2" maxLength:"100" doc:"New organization name"`
+	}
+}
+
+// OrganizationOutput is the response for an action returning an organization.
+type OrganizationOutput struct {
+	Body ApiResponse[OrganizationResponse]
+}
+
+// ListOrganizationMembersOutput is the response for listing organization members.
+type ListOrganizationMembersOutput struct {
+	Body ApiResponse[[]OrganizationMemberResponse]
+}
+
+// AddOrganizationMemberInput is the request for adding an organization member.
+type AddOrganizationMemberInput struct {
+	ID   uuid.UUID `path:"id" doc:"Organization ID"`
+	Body struct {
+		Email string `json:"email" required:"true" format:"email" doc:"Email of the user to add"`
+		Role  string `json:"role" required:"true" enum:"owner,admin,member" doc:"Role in the organization"`
+	}
+}
+
+// UpdateOrganizationMemberInput is the request for changing a member's role.
+type UpdateOrganizationMemberInput struct {
+	ID     uuid.UUID `path:"id" doc:"Organization ID"`
+	UserID uuid.UUID `path:"user_id" doc:"User ID of the member"`
+	Body   struct {
+		Role string `json:"role" required:"true" enum:"owner,admin,member" doc:"New role in the organization"`
+	}
+}
+
+// RemoveOrganizationMemberInput is the request for removing a member.
+type RemoveOrganizationMemberInput struct {
+	ID     uuid.UUID `path:"id" doc:"Organization ID"`
+	UserID uuid.UUID `path:"user_id" doc:"User ID of the member"`
+}
+
+// OrganizationMemberOutput is the response for an action returning a member.
+type OrganizationMemberOutput struct {
+	Body ApiResponse[OrganizationMemberResponse]
+}
+
+// RemoveOrganizationMemberOutput is the response for removing a member.
+type RemoveOrganizationMemberOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// ========== Handlers ==========
+
+func (h *OrganizationHandler) listMyOrganizations(ctx context.Context, input *struct{}) (*ListMyOrganizationsOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	memberships, err := h.orgService.ListForUser(ctx, user.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	response := make([]OrganizationResponse, len(memberships))
+	for i, m := range memberships {
+		response[i] = toOrganizationMembershipResponse(m)
+	}
+
+	return &ListMyOrganizationsOutput{
+		Body: ok("Organizations retrieved successfully", response),
+	}, nil
+}
+
+func (h *OrganizationHandler) createOrganization(ctx context.Context, input *CreateOrganizationInput) (*OrganizationOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	membership, err := h.orgService.Create(ctx, user.ID, input.Body.Name)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &OrganizationOutput{
+		Body: created("Organization created successfully", toOrganizationMembershipResponse(membership)),
+	}, nil
+}
+
+func (h *OrganizationHandler) getOrganization(ctx context.Context, input *OrganizationInput) (*OrganizationOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	membership, usage, err := h.orgService.Get(ctx, input.ID, user.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	response := toOrganizationMembershipResponse(membership)
+	response.Usage = &OrganizationUsageResponse{
+		Projects:     usage.Projects,
+		Members:      usage.Members,
+		Applications: usage.Applications,
+	}
+
+	return &OrganizationOutput{
+		Body: ok("Organization retrieved successfully", response),
+	}, nil
+}
+
+func (h *OrganizationHandler) updateOrganization(ctx context.Context, input *UpdateOrganizationInput) (*OrganizationOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	membership, err := h.orgService.Rename(ctx, input.ID, user.ID, input.Body.Name)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &OrganizationOutput{
+		Body: ok("Organization updated successfully", toOrganizationMembershipResponse(membership)),
+	}, nil
+}
+
+func (h *OrganizationHandler) listMembers(ctx context.Context, input *OrganizationInput) (*ListOrganizationMembersOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	members, err := h.orgService.ListMembers(ctx, input.ID, user.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	response := make([]OrganizationMemberResponse, len(members))
+	for i, m := range members {
+		response[i] = toOrganizationMemberResponse(m)
+	}
+
+	return &ListOrganizationMembersOutput{
+		Body: ok("Members retrieved successfully", response),
+	}, nil
+}
+
+func (h *OrganizationHandler) addMember(ctx context.Context, input *AddOrganizationMemberInput) (*OrganizationMemberOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	member, err := h.orgService.AddMember(ctx, input.ID, user.ID, input.Body.Email, domain.OrganizationRole(input.Body.Role))
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &OrganizationMemberOutput{
+		Body: created("Member added successfully", toOrganizationMemberResponse(member)),
+	}, nil
+}
+
+func (h *OrganizationHandler) updateMember(ctx context.Context, input *UpdateOrganizationMemberInput) (*OrganizationMemberOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	member, err := h.orgService.UpdateMemberRole(ctx, input.ID, user.ID, input.UserID, domain.OrganizationRole(input.Body.Role))
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &OrganizationMemberOutput{
+		Body: ok("Member updated successfully", toOrganizationMemberResponse(member)),
+	}, nil
+}
+
+func (h *OrganizationHandler) removeMember(ctx context.Context, input *RemoveOrganizationMemberInput) (*RemoveOrganizationMemberOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	if err := h.orgService.RemoveMember(ctx, input.ID, user.ID, input.UserID); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RemoveOrganizationMemberOutput{
+		Body: ok("Member removed successfully", emptyData{}),
+	}, nil
+}
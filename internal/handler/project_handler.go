@@ -30,7 +30,7 @@ func (h *ProjectHandler) Register(api huma.API) {
 		Method:      http.MethodGet,
 		Path:        "/projects",
 		Summary:     "List My Projects",
-		Description: "Retrieve all projects owned by the authenticated user.",
+		Description: "Retrieve the projects the authenticated user owns or is a member of, with their role, permissions and project activity.",
 		Tags:        []string{"Projects"},
 		Security: []map[string][]string{
 			{"bearer": {}},
@@ -42,7 +42,7 @@ func (h *ProjectHandler) Register(api huma.API) {
 		Method:      http.MethodGet,
 		Path:        "/projects/{id}",
 		Summary:     "Get Project",
-		Description: "Retrieve a specific project by ID. Only the owner can access their project.",
+		Description: "Retrieve a specific project by ID, with the caller's role and permissions in it. Requires access to the project through ownership, its organization or a membership.",
 		Tags:        []string{"Projects"},
 		Security: []map[string][]string{
 			{"bearer": {}},
@@ -54,7 +54,7 @@ func (h *ProjectHandler) Register(api huma.API) {
 		Method:      http.MethodPost,
 		Path:        "/projects",
 		Summary:     "Create Project",
-		Description: "Create a new project. The authenticated user becomes the owner.",
+		Description: "Create a new project in an organization where the caller is an owner or admin, or in their personal organization by default. The authenticated user becomes the owner.",
 		Tags:        []string{"Projects"},
 		Security: []map[string][]string{
 			{"bearer": {}},
@@ -66,7 +66,7 @@ func (h *ProjectHandler) Register(api huma.API) {
 		Method:      http.MethodPatch,
 		Path:        "/projects/{id}",
 		Summary:     "Update Project",
-		Description: "Update a project's title and/or description. Only the owner can update.",
+		Description: "Update a project's title and/or description. Requires the project.manage permission.",
 		Tags:        []string{"Projects"},
 		Security: []map[string][]string{
 			{"bearer": {}},
@@ -78,19 +78,43 @@ func (h *ProjectHandler) Register(api huma.API) {
 		Method:      http.MethodDelete,
 		Path:        "/projects/{id}",
 		Summary:     "Delete Project",
-		Description: "Soft delete a project. Only the owner can delete.",
+		Description: "Soft delete a project. Only the owner or an owner of its organization can delete. The project and its content go to the trash, where they can be restored until the retention window passes.",
 		Tags:        []string{"Projects"},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
 	}, h.deleteProject)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "get-project-settings",
+		Method:      http.MethodGet,
+		Path:        "/projects/{id}/settings",
+		Summary:     "Get Project Settings",
+		Description: "Retrieve a project's release policy. Anyone with access to the project can read it.",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, h.getSettings)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-project-settings",
+		Method:      http.MethodPatch,
+		Path:        "/projects/{id}/settings",
+		Summary:     "Update Project Settings",
+		Description: "Change a project's release policy. Omitted fields are left unchanged. Requires the project.manage permission.",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, h.updateSettings)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "transfer-project-ownership",
 		Method:      http.MethodPost,
 		Path:        "/projects/{id}/transfer",
 		Summary:     "Transfer Project Ownership",
-		Description: "Transfer ownership of a project to another user. Only the current owner can transfer.",
+		Description: "Transfer ownership of a project to another user. The project stays in its organization. Only the owner or an owner of its organization can transfer.",
 		Tags:        []string{"Projects"},
 		Security: []map[string][]string{
 			{"bearer": {}},
@@ -102,29 +126,172 @@ func (h *ProjectHandler) Register(api huma.API) {
 
 // ProjectResponse represents a project in API responses.
 type ProjectResponse struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	OwnerID     string    `json:"owner_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	OwnerID        string     `json:"owner_id"`
+	OrganizationID string     `json:"organization_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
 }
 
 // toProjectResponse converts a domain project to an API response.
 func toProjectResponse(p *domain.Project) ProjectResponse {
 	return ProjectResponse{
-		ID:          p.ID.String(),
-		Title:       p.Title,
-		Description: p.Description,
-		OwnerID:     p.OwnerID.String(),
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:             p.ID.String(),
+		Title:          p.Title,
+		Description:    p.Description,
+		OwnerID:        p.OwnerID.String(),
+		OrganizationID: p.OrganizationID.String(),
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		DeletedAt:      p.DeletedAt,
 	}
 }
 
+// ProjectDetailResponse represents a project with the caller's access to it.
+type ProjectDetailResponse struct {
+	ProjectResponse
+	Role        string   `json:"role" doc:"Caller's role in the project: owner, admin or member"`
+	Permissions []string `json:"permissions" doc:"Caller's effective permission keys in the project"`
+}
+
+// ProjectSummaryResponse represents a project listed for one of its owners or members.
+type ProjectSummaryResponse struct {
+	ProjectResponse
+	Role                    string                  `json:"role" doc:"Caller's role in the project: owner, admin or member"`
+	Permissions             []string                `json:"permissions" doc:"Caller's effective permission keys in the project"`
+	ApplicationCount        int64                   `json:"application_count"`
+	LatestProductionRelease *ReleaseSummaryResponse `json:"latest_production_release,omitempty" doc:"Most recent production release across the project's applications"`
+}
+
+// ReleaseSummaryResponse identifies a release in project listings.
+type ReleaseSummaryResponse struct {
+	ID            string    `json:"id"`
+	ApplicationID string    `json:"application_id"`
+	VersionName   string    `json:"version_name"`
+	VersionCode   int32     `json:"version_code"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// toProjectSummaryResponse converts a domain project summary to an API response.
+func toProjectSummaryResponse(s *domain.ProjectSummary) ProjectSummaryResponse {
+	resp := ProjectSummaryResponse{
+		ProjectResponse:  toProjectResponse(&s.Project),
+		Role:             s.Role,
+		Permissions:      s.Permissions,
+		ApplicationCount: s.ApplicationCount,
+	}
+	if resp.Permissions == nil {
+		resp.Permissions = []string{}
+	}
+	if r := s.LatestProductionRelease; r != nil {
+		resp.LatestProductionRelease = &ReleaseSummaryResponse{
+			ID:            r.ID.String(),
+			ApplicationID: r.ApplicationID.String(),
+			VersionName:   r.VersionName,
+			VersionCode:   r.VersionCode,
+			CreatedAt:     r.CreatedAt,
+		}
+	}
+	return resp
+}
+
+// ProjectSettingsResponse represents a project's release policy in API responses.
+type ProjectSettingsResponse struct {
+	DefaultEnvironment   domain.ReleaseEnvironment `json:"default_environment" doc:"Default upload channel of new applications; existing applications keep their own"`
+	RequireReleaseNote   bool                      `json:"require_release_note" doc:"Whether releases must have a release note"`
+	AllowedArtifactTypes []string                  `json:"allowed_artifact_types" doc:"Allowed artifact MIME types; empty allows any type"`
+	MaxArtifactSizeBytes int64                     `json:"max_artifact_size_bytes" doc:"Maximum artifact size; 0 means no limit"`
+	Retention            RetentionPolicyBody       `json:"retention"`
+	AllowShareLinks      bool                      `json:"allow_share_links" doc:"Whether releases may be shared through public links"`
+	Pipeline             PipelineBody              `json:"pipeline"`
+	VersionCodePolicy    domain.VersionCodePolicy  `json:"version_code_policy" enum:"increasing,unique" doc:"Whether new version codes must exceed every one used in their channel, or only be unused"`
+	RequireSemver        bool                      `json:"require_semver" doc:"Whether version names must be semantic versions (e.g. 1.4.0)"`
+}
+
+// PipelineBody is the path releases follow between environments.
+type PipelineBody struct {
+	Stages []PipelineStageBody `json:"stages" maxItems:"10" doc:"Ordered stages; releases can only be promoted to the next one. Empty allows any promotion"`
+}
+
+// PipelineStageBody is a stage of the promotion pipeline and its gate.
+type PipelineStageBody struct {
+	Environment       domain.ReleaseEnvironment `json:"environment" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Channel of the stage"`
+	RequiredApprovals int                       `json:"required_approvals" required:"false" minimum:"0" maximum:"10" doc:"Approvals needed to promote into the stage; 0 promotes right away"`
+	ApproverRoles     []string                  `json:"approver_roles" required:"false" maxItems:"10" doc:"Project roles allowed to approve; empty allows any role"`
+	MinSoakHours      int                       `json:"min_soak_hours" required:"false" minimum:"0" maximum:"720" doc:"Hours a release must spend in the previous stage before promotion"`
+}
+
+// RetentionPolicyBody describes how long releases are kept.
+type RetentionPolicyBody struct {
+	KeepLastReleases int `json:"keep_last_releases" minimum:"0" maximum:"1000" doc:"Newest releases kept per application and environment; 0 keeps all"`
+	MaxAgeDays       int `json:"max_age_days" minimum:"0" maximum:"3650" doc:"Age in days after which releases are removed; 0 keeps them forever. With both limits set, releases are removed once past both. Releases go to the trash, never the ones clients are offered"`
+}
+
+// toProjectSettingsResponse converts domain project settings to an API response.
+func toProjectSettingsResponse(s *domain.ProjectSettings) ProjectSettingsResponse {
+	types := s.AllowedArtifactTypes
+	if types == nil {
+		types = []string{}
+	}
+	return ProjectSettingsResponse{
+		DefaultEnvironment:   s.DefaultEnvironment,
+		RequireReleaseNote:   s.RequireReleaseNote,
+		AllowedArtifactTypes: types,
+		MaxArtifactSizeBytes: s.MaxArtifactSizeBytes,
+		Retention: RetentionPolicyBody{
+			KeepLastReleases: s.Retention.KeepLastReleases,
+			MaxAgeDays:       s.Retention.MaxAgeDays,
+		},
+		AllowShareLinks:   s.AllowShareLinks,
+		Pipeline:          toPipelineBody(s.Pipeline),
+		VersionCodePolicy: s.VersionCodePolicy,
+		RequireSemver:     s.RequireSemver,
+	}
+}
+
+func toPipelineBody(p domain.PromotionPipeline) PipelineBody {
+	stages := make([]PipelineStageBody, len(p.Stages))
+	for i, s := range p.Stages {
+		roles := s.ApproverRoles
+		if roles == nil {
+			roles = []string{}
+		}
+		stages[i] = PipelineStageBody{
+			Environment:       s.Environment,
+			RequiredApprovals: s.RequiredApprovals,
+			ApproverRoles:     roles,
+			MinSoakHours:      s.MinSoakHours,
+		}
+	}
+	return PipelineBody{Stages: stages}
+}
+
+func toPromotionPipeline(b PipelineBody) domain.PromotionPipeline {
+	stages := make([]domain.PipelineStage, len(b.Stages))
+	for i, s := range b.Stages {
+		stages[i] = domain.PipelineStage{
+			Environment:       s.Environment,
+			RequiredApprovals: s.RequiredApprovals,
+			ApproverRoles:     s.ApproverRoles,
+			MinSoakHours:      s.MinSoakHours,
+		}
+	}
+	return domain.PromotionPipeline{Stages: stages}
+}
+
+// ListMyProjectsInput is the request for listing user's projects.
+type ListMyProjectsInput struct {
+	PageQuery
+	Sort   string `query:"sort" enum:"-created_at,created_at" default:"-created_at" doc:"Sort order, newest first by default"`
+	Search string `query:"q" maxLength:"100" doc:"Only return projects whose title contains this text"`
+}
+
 // ListMyProjectsOutput is the response for listing user's projects.
 type ListMyProjectsOutput struct {
-	Body ApiResponse[[]ProjectResponse]
+	Body ApiResponse[[]ProjectSummaryResponse]
 }
 
 // GetProjectInput is the request for getting a project.
@@ -134,14 +301,15 @@ type GetProjectInput struct {
 
 // GetProjectOutput is the response for getting a project.
 type GetProjectOutput struct {
-	Body ApiResponse[ProjectResponse]
+	Body ApiResponse[ProjectDetailResponse]
 }
 
 // CreateProjectInput is the request for creating a project.
 type CreateProjectInput struct {
 	Body struct {
-		Title       string `json:"title" required:"true" minLength:"1" maxLength:"100" doc:"Project title"`
-		Description string `json:"description" maxLength:"1000" doc:"Project description (optional)"`
+		Title          string `json:"title" required:"true" minLength:"1" maxLength:"100" doc:"Project title"`
+		Description    string `json:"description" maxLength:"1000" doc:"Project description (optional)"`
+		OrganizationID string `json:"organization_id,omitempty" doc:"Organization owning the project (optional, defaults to the personal organization)"`
 	}
 }
 
@@ -174,6 +342,32 @@ type DeleteProjectOutput struct {
 	Body ApiResponse[emptyData]
 }
 
+// GetProjectSettingsInput is the request for getting project settings.
+type GetProjectSettingsInput struct {
+	ID string `path:"id" doc:"Project ID (UUID)"`
+}
+
+// UpdateProjectSettingsInput is the request for updating project settings.
+type UpdateProjectSettingsInput struct {
+	ID   string `path:"id" doc:"Project ID (UUID)"`
+	Body struct {
+		DefaultEnvironment   *domain.ReleaseEnvironment `json:"default_environment,omitempty" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Default upload channel of new applications; existing applications keep their own"`
+		RequireReleaseNote   *bool                      `json:"require_release_note,omitempty" doc:"Whether releases must have a release note"`
+		AllowedArtifactTypes *[]string                  `json:"allowed_artifact_types,omitempty" maxItems:"20" doc:"Allowed artifact MIME types; empty allows any type"`
+		MaxArtifactSizeBytes *int64                     `json:"max_artifact_size_bytes,omitempty" minimum:"0" doc:"Maximum artifact size; 0 means no limit"`
+		Retention            *RetentionPolicyBody       `json:"retention,omitempty" doc:"Release retention policy"`
+		AllowShareLinks      *bool                      `json:"allow_share_links,omitempty" doc:"Whether releases may be shared through public links"`
+		Pipeline             *PipelineBody              `json:"pipeline,omitempty" doc:"Promotion pipeline, replaced as a whole"`
+		VersionCodePolicy    *domain.VersionCodePolicy  `json:"version_code_policy,omitempty" enum:"increasing,unique" doc:"Whether new version codes must exceed every one used in their channel, or only be unused"`
+		RequireSemver        *bool                      `json:"require_semver,omitempty" doc:"Whether version names must be semantic versions (e.g. 1.4.0)"`
+	}
+}
+
+// ProjectSettingsOutput is the response for reading or updating project settings.
+type ProjectSettingsOutput struct {
+	Body ApiResponse[ProjectSettingsResponse]
+}
+
 // TransferOwnershipInput is the request for transferring project ownership.
 type TransferOwnershipInput struct {
 	ID   string `path:"id" doc:"Project ID (UUID)"`
@@ -189,24 +383,29 @@ type TransferOwnershipOutput struct {
 
 // ========== Handlers ==========
 
-func (h *ProjectHandler) listMyProjects(ctx context.Context, input *struct{}) (*ListMyProjectsOutput, error) {
+func (h *ProjectHandler) listMyProjects(ctx context.Context, input *ListMyProjectsInput) (*ListMyProjectsOutput, error) {
 	user := auth.UserFromContext(ctx)
 	if user == nil {
 		return nil, huma.Error401Unauthorized("authentication required")
 	}
 
-	projects, err := h.projectService.ListByOwner(ctx, user.ID)
+	page, err := pageRequest(input.PageQuery, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := h.projectService.ListForUser(ctx, user.ID, domain.ProjectFilter{Search: input.Search}, page)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
-	response := make([]ProjectResponse, len(projects))
-	for i, p := range projects {
-		response[i] = toProjectResponse(p)
+	response := make([]ProjectSummaryResponse, len(projects.Items))
+	for i, p := range projects.Items {
+		response[i] = toProjectSummaryResponse(p)
 	}
 
 	return &ListMyProjectsOutput{
-		Body: ok("Projects retrieved successfully", response),
+		Body: okPage("Projects retrieved successfully", response, projects.NextCursor),
 	}, nil
 }
 
@@ -221,19 +420,22 @@ func (h *ProjectHandler) getProject(ctx context.Context, input *GetProjectInput)
 		return nil, huma.Error400BadRequest("invalid project ID format")
 	}
 
-	project, err := h.projectService.GetByID(ctx, id)
+	project, access, err := h.projectService.GetForUser(ctx, id, user.ID)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
-	// Only owner can access their project (for now)
-	// TODO: Add support for project members/collaborators
-	if project.OwnerID != user.ID {
-		return nil, huma.Error403Forbidden("not authorized to access this project")
+	permissions := access.Permissions
+	if permissions == nil {
+		permissions = []string{}
 	}
 
 	return &GetProjectOutput{
-		Body: ok("Project retrieved successfully", toProjectResponse(project)),
+		Body: ok("Project retrieved successfully", ProjectDetailResponse{
+			ProjectResponse: toProjectResponse(project),
+			Role:            access.Role,
+			Permissions:     permissions,
+		}),
 	}, nil
 }
 
@@ -243,10 +445,20 @@ func (h *ProjectHandler) createProject(ctx context.Context, input *CreateProject
 		return nil, huma.Error401Unauthorized("authentication required")
 	}
 
+	var orgID *uuid.UUID
+	if input.Body.OrganizationID != "" {
+		id, err := uuid.Parse(input.Body.OrganizationID)
+		if err != nil {
+			return nil, huma.Error400BadRequest("invalid organization ID format")
+		}
+		orgID = &id
+	}
+
 	project, err := h.projectService.Create(ctx, domain.CreateProjectInput{
-		Title:       input.Body.Title,
-		Description: input.Body.Description,
-		OwnerID:     user.ID,
+		Title:          input.Body.Title,
+		Description:    input.Body.Description,
+		OwnerID:        user.ID,
+		OrganizationID: orgID,
 	})
 	if err != nil {
 		return nil, mapDomainError(err)
@@ -281,6 +493,65 @@ func (h *ProjectHandler) updateProject(ctx context.Context, input *UpdateProject
 	}, nil
 }
 
+func (h *ProjectHandler) getSettings(ctx context.Context, input *GetProjectSettingsInput) (*ProjectSettingsOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid project ID format")
+	}
+
+	settings, err := h.projectService.GetSettings(ctx, id, user.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ProjectSettingsOutput{
+		Body: ok("Project settings retrieved successfully", toProjectSettingsResponse(settings)),
+	}, nil
+}
+
+func (h *ProjectHandler) updateSettings(ctx context.Context, input *UpdateProjectSettingsInput) (*ProjectSettingsOutput, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, huma.Error401Unauthorized("authentication required")
+	}
+
+	id, err := uuid.Parse(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid project ID format")
+	}
+
+	update := domain.UpdateProjectSettingsInput{
+		DefaultEnvironment:   input.Body.DefaultEnvironment,
+		RequireReleaseNote:   input.Body.RequireReleaseNote,
+		AllowedArtifactTypes: input.Body.AllowedArtifactTypes,
+		MaxArtifactSizeBytes: input.Body.MaxArtifactSizeBytes,
+		AllowShareLinks:      input.Body.AllowShareLinks,
+		VersionCodePolicy:    input.Body.VersionCodePolicy,
+		RequireSemver:        input.Body.RequireSemver,
+	}
+	if r := input.Body.Retention; r != nil {
+		update.Retention = &domain.RetentionPolicy{KeepLastReleases: r.KeepLastReleases, MaxAgeDays: r.MaxAgeDays}
+	}
+	if p := input.Body.Pipeline; p != nil {
+		pipeline := toPromotionPipeline(*p)
+		update.Pipeline = &pipeline
+	}
+
+	settings, err := h.projectService.UpdateSettings(ctx, id, update, user.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ProjectSettingsOutput{
+		Body: ok("Project settings updated successfully", toProjectSettingsResponse(settings)),
+	}, nil
+}
+
 func (h *ProjectHandler) deleteProject(ctx context.Context, input *DeleteProjectInput) (*DeleteProjectOutput, error) {
 	user := auth.UserFromContext(ctx)
 	if user == nil {
@@ -0,0 +1,428 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// PromotionHandler handles release promotion HTTP requests.
+type PromotionHandler struct {
+	promotionService *service.PromotionService
+}
+
+// NewPromotionHandler creates a new PromotionHandler.
+func NewPromotionHandler(promotionService *service.PromotionService) *PromotionHandler {
+	return &PromotionHandler{promotionService: promotionService}
+}
+
+// Register registers promotion routes with the API.
+func (h *PromotionHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "promote-release",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/promote",
+		Summary:     "Promote Release",
+		Description: "Promote a release to the next stage of the project's pipeline (e.g. development -> staging). " +
+			"The release and its artifacts are copied, so the build stays in its current environment too. " +
+			"Stages without approvals return the copy right away; stages requiring approvals return a pending promotion request with 202 Accepted.",
+		Tags:     []string{"Promotions"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, h.promoteRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-application-promotions",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/promotions",
+		Summary:     "List Promotions",
+		Description: "List the most recent promotions of an application's releases: from and to which environment, by whom and when.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listPromotions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-release-promotions",
+		Method:      http.MethodGet,
+		Path:        "/releases/{id}/promotions",
+		Summary:     "List Release Promotions",
+		Description: "List the promotions a release was copied from or into.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listReleasePromotions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-promotion-requests",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/promotion-requests",
+		Summary:     "List Promotion Requests",
+		Description: "List the most recent promotion requests of an application, without their reviews.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listRequests)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-promotion-request",
+		Method:      http.MethodGet,
+		Path:        "/promotion-requests/{id}",
+		Summary:     "Get Promotion Request",
+		Description: "Get a promotion request with its reviews.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.getRequest)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "approve-promotion-request",
+		Method:      http.MethodPost,
+		Path:        "/promotion-requests/{id}/approve",
+		Summary:     "Approve Promotion",
+		Description: "Approve a pending promotion request. The release is promoted once the request holds the approvals its stage requires. If the release can no longer be promoted by then, e.g. the version is already in the target environment, the request is closed as failed with the reason. Requesters cannot approve their own requests.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.approve)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reject-promotion-request",
+		Method:      http.MethodPost,
+		Path:        "/promotion-requests/{id}/reject",
+		Summary:     "Reject Promotion",
+		Description: "Reject a pending promotion request, which closes it.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.reject)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-promotion-request",
+		Method:      http.MethodPost,
+		Path:        "/promotion-requests/{id}/cancel",
+		Summary:     "Cancel Promotion Request",
+		Description: "Withdraw a pending promotion request. Only its requester or someone who can manage the project may cancel it.",
+		Tags:        []string{"Promotions"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.cancel)
+}
+
+// ========== Request/Response Types ==========
+
+// PromotionResponse represents a promotion in API responses.
+type PromotionResponse struct {
+	ID              uuid.UUID                 `json:"id" doc:"Promotion unique ID"`
+	SourceReleaseID *uuid.UUID                `json:"source_release_id,omitempty" doc:"Promoted release, absent once purged"`
+	ReleaseID       *uuid.UUID                `json:"release_id,omitempty" doc:"Copy in the target environment, absent once purged"`
+	VersionCode     int32                     `json:"version_code" doc:"Version code of the promoted build"`
+	VersionName     string                    `json:"version_name" doc:"Version name of the promoted build"`
+	FromEnvironment domain.ReleaseEnvironment `json:"from_environment" doc:"Environment promoted from"`
+	ToEnvironment   domain.ReleaseEnvironment `json:"to_environment" doc:"Environment promoted to"`
+	ActorID         *uuid.UUID                `json:"actor_id,omitempty" doc:"User who promoted, absent once their account is gone"`
+	Note            string                    `json:"note" doc:"Promotion note"`
+	CreatedAt       time.Time                 `json:"created_at" doc:"Promotion timestamp"`
+}
+
+// PromotionRequestResponse represents a promotion request in API responses.
+type PromotionRequestResponse struct {
+	ID                uuid.UUID                     `json:"id" doc:"Promotion request unique ID"`
+	ApplicationID     uuid.UUID                     `json:"application_id" doc:"Application ID"`
+	ReleaseID         uuid.UUID                     `json:"release_id" doc:"Release to promote"`
+	FromEnvironment   domain.ReleaseEnvironment     `json:"from_environment" doc:"Environment promoted from"`
+	ToEnvironment     domain.ReleaseEnvironment     `json:"to_environment" doc:"Environment promoted to"`
+	RequestedBy       *uuid.UUID                    `json:"requested_by,omitempty" doc:"Requester, absent once their account is gone"`
+	Note              string                        `json:"note" doc:"Promotion note"`
+	Status            domain.PromotionRequestStatus `json:"status" enum:"pending,approved,rejected,cancelled,failed" doc:"Request status"`
+	RequiredApprovals int                           `json:"required_approvals" doc:"Approvals needed to promote"`
+	Approvals         int                           `json:"approvals" doc:"Approvals received so far"`
+	ApproverRoles     []string                      `json:"approver_roles" doc:"Project roles allowed to review; empty allows any role"`
+	PromotedReleaseID *uuid.UUID                    `json:"promoted_release_id,omitempty" doc:"Copy in the target environment, once approved"`
+	FailureReason     string                        `json:"failure_reason,omitempty" doc:"Why the release could not be promoted, once failed"`
+	RolloutPercentage int32                         `json:"rollout_percentage" doc:"Percentage of clients the copy is offered to once approved"`
+	ResolvedAt        *time.Time                    `json:"resolved_at,omitempty" doc:"When the request was approved, rejected, cancelled or failed"`
+	CreatedAt         time.Time                     `json:"created_at" doc:"Creation timestamp"`
+	Reviews           []PromotionReviewResponse     `json:"reviews,omitempty" doc:"Reviews, oldest first; omitted from lists"`
+}
+
+// PromotionReviewResponse represents a review of a promotion request.
+type PromotionReviewResponse struct {
+	ReviewerID *uuid.UUID `json:"reviewer_id,omitempty" doc:"Reviewer, absent once their account is gone"`
+	Approved   bool       `json:"approved" doc:"Whether the reviewer approved"`
+	Comment    string     `json:"comment" doc:"Review comment"`
+	CreatedAt  time.Time  `json:"created_at" doc:"Review timestamp"`
+}
+
+// PromoteResponse is the outcome of a promotion: the copy, or the pending request.
+type PromoteResponse struct {
+	Release *ReleaseResponse          `json:"release,omitempty" doc:"Copy in the target environment, when promoted right away"`
+	Request *PromotionRequestResponse `json:"request,omitempty" doc:"Pending request, when the target stage requires approvals"`
+}
+
+// PromoteReleaseInput is the request for promoting a release.
+type PromoteReleaseInput struct {
+	ID   uuid.UUID `path:"id" doc:"Release ID"`
+	Body struct {
+		Environment domain.ReleaseEnvironment `json:"environment" required:"true" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Target channel"`
+		Note        string                    `json:"note,omitempty" maxLength:"1000" doc:"Why the release is promoted, kept in the promotion history"`
+		Rollout     int32                     `json:"rollout_percentage,omitempty" minimum:"1" maximum:"100" doc:"Percentage of clients offered the promoted release, all of them when omitted"`
+	}
+}
+
+// PromoteReleaseOutput is the response for promoting a release.
+type PromoteReleaseOutput struct {
+	Status int
+	Body   ApiResponse[PromoteResponse]
+}
+
+// ListPromotionsInput is the request for listing an application's promotions.
+type ListPromotionsInput struct {
+	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+	Limit int32     `query:"limit" default:"50" minimum:"1" maximum:"100" doc:"Maximum number of promotions"`
+}
+
+// ListReleasePromotionsInput is the request for listing a release's promotions.
+type ListReleasePromotionsInput struct {
+	ID uuid.UUID `path:"id" doc:"Release ID"`
+}
+
+// ListPromotionsOutput is the response for listing promotions.
+type ListPromotionsOutput struct {
+	Body ApiResponse[[]PromotionResponse]
+}
+
+// ListPromotionRequestsInput is the request for listing an application's promotion requests.
+type ListPromotionRequestsInput struct {
+	AppID  uuid.UUID `path:"app_id" doc:"Application ID"`
+	Status string    `query:"status" enum:"pending,approved,rejected,cancelled,failed" doc:"Only return requests in this status"`
+	Limit  int32     `query:"limit" default:"50" minimum:"1" maximum:"100" doc:"Maximum number of requests"`
+}
+
+// ListPromotionRequestsOutput is the response for listing promotion requests.
+type ListPromotionRequestsOutput struct {
+	Body ApiResponse[[]PromotionRequestResponse]
+}
+
+// PromotionRequestIDInput is the request for reading or cancelling a promotion request.
+type PromotionRequestIDInput struct {
+	ID uuid.UUID `path:"id" doc:"Promotion request ID"`
+}
+
+// ReviewPromotionInput is the request for approving or rejecting a promotion request.
+type ReviewPromotionInput struct {
+	ID   uuid.UUID `path:"id" doc:"Promotion request ID"`
+	Body struct {
+		Comment string `json:"comment,omitempty" maxLength:"1000" doc:"Review comment"`
+	}
+}
+
+// PromotionRequestOutput is the response for a single promotion request.
+type PromotionRequestOutput struct {
+	Body ApiResponse[PromotionRequestResponse]
+}
+
+// ========== Handlers ==========
+
+func (h *PromotionHandler) promoteRelease(ctx context.Context, input *PromoteReleaseInput) (*PromoteReleaseOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	result, err := h.promotionService.Promote(ctx, authUser.ID, input.ID, input.Body.Environment, input.Body.Note, input.Body.Rollout)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	if result.Request != nil {
+		request := toPromotionRequestResponse(result.Request)
+		return &PromoteReleaseOutput{
+			Status: http.StatusAccepted,
+			Body:   ok("Promotion is waiting for approval", PromoteResponse{Request: &request}),
+		}, nil
+	}
+
+	release := toReleaseResponse(result.Release)
+	return &PromoteReleaseOutput{
+		Status: http.StatusOK,
+		Body:   ok("Release promoted successfully", PromoteResponse{Release: &release}),
+	}, nil
+}
+
+func (h *PromotionHandler) listPromotions(ctx context.Context, input *ListPromotionsInput) (*ListPromotionsOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	promotions, err := h.promotionService.ListPromotions(ctx, authUser.ID, input.AppID, input.Limit)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ListPromotionsOutput{
+		Body: ok("Promotions retrieved successfully", toPromotionResponses(promotions)),
+	}, nil
+}
+
+func (h *PromotionHandler) listReleasePromotions(ctx context.Context, input *ListReleasePromotionsInput) (*ListPromotionsOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	promotions, err := h.promotionService.ListReleasePromotions(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ListPromotionsOutput{
+		Body: ok("Promotions retrieved successfully", toPromotionResponses(promotions)),
+	}, nil
+}
+
+func (h *PromotionHandler) listRequests(ctx context.Context, input *ListPromotionRequestsInput) (*ListPromotionRequestsOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	requests, err := h.promotionService.ListRequests(ctx, authUser.ID, input.AppID, domain.PromotionRequestStatus(input.Status), input.Limit)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	res := make([]PromotionRequestResponse, len(requests))
+	for i, r := range requests {
+		res[i] = toPromotionRequestResponse(r)
+	}
+
+	return &ListPromotionRequestsOutput{
+		Body: ok("Promotion requests retrieved successfully", res),
+	}, nil
+}
+
+func (h *PromotionHandler) getRequest(ctx context.Context, input *PromotionRequestIDInput) (*PromotionRequestOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	request, err := h.promotionService.GetRequest(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &PromotionRequestOutput{
+		Body: ok("Promotion request retrieved successfully", toPromotionRequestResponse(request)),
+	}, nil
+}
+
+func (h *PromotionHandler) approve(ctx context.Context, input *ReviewPromotionInput) (*PromotionRequestOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	request, err := h.promotionService.Approve(ctx, authUser.ID, input.ID, input.Body.Comment)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	message := "Approval recorded successfully"
+	switch request.Status {
+	case domain.PromotionApproved:
+		message = "Release promoted successfully"
+	case domain.PromotionFailed:
+		message = "Approval recorded, but the release could not be promoted: " + request.FailureReason
+	}
+	return &PromotionRequestOutput{
+		Body: ok(message, toPromotionRequestResponse(request)),
+	}, nil
+}
+
+func (h *PromotionHandler) reject(ctx context.Context, input *ReviewPromotionInput) (*PromotionRequestOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	request, err := h.promotionService.Reject(ctx, authUser.ID, input.ID, input.Body.Comment)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &PromotionRequestOutput{
+		Body: ok("Promotion rejected successfully", toPromotionRequestResponse(request)),
+	}, nil
+}
+
+func (h *PromotionHandler) cancel(ctx context.Context, input *PromotionRequestIDInput) (*PromotionRequestOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	request, err := h.promotionService.Cancel(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &PromotionRequestOutput{
+		Body: ok("Promotion request cancelled successfully", toPromotionRequestResponse(request)),
+	}, nil
+}
+
+// ========== Helpers ==========
+
+func toPromotionResponses(promotions []*domain.ReleasePromotion) []PromotionResponse {
+	res := make([]PromotionResponse, len(promotions))
+	for i, p := range promotions {
+		res[i] = PromotionResponse{
+			ID:              p.ID,
+			SourceReleaseID: p.SourceReleaseID,
+			ReleaseID:       p.ReleaseID,
+			VersionCode:     p.VersionCode,
+			VersionName:     p.VersionName,
+			FromEnvironment: p.FromEnvironment,
+			ToEnvironment:   p.ToEnvironment,
+			ActorID:         p.ActorID,
+			Note:            p.Note,
+			CreatedAt:       p.CreatedAt,
+		}
+	}
+	return res
+}
+
+func toPromotionRequestResponse(r *domain.PromotionRequest) PromotionRequestResponse {
+	roles := r.ApproverRoles
+	if roles == nil {
+		roles = []string{}
+	}
+	res := PromotionRequestResponse{
+		ID:                r.ID,
+		ApplicationID:     r.ApplicationID,
+		ReleaseID:         r.ReleaseID,
+		FromEnvironment:   r.FromEnvironment,
+		ToEnvironment:     r.ToEnvironment,
+		RequestedBy:       r.RequestedBy,
+		Note:              r.Note,
+		Status:            r.Status,
+		RequiredApprovals: r.RequiredApprovals,
+		Approvals:         r.Approvals(),
+		ApproverRoles:     roles,
+		PromotedReleaseID: r.PromotedReleaseID,
+		FailureReason:     r.FailureReason,
+		RolloutPercentage: r.RolloutPercentage,
+		ResolvedAt:        r.ResolvedAt,
+		CreatedAt:         r.CreatedAt,
+	}
+	for _, review := range r.Reviews {
+		res.Reviews = append(res.Reviews, PromotionReviewResponse{
+			ReviewerID: review.ReviewerID,
+			Approved:   review.Approved,
+			Comment:    review.Comment,
+			CreatedAt:  review.CreatedAt,
+		})
+	}
+	return res
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/bsrodrigue/appshare-backend/internal/auth"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/markdown"
 	"github.com/bsrodrigue/appshare-backend/internal/service"
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
@@ -30,7 +31,7 @@ func (h *ReleaseHandler) Register(api huma.API) {
 		Method:      http.MethodPost,
 		Path:        "/applications/{app_id}/releases",
 		Summary:     "Create Release",
-		Description: "Create a new release for an application. Only the project owner can create releases.",
+		Description: "Create a new release for an application. Releases start as drafts, only visible to uploaders, until they are published.",
 		Tags:        []string{"Releases"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.createRelease)
@@ -40,21 +41,11 @@ func (h *ReleaseHandler) Register(api huma.API) {
 		Method:      http.MethodPatch,
 		Path:        "/releases/{id}",
 		Summary:     "Update Release",
-		Description: "Update a release's title and release notes.",
+		Description: "Update a release's title, release notes and mandatory flag. The title and notes of a release promoted from or into another are frozen.",
 		Tags:        []string{"Releases"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.updateRelease)
 
-	huma.Register(api, huma.Operation{
-		OperationID: "promote-release",
-		Method:      http.MethodPost,
-		Path:        "/releases/{id}/promote",
-		Summary:     "Promote Release",
-		Description: "Promote a release to a different environment (e.g. development -> staging).",
-		Tags:        []string{"Releases"},
-		Security:    []map[string][]string{{"bearer": {}}},
-	}, h.promoteRelease)
-
 	huma.Register(api, huma.Operation{
 		OperationID: "delete-release",
 		Method:      http.MethodDelete,
@@ -70,7 +61,7 @@ func (h *ReleaseHandler) Register(api huma.API) {
 		Method:      http.MethodGet,
 		Path:        "/releases/{id}",
 		Summary:     "Get Release",
-		Description: "Get a specific release by ID.",
+		Description: "Get a specific release by ID. Drafts are only visible to users who can upload to the project.",
 		Tags:        []string{"Releases"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.getRelease)
@@ -80,7 +71,7 @@ func (h *ReleaseHandler) Register(api huma.API) {
 		Method:      http.MethodGet,
 		Path:        "/applications/{app_id}/releases",
 		Summary:     "List Releases",
-		Description: "List all releases for an application.",
+		Description: "List all releases for an application. Drafts are only listed for users who can upload to the project.",
 		Tags:        []string{"Releases"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.listReleases)
@@ -94,6 +85,86 @@ func (h *ReleaseHandler) Register(api huma.API) {
 		Tags:        []string{"Releases"},
 		Security:    []map[string][]string{{"bearer": {}}},
 	}, h.createReleaseWithArtifact)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-next-version",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/next-version",
+		Summary:     "Get Next Version Code",
+		Description: "Compute the version code the next build of a channel should use, one above the highest ever used in it. Meant for CI pipelines.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.nextVersion)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "check-update",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/environments/{env}/latest",
+		Summary:     "Check for Update",
+		Description: "Get the release of a channel offered to the caller. Releases under staged rollout are only offered to the share of clients their percentage covers, picked by hashing the device ID, or the user ID without one; other clients get the previous release.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.checkUpdate)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rollback-environment",
+		Method:      http.MethodPost,
+		Path:        "/applications/{app_id}/environments/{env}/rollback",
+		Summary:     "Roll Back Channel",
+		Description: "Withdraw the latest release of a channel so that the previous release becomes its latest again. The withdrawn build is kept, with the reason, and can still be downloaded by ID. Refused when the channel would be left without a release offered to every client at or above its minimum supported version code.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.rollback)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-release",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/publish",
+		Summary:     "Publish Release",
+		Description: "Publish a draft, or reinstate a withdrawn release. The release needs at least one verified artifact.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.publishRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "withdraw-release",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/withdraw",
+		Summary:     "Withdraw Release",
+		Description: "Withdraw a published release. It stops being offered as its channel's latest, and the reason is shown on it. Refused when the channel would be left without a release offered to every client at or above its minimum supported version code.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.withdrawRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "archive-release",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/archive",
+		Summary:     "Archive Release",
+		Description: "Archive a published or withdrawn release. Archived releases are kept for the record and cannot be published again. Refused when the channel would be left without a release offered to every client at or above its minimum supported version code.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.archiveRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "increase-release-rollout",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/rollout",
+		Summary:     "Increase Rollout",
+		Description: "Offer a published release to a larger percentage of clients. Clients already offered it keep it. Increasing a halted rollout resumes it.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.increaseRollout)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "halt-release-rollout",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/rollout/halt",
+		Summary:     "Halt Rollout",
+		Description: "Stop offering a release under staged rollout; clients get the previous release of the channel until the rollout is increased again. Refused when the channel would be left without a release offered to every client at or above its minimum supported version code.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.haltRollout)
 }
 
 // ========== Request/Response Types ==========
@@ -104,11 +175,24 @@ type ReleaseResponse struct {
 	Title         string                    `json:"title" doc:"Release title"`
 	VersionCode   int32                     `json:"version_code" doc:"Numeric version code (e.g. 101)"`
 	VersionName   string                    `json:"version_name" doc:"Semantic version string (e.g. 1.0.1)"`
-	ReleaseNote   string                    `json:"release_note" doc:"Description of changes in this release"`
-	Environment   domain.ReleaseEnvironment `json:"environment" doc:"Target environment"`
+	ReleaseNote   string                    `json:"release_note" doc:"Default notes of the release, in Markdown"`
+	NoteHTML      string                    `json:"release_note_html" doc:"Default notes rendered to sanitized HTML"`
+	Environment   domain.ReleaseEnvironment `json:"environment" doc:"Release channel"`
 	ApplicationID uuid.UUID                 `json:"application_id" doc:"Parent application ID"`
 	CreatedAt     time.Time                 `json:"created_at" doc:"Creation timestamp"`
 	UpdatedAt     time.Time                 `json:"updated_at" doc:"Last update timestamp"`
+	DeletedAt     *time.Time                `json:"deleted_at,omitempty" doc:"Deletion timestamp, set on releases in the trash"`
+	Status        domain.ReleaseStatus      `json:"status" enum:"draft,published,withdrawn,archived" doc:"Release status"`
+	PublishedAt   *time.Time                `json:"published_at,omitempty" doc:"When the release was first published"`
+
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty" doc:"Set on withdrawn releases"`
+	WithdrawnBy      *uuid.UUID `json:"withdrawn_by,omitempty" doc:"User who withdrew the release"`
+	WithdrawalReason string     `json:"withdrawal_reason,omitempty" doc:"Why the release was withdrawn"`
+
+	RolloutPercentage int32      `json:"rollout_percentage" doc:"Percentage of clients offered the release"`
+	RolloutHaltedAt   *time.Time `json:"rollout_halted_at,omitempty" doc:"Set on halted rollouts, which are offered to no one"`
+
+	Mandatory bool `json:"mandatory" doc:"Clients offered the release, or updating past it, must install it"`
 }
 
 // CreateReleaseInput is the request for creating a release.
@@ -119,7 +203,9 @@ type CreateReleaseInput struct {
 		VersionCode int32                     `json:"version_code" required:"true" minimum:"1" doc:"Version code"`
 		VersionName string                    `json:"version_name" required:"true" doc:"Version name"`
 		ReleaseNote string                    `json:"release_note" maxLength:"2000" doc:"Release notes"`
-		Environment domain.ReleaseEnvironment `json:"environment" required:"true" enum:"development,staging,production" doc:"Environment"`
+		Environment domain.ReleaseEnvironment `json:"environment,omitempty" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Release channel, the application's default channel when omitted"`
+		Rollout     int32                     `json:"rollout_percentage,omitempty" minimum:"1" maximum:"100" doc:"Percentage of clients offered the release once published, all of them when omitted"`
+		Mandatory   bool                      `json:"mandatory,omitempty" doc:"Clients offered the release, or updating past it, must install it"`
 	}
 }
 
@@ -134,6 +220,7 @@ type UpdateReleaseInput struct {
 	Body struct {
 		Title       *string `json:"title,omitempty" minLength:"3" maxLength:"100" doc:"Release title"`
 		ReleaseNote *string `json:"release_note,omitempty" maxLength:"2000" doc:"Release notes"`
+		Mandatory   *bool   `json:"mandatory,omitempty" doc:"Clients offered the release, or updating past it, must install it"`
 	}
 }
 
@@ -142,19 +229,6 @@ type UpdateReleaseOutput struct {
 	Body ApiResponse[ReleaseResponse]
 }
 
-// PromoteReleaseInput is the request for promoting a release.
-type PromoteReleaseInput struct {
-	ID   uuid.UUID `path:"id" doc:"Release ID"`
-	Body struct {
-		Environment domain.ReleaseEnvironment `json:"environment" required:"true" enum:"development,staging,production" doc:"New environment"`
-	}
-}
-
-// PromoteReleaseOutput is the response for promoting a release.
-type PromoteReleaseOutput struct {
-	Body ApiResponse[ReleaseResponse]
-}
-
 // DeleteReleaseInput is the request for deleting a release.
 type DeleteReleaseInput struct {
 	ID uuid.UUID `path:"id" doc:"Release ID"`
@@ -178,6 +252,10 @@ type GetReleaseOutput struct {
 // ListReleasesInput is the request for listing releases.
 type ListReleasesInput struct {
 	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
+	PageQuery
+	Sort        string                    `query:"sort" enum:"-version_code,version_code" default:"-version_code" doc:"Sort order, highest version first by default"`
+	Environment domain.ReleaseEnvironment `query:"environment" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Only return releases of this channel; unlisted channels are only returned when named"`
+	Status      domain.ReleaseStatus      `query:"status" enum:"draft,published,withdrawn,archived" doc:"Only return releases with this status"`
 }
 
 // ListReleasesOutput is the response for listing releases.
@@ -185,13 +263,91 @@ type ListReleasesOutput struct {
 	Body ApiResponse[[]ReleaseResponse]
 }
 
+// NextVersionInput is the request for computing the next version code.
+type NextVersionInput struct {
+	AppID       uuid.UUID                 `path:"app_id" doc:"Application ID"`
+	Environment domain.ReleaseEnvironment `query:"environment" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Release channel, the application's default channel when omitted"`
+}
+
+// NextVersionResponse is the version code the next build should use.
+type NextVersionResponse struct {
+	Environment        domain.ReleaseEnvironment `json:"environment" doc:"Release channel"`
+	HighestVersionCode int32                     `json:"highest_version_code" doc:"Highest version code used in the channel, deleted releases included; 0 if none"`
+	NextVersionCode    int32                     `json:"next_version_code" doc:"Version code to use for the next build"`
+}
+
+// NextVersionOutput is the response for computing the next version code.
+type NextVersionOutput struct {
+	Body ApiResponse[NextVersionResponse]
+}
+
+// CheckUpdateInput is the request for the release of a channel offered to the caller.
+type CheckUpdateInput struct {
+	AppID    uuid.UUID                 `path:"app_id" doc:"Application ID"`
+	Env      domain.ReleaseEnvironment `path:"env" doc:"Release channel"`
+	DeviceID string                    `query:"device_id" maxLength:"200" doc:"Stable device identifier used for staged rollouts; the user ID is used without one"`
+}
+
+// CheckUpdateOutput is the response for checking for an update.
+type CheckUpdateOutput struct {
+	Body ApiResponse[ReleaseResponse]
+}
+
+// IncreaseRolloutInput is the request for increasing a release's rollout.
+type IncreaseRolloutInput struct {
+	ID   uuid.UUID `path:"id" doc:"Release ID"`
+	Body struct {
+		Percentage int32 `json:"percentage" required:"true" minimum:"1" maximum:"100" doc:"New percentage of clients offered the release"`
+	}
+}
+
+// RollbackInput is the request for rolling a channel back.
+type RollbackInput struct {
+	AppID uuid.UUID                 `path:"app_id" doc:"Application ID"`
+	Env   domain.ReleaseEnvironment `path:"env" doc:"Channel to roll back"`
+	Body  struct {
+		Reason string `json:"reason" required:"true" minLength:"3" maxLength:"1000" doc:"Why the latest release is withdrawn, shown on it from now on"`
+	}
+}
+
+// RollbackResponse is the outcome of a rollback.
+type RollbackResponse struct {
+	Withdrawn ReleaseResponse `json:"withdrawn" doc:"Release taken out of the channel"`
+	Current   ReleaseResponse `json:"current" doc:"Channel's latest release from now on"`
+}
+
+// RollbackOutput is the response for rolling a channel back.
+type RollbackOutput struct {
+	Body ApiResponse[RollbackResponse]
+}
+
+// ReleaseStatusInput is the request for publishing or archiving a release, or halting its rollout.
+type ReleaseStatusInput struct {
+	ID uuid.UUID `path:"id" doc:"Release ID"`
+}
+
+// WithdrawReleaseInput is the request for withdrawing a release.
+type WithdrawReleaseInput struct {
+	ID   uuid.UUID `path:"id" doc:"Release ID"`
+	Body struct {
+		Reason string `json:"reason" required:"true" minLength:"3" maxLength:"1000" doc:"Why the release is withdrawn, shown to downloaders"`
+	}
+}
+
+// ReleaseStatusOutput is the response for changing a release's status or rollout.
+type ReleaseStatusOutput struct {
+	Body ApiResponse[ReleaseResponse]
+}
+
 // CreateReleaseWithArtifactInput is the request for creating a release with an artifact URL.
 type CreateReleaseWithArtifactInput struct {
 	AppID uuid.UUID `path:"app_id" doc:"Application ID"`
 	Body  struct {
 		ArtifactURL string                    `json:"artifact_url" required:"true" doc:"URL of the uploaded artifact (must be in our storage)"`
 		ReleaseNote string                    `json:"release_note" maxLength:"2000" doc:"Release notes"`
-		Environment domain.ReleaseEnvironment `json:"environment" required:"true" enum:"development,staging,production" doc:"Environment"`
+		Environment domain.ReleaseEnvironment `json:"environment,omitempty" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Release channel, the application's default channel when omitted"`
+		Rollout     int32                     `json:"rollout_percentage,omitempty" minimum:"1" maximum:"100" doc:"Percentage of clients offered the release, all of them when omitted"`
+		Mandatory   bool                      `json:"mandatory,omitempty" doc:"Clients offered the release, or updating past it, must install it"`
 	}
 }
 
@@ -209,12 +365,14 @@ func (h *ReleaseHandler) createRelease(ctx context.Context, input *CreateRelease
 	}
 
 	release, err := h.releaseService.Create(ctx, authUser.ID, domain.CreateReleaseInput{
-		ApplicationID: input.AppID,
-		Title:         input.Body.Title,
-		VersionCode:   input.Body.VersionCode,
-		VersionName:   input.Body.VersionName,
-		ReleaseNote:   input.Body.ReleaseNote,
-		Environment:   input.Body.Environment,
+		ApplicationID:     input.AppID,
+		Title:             input.Body.Title,
+		VersionCode:       input.Body.VersionCode,
+		VersionName:       input.Body.VersionName,
+		ReleaseNote:       input.Body.ReleaseNote,
+		Environment:       input.Body.Environment,
+		RolloutPercentage: input.Body.Rollout,
+		Mandatory:         input.Body.Mandatory,
 	})
 	if err != nil {
 		return nil, mapDomainError(err)
@@ -239,6 +397,7 @@ func (h *ReleaseHandler) updateRelease(ctx context.Context, input *UpdateRelease
 	release, err := h.releaseService.Update(ctx, authUser.ID, releaseID, domain.UpdateReleaseInput{
 		Title:       input.Body.Title,
 		ReleaseNote: input.Body.ReleaseNote,
+		Mandatory:   input.Body.Mandatory,
 	})
 	if err != nil {
 		return nil, mapDomainError(err)
@@ -249,22 +408,6 @@ func (h *ReleaseHandler) updateRelease(ctx context.Context, input *UpdateRelease
 	}, nil
 }
 
-func (h *ReleaseHandler) promoteRelease(ctx context.Context, input *PromoteReleaseInput) (*PromoteReleaseOutput, error) {
-	authUser := auth.UserFromContext(ctx)
-	if authUser == nil {
-		return nil, mapDomainError(domain.ErrUnauthorized)
-	}
-
-	release, err := h.releaseService.Promote(ctx, authUser.ID, input.ID, input.Body.Environment)
-	if err != nil {
-		return nil, mapDomainError(err)
-	}
-
-	return &PromoteReleaseOutput{
-		Body: ok("Release promoted successfully", toReleaseResponse(release)),
-	}, nil
-}
-
 func (h *ReleaseHandler) deleteRelease(ctx context.Context, input *DeleteReleaseInput) (*DeleteReleaseOutput, error) {
 	authUser := auth.UserFromContext(ctx)
 	if authUser == nil {
@@ -282,7 +425,12 @@ func (h *ReleaseHandler) deleteRelease(ctx context.Context, input *DeleteRelease
 }
 
 func (h *ReleaseHandler) getRelease(ctx context.Context, input *GetReleaseInput) (*GetReleaseOutput, error) {
-	release, err := h.releaseService.GetByID(ctx, input.ID)
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.GetByID(ctx, authUser.ID, input.ID)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
@@ -293,18 +441,29 @@ func (h *ReleaseHandler) getRelease(ctx context.Context, input *GetReleaseInput)
 }
 
 func (h *ReleaseHandler) listReleases(ctx context.Context, input *ListReleasesInput) (*ListReleasesOutput, error) {
-	releases, err := h.releaseService.ListByApplication(ctx, input.AppID)
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	page, err := pageRequest(input.PageQuery, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.ReleaseFilter{Environment: input.Environment, Status: input.Status}
+	releases, err := h.releaseService.ListByApplication(ctx, authUser.ID, input.AppID, filter, page)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
-	responses := make([]ReleaseResponse, len(releases))
-	for i, r := range releases {
+	responses := make([]ReleaseResponse, len(releases.Items))
+	for i, r := range releases.Items {
 		responses[i] = toReleaseResponse(r)
 	}
 
 	return &ListReleasesOutput{
-		Body: ok("Releases retrieved successfully", responses),
+		Body: okPage("Releases retrieved successfully", responses, releases.NextCursor),
 	}, nil
 }
 
@@ -314,7 +473,7 @@ func (h *ReleaseHandler) createReleaseWithArtifact(ctx context.Context, input *C
 		return nil, mapDomainError(domain.ErrUnauthorized)
 	}
 
-	release, err := h.releaseService.CreateReleaseWithArtifactURL(ctx, authUser.ID, input.AppID, input.Body.ArtifactURL, input.Body.ReleaseNote, input.Body.Environment)
+	release, err := h.releaseService.CreateReleaseWithArtifactURL(ctx, authUser.ID, input.AppID, input.Body.ArtifactURL, input.Body.ReleaseNote, input.Body.Environment, input.Body.Rollout, input.Body.Mandatory)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
@@ -324,6 +483,141 @@ func (h *ReleaseHandler) createReleaseWithArtifact(ctx context.Context, input *C
 	}, nil
 }
 
+func (h *ReleaseHandler) nextVersion(ctx context.Context, input *NextVersionInput) (*NextVersionOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	next, err := h.releaseService.NextVersion(ctx, authUser.ID, input.AppID, input.Environment)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &NextVersionOutput{
+		Body: ok("Next version code computed successfully", NextVersionResponse{
+			Environment:        next.Environment,
+			HighestVersionCode: next.HighestVersionCode,
+			NextVersionCode:    next.NextVersionCode,
+		}),
+	}, nil
+}
+
+func (h *ReleaseHandler) checkUpdate(ctx context.Context, input *CheckUpdateInput) (*CheckUpdateOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.CheckUpdate(ctx, authUser.ID, input.AppID, input.Env, input.DeviceID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &CheckUpdateOutput{
+		Body: ok("Release retrieved successfully", toReleaseResponse(release)),
+	}, nil
+}
+
+func (h *ReleaseHandler) rollback(ctx context.Context, input *RollbackInput) (*RollbackOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	result, err := h.releaseService.Rollback(ctx, authUser.ID, input.AppID, input.Env, input.Body.Reason)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RollbackOutput{
+		Body: ok("Channel rolled back successfully", RollbackResponse{
+			Withdrawn: toReleaseResponse(result.Withdrawn),
+			Current:   toReleaseResponse(result.Current),
+		}),
+	}, nil
+}
+
+func (h *ReleaseHandler) publishRelease(ctx context.Context, input *ReleaseStatusInput) (*ReleaseStatusOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.Publish(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReleaseStatusOutput{
+		Body: ok("Release published successfully", toReleaseResponse(release)),
+	}, nil
+}
+
+func (h *ReleaseHandler) withdrawRelease(ctx context.Context, input *WithdrawReleaseInput) (*ReleaseStatusOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.Withdraw(ctx, authUser.ID, input.ID, input.Body.Reason)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReleaseStatusOutput{
+		Body: ok("Release withdrawn successfully", toReleaseResponse(release)),
+	}, nil
+}
+
+func (h *ReleaseHandler) archiveRelease(ctx context.Context, input *ReleaseStatusInput) (*ReleaseStatusOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.Archive(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReleaseStatusOutput{
+		Body: ok("Release archived successfully", toReleaseResponse(release)),
+	}, nil
+}
+
+func (h *ReleaseHandler) increaseRollout(ctx context.Context, input *IncreaseRolloutInput) (*ReleaseStatusOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.IncreaseRollout(ctx, authUser.ID, input.ID, input.Body.Percentage)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReleaseStatusOutput{
+		Body: ok("Rollout increased successfully", toReleaseResponse(release)),
+	}, nil
+}
+
+func (h *ReleaseHandler) haltRollout(ctx context.Context, input *ReleaseStatusInput) (*ReleaseStatusOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.releaseService.HaltRollout(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReleaseStatusOutput{
+		Body: ok("Rollout halted successfully", toReleaseResponse(release)),
+	}, nil
+}
+
 // ========== Helpers ==========
 
 func toReleaseResponse(r *domain.ApplicationRelease) ReleaseResponse {
@@ -333,9 +627,22 @@ func toReleaseResponse(r *domain.ApplicationRelease) ReleaseResponse {
 		VersionCode:   r.VersionCode,
 		VersionName:   r.VersionName,
 		ReleaseNote:   r.ReleaseNote,
+		NoteHTML:      markdown.ToHTML(r.ReleaseNote),
 		Environment:   r.Environment,
 		ApplicationID: r.ApplicationID,
 		CreatedAt:     r.CreatedAt,
 		UpdatedAt:     r.UpdatedAt,
+		DeletedAt:     r.DeletedAt,
+		Status:        r.Status,
+		PublishedAt:   r.PublishedAt,
+
+		WithdrawnAt:      r.WithdrawnAt,
+		WithdrawnBy:      r.WithdrawnBy,
+		WithdrawalReason: r.WithdrawalReason,
+
+		RolloutPercentage: r.Rollout.Percentage,
+		RolloutHaltedAt:   r.Rollout.HaltedAt,
+
+		Mandatory: r.Mandatory,
 	}
 }
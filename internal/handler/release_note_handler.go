@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/markdown"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// ReleaseNoteHandler handles translated release notes and changelog HTTP requests.
+type ReleaseNoteHandler struct {
+	noteService *service.ReleaseNoteService
+}
+
+// NewReleaseNoteHandler creates a new ReleaseNoteHandler.
+func NewReleaseNoteHandler(noteService *service.ReleaseNoteService) *ReleaseNoteHandler {
+	return &ReleaseNoteHandler{noteService: noteService}
+}
+
+// Register registers release note routes with the API.
+func (h *ReleaseNoteHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-release-notes",
+		Method:      http.MethodGet,
+		Path:        "/releases/{id}/notes",
+		Summary:     "List Release Notes",
+		Description: "List the translations of a release's notes. The release's own release_note is its default note.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listNotes)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-release-note",
+		Method:      http.MethodPut,
+		Path:        "/releases/{id}/notes/{locale}",
+		Summary:     "Set Release Note",
+		Description: "Create or replace the notes of a release in a language, in Markdown. Readers get the translation of their language, e.g. pt-BR, then of its base language, e.g. pt, then the default note. The notes of a release promoted from or into another are frozen.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.setNote)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-release-note",
+		Method:      http.MethodDelete,
+		Path:        "/releases/{id}/notes/{locale}",
+		Summary:     "Delete Release Note",
+		Description: "Remove the translation of a release's notes in a language. The notes of a release promoted from or into another are frozen.",
+		Tags:        []string{"Releases"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.deleteNote)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-changelog",
+		Method:      http.MethodGet,
+		Path:        "/applications/{app_id}/changelog",
+		Summary:     "Get Changelog",
+		Description: "Gather the notes of the published releases of a channel above a version code, up to another one or the newest, e.g. for a tester who skipped several builds. " +
+			"Entries are newest first, and the changelog is also given as one Markdown document and its HTML rendering.",
+		Tags:     []string{"Releases"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, h.getChangelog)
+}
+
+// ========== Request/Response Types ==========
+
+// ReleaseNoteResponse represents a translated release note in API responses.
+type ReleaseNoteResponse struct {
+	Locale    string    `json:"locale" doc:"Language tag (e.g. fr, pt-BR)"`
+	Note      string    `json:"note" doc:"Notes in Markdown"`
+	NoteHTML  string    `json:"note_html" doc:"Notes rendered to sanitized HTML"`
+	CreatedAt time.Time `json:"created_at" doc:"Creation timestamp"`
+	UpdatedAt time.Time `json:"updated_at" doc:"Last update timestamp"`
+}
+
+// ListReleaseNotesInput is the request for listing the translated notes of a release.
+type ListReleaseNotesInput struct {
+	ID uuid.UUID `path:"id" doc:"Release ID"`
+}
+
+// ListReleaseNotesOutput is the response for listing the translated notes of a release.
+type ListReleaseNotesOutput struct {
+	Body ApiResponse[[]ReleaseNoteResponse]
+}
+
+// SetReleaseNoteInput is the request for setting the note of a release in a locale.
+type SetReleaseNoteInput struct {
+	ID     uuid.UUID `path:"id" doc:"Release ID"`
+	Locale string    `path:"locale" maxLength:"35" doc:"Language tag (e.g. fr, pt-BR)"`
+	Body   struct {
+		Note string `json:"note" required:"true" minLength:"1" maxLength:"2000" doc:"Notes in Markdown"`
+	}
+}
+
+// ReleaseNoteOutput is the response for a single translated release note.
+type ReleaseNoteOutput struct {
+	Body ApiResponse[ReleaseNoteResponse]
+}
+
+// DeleteReleaseNoteInput is the request for deleting the note of a release in a locale.
+type DeleteReleaseNoteInput struct {
+	ID     uuid.UUID `path:"id" doc:"Release ID"`
+	Locale string    `path:"locale" maxLength:"35" doc:"Language tag (e.g. fr, pt-BR)"`
+}
+
+// DeleteReleaseNoteOutput is the response for deleting the note of a release in a locale.
+type DeleteReleaseNoteOutput struct {
+	Body ApiResponse[emptyData]
+}
+
+// GetChangelogInput is the request for the changelog of a channel.
+type GetChangelogInput struct {
+	AppID           uuid.UUID                 `path:"app_id" doc:"Application ID"`
+	Environment     domain.ReleaseEnvironment `query:"environment" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Release channel, the application's default channel when omitted"`
+	FromVersionCode int32                     `query:"from_version_code" required:"true" minimum:"0" doc:"Version code installed; releases above it are included"`
+	ToVersionCode   int32                     `query:"to_version_code" minimum:"0" doc:"Highest version code included, the newest release when omitted"`
+	Locale          string                    `query:"locale" maxLength:"35" doc:"Language of the notes (e.g. pt-BR), the default notes when omitted"`
+}
+
+// GetChangelogOutput is the response for the changelog of a channel.
+type GetChangelogOutput struct {
+	Body ApiResponse[ChangelogResponse]
+}
+
+// ChangelogResponse represents a changelog in API responses.
+type ChangelogResponse struct {
+	Environment     domain.ReleaseEnvironment `json:"environment" doc:"Release channel"`
+	FromVersionCode int32                     `json:"from_version_code" doc:"Releases above this version code are included"`
+	ToVersionCode   int32                     `json:"to_version_code,omitempty" doc:"Highest version code included, absent up to the newest release"`
+	Truncated       bool                      `json:"truncated" doc:"Whether older releases were left out because the range holds too many"`
+	Entries         []ChangelogEntryResponse  `json:"entries" doc:"Releases in the range, newest first"`
+	Markdown        string                    `json:"markdown" doc:"The changelog as one Markdown document, a section per release"`
+	HTML            string                    `json:"html" doc:"The Markdown document rendered to sanitized HTML"`
+}
+
+// ChangelogEntryResponse represents one release of a changelog.
+type ChangelogEntryResponse struct {
+	ReleaseID   uuid.UUID  `json:"release_id" doc:"Release ID"`
+	VersionCode int32      `json:"version_code" doc:"Numeric version code"`
+	VersionName string     `json:"version_name" doc:"Version name"`
+	PublishedAt *time.Time `json:"published_at,omitempty" doc:"When the release was first published"`
+	Note        string     `json:"note" doc:"Notes in Markdown"`
+	NoteHTML    string     `json:"note_html" doc:"Notes rendered to sanitized HTML"`
+	NoteLocale  string     `json:"note_locale,omitempty" doc:"Language of the notes, absent for the default notes"`
+}
+
+// ========== Handlers ==========
+
+func (h *ReleaseNoteHandler) listNotes(ctx context.Context, input *ListReleaseNotesInput) (*ListReleaseNotesOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	notes, err := h.noteService.List(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	res := make([]ReleaseNoteResponse, len(notes))
+	for i, n := range notes {
+		res[i] = toReleaseNoteResponse(n)
+	}
+
+	return &ListReleaseNotesOutput{
+		Body: ok("Release notes retrieved successfully", res),
+	}, nil
+}
+
+func (h *ReleaseNoteHandler) setNote(ctx context.Context, input *SetReleaseNoteInput) (*ReleaseNoteOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	note, err := h.noteService.Set(ctx, authUser.ID, input.ID, input.Locale, input.Body.Note)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &ReleaseNoteOutput{
+		Body: ok("Release note saved successfully", toReleaseNoteResponse(note)),
+	}, nil
+}
+
+func (h *ReleaseNoteHandler) deleteNote(ctx context.Context, input *DeleteReleaseNoteInput) (*DeleteReleaseNoteOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	if err := h.noteService.Delete(ctx, authUser.ID, input.ID, input.Locale); err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &DeleteReleaseNoteOutput{
+		Body: ok("Release note deleted successfully", emptyData{}),
+	}, nil
+}
+
+func (h *ReleaseNoteHandler) getChangelog(ctx context.Context, input *GetChangelogInput) (*GetChangelogOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	versions := domain.ChangelogRange{From: input.FromVersionCode, To: input.ToVersionCode}
+	changelog, err := h.noteService.Changelog(ctx, authUser.ID, input.AppID, input.Environment, versions, input.Locale)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	entries := make([]ChangelogEntryResponse, len(changelog.Entries))
+	for i, e := range changelog.Entries {
+		entries[i] = ChangelogEntryResponse{
+			ReleaseID:   e.Release.ID,
+			VersionCode: e.Release.VersionCode,
+			VersionName: e.Release.VersionName,
+			PublishedAt: e.Release.PublishedAt,
+			Note:        e.Note.Note,
+			NoteHTML:    markdown.ToHTML(e.Note.Note),
+			NoteLocale:  e.Note.Locale,
+		}
+	}
+	doc := changelog.Markdown()
+
+	return &GetChangelogOutput{
+		Body: ok("Changelog retrieved successfully", ChangelogResponse{
+			Environment:     changelog.Environment,
+			FromVersionCode: changelog.Range.From,
+			ToVersionCode:   changelog.Range.To,
+			Truncated:       changelog.Truncated,
+			Entries:         entries,
+			Markdown:        doc,
+			HTML:            markdown.ToHTML(doc),
+		}),
+	}, nil
+}
+
+// ========== Helpers ==========
+
+func toReleaseNoteResponse(n *domain.ReleaseNote) ReleaseNoteResponse {
+	return ReleaseNoteResponse{
+		Locale:    n.Locale,
+		Note:      n.Note,
+		NoteHTML:  markdown.ToHTML(n.Note),
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
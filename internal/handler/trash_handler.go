@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// TrashHandler handles listing and restoring deleted projects and their content.
+type TrashHandler struct {
+	trashService *service.TrashService
+}
+
+// NewTrashHandler creates a new TrashHandler.
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// Register registers trash routes with the API.
+func (h *TrashHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-project-trash",
+		Method:      http.MethodGet,
+		Path:        "/projects/{project_id}/trash",
+		Summary:     "Get Project Trash",
+		Description: "List the deleted applications, releases and artifacts of a project, most recently deleted first. They can be restored until the retention window passes and they are purged.",
+		Tags:        []string{"Trash"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.getTrash)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-deleted-projects",
+		Method:      http.MethodGet,
+		Path:        "/trash/projects",
+		Summary:     "List Deleted Projects",
+		Description: "List the deleted projects the caller can restore, the ones they own and those of the organizations they own, most recently deleted first.",
+		Tags:        []string{"Trash"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.listDeletedProjects)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-project",
+		Method:      http.MethodPost,
+		Path:        "/projects/{id}/restore",
+		Summary:     "Restore Project",
+		Description: "Restore a deleted project, along with the applications, releases and artifacts deleted with it. Its organization must have room for one more project.",
+		Tags:        []string{"Trash"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.restoreProject)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-application",
+		Method:      http.MethodPost,
+		Path:        "/applications/{id}/restore",
+		Summary:     "Restore Application",
+		Description: "Restore a deleted application, along with the releases and artifacts deleted with it.",
+		Tags:        []string{"Trash"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.restoreApplication)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-release",
+		Method:      http.MethodPost,
+		Path:        "/releases/{id}/restore",
+		Summary:     "Restore Release",
+		Description: "Restore a deleted release, along with the artifacts deleted with it. Its application must not be deleted.",
+		Tags:        []string{"Trash"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.restoreRelease)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-artifact",
+		Method:      http.MethodPost,
+		Path:        "/artifacts/{id}/restore",
+		Summary:     "Restore Artifact",
+		Description: "Restore a deleted artifact. Its release must not be deleted.",
+		Tags:        []string{"Trash"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, h.restoreArtifact)
+}
+
+// ========== Request/Response Types ==========
+
+// TrashResponse represents the deleted content of a project.
+type TrashResponse struct {
+	Applications []ApplicationResponse `json:"applications" doc:"Deleted applications"`
+	Releases     []ReleaseResponse     `json:"releases" doc:"Deleted releases"`
+	Artifacts    []domain.Artifact     `json:"artifacts" doc:"Deleted artifacts"`
+}
+
+// GetTrashInput is the request for listing a project's trash.
+type GetTrashInput struct {
+	ProjectID uuid.UUID `path:"project_id" doc:"Project ID"`
+	Limit     int32     `query:"limit" default:"50" minimum:"1" maximum:"100" doc:"Maximum number of items of each kind"`
+}
+
+// GetTrashOutput is the response for listing a project's trash.
+type GetTrashOutput struct {
+	Body ApiResponse[TrashResponse]
+}
+
+// ListDeletedProjectsInput is the request for listing deleted projects.
+type ListDeletedProjectsInput struct {
+	Limit int32 `query:"limit" default:"50" minimum:"1" maximum:"100" doc:"Maximum number of projects"`
+}
+
+// ListDeletedProjectsOutput is the response for listing deleted projects.
+type ListDeletedProjectsOutput struct {
+	Body ApiResponse[[]ProjectResponse]
+}
+
+// RestoreInput is the request for restoring a deleted item.
+type RestoreInput struct {
+	ID uuid.UUID `path:"id" doc:"ID of the deleted item"`
+}
+
+// RestoreProjectOutput is the response for restoring a project.
+type RestoreProjectOutput struct {
+	Body ApiResponse[ProjectResponse]
+}
+
+// RestoreApplicationOutput is the response for restoring an application.
+type RestoreApplicationOutput struct {
+	Body ApiResponse[ApplicationResponse]
+}
+
+// RestoreReleaseOutput is the response for restoring a release.
+type RestoreReleaseOutput struct {
+	Body ApiResponse[ReleaseResponse]
+}
+
+// RestoreArtifactOutput is the response for restoring an artifact.
+type RestoreArtifactOutput struct {
+	Body ApiResponse[domain.Artifact]
+}
+
+// ========== Handlers ==========
+
+func (h *TrashHandler) getTrash(ctx context.Context, input *GetTrashInput) (*GetTrashOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	trash, err := h.trashService.List(ctx, authUser.ID, input.ProjectID, input.Limit)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	res := TrashResponse{
+		Applications: make([]ApplicationResponse, len(trash.Applications)),
+		Releases:     make([]ReleaseResponse, len(trash.Releases)),
+		Artifacts:    make([]domain.Artifact, len(trash.Artifacts)),
+	}
+	for i, app := range trash.Applications {
+		res.Applications[i] = toApplicationResponse(app)
+	}
+	for i, release := range trash.Releases {
+		res.Releases[i] = toReleaseResponse(release)
+	}
+	for i, artifact := range trash.Artifacts {
+		res.Artifacts[i] = *artifact
+	}
+
+	return &GetTrashOutput{
+		Body: ok("Trash retrieved successfully", res),
+	}, nil
+}
+
+func (h *TrashHandler) listDeletedProjects(ctx context.Context, input *ListDeletedProjectsInput) (*ListDeletedProjectsOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	projects, err := h.trashService.ListProjects(ctx, authUser.ID, input.Limit)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	res := make([]ProjectResponse, len(projects))
+	for i, p := range projects {
+		res[i] = toProjectResponse(p)
+	}
+
+	return &ListDeletedProjectsOutput{
+		Body: ok("Deleted projects retrieved successfully", res),
+	}, nil
+}
+
+func (h *TrashHandler) restoreProject(ctx context.Context, input *RestoreInput) (*RestoreProjectOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	project, err := h.trashService.RestoreProject(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RestoreProjectOutput{
+		Body: ok("Project restored successfully", toProjectResponse(project)),
+	}, nil
+}
+
+func (h *TrashHandler) restoreApplication(ctx context.Context, input *RestoreInput) (*RestoreApplicationOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	app, err := h.trashService.RestoreApplication(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RestoreApplicationOutput{
+		Body: ok("Application restored successfully", toApplicationResponse(app)),
+	}, nil
+}
+
+func (h *TrashHandler) restoreRelease(ctx context.Context, input *RestoreInput) (*RestoreReleaseOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	release, err := h.trashService.RestoreRelease(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RestoreReleaseOutput{
+		Body: ok("Release restored successfully", toReleaseResponse(release)),
+	}, nil
+}
+
+func (h *TrashHandler) restoreArtifact(ctx context.Context, input *RestoreInput) (*RestoreArtifactOutput, error) {
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	artifact, err := h.trashService.RestoreArtifact(ctx, authUser.ID, input.ID)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &RestoreArtifactOutput{
+		Body: ok("Artifact restored successfully", *artifact),
+	}, nil
+}
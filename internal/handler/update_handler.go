@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/markdown"
+	"github.com/bsrodrigue/appshare-backend/internal/service"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+)
+
+// UpdateHandler handles the update checks of client SDKs.
+type UpdateHandler struct {
+	updateService *service.UpdateService
+}
+
+// NewUpdateHandler creates a new UpdateHandler.
+func NewUpdateHandler(updateService *service.UpdateService) *UpdateHandler {
+	return &UpdateHandler{updateService: updateService}
+}
+
+// Register registers update check routes with the API. They are public and
+// authenticated by application API key rather than by user.
+func (h *UpdateHandler) Register(api huma.API) {
+	huma.Register(api, huma.Operation{
+		OperationID: "sdk-check-update",
+		Method:      http.MethodGet,
+		Path:        "/v1/apps/{package_name}/updates",
+		Summary:     "Check for Update (SDK)",
+		Description: "Tell the client SDK of an application whether a newer build than the installed one is offered to the device, with a short-lived download link to the artifact matching its ABI. " +
+			"Staged rollouts are applied by device ID; devices without one only get fully rolled-out releases. " +
+			"The update is mandatory when the installed build is below the channel's minimum supported version code, or when it is or skips a mandatory release. " +
+			"Responses carry an ETag: send it back in If-None-Match to get a 304 while nothing changed.",
+		Tags:     []string{"SDK"},
+		Security: []map[string][]string{{"apiKey": {}}},
+	}, h.checkUpdate)
+}
+
+// ========== Request/Response Types ==========
+
+// UpdateCheckInput is the request for an SDK update check.
+type UpdateCheckInput struct {
+	PackageName string                    `path:"package_name" maxLength:"255" doc:"Package name of the application (e.g. com.example.app)"`
+	VersionCode int32                     `query:"version_code" required:"true" minimum:"0" doc:"Version code of the installed build"`
+	Env         domain.ReleaseEnvironment `query:"env" maxLength:"40" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Release channel, the application's default channel when omitted"`
+	ABI         string                    `query:"abi" maxLength:"50" doc:"Device ABI (e.g. arm64-v8a); universal artifacts are used when none matches"`
+	DeviceID    string                    `query:"device_id" maxLength:"200" doc:"Stable device identifier used for staged rollouts"`
+	Locale      string                    `query:"locale" maxLength:"35" doc:"Language of the device (e.g. pt-BR); release notes fall back to the language, then to the default notes"`
+	APIKey      string                    `header:"X-Api-Key" doc:"API key of the application"`
+	IfNoneMatch string                    `header:"If-None-Match" doc:"ETag of a previous response"`
+}
+
+// UpdateCheckOutput is the response for an SDK update check.
+type UpdateCheckOutput struct {
+	ETag         string `header:"ETag" doc:"Identifies the answer, for If-None-Match"`
+	CacheControl string `header:"Cache-Control"`
+	Body         ApiResponse[UpdateResponse]
+}
+
+// UpdateResponse tells a client whether an update is available.
+type UpdateResponse struct {
+	UpdateAvailable bool                      `json:"update_available" doc:"Whether a newer build is offered to the device"`
+	Environment     domain.ReleaseEnvironment `json:"environment" doc:"Release channel checked"`
+	Release         *UpdateReleaseResponse    `json:"release,omitempty" doc:"The build offered, when an update is available"`
+	Download        *UpdateDownloadResponse   `json:"download,omitempty" doc:"Where to download the build, when an update is available"`
+
+	Mandatory               bool  `json:"mandatory" doc:"Whether the update must be installed: the installed build is no longer supported, or the update is or skips a mandatory release"`
+	Supported               bool  `json:"supported" doc:"Whether the installed build is at or above the channel's minimum supported version code"`
+	MinSupportedVersionCode int32 `json:"min_supported_version_code" doc:"Minimum supported version code of the channel, 0 when every build is supported"`
+}
+
+// UpdateReleaseResponse describes the build offered to a client.
+type UpdateReleaseResponse struct {
+	ID          uuid.UUID  `json:"id" doc:"Release unique ID"`
+	Title       string     `json:"title" doc:"Release title"`
+	VersionCode int32      `json:"version_code" doc:"Numeric version code"`
+	VersionName string     `json:"version_name" doc:"Version name"`
+	Mandatory   bool       `json:"mandatory" doc:"Whether the release itself is mandatory"`
+	ReleaseNote string     `json:"release_note" doc:"Notes of the release in Markdown, in the device's language when translated"`
+	NoteHTML    string     `json:"release_note_html" doc:"Notes rendered to sanitized HTML"`
+	NoteLocale  string     `json:"release_note_locale,omitempty" doc:"Language of the notes, absent for the default notes"`
+	PublishedAt *time.Time `json:"published_at,omitempty" doc:"When the release was first published"`
+}
+
+// UpdateDownloadResponse is where to download the build offered to a client.
+type UpdateDownloadResponse struct {
+	URL       string     `json:"url" doc:"Download link"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" doc:"When the link stops working; check again for a new one"`
+	SHA256    string     `json:"sha256" doc:"SHA-256 of the file, to check the download"`
+	FileSize  int64      `json:"file_size" doc:"File size in bytes"`
+	ABI       *string    `json:"abi,omitempty" doc:"ABI of the artifact, absent for universal builds"`
+}
+
+// ========== Handlers ==========
+
+func (h *UpdateHandler) checkUpdate(ctx context.Context, input *UpdateCheckInput) (*UpdateCheckOutput, error) {
+	info, err := h.updateService.Check(ctx, input.APIKey, domain.UpdateCheckInput{
+		PackageName: input.PackageName,
+		VersionCode: input.VersionCode,
+		Environment: input.Env,
+		ABI:         input.ABI,
+		DeviceID:    input.DeviceID,
+		Locale:      input.Locale,
+	})
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	etag := info.ETag()
+	if etagMatches(input.IfNoneMatch, etag) {
+		headers := http.Header{}
+		headers.Set("ETag", etag)
+		return nil, huma.ErrorWithHeaders(huma.Status304NotModified(), headers)
+	}
+
+	res := UpdateResponse{
+		UpdateAvailable: info.Available(),
+		Environment:     info.Environment,
+
+		Mandatory:               info.Mandatory,
+		Supported:               info.Supported,
+		MinSupportedVersionCode: info.MinSupportedVersionCode,
+	}
+	if info.Available() {
+		res.Release = &UpdateReleaseResponse{
+			ID:          info.Release.ID,
+			Title:       info.Release.Title,
+			VersionCode: info.Release.VersionCode,
+			VersionName: info.Release.VersionName,
+			Mandatory:   info.Release.Mandatory,
+			ReleaseNote: info.Note.Note,
+			NoteHTML:    markdown.ToHTML(info.Note.Note),
+			NoteLocale:  info.Note.Locale,
+			PublishedAt: info.Release.PublishedAt,
+		}
+		res.Download = &UpdateDownloadResponse{
+			URL:       info.DownloadURL,
+			ExpiresAt: info.DownloadExpiresAt,
+			SHA256:    info.Artifact.SHA256,
+			FileSize:  info.Artifact.FileSize,
+			ABI:       info.Artifact.ABI,
+		}
+	}
+
+	message := "No update available"
+	if res.UpdateAvailable {
+		message = "Update available"
+	}
+	return &UpdateCheckOutput{
+		ETag:         etag,
+		CacheControl: "no-cache",
+		Body:         ok(message, res),
+	}, nil
+}
+
+// ========== Helpers ==========
+
+// etagMatches reports whether an If-None-Match header lists the given ETag,
+// using the weak comparison RFC 9110 prescribes for it.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/avatar"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/bsrodrigue/appshare-backend/internal/service"
 	"github.com/danielgtaylor/huma/v2"
@@ -13,12 +15,13 @@ import (
 
 // UserHandler handles user-related HTTP requests.
 type UserHandler struct {
-	userService *service.UserService
+	userService  *service.UserService
+	adminService *service.AdminService
 }
 
 // NewUserHandler creates a new UserHandler.
-func NewUserHandler(userService *service.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService *service.UserService, adminService *service.AdminService) *UserHandler {
+	return &UserHandler{userService: userService, adminService: adminService}
 }
 
 // Register registers all user routes with the API.
@@ -28,7 +31,7 @@ func (h *UserHandler) Register(api huma.API) {
 		Method:      http.MethodGet,
 		Path:        "/users",
 		Summary:     "List Users",
-		Description: "Retrieve a list of all active users.",
+		Description: "Retrieve a list of all users. Requires administrator access.",
 		Tags:        []string{"Users"},
 	}, h.listUsers)
 
@@ -46,7 +49,7 @@ func (h *UserHandler) Register(api huma.API) {
 		Method:      http.MethodPost,
 		Path:        "/users",
 		Summary:     "Create User",
-		Description: "Create a new user account.",
+		Description: "Create a new user account. Requires administrator access.",
 		Tags:        []string{"Users"},
 	}, h.createUser)
 
@@ -55,7 +58,7 @@ func (h *UserHandler) Register(api huma.API) {
 		Method:      http.MethodPatch,
 		Path:        "/users/{id}/profile",
 		Summary:     "Update User Profile",
-		Description: "Update a user's profile (first name, last name).",
+		Description: "Update a user's profile (first name, last name). Only the user themselves or an administrator may do this.",
 		Tags:        []string{"Users"},
 	}, h.updateProfile)
 
@@ -64,7 +67,7 @@ func (h *UserHandler) Register(api huma.API) {
 		Method:      http.MethodDelete,
 		Path:        "/users/{id}",
 		Summary:     "Delete User",
-		Description: "Soft delete a user account.",
+		Description: "Delete a user account. Requires administrator access. Refused while the user owns projects; the user is emailed a link to restore the account until the grace period ends.",
 		Tags:        []string{"Users"},
 	}, h.deleteUser)
 }
@@ -80,6 +83,8 @@ type UserResponse struct {
 	FirstName   string     `json:"first_name"`
 	LastName    string     `json:"last_name"`
 	IsActive    bool       `json:"is_active"`
+	IsAdmin     bool       `json:"is_admin"`
+	AvatarURL   string     `json:"avatar_url" doc:"Profile picture (256px, with 128.png and 64.png alongside), or an initials placeholder as a data URI"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
@@ -95,12 +100,30 @@ func toUserResponse(u *domain.User) UserResponse {
 		FirstName:   u.FirstName,
 		LastName:    u.LastName,
 		IsActive:    u.IsActive,
+		IsAdmin:     u.IsAdmin,
+		AvatarURL:   avatarURL(u),
 		CreatedAt:   u.CreatedAt,
 		UpdatedAt:   u.UpdatedAt,
 		LastLoginAt: u.LastLoginAt,
 	}
 }
 
+// avatarURL returns the user's avatar, or a generated initials placeholder.
+func avatarURL(u *domain.User) string {
+	if u.AvatarURL != "" {
+		return u.AvatarURL
+	}
+	return avatar.InitialsDataURI(u.FirstName, u.LastName, u.ID.String())
+}
+
+// ListUsersInput is the request for listing users.
+type ListUsersInput struct {
+	PageQuery
+	Sort   string `query:"sort" enum:"-created_at,created_at" default:"-created_at" doc:"Sort order, newest first by default"`
+	Search string `query:"q" maxLength:"100" doc:"Only return users whose email or username contains this text"`
+	Status string `query:"status" enum:"active,inactive" doc:"Only return active or inactive users"`
+}
+
 // ListUsersOutput is the response for listing users.
 type ListUsersOutput struct {
 	Body ApiResponse[[]UserResponse]
@@ -122,7 +145,7 @@ type CreateUserInput struct {
 		Email       string `json:"email" required:"true" doc:"User email address"`
 		Username    string `json:"username" required:"true" doc:"Unique username"`
 		PhoneNumber string `json:"phone_number" required:"true" doc:"Phone number with country code"`
-		Password    string `json:"password" required:"true" minLength:"8" doc:"Password (min 8 characters)"`
+		Password    string `json:"password" required:"true" doc:"Password (must satisfy the password policy)"`
 		FirstName   string `json:"first_name" required:"true" doc:"First name"`
 		LastName    string `json:"last_name" required:"true" doc:"Last name"`
 	}
@@ -159,19 +182,34 @@ type DeleteUserOutput struct {
 
 // ========== Handlers ==========
 
-func (h *UserHandler) listUsers(ctx context.Context, input *struct{}) (*ListUsersOutput, error) {
-	users, err := h.userService.List(ctx)
+func (h *UserHandler) listUsers(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error) {
+	if _, err := adminActor(ctx); err != nil {
+		return nil, err
+	}
+
+	page, err := pageRequest(input.PageQuery, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.UserFilter{Search: input.Search}
+	if input.Status != "" {
+		isActive := input.Status == "active"
+		filter.IsActive = &isActive
+	}
+
+	users, err := h.adminService.ListUsers(ctx, filter, page)
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
 
-	response := make([]UserResponse, len(users))
-	for i, u := range users {
+	response := make([]UserResponse, len(users.Items))
+	for i, u := range users.Items {
 		response[i] = toUserResponse(u)
 	}
 
 	return &ListUsersOutput{
-		Body: ok("Users retrieved successfully", response),
+		Body: okPage("Users retrieved successfully", response, users.NextCursor),
 	}, nil
 }
 
@@ -192,7 +230,12 @@ func (h *UserHandler) getUser(ctx context.Context, input *GetUserInput) (*GetUse
 }
 
 func (h *UserHandler) createUser(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, error) {
-	user, err := h.userService.Create(ctx, domain.CreateUserInput{
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.adminService.CreateUser(ctx, actor, domain.CreateUserInput{
 		Email:       input.Body.Email,
 		Username:    input.Body.Username,
 		PhoneNumber: input.Body.PhoneNumber,
@@ -215,7 +258,22 @@ func (h *UserHandler) updateProfile(ctx context.Context, input *UpdateProfileInp
 		return nil, huma.Error400BadRequest("invalid user ID format")
 	}
 
-	user, err := h.userService.UpdateProfile(ctx, id, input.Body.FirstName, input.Body.LastName)
+	authUser := auth.UserFromContext(ctx)
+	if authUser == nil {
+		return nil, mapDomainError(domain.ErrUnauthorized)
+	}
+
+	var user *domain.User
+	if authUser.ID == id {
+		user, err = h.userService.UpdateProfile(ctx, id, input.Body.FirstName, input.Body.LastName)
+	} else {
+		// Editing someone else's profile is an audited administrative action.
+		actor, actorErr := adminActor(ctx)
+		if actorErr != nil {
+			return nil, actorErr
+		}
+		user, err = h.adminService.UpdateProfile(ctx, actor, id, input.Body.FirstName, input.Body.LastName)
+	}
 	if err != nil {
 		return nil, mapDomainError(err)
 	}
@@ -226,12 +284,17 @@ func (h *UserHandler) updateProfile(ctx context.Context, input *UpdateProfileInp
 }
 
 func (h *UserHandler) deleteUser(ctx context.Context, input *DeleteUserInput) (*DeleteUserOutput, error) {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	id, err := uuid.Parse(input.ID)
 	if err != nil {
 		return nil, huma.Error400BadRequest("invalid user ID format")
 	}
 
-	if err := h.userService.Delete(ctx, id); err != nil {
+	if err := h.adminService.DeleteUser(ctx, actor, id); err != nil {
 		return nil, mapDomainError(err)
 	}
 
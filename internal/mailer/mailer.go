@@ -0,0 +1,16 @@
+package mailer
+
+import "context"
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer defines the interface for sending transactional emails.
+type Mailer interface {
+	// Send delivers a message to its recipient.
+	Send(ctx context.Context, msg Message) error
+}
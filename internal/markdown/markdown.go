@@ -0,0 +1,164 @@
+// Package markdown renders the Markdown of release notes to HTML that is safe
+// to embed in web pages and app screens.
+//
+// Only a subset is supported: headings, paragraphs, flat bullet and numbered
+// lists, block quotes, fenced code blocks, horizontal rules, and inline
+// emphasis, code spans and links. All other input, raw HTML included, is
+// escaped and shown as text, and links are limited to http, https and mailto.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	bulletPattern    = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	numberedPattern  = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	rulePattern      = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	quotePattern     = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	fencePattern     = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern      = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	italicStar       = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*`)
+	italicUnderscore = regexp.MustCompile(`(^|[^\w])_(\S(?:.*?\S)?)_([^\w]|$)`)
+)
+
+// ToHTML renders Markdown to sanitized HTML.
+func ToHTML(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var out []string
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			out = append(out, "<p>"+inline(strings.Join(paragraph, "\n"))+"</p>")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case fencePattern.MatchString(line):
+			flush()
+			fence := fencePattern.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			out = append(out, "<pre><code>"+html.EscapeString(strings.Join(code, "\n"))+"</code></pre>")
+
+		case headingPattern.MatchString(line):
+			flush()
+			m := headingPattern.FindStringSubmatch(line)
+			out = append(out, fmt.Sprintf("<h%d>%s</h%d>", len(m[1]), inline(m[2]), len(m[1])))
+
+		case rulePattern.MatchString(line):
+			flush()
+			out = append(out, "<hr>")
+
+		case bulletPattern.MatchString(line), numberedPattern.MatchString(line):
+			flush()
+			pattern, tag := bulletPattern, "ul"
+			if !bulletPattern.MatchString(line) {
+				pattern, tag = numberedPattern, "ol"
+			}
+			var items []string
+			for ; i < len(lines) && pattern.MatchString(lines[i]); i++ {
+				items = append(items, "<li>"+inline(pattern.FindStringSubmatch(lines[i])[1])+"</li>")
+			}
+			i--
+			out = append(out, "<"+tag+">\n"+strings.Join(items, "\n")+"\n</"+tag+">")
+
+		case quotePattern.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			out = append(out, "<blockquote>\n"+ToHTML(strings.Join(quoted, "\n"))+"\n</blockquote>")
+
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flush()
+
+	return strings.Join(out, "\n")
+}
+
+// inline renders the inline markup of a block. Code spans are split out first
+// so that their content is shown verbatim.
+func inline(text string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "`")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start+1:], "`")
+		if end < 0 {
+			break
+		}
+		b.WriteString(emphasis(text[:start]))
+		b.WriteString("<code>" + html.EscapeString(text[start+1:start+1+end]) + "</code>")
+		text = text[start+1+end+1:]
+	}
+	b.WriteString(emphasis(text))
+	return b.String()
+}
+
+// emphasis escapes text, then renders links, bold and italics. Link targets
+// are kept out of the emphasis pass so that their characters stay intact.
+func emphasis(text string) string {
+	text = html.EscapeString(text)
+
+	var b strings.Builder
+	for {
+		loc := linkPattern.FindStringSubmatchIndex(text)
+		if loc == nil {
+			break
+		}
+		label, url := text[loc[2]:loc[3]], text[loc[4]:loc[5]]
+		b.WriteString(styles(text[:loc[0]]))
+		if safeURL(url) {
+			b.WriteString(`<a href="` + url + `" rel="nofollow noopener">` + styles(label) + `</a>`)
+		} else {
+			b.WriteString(styles(label))
+		}
+		text = text[loc[1]:]
+	}
+	b.WriteString(styles(text))
+	return b.String()
+}
+
+// styles renders bold and italics in escaped text.
+func styles(text string) string {
+	text = boldPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := boldPattern.FindStringSubmatch(m)
+		return "<strong>" + parts[1] + parts[2] + "</strong>"
+	})
+	text = italicStar.ReplaceAllString(text, "<em>$1</em>")
+	return italicUnderscore.ReplaceAllString(text, "$1<em>$2</em>$3")
+}
+
+// safeURL reports whether a link target uses a scheme that cannot run script.
+// The target is already HTML-escaped, so it cannot break out of the attribute.
+func safeURL(url string) bool {
+	lower := strings.ToLower(url)
+	for _, scheme := range []string{"https://", "http://", "mailto:"} {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return false
+}
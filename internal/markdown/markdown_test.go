@@ -0,0 +1,40 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML_Blocks(t *testing.T) {
+	src := "## What's new\n\n- Dark mode\n- Faster **sync**\n\n1. Update\n2. Restart\n\nFixed a crash\non launch.\n\n---\n\n> Thanks to our testers\n\n```\nif a < b {}\n```"
+	want := "<h2>What&#39;s new</h2>\n" +
+		"<ul>\n<li>Dark mode</li>\n<li>Faster <strong>sync</strong></li>\n</ul>\n" +
+		"<ol>\n<li>Update</li>\n<li>Restart</li>\n</ol>\n" +
+		"<p>Fixed a crash\non launch.</p>\n" +
+		"<hr>\n" +
+		"<blockquote>\n<p>Thanks to our testers</p>\n</blockquote>\n" +
+		"<pre><code>if a &lt; b {}</code></pre>"
+	assert.Equal(t, want, ToHTML(src))
+}
+
+func TestToHTML_Inline(t *testing.T) {
+	assert.Equal(t, "<p><em>new</em> and <em>improved</em>, <code>**raw**</code></p>", ToHTML("*new* and _improved_, `**raw**`"))
+	assert.Equal(t, "<p>keeps snake_case_names</p>", ToHTML("keeps snake_case_names"))
+	assert.Equal(t, `<p>See <a href="https://example.com/a_b_/?x=1&amp;y=2" rel="nofollow noopener">the <strong>docs</strong></a></p>`,
+		ToHTML("See [the **docs**](https://example.com/a_b_/?x=1&y=2)"))
+}
+
+func TestToHTML_Sanitizes(t *testing.T) {
+	assert.Equal(t, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>", ToHTML("<script>alert(1)</script>"))
+	assert.Equal(t, "<p>click</p>", ToHTML("[click](javascript:alert%281%29)"))
+	assert.Equal(t, "<p>click</p>", ToHTML("[click](JavaScript:void)"))
+	assert.Equal(t, `<p><a href="https://x.test/&#34;onmouseover=&#34;alert" rel="nofollow noopener">x</a></p>`,
+		ToHTML(`[x](https://x.test/"onmouseover="alert)`))
+	assert.Equal(t, "<h1>&lt;img src=x onerror=alert(1)&gt;</h1>", ToHTML("# <img src=x onerror=alert(1)>"))
+}
+
+func TestToHTML_Empty(t *testing.T) {
+	assert.Equal(t, "", ToHTML(""))
+	assert.Equal(t, "", ToHTML("\n  \n"))
+}
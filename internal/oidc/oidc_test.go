@@ -0,0 +1,212 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIdP is a minimal OpenID provider serving discovery, JWKS and a token endpoint.
+type stubIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// Values checked by / returned from the token endpoint
+	code      string
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{key: key}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client-id" || secret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		if r.FormValue("code") != idp.code || CodeChallengeS256(r.FormValue("code_verifier")) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *stubIdP) provider() *Provider {
+	return NewProvider(Config{
+		Name:           "stub",
+		IssuerURL:      idp.server.URL,
+		ClientID:       "client-id",
+		ClientSecret:   "client-secret",
+		RedirectURL:    "http://localhost:3000/sso/callback",
+		Scopes:         []string{"email", "profile"},
+		AllowedDomains: []string{"example.com"},
+	}, idp.server.Client())
+}
+
+func (idp *stubIdP) validClaims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"aud":            "client-id",
+		"sub":            "user-123",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := newStubIdP(t)
+	p := idp.provider()
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge-1")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "client-id", q.Get("client_id"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "challenge-1", q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	idp := newStubIdP(t)
+	p := idp.provider()
+	ctx := context.Background()
+
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+	idp.code = "auth-code"
+	idp.challenge = CodeChallengeS256(verifier)
+	idp.claims = idp.validClaims("nonce-1")
+
+	claims, err := p.Exchange(ctx, "auth-code", verifier, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+	assert.True(t, bool(claims.EmailVerified))
+	assert.Equal(t, "example.com", claims.EmailDomain())
+	assert.True(t, p.AllowsDomain(claims.EmailDomain()))
+
+	// Wrong PKCE verifier is rejected by the provider
+	_, err = p.Exchange(ctx, "auth-code", "wrong-verifier", "nonce-1")
+	assert.ErrorIs(t, err, ErrExchange)
+
+	// Nonce must match the one bound to the login attempt
+	_, err = p.Exchange(ctx, "auth-code", verifier, "other-nonce")
+	assert.ErrorIs(t, err, ErrNonceMismatch)
+}
+
+func TestProvider_VerifyIDToken_Rejections(t *testing.T) {
+	idp := newStubIdP(t)
+	p := idp.provider()
+	ctx := context.Background()
+
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		s, err := token.SignedString(idp.key)
+		require.NoError(t, err)
+		return s
+	}
+
+	claims := idp.validClaims("n")
+	claims["aud"] = "someone-else"
+	_, err := p.VerifyIDToken(ctx, sign(claims), "n")
+	assert.ErrorIs(t, err, ErrInvalidToken, "wrong audience")
+
+	claims = idp.validClaims("n")
+	claims["iss"] = "https://evil.example.com"
+	_, err = p.VerifyIDToken(ctx, sign(claims), "n")
+	assert.ErrorIs(t, err, ErrInvalidToken, "wrong issuer")
+
+	claims = idp.validClaims("n")
+	claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	_, err = p.VerifyIDToken(ctx, sign(claims), "n")
+	assert.ErrorIs(t, err, ErrInvalidToken, "expired")
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.validClaims("n"))
+	forged.Header["kid"] = "test-key"
+	forgedToken, err := forged.SignedString(otherKey)
+	require.NoError(t, err)
+	_, err = p.VerifyIDToken(ctx, forgedToken, "n")
+	assert.ErrorIs(t, err, ErrInvalidToken, "bad signature")
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.validClaims("n")).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = p.VerifyIDToken(ctx, hmacToken, "n")
+	assert.ErrorIs(t, err, ErrInvalidToken, "symmetric algorithm")
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AccountRestoreRepository defines the interface for account restore token data access.
+type AccountRestoreRepository interface {
+	// GetByHash retrieves a token by the hash of its raw value.
+	GetByHash(ctx context.Context, tokenHash string) (*domain.AccountRestoreToken, error)
+
+	// ========== Transaction Methods ==========
+
+	// CreateTx stores a new token hash for a deleted account within a transaction.
+	CreateTx(ctx context.Context, q *db.Queries, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*domain.AccountRestoreToken, error)
+
+	// MarkUsedTx consumes a token within a transaction.
+	// Returns domain.ErrNotFound if the token was already used.
+	MarkUsedTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
+
+	// InvalidateForUserTx consumes every outstanding token of a user within a transaction.
+	InvalidateForUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error
+}
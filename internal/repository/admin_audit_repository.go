@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AdminAuditRepository defines the interface for the administrative audit trail.
+type AdminAuditRepository interface {
+	// Create records an administrative action.
+	Create(ctx context.Context, input domain.CreateAdminAuditLogInput) (*domain.AdminAuditLog, error)
+
+	// List returns the most recent entries, newest first.
+	List(ctx context.Context, limit int32) ([]*domain.AdminAuditLog, error)
+
+	// ListByTarget returns the most recent entries about a user, newest first.
+	ListByTarget(ctx context.Context, targetUserID uuid.UUID, limit int32) ([]*domain.AdminAuditLog, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository defines the interface for application API key data access.
+type APIKeyRepository interface {
+	// Create records a new API key.
+	Create(ctx context.Context, input domain.CreateAPIKeyInput) (*domain.APIKey, error)
+
+	// GetByHash retrieves a live key of a live application by the hash of its raw value.
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+
+	// ListByApplication retrieves the keys of an application, revoked ones included, newest first.
+	ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.APIKey, error)
+
+	// Revoke revokes a live key of an application.
+	Revoke(ctx context.Context, appID, id uuid.UUID) (*domain.APIKey, error)
+
+	// Touch records that a key was used.
+	Touch(ctx context.Context, id uuid.UUID) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -19,8 +20,8 @@ type ApplicationRepository interface {
 	// GetByPackageName retrieves an application by its package name.
 	GetByPackageName(ctx context.Context, packageName string) (*domain.Application, error)
 
-	// ListByProject retrieves all applications belonging to a project.
-	ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Application, error)
+	// ListByProject retrieves a page of the applications belonging to a project, newest first by default.
+	ListByProject(ctx context.Context, projectID uuid.UUID, filter domain.ApplicationFilter, page domain.PageRequest) (*domain.Page[*domain.Application], error)
 
 	// Update updates an application's title and description.
 	Update(ctx context.Context, id uuid.UUID, title, description string) (*domain.Application, error)
@@ -31,8 +32,34 @@ type ApplicationRepository interface {
 	// PackageNameExists checks if a package name is already in use.
 	PackageNameExists(ctx context.Context, packageName string) (bool, error)
 
+	// ========== Trash ==========
+
+	// GetDeletedByID retrieves a soft-deleted application by its ID.
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Application, error)
+
+	// ListDeletedByProject retrieves the most recently deleted applications of a project.
+	ListDeletedByProject(ctx context.Context, projectID uuid.UUID, limit int32) ([]*domain.Application, error)
+
+	// ListPurgeable retrieves applications deleted before the cutoff whose releases are all purged.
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error)
+
+	// HardDelete permanently removes an application.
+	HardDelete(ctx context.Context, id uuid.UUID) error
+
 	// ========== Transaction Methods ==========
 
 	// CreateTx creates a new application within a transaction.
 	CreateTx(ctx context.Context, q *db.Queries, input domain.CreateApplicationInput) (*domain.Application, error)
+
+	// RestoreTx undoes the soft delete of an application within a transaction.
+	RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Application, error)
+
+	// RestoreDeletedWithProjectTx restores the applications deleted at the same time as their project.
+	RestoreDeletedWithProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID, deletedAt time.Time) error
+
+	// SoftDeleteTx marks an application as deleted within a transaction.
+	SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
+
+	// SoftDeleteByProjectTx marks the live applications of a project as deleted within a transaction.
+	SoftDeleteByProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID) error
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -16,14 +17,66 @@ type ArtifactRepository interface {
 	// GetByID retrieves an artifact by its ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Artifact, error)
 
-	// ListByRelease retrieves all artifacts for a release.
-	ListByRelease(ctx context.Context, releaseID uuid.UUID) ([]*domain.Artifact, error)
+	// ListByRelease retrieves a page of the artifacts of a release, newest first by default.
+	ListByRelease(ctx context.Context, releaseID uuid.UUID, filter domain.ArtifactFilter, page domain.PageRequest) (*domain.Page[*domain.Artifact], error)
+
+	// GetForABI retrieves the artifact of a release to install on a device with the
+	// given ABI, falling back to a universal one; any ABI matches when abi is empty.
+	GetForABI(ctx context.Context, releaseID uuid.UUID, abi string) (*domain.Artifact, error)
 
 	// Delete removes an artifact record.
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// MarkVerified records that the stored file matches the artifact's hash and size.
+	MarkVerified(ctx context.Context, id uuid.UUID) (*domain.Artifact, error)
+
+	// HasVerified checks if a release has at least one live verified artifact.
+	HasVerified(ctx context.Context, releaseID uuid.UUID) (bool, error)
+
+	// ========== Trash ==========
+
+	// GetDeletedByID retrieves a soft-deleted artifact by its ID.
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Artifact, error)
+
+	// ListDeletedByProject retrieves the most recently deleted artifacts of a project's releases.
+	ListDeletedByProject(ctx context.Context, projectID uuid.UUID, limit int32) ([]*domain.Artifact, error)
+
+	// ListPurgeable retrieves artifacts deleted before the cutoff, directly or with
+	// their release or application.
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]*domain.Artifact, error)
+
+	// CountOthersWithFileURL counts the other artifacts pointing to the same stored file.
+	CountOthersWithFileURL(ctx context.Context, fileURL string, id uuid.UUID) (int64, error)
+
+	// HardDelete permanently removes an artifact record.
+	HardDelete(ctx context.Context, id uuid.UUID) error
+
 	// ========== Transaction Methods ==========
 
 	// CreateTx creates a new artifact record within a transaction.
 	CreateTx(ctx context.Context, q *db.Queries, input domain.CreateArtifactInput) (*domain.Artifact, error)
+
+	// CopyToReleaseTx copies the live artifacts of a release to another release within a transaction.
+	CopyToReleaseTx(ctx context.Context, q *db.Queries, sourceReleaseID, targetReleaseID uuid.UUID) error
+
+	// RestoreTx undoes the soft delete of an artifact within a transaction.
+	RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Artifact, error)
+
+	// RestoreDeletedWithReleaseTx restores the artifacts deleted at the same time as their release.
+	RestoreDeletedWithReleaseTx(ctx context.Context, q *db.Queries, releaseID uuid.UUID, deletedAt time.Time) error
+
+	// RestoreDeletedWithApplicationTx restores the artifacts deleted at the same time as their application.
+	RestoreDeletedWithApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID, deletedAt time.Time) error
+
+	// RestoreDeletedWithProjectTx restores the artifacts deleted at the same time as their project.
+	RestoreDeletedWithProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID, deletedAt time.Time) error
+
+	// SoftDeleteByReleaseTx marks the live artifacts of a release as deleted within a transaction.
+	SoftDeleteByReleaseTx(ctx context.Context, q *db.Queries, releaseID uuid.UUID) error
+
+	// SoftDeleteByApplicationTx marks the live artifacts of an application's releases as deleted within a transaction.
+	SoftDeleteByApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID) error
+
+	// SoftDeleteByProjectTx marks the live artifacts of a project's releases as deleted within a transaction.
+	SoftDeleteByProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID) error
 }
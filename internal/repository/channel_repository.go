@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ChannelRepository defines the interface for release channel data access.
+type ChannelRepository interface {
+	// ListByApplication retrieves the channels of an application in display order.
+	ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.ReleaseChannel, error)
+
+	// GetByName retrieves a channel of an application by name.
+	GetByName(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error)
+
+	// GetDefault retrieves the channel uploads default to.
+	GetDefault(ctx context.Context, appID uuid.UUID) (*domain.ReleaseChannel, error)
+
+	// InUse checks if any release, trashed ones included, lives in the channel.
+	InUse(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) (bool, error)
+
+	// Delete removes a channel.
+	Delete(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) error
+
+	// ========== Transaction Methods ==========
+
+	// CreateTx creates a channel within a transaction.
+	CreateTx(ctx context.Context, q *db.Queries, input domain.CreateReleaseChannelInput) (*domain.ReleaseChannel, error)
+
+	// UpdateTx updates a channel's position, visibility and minimum supported
+	// version code within a transaction.
+	UpdateTx(ctx context.Context, q *db.Queries, appID uuid.UUID, name domain.ReleaseEnvironment, position int32, visibility domain.ChannelVisibility, minSupportedVersionCode int32) (*domain.ReleaseChannel, error)
+
+	// LockTx retrieves a channel and locks it until the transaction ends.
+	LockTx(ctx context.Context, q *db.Queries, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error)
+
+	// SetDefaultTx makes a channel the application's default within a transaction.
+	SetDefaultTx(ctx context.Context, q *db.Queries, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// EmailChangeRepository defines the interface for email change token data access.
+type EmailChangeRepository interface {
+	// GetByHash retrieves a token by the hash of its raw value.
+	GetByHash(ctx context.Context, tokenHash string) (*domain.EmailChangeToken, error)
+
+	// ========== Transaction Methods ==========
+
+	// CreateTx stores a new token hash for a pending email change within a transaction.
+	CreateTx(ctx context.Context, q *db.Queries, userID uuid.UUID, newEmail, tokenHash string, expiresAt time.Time) (*domain.EmailChangeToken, error)
+
+	// MarkUsedTx consumes a token within a transaction.
+	// Returns domain.ErrNotFound if the token was already used.
+	MarkUsedTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
+
+	// InvalidateForUserTx consumes every pending email change of a user within a transaction.
+	InvalidateForUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error
+}
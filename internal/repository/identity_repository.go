@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// IdentityRepository defines the interface for external identity data access.
+type IdentityRepository interface {
+	// GetByProviderSubject retrieves an identity by provider and subject.
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+
+	// HasAnyForUser reports whether a user has linked at least one external identity.
+	HasAnyForUser(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// RecordLogin updates the last login time and email of an identity.
+	RecordLogin(ctx context.Context, id uuid.UUID, email string) error
+
+	// ========== Transaction Methods ==========
+
+	// CreateTx links an external identity to a user within a transaction.
+	CreateTx(ctx context.Context, q *db.Queries, input domain.CreateUserIdentityInput) (*domain.UserIdentity, error)
+
+	// DeleteByUserTx unlinks every external identity of a user within a transaction.
+	DeleteByUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// LoginThrottleRepository defines the interface for login attempt and lockout data access.
+type LoginThrottleRepository interface {
+	// RecordAttempt stores a login attempt in the audit trail.
+	RecordAttempt(ctx context.Context, input domain.CreateLoginAttemptInput) error
+
+	// ListAttempts returns the most recent attempts for an identifier, newest first.
+	ListAttempts(ctx context.Context, identifier string, limit int32) ([]*domain.LoginAttempt, error)
+
+	// Get retrieves the throttle for a key.
+	// Returns domain.ErrNotFound if the key has no recorded failures.
+	Get(ctx context.Context, key string) (*domain.LoginThrottle, error)
+
+	// RecordFailure increments the failure counter of a key.
+	// The counter restarts when the previous failure happened before windowStart.
+	RecordFailure(ctx context.Context, key string, windowStart time.Time) (*domain.LoginThrottle, error)
+
+	// Lock refuses logins for a key until the given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+
+	// Delete clears the failures and any lockout of a key.
+	Delete(ctx context.Context, key string) error
+
+	// ListLocked returns every currently locked key.
+	ListLocked(ctx context.Context) ([]*domain.LoginThrottle, error)
+
+	// ========== Transaction Methods ==========
+
+	// DeleteAttemptsByUserTx removes the recorded login attempts of a user within a transaction.
+	DeleteAttemptsByUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error
+}
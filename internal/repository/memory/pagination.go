@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+)
+
+// paginate orders items by their keyset position and cuts the requested page,
+// mirroring the keyset queries of the PostgreSQL repositories.
+func paginate[T any](items []T, page domain.PageRequest, cursorOf func(T) domain.Cursor) *domain.Page[T] {
+	sort.Slice(items, func(i, j int) bool {
+		cmp := cursorOf(items[i]).Compare(cursorOf(items[j]))
+		if page.Ascending {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+
+	start := 0
+	if page.After != nil {
+		start = sort.Search(len(items), func(i int) bool {
+			cmp := cursorOf(items[i]).Compare(*page.After)
+			if page.Ascending {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+
+	end := min(len(items), start+int(page.Limit)+1)
+	return domain.NewPage(items[start:end], page.Limit, cursorOf)
+}
+
+// containsFold reports whether s contains substr, ignoring case, like ILIKE '%substr%'.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
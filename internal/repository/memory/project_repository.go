@@ -2,7 +2,7 @@ package memory
 
 import (
 	"context"
-	"sort"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,12 +15,14 @@ import (
 type ProjectRepository struct {
 	mu       sync.RWMutex
 	projects map[uuid.UUID]*domain.Project
+	deleted  map[uuid.UUID]*domain.Project
 }
 
 // NewProjectRepository creates a new in-memory project repository.
 func NewProjectRepository() *ProjectRepository {
 	return &ProjectRepository{
 		projects: make(map[uuid.UUID]*domain.Project),
+		deleted:  make(map[uuid.UUID]*domain.Project),
 	}
 }
 
@@ -29,6 +31,7 @@ func (r *ProjectRepository) Reset() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.projects = make(map[uuid.UUID]*domain.Project)
+	r.deleted = make(map[uuid.UUID]*domain.Project)
 }
 
 // ============================================================================
@@ -43,24 +46,58 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return r.GetByIDTx(ctx, nil, id)
 }
 
-func (r *ProjectRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
+func (r *ProjectRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.Project], error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	projects := make([]*domain.Project, 0)
 	for _, p := range r.projects {
-		if p.OwnerID == ownerID {
+		if p.OwnerID == ownerID && containsFold(p.Title, filter.Search) {
 			project := *p
 			projects = append(projects, &project)
 		}
 	}
 
-	// Sort by CreatedAt descending
-	sort.Slice(projects, func(i, j int) bool {
-		return projects[i].CreatedAt.After(projects[j].CreatedAt)
-	})
+	return paginate(projects, page, (*domain.Project).Cursor), nil
+}
 
-	return projects, nil
+// ListForUser lists owned projects only; memberships and applications are not tracked in memory.
+func (r *ProjectRepository) ListForUser(ctx context.Context, userID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.ProjectSummary], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summaries := make([]*domain.ProjectSummary, 0)
+	for _, p := range r.projects {
+		if p.OwnerID == userID && containsFold(p.Title, filter.Search) {
+			summaries = append(summaries, &domain.ProjectSummary{Project: *p, Role: domain.ProjectRoleOwner})
+		}
+	}
+
+	return paginate(summaries, page, (*domain.ProjectSummary).Cursor), nil
+}
+
+// GetAccess only knows owners; organizations and memberships are not tracked in memory.
+func (r *ProjectRepository) GetAccess(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectAccess, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.projects[projectID]
+	if !ok || p.OwnerID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.ProjectAccess{
+		Role: domain.ProjectRoleOwner,
+		Permissions: []string{
+			domain.PermissionProjectManage,
+			domain.PermissionApplicationCreate,
+			domain.PermissionApplicationUpdate,
+			domain.PermissionApplicationDelete,
+			domain.PermissionPackageUpload,
+			domain.PermissionPackageDownload,
+			domain.PermissionMemberInvite,
+			domain.PermissionMemberRemove,
+		},
+	}, nil
 }
 
 func (r *ProjectRepository) UpdateTitle(ctx context.Context, id uuid.UUID, title string) (*domain.Project, error) {
@@ -109,6 +146,21 @@ func (r *ProjectRepository) Update(ctx context.Context, id uuid.UUID, title, des
 	return &project, nil
 }
 
+func (r *ProjectRepository) UpdateSettings(ctx context.Context, id uuid.UUID, settings domain.ProjectSettings) (*domain.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.projects[id]
+	if !ok {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	p.Settings = settings
+	p.UpdatedAt = time.Now()
+	project := *p
+	return &project, nil
+}
+
 func (r *ProjectRepository) TransferOwnership(ctx context.Context, id, newOwnerID uuid.UUID) (*domain.Project, error) {
 	return r.TransferOwnershipTx(ctx, nil, id, newOwnerID)
 }
@@ -117,6 +169,66 @@ func (r *ProjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) error
 	return r.SoftDeleteTx(ctx, nil, id)
 }
 
+func (r *ProjectRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.deleted[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	project := *p
+	return &project, nil
+}
+
+// ListDeletedForUser lists owned projects only; organizations are not tracked in memory.
+func (r *ProjectRepository) ListDeletedForUser(ctx context.Context, userID uuid.UUID, limit int32) ([]*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]*domain.Project, 0)
+	for _, p := range r.deleted {
+		if p.OwnerID == userID {
+			project := *p
+			projects = append(projects, &project)
+		}
+	}
+	slices.SortFunc(projects, func(a, b *domain.Project) int {
+		return b.DeletedAt.Compare(*a.DeletedAt)
+	})
+	if len(projects) > int(limit) {
+		projects = projects[:limit]
+	}
+	return projects, nil
+}
+
+// ListPurgeable lists every project deleted before the cutoff; applications are not tracked in memory.
+func (r *ProjectRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0)
+	for id, p := range r.deleted {
+		if len(ids) == int(limit) {
+			break
+		}
+		if p.DeletedAt.Before(deletedBefore) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *ProjectRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.projects, id)
+	delete(r.deleted, id)
+	return nil
+}
+
 // ============================================================================
 // Transaction Methods
 // ============================================================================
@@ -132,9 +244,13 @@ func (r *ProjectRepository) CreateTx(ctx context.Context, q *db.Queries, input d
 		Title:       input.Title,
 		Description: input.Description,
 		OwnerID:     input.OwnerID,
+		Settings:    domain.DefaultProjectSettings(),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
+	if input.OrganizationID != nil {
+		project.OrganizationID = *input.OrganizationID
+	}
 
 	r.projects[id] = project
 	p := *project
@@ -173,10 +289,31 @@ func (r *ProjectRepository) SoftDeleteTx(ctx context.Context, q *db.Queries, id
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.projects[id]; !ok {
+	p, ok := r.projects[id]
+	if !ok {
 		return domain.ErrProjectNotFound
 	}
 
+	now := time.Now()
+	p.DeletedAt = &now
+	r.deleted[id] = p
 	delete(r.projects, id)
 	return nil
 }
+
+func (r *ProjectRepository) RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.deleted[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	p.DeletedAt = nil
+	p.UpdatedAt = time.Now()
+	r.projects[id] = p
+	delete(r.deleted, id)
+	project := *p
+	return &project, nil
+}
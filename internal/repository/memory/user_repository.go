@@ -2,7 +2,6 @@ package memory
 
 import (
 	"context"
-	"sort"
 	"sync"
 	"time"
 
@@ -111,24 +110,24 @@ func (r *UserRepository) GetCredentialsByUsername(ctx context.Context, username
 	return nil, domain.ErrNotFound
 }
 
-func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
+func (r *UserRepository) List(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) (*domain.Page[*domain.User], error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	users := make([]*domain.User, 0, len(r.users))
 	for _, rec := range r.users {
-		if rec.user.IsActive {
-			user := rec.user
-			users = append(users, &user)
+		// Inactive users are soft-deleted in this repository
+		if !rec.user.IsActive || (filter.IsActive != nil && !*filter.IsActive) {
+			continue
+		}
+		if !containsFold(rec.user.Email, filter.Search) && !containsFold(rec.user.Username, filter.Search) {
+			continue
 		}
+		user := rec.user
+		users = append(users, &user)
 	}
 
-	// Sort by ID to ensure deterministic output
-	sort.Slice(users, func(i, j int) bool {
-		return users[i].ID.String() < users[j].ID.String()
-	})
-
-	return users, nil
+	return paginate(users, page, (*domain.User).Cursor), nil
 }
 
 func (r *UserRepository) UpdateEmail(ctx context.Context, id uuid.UUID, email string) (*domain.User, error) {
@@ -84,3 +84,41 @@ func TestUserRepository_SoftDelete(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, domain.CodeNotFound, domain.GetErrorCode(err))
 }
+
+func TestUserRepository_List(t *testing.T) {
+	repo := NewUserRepository()
+	ctx := context.Background()
+
+	for _, name := range []string{"alice", "bob", "carol", "dave", "erin"} {
+		_, err := repo.Create(ctx, domain.CreateUserInput{Email: name + "@ex.com", Username: name}, "hash")
+		require.NoError(t, err)
+	}
+
+	// Walk every page and check nothing is skipped or repeated
+	seen := make(map[uuid.UUID]bool)
+	page := domain.PageRequest{Limit: 2}
+	pages := 0
+	for {
+		result, err := repo.List(ctx, domain.UserFilter{}, page)
+		require.NoError(t, err)
+		pages++
+		for _, u := range result.Items {
+			assert.False(t, seen[u.ID])
+			seen[u.ID] = true
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.After, err = domain.DecodeCursor(result.NextCursor)
+		require.NoError(t, err)
+	}
+	assert.Len(t, seen, 5)
+	assert.Equal(t, 3, pages)
+
+	// Search is case-insensitive on email and username
+	result, err := repo.List(ctx, domain.UserFilter{Search: "CAR"}, domain.PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "carol", result.Items[0].Username)
+	assert.Empty(t, result.NextCursor)
+}
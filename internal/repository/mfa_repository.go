@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MFARepository defines the interface for two-factor authentication data access.
+type MFARepository interface {
+	// GetByUserID retrieves a user's second factor (pending or enabled).
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error)
+
+	// Enable confirms a pending enrollment and records the step of the confirming code.
+	// Returns domain.ErrNotFound if there is no pending enrollment.
+	Enable(ctx context.Context, userID uuid.UUID, step int64) (*domain.UserMFA, error)
+
+	// ConsumeStep records an accepted TOTP time step.
+	// Returns false if the step is not newer than the last accepted one (replay).
+	ConsumeStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// UseRecoveryCode consumes an unused recovery code.
+	// Returns false if no such unused code exists.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+
+	// CountUnusedRecoveryCodes returns how many recovery codes are left.
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ========== Transaction Methods ==========
+
+	// SaveSecretTx starts (or restarts) an enrollment within a transaction.
+	SaveSecretTx(ctx context.Context, q *db.Queries, userID uuid.UUID, secret string) (*domain.UserMFA, error)
+
+	// ReplaceRecoveryCodesTx replaces all recovery codes of a user within a transaction.
+	ReplaceRecoveryCodesTx(ctx context.Context, q *db.Queries, userID uuid.UUID, codeHashes []string) error
+
+	// DeleteTx removes the second factor and recovery codes within a transaction.
+	DeleteTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+)
+
+// OAuthStateRepository defines the interface for pending SSO authorization requests.
+type OAuthStateRepository interface {
+	// Create stores a pending authorization request.
+	Create(ctx context.Context, state domain.OAuthState) error
+
+	// Consume retrieves and deletes a pending authorization request.
+	// Returns domain.ErrNotFound if it does not exist or was already used.
+	Consume(ctx context.Context, state string) (*domain.OAuthState, error)
+
+	// DeleteExpired removes expired authorization requests.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
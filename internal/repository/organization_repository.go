@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository defines the interface for organization data access.
+type OrganizationRepository interface {
+	// GetByID retrieves an organization by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+
+	// GetPersonal retrieves the personal organization of a user.
+	GetPersonal(ctx context.Context, userID uuid.UUID) (*domain.Organization, error)
+
+	// ListForUser retrieves the organizations a user belongs to, with their role.
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]*domain.OrganizationMembership, error)
+
+	// GetUsage counts what an organization currently holds.
+	GetUsage(ctx context.Context, id uuid.UUID) (*domain.OrganizationUsage, error)
+
+	// UpdateName renames an organization.
+	UpdateName(ctx context.Context, id uuid.UUID, name string) (*domain.Organization, error)
+
+	// UpdateQuotas replaces an organization's quotas.
+	UpdateQuotas(ctx context.Context, id uuid.UUID, quotas domain.OrganizationQuotas) (*domain.Organization, error)
+
+	// GetMember retrieves a user's membership in an organization.
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrganizationMember, error)
+
+	// ListMembers retrieves every member of an organization.
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrganizationMember, error)
+
+	// AddMember adds a user to an organization.
+	AddMember(ctx context.Context, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error)
+
+	// UpdateMemberRole changes a member's role.
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error)
+
+	// RemoveMember removes a user from an organization.
+	// Returns domain.ErrNotFound if the user is not a member.
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+
+	// CountOwners counts the owners of an organization.
+	CountOwners(ctx context.Context, orgID uuid.UUID) (int64, error)
+
+	// CountSoleOwnedWithProjects counts the organizations holding projects that
+	// would be left without an owner if the user left.
+	CountSoleOwnedWithProjects(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ========== Transaction Methods ==========
+
+	// CreateTx creates an organization within a transaction.
+	// personalOwnerID is set for a user's personal organization.
+	CreateTx(ctx context.Context, q *db.Queries, name string, personalOwnerID *uuid.UUID) (*domain.Organization, error)
+
+	// LockTx retrieves an organization and locks it until the transaction ends,
+	// so that quota checks made under the lock hold until their insert commits.
+	LockTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Organization, error)
+
+	// GetUsageTx counts what an organization currently holds within a transaction.
+	GetUsageTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.OrganizationUsage, error)
+
+	// AddMemberTx adds a user to an organization within a transaction.
+	AddMemberTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error)
+
+	// UpdateMemberRoleTx changes a member's role within a transaction.
+	UpdateMemberRoleTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error)
+
+	// RemoveMemberTx removes a user from an organization within a transaction.
+	// Returns domain.ErrNotFound if the user is not a member.
+	RemoveMemberTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID) error
+
+	// GetOtherOwnerTx retrieves the longest-standing owner of an organization other than the user.
+	// Returns domain.ErrNotFound if there is none.
+	GetOtherOwnerTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID) (uuid.UUID, error)
+
+	// HandOverProjectsTx gives the projects a user owns in an organization to another user within a transaction.
+	HandOverProjectsTx(ctx context.Context, q *db.Queries, orgID, fromUserID, toUserID uuid.UUID) error
+
+	// DeleteMembershipsByUserTx removes a user from every organization within a transaction.
+	DeleteMembershipsByUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error
+}
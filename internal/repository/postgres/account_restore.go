@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AccountRestoreRepository implements repository.AccountRestoreRepository using PostgreSQL.
+type AccountRestoreRepository struct {
+	q *db.Queries
+}
+
+// NewAccountRestoreRepository creates a new PostgreSQL account restore repository.
+func NewAccountRestoreRepository(q *db.Queries) *AccountRestoreRepository {
+	return &AccountRestoreRepository{q: q}
+}
+
+// GetByHash retrieves a token by the hash of its raw value.
+func (r *AccountRestoreRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.AccountRestoreToken, error) {
+	row, err := r.q.GetAccountRestoreTokenByHash(ctx, tokenHash)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToAccountRestoreToken(&row), nil
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx stores a new token hash for a deleted account within a transaction.
+func (r *AccountRestoreRepository) CreateTx(ctx context.Context, q *db.Queries, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*domain.AccountRestoreToken, error) {
+	row, err := q.CreateAccountRestoreToken(ctx, db.CreateAccountRestoreTokenParams{
+		TokenHash: tokenHash,
+		UserID:    uuidToPgtype(userID),
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToAccountRestoreToken(&row), nil
+}
+
+// MarkUsedTx consumes a token within a transaction.
+func (r *AccountRestoreRepository) MarkUsedTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	_, err := q.MarkAccountRestoreTokenUsed(ctx, uuidToPgtype(id))
+	return translateError(err)
+}
+
+// InvalidateForUserTx consumes every outstanding token of a user within a transaction.
+func (r *AccountRestoreRepository) InvalidateForUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	return translateError(q.InvalidateUserAccountRestoreTokens(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain AccountRestoreToken
+func rowToAccountRestoreToken(row *db.AccountRestoreToken) *domain.AccountRestoreToken {
+	return &domain.AccountRestoreToken{
+		ID:        pgtypeToUUID(row.ID),
+		TokenHash: row.TokenHash,
+		UserID:    pgtypeToUUID(row.UserID),
+		ExpiresAt: row.ExpiresAt.Time,
+		UsedAt:    pgtypeToTimePtr(row.UsedAt),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AdminAuditRepository implements repository.AdminAuditRepository using PostgreSQL.
+type AdminAuditRepository struct {
+	q *db.Queries
+}
+
+// NewAdminAuditRepository creates a new PostgreSQL admin audit repository.
+func NewAdminAuditRepository(q *db.Queries) *AdminAuditRepository {
+	return &AdminAuditRepository{q: q}
+}
+
+// Create records an administrative action.
+func (r *AdminAuditRepository) Create(ctx context.Context, input domain.CreateAdminAuditLogInput) (*domain.AdminAuditLog, error) {
+	details := input.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.q.CreateAdminAuditLog(ctx, db.CreateAdminAuditLogParams{
+		ActorID:      uuidToPgtype(input.ActorID),
+		Action:       string(input.Action),
+		TargetUserID: uuidPtrToPgtype(input.TargetUserID),
+		Details:      detailsJSON,
+		IpAddress:    input.IPAddress,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToAdminAuditLog(&row), nil
+}
+
+// List returns the most recent entries, newest first.
+func (r *AdminAuditRepository) List(ctx context.Context, limit int32) ([]*domain.AdminAuditLog, error) {
+	rows, err := r.q.ListAdminAuditLogs(ctx, limit)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToAdminAuditLogs(rows), nil
+}
+
+// ListByTarget returns the most recent entries about a user, newest first.
+func (r *AdminAuditRepository) ListByTarget(ctx context.Context, targetUserID uuid.UUID, limit int32) ([]*domain.AdminAuditLog, error) {
+	rows, err := r.q.ListAdminAuditLogsByTarget(ctx, db.ListAdminAuditLogsByTargetParams{
+		TargetUserID: uuidToPgtype(targetUserID),
+		MaxResults:   limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToAdminAuditLogs(rows), nil
+}
+
+func rowsToAdminAuditLogs(rows []db.AdminAuditLog) []*domain.AdminAuditLog {
+	logs := make([]*domain.AdminAuditLog, len(rows))
+	for i, row := range rows {
+		logs[i] = rowToAdminAuditLog(&row)
+	}
+	return logs
+}
+
+// Helper to convert DB row to domain AdminAuditLog
+func rowToAdminAuditLog(row *db.AdminAuditLog) *domain.AdminAuditLog {
+	details := map[string]string{}
+	_ = json.Unmarshal(row.Details, &details)
+
+	return &domain.AdminAuditLog{
+		ID:           pgtypeToUUID(row.ID),
+		ActorID:      pgtypeToUUIDPtr(row.ActorID),
+		Action:       domain.AdminAction(row.Action),
+		TargetUserID: pgtypeToUUIDPtr(row.TargetUserID),
+		Details:      details,
+		IPAddress:    row.IpAddress,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository implements repository.APIKeyRepository using PostgreSQL.
+type APIKeyRepository struct {
+	q *db.Queries
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL API key repository.
+func NewAPIKeyRepository(q *db.Queries) *APIKeyRepository {
+	return &APIKeyRepository{q: q}
+}
+
+// Create records a new API key.
+func (r *APIKeyRepository) Create(ctx context.Context, input domain.CreateAPIKeyInput) (*domain.APIKey, error) {
+	row, err := r.q.CreateApplicationAPIKey(ctx, db.CreateApplicationAPIKeyParams{
+		ApplicationID: uuidToPgtype(input.ApplicationID),
+		Name:          input.Name,
+		KeyPrefix:     input.Prefix,
+		KeyHash:       input.KeyHash,
+		CreatedBy:     uuidToPgtype(input.CreatedBy),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToAPIKey(&row), nil
+}
+
+// GetByHash retrieves a live key of a live application by the hash of its raw value.
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	row, err := r.q.GetApplicationAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToAPIKey(&row), nil
+}
+
+// ListByApplication retrieves the keys of an application, newest first.
+func (r *APIKeyRepository) ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.APIKey, error) {
+	rows, err := r.q.ListApplicationAPIKeys(ctx, uuidToPgtype(appID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	keys := make([]*domain.APIKey, len(rows))
+	for i := range rows {
+		keys[i] = rowToAPIKey(&rows[i])
+	}
+	return keys, nil
+}
+
+// Revoke revokes a live key of an application.
+func (r *APIKeyRepository) Revoke(ctx context.Context, appID, id uuid.UUID) (*domain.APIKey, error) {
+	row, err := r.q.RevokeApplicationAPIKey(ctx, db.RevokeApplicationAPIKeyParams{
+		ID:            uuidToPgtype(id),
+		ApplicationID: uuidToPgtype(appID),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToAPIKey(&row), nil
+}
+
+// Touch records that a key was used, at most once a minute.
+func (r *APIKeyRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.q.TouchApplicationAPIKey(ctx, uuidToPgtype(id)))
+}
+
+// Helper to convert DB row to domain APIKey
+func rowToAPIKey(row *db.ApplicationApiKey) *domain.APIKey {
+	return &domain.APIKey{
+		ID:            pgtypeToUUID(row.ID),
+		ApplicationID: pgtypeToUUID(row.ApplicationID),
+		Name:          row.Name,
+		Prefix:        row.KeyPrefix,
+		CreatedBy:     pgtypeToUUIDPtr(row.CreatedBy),
+		LastUsedAt:    pgtypeToTimePtr(row.LastUsedAt),
+		RevokedAt:     pgtypeToTimePtr(row.RevokedAt),
+		CreatedAt:     row.CreatedAt.Time,
+	}
+}
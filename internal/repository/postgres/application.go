@@ -3,11 +3,13 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // ApplicationRepository implements repository.ApplicationRepository using PostgreSQL.
@@ -52,9 +54,16 @@ func (r *ApplicationRepository) GetByPackageName(ctx context.Context, packageNam
 	return rowToApplication(&row), nil
 }
 
-// ListByProject retrieves all applications for a project.
-func (r *ApplicationRepository) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Application, error) {
-	rows, err := r.q.ListApplicationsByProject(ctx, uuidToPgtype(projectID))
+// ListByProject retrieves a page of the applications of a project.
+func (r *ApplicationRepository) ListByProject(ctx context.Context, projectID uuid.UUID, filter domain.ApplicationFilter, page domain.PageRequest) (*domain.Page[*domain.Application], error) {
+	rows, err := r.q.ListApplicationsByProject(ctx, db.ListApplicationsByProjectParams{
+		ProjectID:      uuidToPgtype(projectID),
+		Search:         stringToPgtype(filter.Search),
+		AfterID:        afterID(page),
+		Ascending:      page.Ascending,
+		AfterCreatedAt: afterCreatedAt(page),
+		MaxResults:     fetchLimit(page),
+	})
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -63,7 +72,7 @@ func (r *ApplicationRepository) ListByProject(ctx context.Context, projectID uui
 	for i, row := range rows {
 		apps[i] = rowToApplication(&row)
 	}
-	return apps, nil
+	return domain.NewPage(apps, page.Limit, (*domain.Application).Cursor), nil
 }
 
 // Update updates an application.
@@ -81,8 +90,7 @@ func (r *ApplicationRepository) Update(ctx context.Context, id uuid.UUID, title,
 
 // SoftDelete marks an application as deleted.
 func (r *ApplicationRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.q.SoftDeleteApplication(ctx, uuidToPgtype(id))
-	return translateError(err)
+	return r.SoftDeleteTx(ctx, r.q, id)
 }
 
 // PackageNameExists checks if a package name exists.
@@ -97,6 +105,58 @@ func (r *ApplicationRepository) PackageNameExists(ctx context.Context, packageNa
 	return true, nil
 }
 
+// ========== Trash ==========
+
+// GetDeletedByID retrieves a soft-deleted application by ID.
+func (r *ApplicationRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Application, error) {
+	row, err := r.q.GetDeletedApplicationByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToApplication(&row), nil
+}
+
+// ListDeletedByProject retrieves the most recently deleted applications of a project.
+func (r *ApplicationRepository) ListDeletedByProject(ctx context.Context, projectID uuid.UUID, limit int32) ([]*domain.Application, error) {
+	rows, err := r.q.ListDeletedApplicationsByProject(ctx, db.ListDeletedApplicationsByProjectParams{
+		ProjectID:  uuidToPgtype(projectID),
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	apps := make([]*domain.Application, len(rows))
+	for i, row := range rows {
+		apps[i] = rowToApplication(&row)
+	}
+	return apps, nil
+}
+
+// ListPurgeable retrieves applications deleted before the cutoff whose releases are all purged.
+func (r *ApplicationRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	rows, err := r.q.ListPurgeableApplications(ctx, db.ListPurgeableApplicationsParams{
+		DeletedBefore: pgtype.Timestamp{Time: deletedBefore, Valid: true},
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+// HardDelete permanently removes an application.
+func (r *ApplicationRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.q.HardDeleteApplication(ctx, uuidToPgtype(id)))
+}
+
+// ========== Transaction Methods ==========
+
 // CreateTx creates a new application within a transaction.
 func (r *ApplicationRepository) CreateTx(ctx context.Context, q *db.Queries, input domain.CreateApplicationInput) (*domain.Application, error) {
 	row, err := q.CreateApplication(ctx, db.CreateApplicationParams{
@@ -111,6 +171,34 @@ func (r *ApplicationRepository) CreateTx(ctx context.Context, q *db.Queries, inp
 	return rowToApplication(&row), nil
 }
 
+// RestoreTx undoes the soft delete of an application within a transaction.
+func (r *ApplicationRepository) RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Application, error) {
+	row, err := q.RestoreApplication(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToApplication(&row), nil
+}
+
+// RestoreDeletedWithProjectTx restores the applications deleted at the same time as their project.
+func (r *ApplicationRepository) RestoreDeletedWithProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID, deletedAt time.Time) error {
+	return translateError(q.RestoreApplicationsDeletedWithProject(ctx, db.RestoreApplicationsDeletedWithProjectParams{
+		ProjectID: uuidToPgtype(projectID),
+		DeletedAt: pgtype.Timestamp{Time: deletedAt, Valid: true},
+	}))
+}
+
+// SoftDeleteTx marks an application as deleted within a transaction.
+func (r *ApplicationRepository) SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	_, err := q.SoftDeleteApplication(ctx, uuidToPgtype(id))
+	return translateError(err)
+}
+
+// SoftDeleteByProjectTx marks the live applications of a project as deleted within a transaction.
+func (r *ApplicationRepository) SoftDeleteByProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID) error {
+	return translateError(q.SoftDeleteApplicationsByProject(ctx, uuidToPgtype(projectID)))
+}
+
 // Helper to convert DB row to domain Application
 func rowToApplication(row *db.Application) *domain.Application {
 	return &domain.Application{
@@ -121,5 +209,6 @@ func rowToApplication(row *db.Application) *domain.Application {
 		ProjectID:   pgtypeToUUID(row.ProjectID),
 		CreatedAt:   row.CreatedAt.Time,
 		UpdatedAt:   row.UpdatedAt.Time,
+		DeletedAt:   pgtypeToTimePtr(row.DeletedAt),
 	}
 }
@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // ArtifactRepository implements repository.ArtifactRepository using PostgreSQL.
@@ -27,6 +29,7 @@ func (r *ArtifactRepository) Create(ctx context.Context, input domain.CreateArti
 		FileType:   input.FileType,
 		Abi:        stringToPgtype(derefString(input.ABI)),
 		ReleaseID:  uuidToPgtype(input.ReleaseID),
+		Verified:   input.Verified,
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -43,9 +46,46 @@ func (r *ArtifactRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return rowToArtifact(&row), nil
 }
 
-// ListByRelease retrieves all artifacts for a release.
-func (r *ArtifactRepository) ListByRelease(ctx context.Context, releaseID uuid.UUID) ([]*domain.Artifact, error) {
-	rows, err := r.q.ListArtifactsByRelease(ctx, uuidToPgtype(releaseID))
+// GetForABI retrieves the artifact of a release to install on a device with the given ABI.
+func (r *ArtifactRepository) GetForABI(ctx context.Context, releaseID uuid.UUID, abi string) (*domain.Artifact, error) {
+	row, err := r.q.GetArtifactForABI(ctx, db.GetArtifactForABIParams{
+		ReleaseID: uuidToPgtype(releaseID),
+		Abi:       abi,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToArtifact(&row), nil
+}
+
+// MarkVerified records that the stored file matches the artifact's hash and size.
+func (r *ArtifactRepository) MarkVerified(ctx context.Context, id uuid.UUID) (*domain.Artifact, error) {
+	row, err := r.q.MarkArtifactVerified(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToArtifact(&row), nil
+}
+
+// HasVerified checks if a release has at least one live verified artifact.
+func (r *ArtifactRepository) HasVerified(ctx context.Context, releaseID uuid.UUID) (bool, error) {
+	verified, err := r.q.CheckReleaseHasVerifiedArtifact(ctx, uuidToPgtype(releaseID))
+	if err != nil {
+		return false, translateError(err)
+	}
+	return verified, nil
+}
+
+// ListByRelease retrieves a page of the artifacts of a release.
+func (r *ArtifactRepository) ListByRelease(ctx context.Context, releaseID uuid.UUID, filter domain.ArtifactFilter, page domain.PageRequest) (*domain.Page[*domain.Artifact], error) {
+	rows, err := r.q.ListArtifactsByRelease(ctx, db.ListArtifactsByReleaseParams{
+		ReleaseID:      uuidToPgtype(releaseID),
+		Abi:            stringToPgtype(filter.ABI),
+		AfterID:        afterID(page),
+		Ascending:      page.Ascending,
+		AfterCreatedAt: afterCreatedAt(page),
+		MaxResults:     fetchLimit(page),
+	})
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -54,7 +94,7 @@ func (r *ArtifactRepository) ListByRelease(ctx context.Context, releaseID uuid.U
 	for i, row := range rows {
 		artifacts[i] = rowToArtifact(&row)
 	}
-	return artifacts, nil
+	return domain.NewPage(artifacts, page.Limit, (*domain.Artifact).Cursor), nil
 }
 
 // Delete marks an artifact as deleted.
@@ -63,6 +103,55 @@ func (r *ArtifactRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return translateError(err)
 }
 
+// ========== Trash ==========
+
+// GetDeletedByID retrieves a soft-deleted artifact by ID.
+func (r *ArtifactRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Artifact, error) {
+	row, err := r.q.GetDeletedArtifactByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToArtifact(&row), nil
+}
+
+// ListDeletedByProject retrieves the most recently deleted artifacts of a project's releases.
+func (r *ArtifactRepository) ListDeletedByProject(ctx context.Context, projectID uuid.UUID, limit int32) ([]*domain.Artifact, error) {
+	rows, err := r.q.ListDeletedArtifactsByProject(ctx, db.ListDeletedArtifactsByProjectParams{
+		ProjectID:  uuidToPgtype(projectID),
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToArtifacts(rows), nil
+}
+
+// ListPurgeable retrieves artifacts deleted before the cutoff, directly or with their release or application.
+func (r *ArtifactRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]*domain.Artifact, error) {
+	rows, err := r.q.ListPurgeableArtifacts(ctx, db.ListPurgeableArtifactsParams{
+		DeletedBefore: pgtype.Timestamp{Time: deletedBefore, Valid: true},
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToArtifacts(rows), nil
+}
+
+// CountOthersWithFileURL counts the other artifacts pointing to the same stored file.
+func (r *ArtifactRepository) CountOthersWithFileURL(ctx context.Context, fileURL string, id uuid.UUID) (int64, error) {
+	count, err := r.q.CountOtherArtifactsWithFileURL(ctx, db.CountOtherArtifactsWithFileURLParams{
+		FileUrl: fileURL,
+		ID:      uuidToPgtype(id),
+	})
+	return count, translateError(err)
+}
+
+// HardDelete permanently removes an artifact record.
+func (r *ArtifactRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.q.HardDeleteArtifact(ctx, uuidToPgtype(id)))
+}
+
 // ========== Transaction Methods ==========
 
 // CreateTx creates a new artifact record within a transaction.
@@ -74,6 +163,7 @@ func (r *ArtifactRepository) CreateTx(ctx context.Context, q *db.Queries, input
 		FileType:   input.FileType,
 		Abi:        stringToPgtype(derefString(input.ABI)),
 		ReleaseID:  uuidToPgtype(input.ReleaseID),
+		Verified:   input.Verified,
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -81,6 +171,70 @@ func (r *ArtifactRepository) CreateTx(ctx context.Context, q *db.Queries, input
 	return rowToArtifact(&row), nil
 }
 
+// CopyToReleaseTx copies the live artifacts of a release to another release within a transaction.
+func (r *ArtifactRepository) CopyToReleaseTx(ctx context.Context, q *db.Queries, sourceReleaseID, targetReleaseID uuid.UUID) error {
+	return translateError(q.CopyArtifactsToRelease(ctx, db.CopyArtifactsToReleaseParams{
+		TargetReleaseID: uuidToPgtype(targetReleaseID),
+		SourceReleaseID: uuidToPgtype(sourceReleaseID),
+	}))
+}
+
+// RestoreTx undoes the soft delete of an artifact within a transaction.
+func (r *ArtifactRepository) RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Artifact, error) {
+	row, err := q.RestoreArtifact(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToArtifact(&row), nil
+}
+
+// RestoreDeletedWithReleaseTx restores the artifacts deleted at the same time as their release.
+func (r *ArtifactRepository) RestoreDeletedWithReleaseTx(ctx context.Context, q *db.Queries, releaseID uuid.UUID, deletedAt time.Time) error {
+	return translateError(q.RestoreArtifactsDeletedWithRelease(ctx, db.RestoreArtifactsDeletedWithReleaseParams{
+		ReleaseID: uuidToPgtype(releaseID),
+		DeletedAt: pgtype.Timestamp{Time: deletedAt, Valid: true},
+	}))
+}
+
+// RestoreDeletedWithApplicationTx restores the artifacts deleted at the same time as their application.
+func (r *ArtifactRepository) RestoreDeletedWithApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID, deletedAt time.Time) error {
+	return translateError(q.RestoreArtifactsDeletedWithApplication(ctx, db.RestoreArtifactsDeletedWithApplicationParams{
+		DeletedAt:     pgtype.Timestamp{Time: deletedAt, Valid: true},
+		ApplicationID: uuidToPgtype(appID),
+	}))
+}
+
+// RestoreDeletedWithProjectTx restores the artifacts deleted at the same time as their project.
+func (r *ArtifactRepository) RestoreDeletedWithProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID, deletedAt time.Time) error {
+	return translateError(q.RestoreArtifactsDeletedWithProject(ctx, db.RestoreArtifactsDeletedWithProjectParams{
+		DeletedAt: pgtype.Timestamp{Time: deletedAt, Valid: true},
+		ProjectID: uuidToPgtype(projectID),
+	}))
+}
+
+// SoftDeleteByReleaseTx marks the live artifacts of a release as deleted within a transaction.
+func (r *ArtifactRepository) SoftDeleteByReleaseTx(ctx context.Context, q *db.Queries, releaseID uuid.UUID) error {
+	return translateError(q.SoftDeleteArtifactsByRelease(ctx, uuidToPgtype(releaseID)))
+}
+
+// SoftDeleteByApplicationTx marks the live artifacts of an application's releases as deleted within a transaction.
+func (r *ArtifactRepository) SoftDeleteByApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID) error {
+	return translateError(q.SoftDeleteArtifactsByApplication(ctx, uuidToPgtype(appID)))
+}
+
+// SoftDeleteByProjectTx marks the live artifacts of a project's releases as deleted within a transaction.
+func (r *ArtifactRepository) SoftDeleteByProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID) error {
+	return translateError(q.SoftDeleteArtifactsByProject(ctx, uuidToPgtype(projectID)))
+}
+
+func rowsToArtifacts(rows []db.Artifact) []*domain.Artifact {
+	artifacts := make([]*domain.Artifact, len(rows))
+	for i, row := range rows {
+		artifacts[i] = rowToArtifact(&row)
+	}
+	return artifacts
+}
+
 // Helper to convert DB row to domain Artifact
 func rowToArtifact(row *db.Artifact) *domain.Artifact {
 	return &domain.Artifact{
@@ -94,6 +248,8 @@ func rowToArtifact(row *db.Artifact) *domain.Artifact {
 		CreatedAt: row.CreatedAt.Time,
 		UpdatedAt: row.UpdatedAt.Time,
 		DeletedAt: pgtypeToTimePtr(row.DeletedAt),
+
+		VerifiedAt: pgtypeToTimePtr(row.VerifiedAt),
 	}
 }
 
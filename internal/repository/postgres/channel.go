@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ChannelRepository implements repository.ChannelRepository using PostgreSQL.
+type ChannelRepository struct {
+	q *db.Queries
+}
+
+// NewChannelRepository creates a new PostgreSQL release channel repository.
+func NewChannelRepository(q *db.Queries) *ChannelRepository {
+	return &ChannelRepository{q: q}
+}
+
+// ListByApplication retrieves the channels of an application in display order.
+func (r *ChannelRepository) ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.ReleaseChannel, error) {
+	rows, err := r.q.ListReleaseChannelsByApplication(ctx, uuidToPgtype(appID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	channels := make([]*domain.ReleaseChannel, len(rows))
+	for i := range rows {
+		channels[i] = rowToChannel(&rows[i])
+	}
+	return channels, nil
+}
+
+// GetByName retrieves a channel of an application by name.
+func (r *ChannelRepository) GetByName(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error) {
+	row, err := r.q.GetReleaseChannelByName(ctx, db.GetReleaseChannelByNameParams{
+		ApplicationID: uuidToPgtype(appID),
+		Name:          string(name),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToChannel(&row), nil
+}
+
+// GetDefault retrieves the channel uploads default to.
+func (r *ChannelRepository) GetDefault(ctx context.Context, appID uuid.UUID) (*domain.ReleaseChannel, error) {
+	row, err := r.q.GetDefaultReleaseChannel(ctx, uuidToPgtype(appID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToChannel(&row), nil
+}
+
+// InUse checks if any release, trashed ones included, lives in the channel.
+func (r *ChannelRepository) InUse(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) (bool, error) {
+	inUse, err := r.q.CheckReleaseChannelInUse(ctx, db.CheckReleaseChannelInUseParams{
+		ApplicationID: uuidToPgtype(appID),
+		Environment:   string(name),
+	})
+	if err != nil {
+		return false, translateError(err)
+	}
+	return inUse, nil
+}
+
+// Delete removes a channel.
+func (r *ChannelRepository) Delete(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) error {
+	err := r.q.DeleteReleaseChannel(ctx, db.DeleteReleaseChannelParams{
+		ApplicationID: uuidToPgtype(appID),
+		Name:          string(name),
+	})
+	return translateError(err)
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx creates a channel within a transaction.
+func (r *ChannelRepository) CreateTx(ctx context.Context, q *db.Queries, input domain.CreateReleaseChannelInput) (*domain.ReleaseChannel, error) {
+	row, err := q.CreateReleaseChannel(ctx, db.CreateReleaseChannelParams{
+		ApplicationID: uuidToPgtype(input.ApplicationID),
+		Name:          string(input.Name),
+		Position:      input.Position,
+		Visibility:    string(input.Visibility),
+		IsDefault:     input.IsDefault,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToChannel(&row), nil
+}
+
+// UpdateTx updates a channel's position, visibility and minimum supported
+// version code within a transaction.
+func (r *ChannelRepository) UpdateTx(ctx context.Context, q *db.Queries, appID uuid.UUID, name domain.ReleaseEnvironment, position int32, visibility domain.ChannelVisibility, minSupportedVersionCode int32) (*domain.ReleaseChannel, error) {
+	row, err := q.UpdateReleaseChannel(ctx, db.UpdateReleaseChannelParams{
+		ApplicationID:           uuidToPgtype(appID),
+		Name:                    string(name),
+		Position:                position,
+		Visibility:              string(visibility),
+		MinSupportedVersionCode: minSupportedVersionCode,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToChannel(&row), nil
+}
+
+// LockTx retrieves a channel and locks it until the transaction ends.
+func (r *ChannelRepository) LockTx(ctx context.Context, q *db.Queries, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error) {
+	row, err := q.LockReleaseChannel(ctx, db.LockReleaseChannelParams{
+		ApplicationID: uuidToPgtype(appID),
+		Name:          string(name),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToChannel(&row), nil
+}
+
+// SetDefaultTx makes a channel the application's default within a transaction.
+func (r *ChannelRepository) SetDefaultTx(ctx context.Context, q *db.Queries, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error) {
+	if err := q.ClearDefaultReleaseChannel(ctx, uuidToPgtype(appID)); err != nil {
+		return nil, translateError(err)
+	}
+
+	row, err := q.SetDefaultReleaseChannel(ctx, db.SetDefaultReleaseChannelParams{
+		ApplicationID: uuidToPgtype(appID),
+		Name:          string(name),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToChannel(&row), nil
+}
+
+func rowToChannel(row *db.ReleaseChannel) *domain.ReleaseChannel {
+	return &domain.ReleaseChannel{
+		ID:            pgtypeToUUID(row.ID),
+		ApplicationID: pgtypeToUUID(row.ApplicationID),
+		Name:          domain.ReleaseEnvironment(row.Name),
+		Position:      row.Position,
+		Visibility:    domain.ChannelVisibility(row.Visibility),
+		IsDefault:     row.IsDefault,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+
+		MinSupportedVersionCode: row.MinSupportedVersionCode,
+	}
+}
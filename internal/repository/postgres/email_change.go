@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EmailChangeRepository implements repository.EmailChangeRepository using PostgreSQL.
+type EmailChangeRepository struct {
+	q *db.Queries
+}
+
+// NewEmailChangeRepository creates a new PostgreSQL email change repository.
+func NewEmailChangeRepository(q *db.Queries) *EmailChangeRepository {
+	return &EmailChangeRepository{q: q}
+}
+
+// GetByHash retrieves a token by the hash of its raw value.
+func (r *EmailChangeRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.EmailChangeToken, error) {
+	row, err := r.q.GetEmailChangeTokenByHash(ctx, tokenHash)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToEmailChangeToken(&row), nil
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx stores a new token hash for a pending email change within a transaction.
+func (r *EmailChangeRepository) CreateTx(ctx context.Context, q *db.Queries, userID uuid.UUID, newEmail, tokenHash string, expiresAt time.Time) (*domain.EmailChangeToken, error) {
+	row, err := q.CreateEmailChangeToken(ctx, db.CreateEmailChangeTokenParams{
+		TokenHash: tokenHash,
+		UserID:    uuidToPgtype(userID),
+		NewEmail:  newEmail,
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToEmailChangeToken(&row), nil
+}
+
+// MarkUsedTx consumes a token within a transaction.
+func (r *EmailChangeRepository) MarkUsedTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	_, err := q.MarkEmailChangeTokenUsed(ctx, uuidToPgtype(id))
+	return translateError(err)
+}
+
+// InvalidateForUserTx consumes every pending email change of a user within a transaction.
+func (r *EmailChangeRepository) InvalidateForUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	return translateError(q.InvalidateUserEmailChangeTokens(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain EmailChangeToken
+func rowToEmailChangeToken(row *db.EmailChangeToken) *domain.EmailChangeToken {
+	return &domain.EmailChangeToken{
+		ID:        pgtypeToUUID(row.ID),
+		TokenHash: row.TokenHash,
+		UserID:    pgtypeToUUID(row.UserID),
+		NewEmail:  row.NewEmail,
+		ExpiresAt: row.ExpiresAt.Time,
+		UsedAt:    pgtypeToTimePtr(row.UsedAt),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// uniqueViolation is the SQLSTATE of unique constraint violations.
+const uniqueViolation = "23505"
+
 // translateError converts database errors to domain errors.
 func translateError(err error) error {
 	if err == nil {
@@ -19,6 +23,10 @@ func translateError(err error) error {
 	if errors.Is(err, pgx.ErrNoRows) {
 		return domain.ErrNotFound
 	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrAlreadyExists
+	}
 
 	// Log unexpected database errors
 	slog.Error("database error",
@@ -41,6 +49,23 @@ func pgtypeToUUID(id pgtype.UUID) uuid.UUID {
 	return id.Bytes
 }
 
+// uuidPtrToPgtype converts an optional google/uuid to pgtype.UUID (NULL when nil).
+func uuidPtrToPgtype(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return uuidToPgtype(*id)
+}
+
+// pgtypeToUUIDPtr converts a nullable pgtype.UUID to *uuid.UUID.
+func pgtypeToUUIDPtr(id pgtype.UUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	u := uuid.UUID(id.Bytes)
+	return &u
+}
+
 // pgtypeToTime converts pgtype.Timestamp to *time.Time.
 func pgtypeToTime(ts pgtype.Timestamp) *time.Time {
 	if !ts.Valid {
@@ -74,3 +99,34 @@ func pgtypeToStringPtr(t pgtype.Text) *string {
 func pgtypeToTimePtr(ts pgtype.Timestamp) *time.Time {
 	return pgtypeToTime(ts)
 }
+
+// ========== Pagination Helpers ==========
+
+// afterID returns the ID part of the page cursor (NULL on the first page).
+func afterID(page domain.PageRequest) pgtype.UUID {
+	if page.After == nil {
+		return pgtype.UUID{}
+	}
+	return uuidToPgtype(page.After.ID)
+}
+
+// afterCreatedAt returns the creation time part of the page cursor (NULL on the first page).
+func afterCreatedAt(page domain.PageRequest) pgtype.Timestamp {
+	if page.After == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: page.After.CreatedAt, Valid: true}
+}
+
+// afterVersionCode returns the version code part of the page cursor (NULL on the first page).
+func afterVersionCode(page domain.PageRequest) pgtype.Int4 {
+	if page.After == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: page.After.VersionCode, Valid: true}
+}
+
+// fetchLimit asks for one row more than the page size to detect a next page.
+func fetchLimit(page domain.PageRequest) int32 {
+	return page.Limit + 1
+}
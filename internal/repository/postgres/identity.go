@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// IdentityRepository implements repository.IdentityRepository using PostgreSQL.
+type IdentityRepository struct {
+	q *db.Queries
+}
+
+// NewIdentityRepository creates a new PostgreSQL identity repository.
+func NewIdentityRepository(q *db.Queries) *IdentityRepository {
+	return &IdentityRepository{q: q}
+}
+
+// GetByProviderSubject retrieves an identity by provider and subject.
+func (r *IdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	row, err := r.q.GetUserIdentityByProviderSubject(ctx, db.GetUserIdentityByProviderSubjectParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToUserIdentity(&row), nil
+}
+
+// HasAnyForUser reports whether a user has linked at least one external identity.
+func (r *IdentityRepository) HasAnyForUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	exists, err := r.q.UserHasIdentities(ctx, uuidToPgtype(userID))
+	return exists, translateError(err)
+}
+
+// RecordLogin updates the last login time and email of an identity.
+func (r *IdentityRepository) RecordLogin(ctx context.Context, id uuid.UUID, email string) error {
+	return translateError(r.q.UpdateUserIdentityLogin(ctx, db.UpdateUserIdentityLoginParams{
+		ID:    uuidToPgtype(id),
+		Email: email,
+	}))
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx links an external identity to a user within a transaction.
+func (r *IdentityRepository) CreateTx(ctx context.Context, q *db.Queries, input domain.CreateUserIdentityInput) (*domain.UserIdentity, error) {
+	row, err := q.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		Provider: input.Provider,
+		Subject:  input.Subject,
+		Email:    input.Email,
+		UserID:   uuidToPgtype(input.UserID),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToUserIdentity(&row), nil
+}
+
+// DeleteByUserTx unlinks every external identity of a user within a transaction.
+func (r *IdentityRepository) DeleteByUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	return translateError(q.DeleteUserIdentitiesByUser(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain UserIdentity
+func rowToUserIdentity(row *db.UserIdentity) *domain.UserIdentity {
+	return &domain.UserIdentity{
+		ID:          pgtypeToUUID(row.ID),
+		Provider:    row.Provider,
+		Subject:     row.Subject,
+		Email:       row.Email,
+		UserID:      pgtypeToUUID(row.UserID),
+		CreatedAt:   row.CreatedAt.Time,
+		LastLoginAt: pgtypeToTimePtr(row.LastLoginAt),
+	}
+}
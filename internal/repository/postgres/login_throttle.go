@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LoginThrottleRepository implements repository.LoginThrottleRepository using PostgreSQL.
+type LoginThrottleRepository struct {
+	q *db.Queries
+}
+
+// NewLoginThrottleRepository creates a new PostgreSQL login throttle repository.
+func NewLoginThrottleRepository(q *db.Queries) *LoginThrottleRepository {
+	return &LoginThrottleRepository{q: q}
+}
+
+// RecordAttempt stores a login attempt in the audit trail.
+func (r *LoginThrottleRepository) RecordAttempt(ctx context.Context, input domain.CreateLoginAttemptInput) error {
+	err := r.q.CreateLoginAttempt(ctx, db.CreateLoginAttemptParams{
+		Identifier: input.Identifier,
+		IpAddress:  input.IPAddress,
+		UserID:     uuidPtrToPgtype(input.UserID),
+		Succeeded:  input.Succeeded,
+	})
+	return translateError(err)
+}
+
+// ListAttempts returns the most recent attempts for an identifier, newest first.
+func (r *LoginThrottleRepository) ListAttempts(ctx context.Context, identifier string, limit int32) ([]*domain.LoginAttempt, error) {
+	rows, err := r.q.ListLoginAttemptsByIdentifier(ctx, db.ListLoginAttemptsByIdentifierParams{
+		Identifier: identifier,
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	attempts := make([]*domain.LoginAttempt, len(rows))
+	for i, row := range rows {
+		attempts[i] = rowToLoginAttempt(&row)
+	}
+	return attempts, nil
+}
+
+// Get retrieves the throttle for a key.
+func (r *LoginThrottleRepository) Get(ctx context.Context, key string) (*domain.LoginThrottle, error) {
+	row, err := r.q.GetLoginThrottle(ctx, key)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToLoginThrottle(&row), nil
+}
+
+// RecordFailure increments the failure counter of a key.
+func (r *LoginThrottleRepository) RecordFailure(ctx context.Context, key string, windowStart time.Time) (*domain.LoginThrottle, error) {
+	row, err := r.q.RecordLoginThrottleFailure(ctx, db.RecordLoginThrottleFailureParams{
+		Key:         key,
+		WindowStart: pgtype.Timestamp{Time: windowStart, Valid: true},
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToLoginThrottle(&row), nil
+}
+
+// Lock refuses logins for a key until the given time.
+func (r *LoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	err := r.q.LockLoginThrottle(ctx, db.LockLoginThrottleParams{
+		Key:         key,
+		LockedUntil: pgtype.Timestamp{Time: until, Valid: true},
+	})
+	return translateError(err)
+}
+
+// Delete clears the failures and any lockout of a key.
+func (r *LoginThrottleRepository) Delete(ctx context.Context, key string) error {
+	return translateError(r.q.DeleteLoginThrottle(ctx, key))
+}
+
+// ListLocked returns every currently locked key.
+func (r *LoginThrottleRepository) ListLocked(ctx context.Context) ([]*domain.LoginThrottle, error) {
+	rows, err := r.q.ListLockedLoginThrottles(ctx)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	throttles := make([]*domain.LoginThrottle, len(rows))
+	for i, row := range rows {
+		throttles[i] = rowToLoginThrottle(&row)
+	}
+	return throttles, nil
+}
+
+// ========== Transaction Methods ==========
+
+// DeleteAttemptsByUserTx removes the recorded login attempts of a user within a transaction.
+func (r *LoginThrottleRepository) DeleteAttemptsByUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	return translateError(q.DeleteLoginAttemptsByUser(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain LoginAttempt
+func rowToLoginAttempt(row *db.LoginAttempt) *domain.LoginAttempt {
+	return &domain.LoginAttempt{
+		ID:         pgtypeToUUID(row.ID),
+		Identifier: row.Identifier,
+		IPAddress:  row.IpAddress,
+		UserID:     pgtypeToUUIDPtr(row.UserID),
+		Succeeded:  row.Succeeded,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
+
+// Helper to convert DB row to domain LoginThrottle
+func rowToLoginThrottle(row *db.LoginThrottle) *domain.LoginThrottle {
+	return &domain.LoginThrottle{
+		Key:           row.Key,
+		FailureCount:  row.FailureCount,
+		LockedUntil:   pgtypeToTimePtr(row.LockedUntil),
+		LastFailureAt: row.LastFailureAt.Time,
+	}
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MFARepository implements repository.MFARepository using PostgreSQL.
+type MFARepository struct {
+	q *db.Queries
+}
+
+// NewMFARepository creates a new PostgreSQL MFA repository.
+func NewMFARepository(q *db.Queries) *MFARepository {
+	return &MFARepository{q: q}
+}
+
+// GetByUserID retrieves a user's second factor.
+func (r *MFARepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	row, err := r.q.GetUserMFA(ctx, uuidToPgtype(userID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToUserMFA(&row), nil
+}
+
+// Enable confirms a pending enrollment.
+func (r *MFARepository) Enable(ctx context.Context, userID uuid.UUID, step int64) (*domain.UserMFA, error) {
+	row, err := r.q.EnableUserMFA(ctx, db.EnableUserMFAParams{
+		UserID:       uuidToPgtype(userID),
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToUserMFA(&row), nil
+}
+
+// ConsumeStep records an accepted TOTP time step.
+func (r *MFARepository) ConsumeStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	n, err := r.q.UpdateUserMFALastUsedStep(ctx, db.UpdateUserMFALastUsedStepParams{
+		UserID:       uuidToPgtype(userID),
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return false, translateError(err)
+	}
+	return n > 0, nil
+}
+
+// UseRecoveryCode consumes an unused recovery code.
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	n, err := r.q.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{
+		UserID:   uuidToPgtype(userID),
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, translateError(err)
+	}
+	return n > 0, nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes are left.
+func (r *MFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	n, err := r.q.CountUnusedUserRecoveryCodes(ctx, uuidToPgtype(userID))
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return n, nil
+}
+
+// ========== Transaction Methods ==========
+
+// SaveSecretTx starts (or restarts) an enrollment within a transaction.
+func (r *MFARepository) SaveSecretTx(ctx context.Context, q *db.Queries, userID uuid.UUID, secret string) (*domain.UserMFA, error) {
+	row, err := q.UpsertUserMFASecret(ctx, db.UpsertUserMFASecretParams{
+		UserID: uuidToPgtype(userID),
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToUserMFA(&row), nil
+}
+
+// ReplaceRecoveryCodesTx replaces all recovery codes of a user within a transaction.
+func (r *MFARepository) ReplaceRecoveryCodesTx(ctx context.Context, q *db.Queries, userID uuid.UUID, codeHashes []string) error {
+	if err := q.DeleteUserRecoveryCodes(ctx, uuidToPgtype(userID)); err != nil {
+		return translateError(err)
+	}
+	for _, hash := range codeHashes {
+		err := q.CreateUserRecoveryCode(ctx, db.CreateUserRecoveryCodeParams{
+			CodeHash: hash,
+			UserID:   uuidToPgtype(userID),
+		})
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+// DeleteTx removes the second factor and recovery codes within a transaction.
+func (r *MFARepository) DeleteTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	if err := q.DeleteUserRecoveryCodes(ctx, uuidToPgtype(userID)); err != nil {
+		return translateError(err)
+	}
+	return translateError(q.DeleteUserMFA(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain UserMFA
+func rowToUserMFA(row *db.UserMfa) *domain.UserMFA {
+	return &domain.UserMFA{
+		UserID:       pgtypeToUUID(row.UserID),
+		Secret:       row.Secret,
+		EnabledAt:    pgtypeToTimePtr(row.EnabledAt),
+		LastUsedStep: row.LastUsedStep,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}
+}
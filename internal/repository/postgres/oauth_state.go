@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// OAuthStateRepository implements repository.OAuthStateRepository using PostgreSQL.
+type OAuthStateRepository struct {
+	q *db.Queries
+}
+
+// NewOAuthStateRepository creates a new PostgreSQL OAuth state repository.
+func NewOAuthStateRepository(q *db.Queries) *OAuthStateRepository {
+	return &OAuthStateRepository{q: q}
+}
+
+// Create stores a pending authorization request.
+func (r *OAuthStateRepository) Create(ctx context.Context, state domain.OAuthState) error {
+	_, err := r.q.CreateOAuthState(ctx, db.CreateOAuthStateParams{
+		State:        state.State,
+		Provider:     state.Provider,
+		CodeVerifier: state.CodeVerifier,
+		Nonce:        state.Nonce,
+		ExpiresAt:    pgtype.Timestamp{Time: state.ExpiresAt, Valid: true},
+	})
+	return translateError(err)
+}
+
+// Consume retrieves and deletes a pending authorization request.
+func (r *OAuthStateRepository) Consume(ctx context.Context, state string) (*domain.OAuthState, error) {
+	row, err := r.q.ConsumeOAuthState(ctx, state)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &domain.OAuthState{
+		State:        row.State,
+		Provider:     row.Provider,
+		CodeVerifier: row.CodeVerifier,
+		Nonce:        row.Nonce,
+		ExpiresAt:    row.ExpiresAt.Time,
+	}, nil
+}
+
+// DeleteExpired removes expired authorization requests.
+func (r *OAuthStateRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	n, err := r.q.DeleteExpiredOAuthStates(ctx)
+	return n, translateError(err)
+}
@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository implements repository.OrganizationRepository using PostgreSQL.
+type OrganizationRepository struct {
+	q *db.Queries
+}
+
+// NewOrganizationRepository creates a new PostgreSQL organization repository.
+func NewOrganizationRepository(q *db.Queries) *OrganizationRepository {
+	return &OrganizationRepository{q: q}
+}
+
+// GetByID retrieves an organization by ID.
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	row, err := r.q.GetOrganizationByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganization(&row), nil
+}
+
+// GetPersonal retrieves the personal organization of a user.
+func (r *OrganizationRepository) GetPersonal(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	row, err := r.q.GetPersonalOrganization(ctx, uuidToPgtype(userID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganization(&row), nil
+}
+
+// ListForUser retrieves the organizations a user belongs to, with their role.
+func (r *OrganizationRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]*domain.OrganizationMembership, error) {
+	rows, err := r.q.ListOrganizationsForUser(ctx, uuidToPgtype(userID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	memberships := make([]*domain.OrganizationMembership, len(rows))
+	for i, row := range rows {
+		memberships[i] = &domain.OrganizationMembership{
+			Organization: *rowToOrganization(&db.Organization{
+				ID:              row.ID,
+				Name:            row.Name,
+				PersonalOwnerID: row.PersonalOwnerID,
+				MaxProjects:     row.MaxProjects,
+				MaxMembers:      row.MaxMembers,
+				MaxApplications: row.MaxApplications,
+				CreatedAt:       row.CreatedAt,
+				UpdatedAt:       row.UpdatedAt,
+			}),
+			Role: domain.OrganizationRole(row.Role),
+		}
+	}
+	return memberships, nil
+}
+
+// GetUsage counts what an organization currently holds.
+func (r *OrganizationRepository) GetUsage(ctx context.Context, id uuid.UUID) (*domain.OrganizationUsage, error) {
+	return r.GetUsageTx(ctx, r.q, id)
+}
+
+// GetUsageTx counts what an organization currently holds within a transaction.
+func (r *OrganizationRepository) GetUsageTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.OrganizationUsage, error) {
+	row, err := q.GetOrganizationUsage(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &domain.OrganizationUsage{
+		Projects:     row.ProjectCount,
+		Members:      row.MemberCount,
+		Applications: row.ApplicationCount,
+	}, nil
+}
+
+// UpdateName renames an organization.
+func (r *OrganizationRepository) UpdateName(ctx context.Context, id uuid.UUID, name string) (*domain.Organization, error) {
+	row, err := r.q.UpdateOrganizationName(ctx, db.UpdateOrganizationNameParams{
+		ID:   uuidToPgtype(id),
+		Name: name,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganization(&row), nil
+}
+
+// UpdateQuotas replaces an organization's quotas.
+func (r *OrganizationRepository) UpdateQuotas(ctx context.Context, id uuid.UUID, quotas domain.OrganizationQuotas) (*domain.Organization, error) {
+	row, err := r.q.UpdateOrganizationQuotas(ctx, db.UpdateOrganizationQuotasParams{
+		ID:              uuidToPgtype(id),
+		MaxProjects:     quotas.MaxProjects,
+		MaxMembers:      quotas.MaxMembers,
+		MaxApplications: quotas.MaxApplications,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganization(&row), nil
+}
+
+// GetMember retrieves a user's membership in an organization.
+func (r *OrganizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	row, err := r.q.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+		OrganizationID: uuidToPgtype(orgID),
+		UserID:         uuidToPgtype(userID),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganizationMember(&row), nil
+}
+
+// ListMembers retrieves every member of an organization.
+func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	rows, err := r.q.ListOrganizationMembers(ctx, uuidToPgtype(orgID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	members := make([]*domain.OrganizationMember, len(rows))
+	for i, row := range rows {
+		member := rowToOrganizationMember(&db.OrganizationMember{
+			ID:             row.ID,
+			Role:           row.Role,
+			OrganizationID: row.OrganizationID,
+			UserID:         row.UserID,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+		})
+		member.Username = row.Username
+		member.Email = row.Email
+		member.FirstName = row.FirstName
+		member.LastName = row.LastName
+		members[i] = member
+	}
+	return members, nil
+}
+
+// AddMember adds a user to an organization.
+func (r *OrganizationRepository) AddMember(ctx context.Context, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error) {
+	return r.AddMemberTx(ctx, r.q, orgID, userID, role)
+}
+
+// UpdateMemberRole changes a member's role.
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error) {
+	return r.UpdateMemberRoleTx(ctx, r.q, orgID, userID, role)
+}
+
+// RemoveMember removes a user from an organization.
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	return r.RemoveMemberTx(ctx, r.q, orgID, userID)
+}
+
+// CountOwners counts the owners of an organization.
+func (r *OrganizationRepository) CountOwners(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	count, err := r.q.CountOrganizationOwners(ctx, uuidToPgtype(orgID))
+	return count, translateError(err)
+}
+
+// CountSoleOwnedWithProjects counts the organizations holding projects that only the user owns.
+func (r *OrganizationRepository) CountSoleOwnedWithProjects(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := r.q.CountSoleOwnedOrganizationsWithProjects(ctx, uuidToPgtype(userID))
+	return count, translateError(err)
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx creates an organization within a transaction.
+func (r *OrganizationRepository) CreateTx(ctx context.Context, q *db.Queries, name string, personalOwnerID *uuid.UUID) (*domain.Organization, error) {
+	row, err := q.CreateOrganization(ctx, db.CreateOrganizationParams{
+		Name:            name,
+		PersonalOwnerID: uuidPtrToPgtype(personalOwnerID),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganization(&row), nil
+}
+
+// LockTx retrieves an organization and locks it until the transaction ends.
+func (r *OrganizationRepository) LockTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Organization, error) {
+	row, err := q.LockOrganization(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganization(&row), nil
+}
+
+// AddMemberTx adds a user to an organization within a transaction.
+func (r *OrganizationRepository) AddMemberTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error) {
+	row, err := q.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{
+		OrganizationID: uuidToPgtype(orgID),
+		UserID:         uuidToPgtype(userID),
+		Role:           string(role),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganizationMember(&row), nil
+}
+
+// UpdateMemberRoleTx changes a member's role within a transaction.
+func (r *OrganizationRepository) UpdateMemberRoleTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error) {
+	row, err := q.UpdateOrganizationMemberRole(ctx, db.UpdateOrganizationMemberRoleParams{
+		OrganizationID: uuidToPgtype(orgID),
+		UserID:         uuidToPgtype(userID),
+		Role:           string(role),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToOrganizationMember(&row), nil
+}
+
+// RemoveMemberTx removes a user from an organization within a transaction.
+func (r *OrganizationRepository) RemoveMemberTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID) error {
+	n, err := q.DeleteOrganizationMember(ctx, db.DeleteOrganizationMemberParams{
+		OrganizationID: uuidToPgtype(orgID),
+		UserID:         uuidToPgtype(userID),
+	})
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetOtherOwnerTx retrieves the longest-standing owner of an organization other than the user.
+func (r *OrganizationRepository) GetOtherOwnerTx(ctx context.Context, q *db.Queries, orgID, userID uuid.UUID) (uuid.UUID, error) {
+	id, err := q.GetOtherOrganizationOwner(ctx, db.GetOtherOrganizationOwnerParams{
+		OrganizationID: uuidToPgtype(orgID),
+		UserID:         uuidToPgtype(userID),
+	})
+	if err != nil {
+		return uuid.Nil, translateError(err)
+	}
+	return pgtypeToUUID(id), nil
+}
+
+// HandOverProjectsTx gives the projects a user owns in an organization to another user within a transaction.
+func (r *OrganizationRepository) HandOverProjectsTx(ctx context.Context, q *db.Queries, orgID, fromUserID, toUserID uuid.UUID) error {
+	return translateError(q.ReassignOrganizationProjects(ctx, db.ReassignOrganizationProjectsParams{
+		NewOwnerID:      uuidToPgtype(toUserID),
+		OrganizationID:  uuidToPgtype(orgID),
+		PreviousOwnerID: uuidToPgtype(fromUserID),
+	}))
+}
+
+// DeleteMembershipsByUserTx removes a user from every organization within a transaction.
+func (r *OrganizationRepository) DeleteMembershipsByUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	return translateError(q.DeleteOrganizationMembershipsByUser(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain Organization
+func rowToOrganization(row *db.Organization) *domain.Organization {
+	return &domain.Organization{
+		ID:              pgtypeToUUID(row.ID),
+		Name:            row.Name,
+		PersonalOwnerID: pgtypeToUUIDPtr(row.PersonalOwnerID),
+		Quotas: domain.OrganizationQuotas{
+			MaxProjects:     row.MaxProjects,
+			MaxMembers:      row.MaxMembers,
+			MaxApplications: row.MaxApplications,
+		},
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+// Helper to convert DB row to domain OrganizationMember
+func rowToOrganizationMember(row *db.OrganizationMember) *domain.OrganizationMember {
+	return &domain.OrganizationMember{
+		ID:             pgtypeToUUID(row.ID),
+		OrganizationID: pgtypeToUUID(row.OrganizationID),
+		UserID:         pgtypeToUUID(row.UserID),
+		Role:           domain.OrganizationRole(row.Role),
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
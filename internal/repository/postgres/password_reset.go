@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PasswordResetRepository implements repository.PasswordResetRepository using PostgreSQL.
+type PasswordResetRepository struct {
+	q *db.Queries
+}
+
+// NewPasswordResetRepository creates a new PostgreSQL password reset repository.
+func NewPasswordResetRepository(q *db.Queries) *PasswordResetRepository {
+	return &PasswordResetRepository{q: q}
+}
+
+// GetByHash retrieves a token by the hash of its raw value.
+func (r *PasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	row, err := r.q.GetPasswordResetTokenByHash(ctx, tokenHash)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToPasswordResetToken(&row), nil
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx stores a new token hash for a user within a transaction.
+func (r *PasswordResetRepository) CreateTx(ctx context.Context, q *db.Queries, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*domain.PasswordResetToken, error) {
+	row, err := q.CreatePasswordResetToken(ctx, db.CreatePasswordResetTokenParams{
+		TokenHash: tokenHash,
+		UserID:    uuidToPgtype(userID),
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToPasswordResetToken(&row), nil
+}
+
+// MarkUsedTx consumes a token within a transaction.
+func (r *PasswordResetRepository) MarkUsedTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	_, err := q.MarkPasswordResetTokenUsed(ctx, uuidToPgtype(id))
+	return translateError(err)
+}
+
+// InvalidateForUserTx consumes every outstanding token of a user within a transaction.
+func (r *PasswordResetRepository) InvalidateForUserTx(ctx context.Context, q *db.Queries, userID uuid.UUID) error {
+	return translateError(q.InvalidateUserPasswordResetTokens(ctx, uuidToPgtype(userID)))
+}
+
+// Helper to convert DB row to domain PasswordResetToken
+func rowToPasswordResetToken(row *db.PasswordResetToken) *domain.PasswordResetToken {
+	return &domain.PasswordResetToken{
+		ID:        pgtypeToUUID(row.ID),
+		TokenHash: row.TokenHash,
+		UserID:    pgtypeToUUID(row.UserID),
+		ExpiresAt: row.ExpiresAt.Time,
+		UsedAt:    pgtypeToTimePtr(row.UsedAt),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
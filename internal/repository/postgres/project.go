@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // ProjectRepository implements repository.ProjectRepository using PostgreSQL.
@@ -32,9 +36,16 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return r.GetByIDTx(ctx, r.q, id)
 }
 
-// ListByOwner retrieves all projects owned by a user.
-func (r *ProjectRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
-	rows, err := r.q.ListProjectsByOwner(ctx, uuidToPgtype(ownerID))
+// ListByOwner retrieves a page of the projects owned by a user.
+func (r *ProjectRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.Project], error) {
+	rows, err := r.q.ListProjectsByOwner(ctx, db.ListProjectsByOwnerParams{
+		OwnerID:        uuidToPgtype(ownerID),
+		Search:         stringToPgtype(filter.Search),
+		AfterID:        afterID(page),
+		Ascending:      page.Ascending,
+		AfterCreatedAt: afterCreatedAt(page),
+		MaxResults:     fetchLimit(page),
+	})
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -43,7 +54,46 @@ func (r *ProjectRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID)
 	for i, row := range rows {
 		projects[i] = projectToDoMain(&row)
 	}
-	return projects, nil
+	return domain.NewPage(projects, page.Limit, (*domain.Project).Cursor), nil
+}
+
+// ListForUser retrieves a page of the projects a user owns or is a member of.
+func (r *ProjectRepository) ListForUser(ctx context.Context, userID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.ProjectSummary], error) {
+	rows, err := r.q.ListProjectsForUser(ctx, db.ListProjectsForUserParams{
+		UserID:         uuidToPgtype(userID),
+		Search:         stringToPgtype(filter.Search),
+		AfterID:        afterID(page),
+		Ascending:      page.Ascending,
+		AfterCreatedAt: afterCreatedAt(page),
+		MaxResults:     fetchLimit(page),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	summaries := make([]*domain.ProjectSummary, len(rows))
+	for i, row := range rows {
+		summaries[i] = projectSummaryRowToDomain(&row)
+	}
+	return domain.NewPage(summaries, page.Limit, (*domain.ProjectSummary).Cursor), nil
+}
+
+// GetAccess retrieves the effective role and permissions of a user in a project.
+func (r *ProjectRepository) GetAccess(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectAccess, error) {
+	row, err := r.q.GetProjectAccess(ctx, db.GetProjectAccessParams{
+		ProjectID: uuidToPgtype(projectID),
+		UserID:    uuidToPgtype(userID),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	if !row.Role.Valid {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.ProjectAccess{
+		Role:        row.Role.String,
+		Permissions: row.Permissions,
+	}, nil
 }
 
 // UpdateTitle updates a project's title.
@@ -83,6 +133,23 @@ func (r *ProjectRepository) Update(ctx context.Context, id uuid.UUID, title, des
 	return projectToDoMain(&row), nil
 }
 
+// UpdateSettings replaces a project's settings document.
+func (r *ProjectRepository) UpdateSettings(ctx context.Context, id uuid.UUID, settings domain.ProjectSettings) (*domain.Project, error) {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.q.UpdateProjectSettings(ctx, db.UpdateProjectSettingsParams{
+		ID:       uuidToPgtype(id),
+		Settings: data,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return projectToDoMain(&row), nil
+}
+
 // TransferOwnership transfers the project to a new owner.
 func (r *ProjectRepository) TransferOwnership(ctx context.Context, id, newOwnerID uuid.UUID) (*domain.Project, error) {
 	return r.TransferOwnershipTx(ctx, r.q, id, newOwnerID)
@@ -93,6 +160,55 @@ func (r *ProjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) error
 	return r.SoftDeleteTx(ctx, r.q, id)
 }
 
+// GetDeletedByID retrieves a soft-deleted project by ID.
+func (r *ProjectRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	row, err := r.q.GetDeletedProjectByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return projectToDoMain(&row), nil
+}
+
+// ListDeletedForUser retrieves the most recently deleted projects a user
+// owns, directly or as an owner of their organization.
+func (r *ProjectRepository) ListDeletedForUser(ctx context.Context, userID uuid.UUID, limit int32) ([]*domain.Project, error) {
+	rows, err := r.q.ListDeletedProjectsForUser(ctx, db.ListDeletedProjectsForUserParams{
+		UserID:     uuidToPgtype(userID),
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	projects := make([]*domain.Project, len(rows))
+	for i, row := range rows {
+		projects[i] = projectToDoMain(&row)
+	}
+	return projects, nil
+}
+
+// ListPurgeable retrieves projects deleted before the cutoff whose applications are all purged.
+func (r *ProjectRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	rows, err := r.q.ListPurgeableProjects(ctx, db.ListPurgeableProjectsParams{
+		DeletedBefore: pgtype.Timestamp{Time: deletedBefore, Valid: true},
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+// HardDelete permanently removes a project.
+func (r *ProjectRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.q.HardDeleteProject(ctx, uuidToPgtype(id)))
+}
+
 // ============================================================================
 // Transaction Methods (use provided queries)
 // ============================================================================
@@ -100,9 +216,10 @@ func (r *ProjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) error
 // CreateTx creates a project within a transaction.
 func (r *ProjectRepository) CreateTx(ctx context.Context, q *db.Queries, input domain.CreateProjectInput) (*domain.Project, error) {
 	row, err := q.CreateProject(ctx, db.CreateProjectParams{
-		Title:       input.Title,
-		Description: input.Description,
-		OwnerID:     uuidToPgtype(input.OwnerID),
+		Title:          input.Title,
+		Description:    input.Description,
+		OwnerID:        uuidToPgtype(input.OwnerID),
+		OrganizationID: uuidPtrToPgtype(input.OrganizationID),
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -137,6 +254,15 @@ func (r *ProjectRepository) SoftDeleteTx(ctx context.Context, q *db.Queries, id
 	return translateError(err)
 }
 
+// RestoreTx undoes the soft delete of a project within a transaction.
+func (r *ProjectRepository) RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Project, error) {
+	row, err := q.RestoreProject(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return projectToDoMain(&row), nil
+}
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
@@ -144,11 +270,54 @@ func (r *ProjectRepository) SoftDeleteTx(ctx context.Context, q *db.Queries, id
 // projectToDoMain converts a db.Project to a domain.Project.
 func projectToDoMain(row *db.Project) *domain.Project {
 	return &domain.Project{
-		ID:          pgtypeToUUID(row.ID),
-		Title:       row.Title,
-		Description: row.Description,
-		OwnerID:     pgtypeToUUID(row.OwnerID),
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
+		ID:             pgtypeToUUID(row.ID),
+		Title:          row.Title,
+		Description:    row.Description,
+		OwnerID:        pgtypeToUUID(row.OwnerID),
+		OrganizationID: pgtypeToUUID(row.OrganizationID),
+		Settings:       decodeProjectSettings(row.Settings),
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+		DeletedAt:      pgtypeToTimePtr(row.DeletedAt),
+	}
+}
+
+// decodeProjectSettings reads a settings document over the defaults, so keys
+// added after the project was created keep their default values.
+func decodeProjectSettings(data []byte) domain.ProjectSettings {
+	settings := domain.DefaultProjectSettings()
+	if err := json.Unmarshal(data, &settings); err != nil {
+		slog.Error("invalid project settings, using defaults", slog.String("error", err.Error()))
+		return domain.DefaultProjectSettings()
+	}
+	return settings
+}
+
+// projectSummaryRowToDomain converts a db.ListProjectsForUserRow to a domain.ProjectSummary.
+func projectSummaryRowToDomain(row *db.ListProjectsForUserRow) *domain.ProjectSummary {
+	summary := &domain.ProjectSummary{
+		Project: domain.Project{
+			ID:             pgtypeToUUID(row.ID),
+			Title:          row.Title,
+			Description:    row.Description,
+			OwnerID:        pgtypeToUUID(row.OwnerID),
+			OrganizationID: pgtypeToUUID(row.OrganizationID),
+			Settings:       decodeProjectSettings(row.Settings),
+			CreatedAt:      row.CreatedAt.Time,
+			UpdatedAt:      row.UpdatedAt.Time,
+		},
+		Role:             row.Role,
+		Permissions:      row.Permissions,
+		ApplicationCount: row.ApplicationCount,
+	}
+	if row.LatestReleaseID.Valid {
+		summary.LatestProductionRelease = &domain.ReleaseSummary{
+			ID:            pgtypeToUUID(row.LatestReleaseID),
+			ApplicationID: pgtypeToUUID(row.LatestReleaseApplicationID),
+			VersionName:   pgtypeToString(row.LatestReleaseVersionName),
+			VersionCode:   row.LatestReleaseVersionCode.Int32,
+			CreatedAt:     row.LatestReleaseCreatedAt.Time,
+		}
 	}
+	return summary
 }
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// PromotionRequestRepository implements repository.PromotionRequestRepository using PostgreSQL.
+type PromotionRequestRepository struct {
+	q *db.Queries
+}
+
+// NewPromotionRequestRepository creates a new PostgreSQL promotion request repository.
+func NewPromotionRequestRepository(q *db.Queries) *PromotionRequestRepository {
+	return &PromotionRequestRepository{q: q}
+}
+
+// GetByID retrieves a promotion request by ID, with its reviews.
+func (r *PromotionRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PromotionRequest, error) {
+	row, err := r.q.GetPromotionRequestByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return r.withReviews(ctx, r.q, &row)
+}
+
+// ListByApplication lists the most recent promotion requests of an application.
+func (r *PromotionRequestRepository) ListByApplication(ctx context.Context, appID uuid.UUID, status domain.PromotionRequestStatus, limit int32) ([]*domain.PromotionRequest, error) {
+	rows, err := r.q.ListPromotionRequestsByApplication(ctx, db.ListPromotionRequestsByApplicationParams{
+		ApplicationID: uuidToPgtype(appID),
+		Status: db.NullPromotionRequestStatus{
+			PromotionRequestStatus: db.PromotionRequestStatus(status),
+			Valid:                  status != "",
+		},
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	requests := make([]*domain.PromotionRequest, len(rows))
+	for i, row := range rows {
+		requests[i] = rowToPromotionRequest(&row)
+	}
+	return requests, nil
+}
+
+// ========== Transaction Methods ==========
+
+// CreateTx opens a promotion request within a transaction.
+func (r *PromotionRequestRepository) CreateTx(ctx context.Context, q *db.Queries, input domain.CreatePromotionRequestInput) (*domain.PromotionRequest, error) {
+	roles := input.ApproverRoles
+	if roles == nil {
+		roles = []string{}
+	}
+	row, err := q.CreatePromotionRequest(ctx, db.CreatePromotionRequestParams{
+		ApplicationID:     uuidToPgtype(input.ApplicationID),
+		ReleaseID:         uuidToPgtype(input.ReleaseID),
+		FromEnvironment:   string(input.FromEnvironment),
+		ToEnvironment:     string(input.ToEnvironment),
+		RequestedBy:       uuidToPgtype(input.RequestedBy),
+		Note:              input.Note,
+		RequiredApprovals: int32(input.RequiredApprovals),
+		ApproverRoles:     roles,
+		RolloutPercentage: input.RolloutPercentage,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToPromotionRequest(&row), nil
+}
+
+// GetByIDForUpdateTx retrieves and locks a promotion request within a transaction.
+func (r *PromotionRequestRepository) GetByIDForUpdateTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.PromotionRequest, error) {
+	row, err := q.GetPromotionRequestByIDForUpdate(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return r.withReviews(ctx, q, &row)
+}
+
+// ResolveTx closes a pending promotion request within a transaction.
+func (r *PromotionRequestRepository) ResolveTx(ctx context.Context, q *db.Queries, id uuid.UUID, status domain.PromotionRequestStatus, promotedReleaseID *uuid.UUID) error {
+	_, err := q.ResolvePromotionRequest(ctx, db.ResolvePromotionRequestParams{
+		ID:                uuidToPgtype(id),
+		Status:            db.PromotionRequestStatus(status),
+		PromotedReleaseID: uuidPtrToPgtype(promotedReleaseID),
+	})
+	return translateError(err)
+}
+
+// FailTx closes a pending promotion request that could not be carried out within a transaction.
+func (r *PromotionRequestRepository) FailTx(ctx context.Context, q *db.Queries, id uuid.UUID, reason string) error {
+	return translateError(q.FailPromotionRequest(ctx, db.FailPromotionRequestParams{
+		ID:            uuidToPgtype(id),
+		FailureReason: reason,
+	}))
+}
+
+// CreateReviewTx records an approval or rejection within a transaction.
+func (r *PromotionRequestRepository) CreateReviewTx(ctx context.Context, q *db.Queries, requestID, reviewerID uuid.UUID, approved bool, comment string) (*domain.PromotionReview, error) {
+	row, err := q.CreatePromotionReview(ctx, db.CreatePromotionReviewParams{
+		RequestID:  uuidToPgtype(requestID),
+		ReviewerID: uuidToPgtype(reviewerID),
+		Approved:   approved,
+		Comment:    comment,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToPromotionReview(&row), nil
+}
+
+func (r *PromotionRequestRepository) withReviews(ctx context.Context, q *db.Queries, row *db.PromotionRequest) (*domain.PromotionRequest, error) {
+	rows, err := q.ListPromotionReviewsByRequest(ctx, row.ID)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	request := rowToPromotionRequest(row)
+	request.Reviews = make([]*domain.PromotionReview, len(rows))
+	for i, review := range rows {
+		request.Reviews[i] = rowToPromotionReview(&review)
+	}
+	return request, nil
+}
+
+// Helper to convert DB row to domain PromotionRequest
+func rowToPromotionRequest(row *db.PromotionRequest) *domain.PromotionRequest {
+	return &domain.PromotionRequest{
+		ID:                pgtypeToUUID(row.ID),
+		ApplicationID:     pgtypeToUUID(row.ApplicationID),
+		ReleaseID:         pgtypeToUUID(row.ReleaseID),
+		FromEnvironment:   domain.ReleaseEnvironment(row.FromEnvironment),
+		ToEnvironment:     domain.ReleaseEnvironment(row.ToEnvironment),
+		RequestedBy:       pgtypeToUUIDPtr(row.RequestedBy),
+		Note:              row.Note,
+		Status:            domain.PromotionRequestStatus(row.Status),
+		RequiredApprovals: int(row.RequiredApprovals),
+		ApproverRoles:     row.ApproverRoles,
+		PromotedReleaseID: pgtypeToUUIDPtr(row.PromotedReleaseID),
+		FailureReason:     row.FailureReason,
+		RolloutPercentage: row.RolloutPercentage,
+		ResolvedAt:        pgtypeToTimePtr(row.ResolvedAt),
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+}
+
+// Helper to convert DB row to domain PromotionReview
+func rowToPromotionReview(row *db.PromotionReview) *domain.PromotionReview {
+	return &domain.PromotionReview{
+		ID:         pgtypeToUUID(row.ID),
+		RequestID:  pgtypeToUUID(row.RequestID),
+		ReviewerID: pgtypeToUUIDPtr(row.ReviewerID),
+		Approved:   row.Approved,
+		Comment:    row.Comment,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // ReleaseRepository implements repository.ReleaseRepository using PostgreSQL.
@@ -20,18 +22,7 @@ func NewReleaseRepository(q *db.Queries) *ReleaseRepository {
 
 // Create creates a new release.
 func (r *ReleaseRepository) Create(ctx context.Context, input domain.CreateReleaseInput) (*domain.ApplicationRelease, error) {
-	row, err := r.q.CreateApplicationRelease(ctx, db.CreateApplicationReleaseParams{
-		Title:         input.Title,
-		VersionCode:   input.VersionCode,
-		VersionName:   input.VersionName,
-		ReleaseNote:   stringToPgtype(input.ReleaseNote),
-		Environment:   db.ReleaseEnvironment(input.Environment),
-		ApplicationID: uuidToPgtype(input.ApplicationID),
-	})
-	if err != nil {
-		return nil, translateError(err)
-	}
-	return rowToRelease(&row), nil
+	return r.CreateTx(ctx, r.q, input)
 }
 
 // GetByID retrieves a release by ID.
@@ -43,9 +34,21 @@ func (r *ReleaseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return rowToRelease(&row), nil
 }
 
-// ListByApplication lists all releases for an application.
-func (r *ReleaseRepository) ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.ApplicationRelease, error) {
-	rows, err := r.q.ListReleasesByApplication(ctx, uuidToPgtype(appID))
+// ListByApplication lists a page of the releases of an application.
+func (r *ReleaseRepository) ListByApplication(ctx context.Context, appID uuid.UUID, filter domain.ReleaseFilter, page domain.PageRequest) (*domain.Page[*domain.ApplicationRelease], error) {
+	rows, err := r.q.ListReleasesByApplication(ctx, db.ListReleasesByApplicationParams{
+		ApplicationID: uuidToPgtype(appID),
+		Status: db.NullReleaseStatus{
+			ReleaseStatus: db.ReleaseStatus(filter.Status),
+			Valid:         filter.Status != "",
+		},
+		IncludeDrafts:    filter.IncludeDrafts,
+		Environment:      stringToPgtype(string(filter.Environment)),
+		AfterID:          afterID(page),
+		Ascending:        page.Ascending,
+		AfterVersionCode: afterVersionCode(page),
+		MaxResults:       fetchLimit(page),
+	})
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -54,14 +57,14 @@ func (r *ReleaseRepository) ListByApplication(ctx context.Context, appID uuid.UU
 	for i, row := range rows {
 		releases[i] = rowToRelease(&row)
 	}
-	return releases, nil
+	return domain.NewPage(releases, page.Limit, (*domain.ApplicationRelease).Cursor), nil
 }
 
 // ListByEnvironment lists releases by environment.
 func (r *ReleaseRepository) ListByEnvironment(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) ([]*domain.ApplicationRelease, error) {
 	rows, err := r.q.ListReleasesByEnvironment(ctx, db.ListReleasesByEnvironmentParams{
 		ApplicationID: uuidToPgtype(appID),
-		Environment:   db.ReleaseEnvironment(env),
+		Environment:   string(env),
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -76,9 +79,14 @@ func (r *ReleaseRepository) ListByEnvironment(ctx context.Context, appID uuid.UU
 
 // GetLatestByEnvironment gets the latest release.
 func (r *ReleaseRepository) GetLatestByEnvironment(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error) {
-	row, err := r.q.GetLatestReleaseByEnvironment(ctx, db.GetLatestReleaseByEnvironmentParams{
+	return r.GetLatestByEnvironmentTx(ctx, r.q, appID, env)
+}
+
+// GetLatestByEnvironmentTx retrieves the latest release of a channel within a transaction.
+func (r *ReleaseRepository) GetLatestByEnvironmentTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error) {
+	row, err := q.GetLatestReleaseByEnvironment(ctx, db.GetLatestReleaseByEnvironmentParams{
 		ApplicationID: uuidToPgtype(appID),
-		Environment:   db.ReleaseEnvironment(env),
+		Environment:   string(env),
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -87,11 +95,12 @@ func (r *ReleaseRepository) GetLatestByEnvironment(ctx context.Context, appID uu
 }
 
 // Update updates a release.
-func (r *ReleaseRepository) Update(ctx context.Context, id uuid.UUID, title, releaseNote string) (*domain.ApplicationRelease, error) {
+func (r *ReleaseRepository) Update(ctx context.Context, id uuid.UUID, title, releaseNote string, mandatory bool) (*domain.ApplicationRelease, error) {
 	row, err := r.q.UpdateRelease(ctx, db.UpdateReleaseParams{
 		ID:          uuidToPgtype(id),
 		Title:       title,
 		ReleaseNote: stringToPgtype(releaseNote),
+		Mandatory:   mandatory,
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -99,11 +108,100 @@ func (r *ReleaseRepository) Update(ctx context.Context, id uuid.UUID, title, rel
 	return rowToRelease(&row), nil
 }
 
-// Promote updates the environment.
-func (r *ReleaseRepository) Promote(ctx context.Context, id uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error) {
-	row, err := r.q.PromoteRelease(ctx, db.PromoteReleaseParams{
-		ID:          uuidToPgtype(id),
-		Environment: db.ReleaseEnvironment(env),
+// SoftDelete marks a release as deleted.
+func (r *ReleaseRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return r.SoftDeleteTx(ctx, r.q, id)
+}
+
+// VersionExists checks if a release with the given version code and environment already exists for an application.
+func (r *ReleaseRepository) VersionExists(ctx context.Context, appID uuid.UUID, versionCode int32, env domain.ReleaseEnvironment) (bool, error) {
+	return r.VersionExistsTx(ctx, r.q, appID, versionCode, env)
+}
+
+// VersionExistsTx checks if a release with the given version code and environment
+// already exists for an application within a transaction.
+func (r *ReleaseRepository) VersionExistsTx(ctx context.Context, q *db.Queries, appID uuid.UUID, versionCode int32, env domain.ReleaseEnvironment) (bool, error) {
+	exists, err := q.CheckReleaseExists(ctx, db.CheckReleaseExistsParams{
+		ApplicationID: uuidToPgtype(appID),
+		VersionCode:   versionCode,
+		Environment:   string(env),
+	})
+	if err != nil {
+		return false, translateError(err)
+	}
+	return exists, nil
+}
+
+// HighestVersionCode returns the highest version code ever used in an environment,
+// deleted releases included, or 0 if none.
+func (r *ReleaseRepository) HighestVersionCode(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error) {
+	return r.HighestVersionCodeTx(ctx, r.q, appID, env)
+}
+
+// HighestVersionCodeTx returns the highest version code ever used in an environment
+// within a transaction, deleted releases included, or 0 if none.
+func (r *ReleaseRepository) HighestVersionCodeTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error) {
+	highest, err := q.GetHighestVersionCode(ctx, db.GetHighestVersionCodeParams{
+		ApplicationID: uuidToPgtype(appID),
+		Environment:   string(env),
+	})
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return highest, nil
+}
+
+// ListChangelog retrieves the published and archived releases of an environment
+// within a range of version codes, newest first, at most limit of them.
+func (r *ReleaseRepository) ListChangelog(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment, versions domain.ChangelogRange, limit int32) ([]*domain.ApplicationRelease, error) {
+	rows, err := r.q.ListChangelogReleases(ctx, db.ListChangelogReleasesParams{
+		ApplicationID:    uuidToPgtype(appID),
+		Environment:      string(env),
+		AfterVersionCode: versions.From,
+		UpToVersionCode:  versions.UpTo(),
+		MaxResults:       limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	releases := make([]*domain.ApplicationRelease, len(rows))
+	for i, row := range rows {
+		releases[i] = rowToRelease(&row)
+	}
+	return releases, nil
+}
+
+// ========== Rollouts ==========
+
+// ListRolloutCandidates retrieves the published releases of a channel, newest
+// first, down to the newest one fully rolled out.
+func (r *ReleaseRepository) ListRolloutCandidates(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) ([]*domain.ApplicationRelease, error) {
+	rows, err := r.q.ListRolloutCandidates(ctx, db.ListRolloutCandidatesParams{
+		ApplicationID: uuidToPgtype(appID),
+		Environment:   string(env),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	releases := make([]*domain.ApplicationRelease, len(rows))
+	for i, row := range rows {
+		releases[i] = rowToRelease(&row)
+	}
+	return releases, nil
+}
+
+// IncreaseRollout raises the rollout percentage of a published release, resuming it if halted.
+func (r *ReleaseRepository) IncreaseRollout(ctx context.Context, id uuid.UUID, percentage int32) (*domain.ApplicationRelease, error) {
+	return r.IncreaseRolloutTx(ctx, r.q, id, percentage)
+}
+
+// IncreaseRolloutTx raises the rollout percentage of a published release within a transaction.
+func (r *ReleaseRepository) IncreaseRolloutTx(ctx context.Context, q *db.Queries, id uuid.UUID, percentage int32) (*domain.ApplicationRelease, error) {
+	row, err := q.IncreaseReleaseRollout(ctx, db.IncreaseReleaseRolloutParams{
+		RolloutPercentage: percentage,
+		ID:                uuidToPgtype(id),
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -111,18 +209,47 @@ func (r *ReleaseRepository) Promote(ctx context.Context, id uuid.UUID, env domai
 	return rowToRelease(&row), nil
 }
 
-// SoftDelete marks a release as deleted.
-func (r *ReleaseRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.q.SoftDeleteApplicationRelease(ctx, uuidToPgtype(id))
-	return translateError(err)
+// HaltRollout halts the staged rollout of a published release.
+func (r *ReleaseRepository) HaltRollout(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	return r.HaltRolloutTx(ctx, r.q, id)
 }
 
-// VersionExists checks if a release with the given version code and environment already exists for an application.
-func (r *ReleaseRepository) VersionExists(ctx context.Context, appID uuid.UUID, versionCode int32, env domain.ReleaseEnvironment) (bool, error) {
-	exists, err := r.q.CheckReleaseExists(ctx, db.CheckReleaseExistsParams{
+// HaltRolloutTx halts the staged rollout of a published release within a transaction.
+func (r *ReleaseRepository) HaltRolloutTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	row, err := q.HaltReleaseRollout(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToRelease(&row), nil
+}
+
+// HighestFullyRolledOutVersionCode returns the version code of the newest
+// published release of a channel offered to every client, or 0 if none.
+func (r *ReleaseRepository) HighestFullyRolledOutVersionCode(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error) {
+	return r.HighestFullyRolledOutVersionCodeTx(ctx, r.q, appID, env)
+}
+
+// HighestFullyRolledOutVersionCodeTx returns the version code of the newest published
+// release of a channel offered to every client within a transaction, or 0 if none.
+func (r *ReleaseRepository) HighestFullyRolledOutVersionCodeTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error) {
+	highest, err := q.GetHighestFullyRolledOutVersionCode(ctx, db.GetHighestFullyRolledOutVersionCodeParams{
 		ApplicationID: uuidToPgtype(appID),
-		VersionCode:   versionCode,
-		Environment:   db.ReleaseEnvironment(env),
+		Environment:   string(env),
+	})
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return highest, nil
+}
+
+// HasMandatoryBetween checks if a published mandatory release of a channel has
+// a version code above after and up to upTo.
+func (r *ReleaseRepository) HasMandatoryBetween(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment, after, upTo int32) (bool, error) {
+	exists, err := r.q.CheckMandatoryReleaseBetween(ctx, db.CheckMandatoryReleaseBetweenParams{
+		ApplicationID:    uuidToPgtype(appID),
+		Environment:      string(env),
+		AfterVersionCode: after,
+		UpToVersionCode:  upTo,
 	})
 	if err != nil {
 		return false, translateError(err)
@@ -130,17 +257,117 @@ func (r *ReleaseRepository) VersionExists(ctx context.Context, appID uuid.UUID,
 	return exists, nil
 }
 
+// ========== Promotions ==========
+
+// ListPromotionsByApplication lists the most recent promotions of an application's releases.
+func (r *ReleaseRepository) ListPromotionsByApplication(ctx context.Context, appID uuid.UUID, limit int32) ([]*domain.ReleasePromotion, error) {
+	rows, err := r.q.ListReleasePromotionsByApplication(ctx, db.ListReleasePromotionsByApplicationParams{
+		ApplicationID: uuidToPgtype(appID),
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToPromotions(rows), nil
+}
+
+// ListPromotionsByRelease lists the promotions a release was copied from or into.
+func (r *ReleaseRepository) ListPromotionsByRelease(ctx context.Context, releaseID uuid.UUID) ([]*domain.ReleasePromotion, error) {
+	rows, err := r.q.ListReleasePromotionsByRelease(ctx, uuidToPgtype(releaseID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToPromotions(rows), nil
+}
+
+// IsPromoted reports whether a release was promoted from or into another.
+func (r *ReleaseRepository) IsPromoted(ctx context.Context, releaseID uuid.UUID) (bool, error) {
+	promoted, err := r.q.CheckReleasePromoted(ctx, uuidToPgtype(releaseID))
+	return promoted, translateError(err)
+}
+
+// ========== Trash ==========
+
+// GetDeletedByID retrieves a soft-deleted release by ID.
+func (r *ReleaseRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	row, err := r.q.GetDeletedApplicationReleaseByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToRelease(&row), nil
+}
+
+// ListDeletedByProject retrieves the most recently deleted releases of a project's applications.
+func (r *ReleaseRepository) ListDeletedByProject(ctx context.Context, projectID uuid.UUID, limit int32) ([]*domain.ApplicationRelease, error) {
+	rows, err := r.q.ListDeletedReleasesByProject(ctx, db.ListDeletedReleasesByProjectParams{
+		ProjectID:  uuidToPgtype(projectID),
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	releases := make([]*domain.ApplicationRelease, len(rows))
+	for i, row := range rows {
+		releases[i] = rowToRelease(&row)
+	}
+	return releases, nil
+}
+
+// ListPastRetention retrieves live releases their project's retention policy no longer keeps.
+func (r *ReleaseRepository) ListPastRetention(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	rows, err := r.q.ListReleasesPastRetention(ctx, limit)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+// ListPurgeable retrieves releases deleted before the cutoff whose artifacts are all purged.
+func (r *ReleaseRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	rows, err := r.q.ListPurgeableReleases(ctx, db.ListPurgeableReleasesParams{
+		DeletedBefore: pgtype.Timestamp{Time: deletedBefore, Valid: true},
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+// HardDelete permanently removes a release.
+func (r *ReleaseRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.q.HardDeleteApplicationRelease(ctx, uuidToPgtype(id)))
+}
+
 // ========== Transaction Methods ==========
 
 // CreateTx creates a new release within a transaction.
 func (r *ReleaseRepository) CreateTx(ctx context.Context, q *db.Queries, input domain.CreateReleaseInput) (*domain.ApplicationRelease, error) {
+	rollout := input.RolloutPercentage
+	if rollout == 0 {
+		rollout = domain.FullRollout
+	}
 	row, err := q.CreateApplicationRelease(ctx, db.CreateApplicationReleaseParams{
-		Title:         input.Title,
-		VersionCode:   input.VersionCode,
-		VersionName:   input.VersionName,
-		ReleaseNote:   stringToPgtype(input.ReleaseNote),
-		Environment:   db.ReleaseEnvironment(input.Environment),
-		ApplicationID: uuidToPgtype(input.ApplicationID),
+		Title:             input.Title,
+		VersionCode:       input.VersionCode,
+		VersionName:       input.VersionName,
+		ReleaseNote:       stringToPgtype(input.ReleaseNote),
+		Environment:       string(input.Environment),
+		ApplicationID:     uuidToPgtype(input.ApplicationID),
+		RolloutPercentage: rollout,
+		Mandatory:         input.Mandatory,
+		Status:            db.ReleaseStatus(input.Status),
 	})
 	if err != nil {
 		return nil, translateError(err)
@@ -157,6 +384,112 @@ func (r *ReleaseRepository) GetByIDTx(ctx context.Context, q *db.Queries, id uui
 	return rowToRelease(&row), nil
 }
 
+// Publish publishes a draft or reinstates a withdrawn release.
+func (r *ReleaseRepository) Publish(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	return r.PublishTx(ctx, r.q, id)
+}
+
+// Withdraw withdraws a published release.
+func (r *ReleaseRepository) Withdraw(ctx context.Context, id, actorID uuid.UUID, reason string) (*domain.ApplicationRelease, error) {
+	return r.WithdrawTx(ctx, r.q, id, actorID, reason)
+}
+
+// Archive archives a published or withdrawn release.
+func (r *ReleaseRepository) Archive(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	return r.ArchiveTx(ctx, r.q, id)
+}
+
+// WithdrawTx withdraws a release from its channel within a transaction.
+func (r *ReleaseRepository) WithdrawTx(ctx context.Context, q *db.Queries, id, actorID uuid.UUID, reason string) (*domain.ApplicationRelease, error) {
+	row, err := q.WithdrawApplicationRelease(ctx, db.WithdrawApplicationReleaseParams{
+		ID:               uuidToPgtype(id),
+		WithdrawnBy:      uuidToPgtype(actorID),
+		WithdrawalReason: reason,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToRelease(&row), nil
+}
+
+// PublishTx publishes a draft or reinstates a withdrawn release within a transaction.
+func (r *ReleaseRepository) PublishTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	row, err := q.PublishApplicationRelease(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToRelease(&row), nil
+}
+
+// ArchiveTx archives a published or withdrawn release within a transaction.
+func (r *ReleaseRepository) ArchiveTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	row, err := q.ArchiveApplicationRelease(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToRelease(&row), nil
+}
+
+// CreatePromotionTx records a promotion within a transaction.
+func (r *ReleaseRepository) CreatePromotionTx(ctx context.Context, q *db.Queries, input domain.CreateReleasePromotionInput) (*domain.ReleasePromotion, error) {
+	row, err := q.CreateReleasePromotion(ctx, db.CreateReleasePromotionParams{
+		ApplicationID:   uuidToPgtype(input.ApplicationID),
+		SourceReleaseID: uuidToPgtype(input.SourceReleaseID),
+		ReleaseID:       uuidToPgtype(input.ReleaseID),
+		VersionCode:     input.VersionCode,
+		VersionName:     input.VersionName,
+		FromEnvironment: string(input.FromEnvironment),
+		ToEnvironment:   string(input.ToEnvironment),
+		ActorID:         uuidToPgtype(input.ActorID),
+		Note:            input.Note,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToPromotion(&row), nil
+}
+
+// RestoreTx undoes the soft delete of a release within a transaction.
+func (r *ReleaseRepository) RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	row, err := q.RestoreApplicationRelease(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToRelease(&row), nil
+}
+
+// RestoreDeletedWithApplicationTx restores the releases deleted at the same time as their application.
+func (r *ReleaseRepository) RestoreDeletedWithApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID, deletedAt time.Time) error {
+	return translateError(q.RestoreReleasesDeletedWithApplication(ctx, db.RestoreReleasesDeletedWithApplicationParams{
+		ApplicationID: uuidToPgtype(appID),
+		DeletedAt:     pgtype.Timestamp{Time: deletedAt, Valid: true},
+	}))
+}
+
+// RestoreDeletedWithProjectTx restores the releases deleted at the same time as their project.
+func (r *ReleaseRepository) RestoreDeletedWithProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID, deletedAt time.Time) error {
+	return translateError(q.RestoreReleasesDeletedWithProject(ctx, db.RestoreReleasesDeletedWithProjectParams{
+		DeletedAt: pgtype.Timestamp{Time: deletedAt, Valid: true},
+		ProjectID: uuidToPgtype(projectID),
+	}))
+}
+
+// SoftDeleteTx marks a release as deleted within a transaction.
+func (r *ReleaseRepository) SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	_, err := q.SoftDeleteApplicationRelease(ctx, uuidToPgtype(id))
+	return translateError(err)
+}
+
+// SoftDeleteByApplicationTx marks the live releases of an application as deleted within a transaction.
+func (r *ReleaseRepository) SoftDeleteByApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID) error {
+	return translateError(q.SoftDeleteReleasesByApplication(ctx, uuidToPgtype(appID)))
+}
+
+// SoftDeleteByProjectTx marks the live releases of a project's applications as deleted within a transaction.
+func (r *ReleaseRepository) SoftDeleteByProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID) error {
+	return translateError(q.SoftDeleteReleasesByProject(ctx, uuidToPgtype(projectID)))
+}
+
 // Helper to convert DB row to domain ApplicationRelease
 func rowToRelease(row *db.ApplicationRelease) *domain.ApplicationRelease {
 	return &domain.ApplicationRelease{
@@ -167,7 +500,45 @@ func rowToRelease(row *db.ApplicationRelease) *domain.ApplicationRelease {
 		ReleaseNote:   pgtypeToString(row.ReleaseNote),
 		Environment:   domain.ReleaseEnvironment(row.Environment),
 		ApplicationID: pgtypeToUUID(row.ApplicationID),
+		Status:        domain.ReleaseStatus(row.Status),
+		PublishedAt:   pgtypeToTimePtr(row.PublishedAt),
 		CreatedAt:     row.CreatedAt.Time,
 		UpdatedAt:     row.UpdatedAt.Time,
+		DeletedAt:     pgtypeToTimePtr(row.DeletedAt),
+
+		WithdrawnAt:      pgtypeToTimePtr(row.WithdrawnAt),
+		WithdrawnBy:      pgtypeToUUIDPtr(row.WithdrawnBy),
+		WithdrawalReason: row.WithdrawalReason,
+
+		Rollout: domain.Rollout{
+			Percentage: row.RolloutPercentage,
+			HaltedAt:   pgtypeToTimePtr(row.RolloutHaltedAt),
+		},
+		Mandatory: row.Mandatory,
+	}
+}
+
+func rowsToPromotions(rows []db.ReleasePromotion) []*domain.ReleasePromotion {
+	promotions := make([]*domain.ReleasePromotion, len(rows))
+	for i, row := range rows {
+		promotions[i] = rowToPromotion(&row)
+	}
+	return promotions
+}
+
+// Helper to convert DB row to domain ReleasePromotion
+func rowToPromotion(row *db.ReleasePromotion) *domain.ReleasePromotion {
+	return &domain.ReleasePromotion{
+		ID:              pgtypeToUUID(row.ID),
+		ApplicationID:   pgtypeToUUID(row.ApplicationID),
+		SourceReleaseID: pgtypeToUUIDPtr(row.SourceReleaseID),
+		ReleaseID:       pgtypeToUUIDPtr(row.ReleaseID),
+		VersionCode:     row.VersionCode,
+		VersionName:     row.VersionName,
+		FromEnvironment: domain.ReleaseEnvironment(row.FromEnvironment),
+		ToEnvironment:   domain.ReleaseEnvironment(row.ToEnvironment),
+		ActorID:         pgtypeToUUIDPtr(row.ActorID),
+		Note:            row.Note,
+		CreatedAt:       row.CreatedAt.Time,
 	}
 }
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ReleaseNoteRepository implements repository.ReleaseNoteRepository using PostgreSQL.
+type ReleaseNoteRepository struct {
+	q *db.Queries
+}
+
+// NewReleaseNoteRepository creates a new PostgreSQL release note repository.
+func NewReleaseNoteRepository(q *db.Queries) *ReleaseNoteRepository {
+	return &ReleaseNoteRepository{q: q}
+}
+
+// Upsert creates or replaces the note of a release in a locale.
+func (r *ReleaseNoteRepository) Upsert(ctx context.Context, releaseID uuid.UUID, locale, note string) (*domain.ReleaseNote, error) {
+	row, err := r.q.UpsertReleaseNote(ctx, db.UpsertReleaseNoteParams{
+		ReleaseID: uuidToPgtype(releaseID),
+		Locale:    locale,
+		Note:      note,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowToReleaseNote(&row), nil
+}
+
+// ListByRelease retrieves the translated notes of a release, by locale.
+func (r *ReleaseNoteRepository) ListByRelease(ctx context.Context, releaseID uuid.UUID) ([]*domain.ReleaseNote, error) {
+	rows, err := r.q.ListReleaseNotes(ctx, uuidToPgtype(releaseID))
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToReleaseNotes(rows), nil
+}
+
+// ListForReleases retrieves the notes of several releases in any of the given locales.
+func (r *ReleaseNoteRepository) ListForReleases(ctx context.Context, releaseIDs []uuid.UUID, locales []string) ([]*domain.ReleaseNote, error) {
+	if len(releaseIDs) == 0 || len(locales) == 0 {
+		return nil, nil
+	}
+	ids := make([]pgtype.UUID, len(releaseIDs))
+	for i, id := range releaseIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	rows, err := r.q.ListReleaseNotesForReleases(ctx, db.ListReleaseNotesForReleasesParams{
+		ReleaseIds: ids,
+		Locales:    locales,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return rowsToReleaseNotes(rows), nil
+}
+
+// Delete removes the note of a release in a locale.
+func (r *ReleaseNoteRepository) Delete(ctx context.Context, releaseID uuid.UUID, locale string) error {
+	n, err := r.q.DeleteReleaseNote(ctx, db.DeleteReleaseNoteParams{
+		ReleaseID: uuidToPgtype(releaseID),
+		Locale:    locale,
+	})
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ========== Transaction Methods ==========
+
+// CopyTx gives a release the translated notes of another within a transaction.
+func (r *ReleaseNoteRepository) CopyTx(ctx context.Context, q *db.Queries, sourceReleaseID, targetReleaseID uuid.UUID) error {
+	err := q.CopyReleaseNotes(ctx, db.CopyReleaseNotesParams{
+		TargetReleaseID: uuidToPgtype(targetReleaseID),
+		SourceReleaseID: uuidToPgtype(sourceReleaseID),
+	})
+	return translateError(err)
+}
+
+func rowsToReleaseNotes(rows []db.ReleaseNote) []*domain.ReleaseNote {
+	notes := make([]*domain.ReleaseNote, len(rows))
+	for i, row := range rows {
+		notes[i] = rowToReleaseNote(&row)
+	}
+	return notes
+}
+
+func rowToReleaseNote(row *db.ReleaseNote) *domain.ReleaseNote {
+	return &domain.ReleaseNote{
+		ReleaseID: pgtypeToUUID(row.ReleaseID),
+		Locale:    row.Locale,
+		Note:      row.Note,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
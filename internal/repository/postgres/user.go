@@ -4,11 +4,13 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // UserRepository implements repository.UserRepository using PostgreSQL.
@@ -81,9 +83,21 @@ func (r *UserRepository) GetCredentialsByUsername(ctx context.Context, username
 	}, nil
 }
 
-// List retrieves all active users.
-func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
-	rows, err := r.q.ListUsers(ctx)
+// List retrieves a page of users.
+func (r *UserRepository) List(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	isActive := pgtype.Bool{}
+	if filter.IsActive != nil {
+		isActive = pgtype.Bool{Bool: *filter.IsActive, Valid: true}
+	}
+
+	rows, err := r.q.ListUsers(ctx, db.ListUsersParams{
+		Search:         stringToPgtype(filter.Search),
+		IsActive:       isActive,
+		AfterID:        afterID(page),
+		Ascending:      page.Ascending,
+		AfterCreatedAt: afterCreatedAt(page),
+		MaxResults:     fetchLimit(page),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -92,19 +106,12 @@ func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
 	for i, row := range rows {
 		users[i] = listUserRowToUser(&row)
 	}
-	return users, nil
+	return domain.NewPage(users, page.Limit, (*domain.User).Cursor), nil
 }
 
 // UpdateEmail updates a user's email.
 func (r *UserRepository) UpdateEmail(ctx context.Context, id uuid.UUID, email string) (*domain.User, error) {
-	row, err := r.q.UpdateUserEmail(ctx, db.UpdateUserEmailParams{
-		ID:    uuidToPgtype(id),
-		Email: email,
-	})
-	if err != nil {
-		return nil, translateError(err)
-	}
-	return updateUserEmailRowToUser(&row), nil
+	return r.UpdateEmailTx(ctx, r.q, id, email)
 }
 
 // UpdateUsername updates a user's username.
@@ -119,6 +126,18 @@ func (r *UserRepository) UpdateUsername(ctx context.Context, id uuid.UUID, usern
 	return updateUserUsernameRowToUser(&row), nil
 }
 
+// UpdatePhoneNumber updates a user's phone number.
+func (r *UserRepository) UpdatePhoneNumber(ctx context.Context, id uuid.UUID, phoneNumber string) (*domain.User, error) {
+	row, err := r.q.UpdateUserPhoneNumber(ctx, db.UpdateUserPhoneNumberParams{
+		ID:          uuidToPgtype(id),
+		PhoneNumber: stringToPgtype(phoneNumber),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return updateUserPhoneNumberRowToUser(&row), nil
+}
+
 // UpdatePassword updates a user's password hash.
 func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
 	_, err := r.q.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
@@ -141,6 +160,42 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, firstN
 	return updateUserProfileRowToUser(&row), nil
 }
 
+// UpdateAvatar sets the avatar URL of a user; an empty URL removes the avatar.
+func (r *UserRepository) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL string) (*domain.User, error) {
+	row, err := r.q.UpdateUserAvatar(ctx, db.UpdateUserAvatarParams{
+		ID:        uuidToPgtype(id),
+		AvatarUrl: stringToPgtype(avatarURL),
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return updateUserAvatarRowToUser(&row), nil
+}
+
+// UpdateActiveStatus activates or deactivates a user and revokes all issued tokens.
+func (r *UserRepository) UpdateActiveStatus(ctx context.Context, id uuid.UUID, isActive bool) (*domain.User, error) {
+	row, err := r.q.UpdateUserActiveStatus(ctx, db.UpdateUserActiveStatusParams{
+		ID:       uuidToPgtype(id),
+		IsActive: isActive,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return updateUserActiveStatusRowToUser(&row), nil
+}
+
+// UpdateAdminStatus grants or revokes system administration and revokes all issued tokens.
+func (r *UserRepository) UpdateAdminStatus(ctx context.Context, id uuid.UUID, isAdmin bool) (*domain.User, error) {
+	row, err := r.q.UpdateUserAdminStatus(ctx, db.UpdateUserAdminStatusParams{
+		ID:      uuidToPgtype(id),
+		IsAdmin: isAdmin,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return updateUserAdminStatusRowToUser(&row), nil
+}
+
 // UpdateLastLogin updates the last login timestamp.
 func (r *UserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID) error {
 	_, err := r.q.UpdateLastLogin(ctx, uuidToPgtype(id))
@@ -152,6 +207,42 @@ func (r *UserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return r.SoftDeleteTx(ctx, r.q, id)
 }
 
+// ListPendingAnonymization returns deleted users awaiting anonymisation, oldest first.
+func (r *UserRepository) ListPendingAnonymization(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	rows, err := r.q.ListUsersPendingAnonymization(ctx, db.ListUsersPendingAnonymizationParams{
+		DeletedBefore: pgtype.Timestamp{Time: deletedBefore, Valid: true},
+		MaxResults:    limit,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+// ListPendingStoragePurge returns anonymised users whose files are not deleted yet, oldest first.
+func (r *UserRepository) ListPendingStoragePurge(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	rows, err := r.q.ListUsersPendingStoragePurge(ctx, limit)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+// MarkStoragePurged records that the stored files of an anonymised user are deleted.
+func (r *UserRepository) MarkStoragePurged(ctx context.Context, id uuid.UUID) error {
+	return translateError(r.q.MarkUserStoragePurged(ctx, uuidToPgtype(id)))
+}
+
 // EmailExists checks if an email is already registered.
 func (r *UserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	return r.EmailExistsTx(ctx, r.q, email)
@@ -167,6 +258,15 @@ func (r *UserRepository) PhoneNumberExists(ctx context.Context, phoneNumber stri
 	return r.PhoneNumberExistsTx(ctx, r.q, phoneNumber)
 }
 
+// IsEmailVerified checks if the user proved to own their current email address.
+func (r *UserRepository) IsEmailVerified(ctx context.Context, id uuid.UUID) (bool, error) {
+	verified, err := r.q.CheckUserEmailVerified(ctx, uuidToPgtype(id))
+	if err != nil {
+		return false, translateError(err)
+	}
+	return verified, nil
+}
+
 // ============================================================================
 // Transaction Methods (use provided queries)
 // ============================================================================
@@ -176,7 +276,7 @@ func (r *UserRepository) CreateTx(ctx context.Context, q *db.Queries, input doma
 	row, err := q.CreateUser(ctx, db.CreateUserParams{
 		Email:        input.Email,
 		Username:     input.Username,
-		PhoneNumber:  input.PhoneNumber,
+		PhoneNumber:  stringToPgtype(input.PhoneNumber),
 		PasswordHash: passwordHash,
 		IsActive:     true,
 		FirstName:    input.FirstName,
@@ -223,7 +323,7 @@ func (r *UserRepository) UsernameExistsTx(ctx context.Context, q *db.Queries, us
 
 // PhoneNumberExistsTx checks phone number existence within a transaction.
 func (r *UserRepository) PhoneNumberExistsTx(ctx context.Context, q *db.Queries, phoneNumber string) (bool, error) {
-	_, err := q.GetUserByPhoneNumber(ctx, phoneNumber)
+	_, err := q.GetUserByPhoneNumber(ctx, stringToPgtype(phoneNumber))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
@@ -233,132 +333,320 @@ func (r *UserRepository) PhoneNumberExistsTx(ctx context.Context, q *db.Queries,
 	return true, nil
 }
 
+// UpdateEmailTx updates a user's email within a transaction.
+func (r *UserRepository) UpdateEmailTx(ctx context.Context, q *db.Queries, id uuid.UUID, email string) (*domain.User, error) {
+	row, err := q.UpdateUserEmail(ctx, db.UpdateUserEmailParams{
+		ID:    uuidToPgtype(id),
+		Email: email,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return updateUserEmailRowToUser(&row), nil
+}
+
+// MarkEmailVerifiedTx records that the user proved to own an address within a transaction.
+func (r *UserRepository) MarkEmailVerifiedTx(ctx context.Context, q *db.Queries, id uuid.UUID, email string) error {
+	return translateError(q.MarkUserEmailVerified(ctx, db.MarkUserEmailVerifiedParams{
+		ID:    uuidToPgtype(id),
+		Email: email,
+	}))
+}
+
+// ResetPasswordTx sets a new password hash and revokes all issued tokens within a transaction.
+func (r *UserRepository) ResetPasswordTx(ctx context.Context, q *db.Queries, id uuid.UUID, passwordHash string) (*domain.User, error) {
+	row, err := q.ResetUserPassword(ctx, db.ResetUserPasswordParams{
+		ID:           uuidToPgtype(id),
+		PasswordHash: passwordHash,
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return resetUserPasswordRowToUser(&row), nil
+}
+
 // SoftDeleteTx marks a user as deleted within a transaction.
 func (r *UserRepository) SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
 	_, err := q.SoftDeleteUser(ctx, uuidToPgtype(id))
 	return translateError(err)
 }
 
+// RestoreTx cancels the deletion of a user deleted after graceStart within a transaction.
+func (r *UserRepository) RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID, graceStart time.Time) (*domain.User, error) {
+	row, err := q.RestoreUser(ctx, db.RestoreUserParams{
+		ID:         uuidToPgtype(id),
+		GraceStart: pgtype.Timestamp{Time: graceStart, Valid: true},
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return restoreUserRowToUser(&row), nil
+}
+
+// AnonymizeTx overwrites the personal data of a deleted user within a transaction.
+func (r *UserRepository) AnonymizeTx(ctx context.Context, q *db.Queries, id uuid.UUID) error {
+	n, err := q.AnonymizeUser(ctx, uuidToPgtype(id))
+	if err != nil {
+		return translateError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 // ============================================================================
 // Row Conversion Functions
 // ============================================================================
 
 func rowToUser(row *db.CreateUserRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func getUserByIDRowToUser(row *db.GetUserByIDRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func getUserByEmailRowToUser(row *db.GetUserByEmailRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func getUserByUsernameRowToUser(row *db.GetUserByUsernameRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func listUserRowToUser(row *db.ListUsersRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func updateUserEmailRowToUser(row *db.UpdateUserEmailRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func updateUserUsernameRowToUser(row *db.UpdateUserUsernameRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
+	}
+}
+
+func updateUserPhoneNumberRowToUser(row *db.UpdateUserPhoneNumberRow) *domain.User {
+	return &domain.User{
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
+	}
+}
+
+func restoreUserRowToUser(row *db.RestoreUserRow) *domain.User {
+	return &domain.User{
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
+	}
+}
+
+func updateUserAvatarRowToUser(row *db.UpdateUserAvatarRow) *domain.User {
+	return &domain.User{
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
+	}
+}
+
+func resetUserPasswordRowToUser(row *db.ResetUserPasswordRow) *domain.User {
+	return &domain.User{
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
 
 func updateUserProfileRowToUser(row *db.UpdateUserProfileRow) *domain.User {
 	return &domain.User{
-		ID:          pgtypeToUUID(row.ID),
-		Email:       row.Email,
-		Username:    row.Username,
-		PhoneNumber: row.PhoneNumber,
-		FirstName:   row.FirstName,
-		LastName:    row.LastName,
-		IsActive:    row.IsActive,
-		CreatedAt:   row.CreatedAt.Time,
-		UpdatedAt:   row.UpdatedAt.Time,
-		LastLoginAt: pgtypeToTime(row.LastLoginAt),
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
+	}
+}
+
+func updateUserActiveStatusRowToUser(row *db.UpdateUserActiveStatusRow) *domain.User {
+	return &domain.User{
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
+	}
+}
+
+func updateUserAdminStatusRowToUser(row *db.UpdateUserAdminStatusRow) *domain.User {
+	return &domain.User{
+		ID:           pgtypeToUUID(row.ID),
+		Email:        row.Email,
+		Username:     row.Username,
+		PhoneNumber:  pgtypeToString(row.PhoneNumber),
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+		LastLoginAt:  pgtypeToTime(row.LastLoginAt),
+		TokenVersion: row.TokenVersion,
+		IsAdmin:      row.IsAdmin,
+		AvatarURL:    pgtypeToString(row.AvatarUrl),
 	}
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -18,8 +19,17 @@ type ProjectRepository interface {
 	// GetByID retrieves a project by ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error)
 
-	// ListByOwner retrieves all projects owned by a user.
-	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error)
+	// ListByOwner retrieves a page of the projects owned by a user, newest first by default.
+	ListByOwner(ctx context.Context, ownerID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.Project], error)
+
+	// ListForUser retrieves a page of the projects a user owns or is a member of,
+	// summarised with the user's role and the project's activity.
+	ListForUser(ctx context.Context, userID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.ProjectSummary], error)
+
+	// GetAccess retrieves the effective role and permissions of a user in a project,
+	// whether they come from ownership, the project's organization or a project membership.
+	// Returns domain.ErrNotFound if the user has no access.
+	GetAccess(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectAccess, error)
 
 	// UpdateTitle updates a project's title.
 	UpdateTitle(ctx context.Context, id uuid.UUID, title string) (*domain.Project, error)
@@ -30,12 +40,28 @@ type ProjectRepository interface {
 	// Update updates both title and description.
 	Update(ctx context.Context, id uuid.UUID, title, description string) (*domain.Project, error)
 
+	// UpdateSettings replaces a project's settings document.
+	UpdateSettings(ctx context.Context, id uuid.UUID, settings domain.ProjectSettings) (*domain.Project, error)
+
 	// TransferOwnership transfers the project to a new owner.
 	TransferOwnership(ctx context.Context, id, newOwnerID uuid.UUID) (*domain.Project, error)
 
 	// SoftDelete marks a project as deleted.
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 
+	// GetDeletedByID retrieves a soft-deleted project by its ID.
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Project, error)
+
+	// ListDeletedForUser retrieves the most recently deleted projects a user
+	// owns, directly or as an owner of their organization.
+	ListDeletedForUser(ctx context.Context, userID uuid.UUID, limit int32) ([]*domain.Project, error)
+
+	// ListPurgeable retrieves projects deleted before the cutoff whose applications are all purged.
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error)
+
+	// HardDelete permanently removes a project.
+	HardDelete(ctx context.Context, id uuid.UUID) error
+
 	// ========== Transaction Methods ==========
 
 	// CreateTx creates a project within a transaction.
@@ -49,4 +75,7 @@ type ProjectRepository interface {
 
 	// SoftDeleteTx marks a project as deleted within a transaction.
 	SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
+
+	// RestoreTx undoes the soft delete of a project within a transaction.
+	RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.Project, error)
 }
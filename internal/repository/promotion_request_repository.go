@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// PromotionRequestRepository defines the interface for promotion request data access.
+type PromotionRequestRepository interface {
+	// GetByID retrieves a promotion request by ID, with its reviews.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PromotionRequest, error)
+
+	// ListByApplication retrieves the most recent promotion requests of an application,
+	// without their reviews. An empty status lists requests in any status.
+	ListByApplication(ctx context.Context, appID uuid.UUID, status domain.PromotionRequestStatus, limit int32) ([]*domain.PromotionRequest, error)
+
+	// ========== Transaction Methods ==========
+
+	// CreateTx opens a promotion request within a transaction.
+	CreateTx(ctx context.Context, q *db.Queries, input domain.CreatePromotionRequestInput) (*domain.PromotionRequest, error)
+
+	// GetByIDForUpdateTx retrieves a promotion request with its reviews and locks it
+	// until the transaction ends.
+	GetByIDForUpdateTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.PromotionRequest, error)
+
+	// ResolveTx closes a pending promotion request within a transaction.
+	ResolveTx(ctx context.Context, q *db.Queries, id uuid.UUID, status domain.PromotionRequestStatus, promotedReleaseID *uuid.UUID) error
+
+	// FailTx closes a pending promotion request that could not be carried out within a transaction.
+	FailTx(ctx context.Context, q *db.Queries, id uuid.UUID, reason string) error
+
+	// CreateReviewTx records an approval or rejection within a transaction.
+	CreateReviewTx(ctx context.Context, q *db.Queries, requestID, reviewerID uuid.UUID, approved bool, comment string) (*domain.PromotionReview, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ReleaseNoteRepository defines the interface for translated release note data access.
+type ReleaseNoteRepository interface {
+	// Upsert creates or replaces the note of a release in a locale.
+	Upsert(ctx context.Context, releaseID uuid.UUID, locale, note string) (*domain.ReleaseNote, error)
+
+	// ListByRelease retrieves the translated notes of a release, by locale.
+	ListByRelease(ctx context.Context, releaseID uuid.UUID) ([]*domain.ReleaseNote, error)
+
+	// ListForReleases retrieves the notes of several releases in any of the given locales.
+	ListForReleases(ctx context.Context, releaseIDs []uuid.UUID, locales []string) ([]*domain.ReleaseNote, error)
+
+	// Delete removes the note of a release in a locale.
+	// It returns domain.ErrNotFound when the release has no note in that locale.
+	Delete(ctx context.Context, releaseID uuid.UUID, locale string) error
+
+	// ========== Transaction Methods ==========
+
+	// CopyTx gives a release the translated notes of another within a transaction.
+	CopyTx(ctx context.Context, q *db.Queries, sourceReleaseID, targetReleaseID uuid.UUID) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -16,8 +17,8 @@ type ReleaseRepository interface {
 	// GetByID retrieves a release by its ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error)
 
-	// ListByApplication retrieves all releases for an application.
-	ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.ApplicationRelease, error)
+	// ListByApplication retrieves a page of the releases of an application, highest version first by default.
+	ListByApplication(ctx context.Context, appID uuid.UUID, filter domain.ReleaseFilter, page domain.PageRequest) (*domain.Page[*domain.ApplicationRelease], error)
 
 	// ListByEnvironment retrieves releases for an application filtered by environment.
 	ListByEnvironment(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) ([]*domain.ApplicationRelease, error)
@@ -25,11 +26,21 @@ type ReleaseRepository interface {
 	// GetLatestByEnvironment retrieves the latest release for an application in an environment.
 	GetLatestByEnvironment(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error)
 
-	// Update updates a release's title and release note.
-	Update(ctx context.Context, id uuid.UUID, title, releaseNote string) (*domain.ApplicationRelease, error)
+	// Update updates a release's title, release note and mandatory flag.
+	Update(ctx context.Context, id uuid.UUID, title, releaseNote string, mandatory bool) (*domain.ApplicationRelease, error)
 
-	// Promote updates the environment of a release.
-	Promote(ctx context.Context, id uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error)
+	// ========== Status ==========
+	// Status changes return domain.ErrNotFound when the release is gone or
+	// not in a status the change starts from.
+
+	// Publish publishes a draft or reinstates a withdrawn release.
+	Publish(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// Withdraw withdraws a published release.
+	Withdraw(ctx context.Context, id, actorID uuid.UUID, reason string) (*domain.ApplicationRelease, error)
+
+	// Archive archives a published or withdrawn release.
+	Archive(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error)
 
 	// SoftDelete marks a release as deleted.
 	SoftDelete(ctx context.Context, id uuid.UUID) error
@@ -37,6 +48,64 @@ type ReleaseRepository interface {
 	// VersionExists checks if a version code already exists for an application in an environment.
 	VersionExists(ctx context.Context, appID uuid.UUID, versionCode int32, env domain.ReleaseEnvironment) (bool, error)
 
+	// HighestVersionCode returns the highest version code ever used in an environment, 0 if none.
+	HighestVersionCode(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error)
+
+	// ListChangelog retrieves the published and archived releases of an environment
+	// within a range of version codes, newest first, at most limit of them.
+	ListChangelog(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment, versions domain.ChangelogRange, limit int32) ([]*domain.ApplicationRelease, error)
+
+	// ========== Rollouts ==========
+
+	// ListRolloutCandidates retrieves the published releases of a channel, newest
+	// first, down to the newest one fully rolled out.
+	ListRolloutCandidates(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) ([]*domain.ApplicationRelease, error)
+
+	// IncreaseRollout raises the rollout percentage of a published release, resuming it if halted.
+	// It returns domain.ErrNotFound when the release is not published or the percentage would not grow.
+	IncreaseRollout(ctx context.Context, id uuid.UUID, percentage int32) (*domain.ApplicationRelease, error)
+
+	// HaltRollout halts the staged rollout of a published release.
+	// It returns domain.ErrNotFound when the release is not published or its rollout is complete or halted.
+	HaltRollout(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// HighestFullyRolledOutVersionCode returns the version code of the newest
+	// published release of a channel offered to every client, 0 if none.
+	HighestFullyRolledOutVersionCode(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error)
+
+	// HasMandatoryBetween checks if a published mandatory release of a channel has
+	// a version code above after and up to upTo.
+	HasMandatoryBetween(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment, after, upTo int32) (bool, error)
+
+	// ========== Promotions ==========
+
+	// ListPromotionsByApplication retrieves the most recent promotions of an application's releases.
+	ListPromotionsByApplication(ctx context.Context, appID uuid.UUID, limit int32) ([]*domain.ReleasePromotion, error)
+
+	// ListPromotionsByRelease retrieves the promotions a release was copied from or into, newest first.
+	ListPromotionsByRelease(ctx context.Context, releaseID uuid.UUID) ([]*domain.ReleasePromotion, error)
+
+	// IsPromoted reports whether a release was promoted from or into another.
+	IsPromoted(ctx context.Context, releaseID uuid.UUID) (bool, error)
+
+	// ========== Trash ==========
+
+	// GetDeletedByID retrieves a soft-deleted release by its ID.
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// ListDeletedByProject retrieves the most recently deleted releases of a project's applications.
+	ListDeletedByProject(ctx context.Context, projectID uuid.UUID, limit int32) ([]*domain.ApplicationRelease, error)
+
+	// ListPastRetention retrieves live releases their project's retention policy no longer keeps.
+	ListPastRetention(ctx context.Context, limit int32) ([]uuid.UUID, error)
+
+	// ListPurgeable retrieves releases deleted before the cutoff, directly or with
+	// their application, whose artifacts are all purged.
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error)
+
+	// HardDelete permanently removes a release.
+	HardDelete(ctx context.Context, id uuid.UUID) error
+
 	// ========== Transaction Methods ==========
 
 	// CreateTx creates a new release within a transaction.
@@ -44,4 +113,53 @@ type ReleaseRepository interface {
 
 	// GetByIDTx retrieves a release by its ID within a transaction.
 	GetByIDTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// VersionExistsTx checks if a version code already exists for an application in an environment within a transaction.
+	VersionExistsTx(ctx context.Context, q *db.Queries, appID uuid.UUID, versionCode int32, env domain.ReleaseEnvironment) (bool, error)
+
+	// HighestVersionCodeTx returns the highest version code ever used in an environment within a transaction, 0 if none.
+	HighestVersionCodeTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error)
+
+	// CreatePromotionTx records a promotion within a transaction.
+	CreatePromotionTx(ctx context.Context, q *db.Queries, input domain.CreateReleasePromotionInput) (*domain.ReleasePromotion, error)
+
+	// RestoreTx undoes the soft delete of a release within a transaction.
+	RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// RestoreDeletedWithApplicationTx restores the releases deleted at the same time as their application.
+	RestoreDeletedWithApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID, deletedAt time.Time) error
+
+	// RestoreDeletedWithProjectTx restores the releases deleted at the same time as their project.
+	RestoreDeletedWithProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID, deletedAt time.Time) error
+
+	// GetLatestByEnvironmentTx retrieves the latest release of a channel within a transaction.
+	GetLatestByEnvironmentTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error)
+
+	// PublishTx publishes a draft or reinstates a withdrawn release within a transaction.
+	PublishTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// WithdrawTx withdraws a published release within a transaction.
+	WithdrawTx(ctx context.Context, q *db.Queries, id, actorID uuid.UUID, reason string) (*domain.ApplicationRelease, error)
+
+	// ArchiveTx archives a published or withdrawn release within a transaction.
+	ArchiveTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// IncreaseRolloutTx raises the rollout percentage of a published release within a transaction.
+	IncreaseRolloutTx(ctx context.Context, q *db.Queries, id uuid.UUID, percentage int32) (*domain.ApplicationRelease, error)
+
+	// HaltRolloutTx halts the staged rollout of a published release within a transaction.
+	HaltRolloutTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error)
+
+	// HighestFullyRolledOutVersionCodeTx returns the version code of the newest published
+	// release of a channel offered to every client within a transaction, 0 if none.
+	HighestFullyRolledOutVersionCodeTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error)
+
+	// SoftDeleteTx marks a release as deleted within a transaction.
+	SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
+
+	// SoftDeleteByApplicationTx marks the live releases of an application as deleted within a transaction.
+	SoftDeleteByApplicationTx(ctx context.Context, q *db.Queries, appID uuid.UUID) error
+
+	// SoftDeleteByProjectTx marks the live releases of a project's applications as deleted within a transaction.
+	SoftDeleteByProjectTx(ctx context.Context, q *db.Queries, projectID uuid.UUID) error
 }
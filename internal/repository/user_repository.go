@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -36,8 +37,8 @@ type UserRepository interface {
 	// GetCredentialsByUsername retrieves user credentials for authentication.
 	GetCredentialsByUsername(ctx context.Context, username string) (*domain.UserCredentials, error)
 
-	// List retrieves all active users.
-	List(ctx context.Context) ([]*domain.User, error)
+	// List retrieves a page of users, newest first by default.
+	List(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) (*domain.Page[*domain.User], error)
 
 	// UpdateEmail updates a user's email.
 	UpdateEmail(ctx context.Context, id uuid.UUID, email string) (*domain.User, error)
@@ -45,12 +46,35 @@ type UserRepository interface {
 	// UpdateUsername updates a user's username.
 	UpdateUsername(ctx context.Context, id uuid.UUID, username string) (*domain.User, error)
 
+	// UpdatePhoneNumber updates a user's phone number.
+	UpdatePhoneNumber(ctx context.Context, id uuid.UUID, phoneNumber string) (*domain.User, error)
+
 	// UpdatePassword updates a user's password hash.
 	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 
 	// UpdateProfile updates a user's profile (first name, last name).
 	UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*domain.User, error)
 
+	// UpdateAvatar sets the avatar URL of a user; an empty URL removes the avatar.
+	UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL string) (*domain.User, error)
+
+	// UpdateActiveStatus activates or deactivates a user and revokes all issued tokens.
+	UpdateActiveStatus(ctx context.Context, id uuid.UUID, isActive bool) (*domain.User, error)
+
+	// UpdateAdminStatus grants or revokes system administration and revokes all issued tokens.
+	UpdateAdminStatus(ctx context.Context, id uuid.UUID, isAdmin bool) (*domain.User, error)
+
+	// ListPendingAnonymization returns users deleted before the given time whose data
+	// has not been anonymised yet, oldest first.
+	ListPendingAnonymization(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error)
+
+	// ListPendingStoragePurge returns anonymised users whose stored files have not
+	// been deleted yet, oldest first.
+	ListPendingStoragePurge(ctx context.Context, limit int32) ([]uuid.UUID, error)
+
+	// MarkStoragePurged records that the stored files of an anonymised user are deleted.
+	MarkStoragePurged(ctx context.Context, id uuid.UUID) error
+
 	// UpdateLastLogin updates the user's last login timestamp.
 	UpdateLastLogin(ctx context.Context, id uuid.UUID) error
 
@@ -66,6 +90,9 @@ type UserRepository interface {
 	// PhoneNumberExists checks if a phone number is already registered.
 	PhoneNumberExists(ctx context.Context, phoneNumber string) (bool, error)
 
+	// IsEmailVerified checks if the user proved to own their current email address.
+	IsEmailVerified(ctx context.Context, id uuid.UUID) (bool, error)
+
 	// ========== Transaction Methods ==========
 	// These methods use the provided Queries (which may be transaction-aware).
 
@@ -84,6 +111,24 @@ type UserRepository interface {
 	// PhoneNumberExistsTx checks phone number existence within a transaction.
 	PhoneNumberExistsTx(ctx context.Context, q *db.Queries, phoneNumber string) (bool, error)
 
+	// UpdateEmailTx updates a user's email within a transaction.
+	UpdateEmailTx(ctx context.Context, q *db.Queries, id uuid.UUID, email string) (*domain.User, error)
+
+	// MarkEmailVerifiedTx records that the user proved to own an address within a
+	// transaction. It has no effect once the address is no longer the user's email.
+	MarkEmailVerifiedTx(ctx context.Context, q *db.Queries, id uuid.UUID, email string) error
+
+	// ResetPasswordTx sets a new password hash and revokes all issued tokens within a transaction.
+	ResetPasswordTx(ctx context.Context, q *db.Queries, id uuid.UUID, passwordHash string) (*domain.User, error)
+
 	// SoftDeleteTx marks a user as deleted within a transaction.
 	SoftDeleteTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
+
+	// RestoreTx cancels the deletion of a user deleted after graceStart within a transaction.
+	// Returns domain.ErrNotFound if the account is not restorable.
+	RestoreTx(ctx context.Context, q *db.Queries, id uuid.UUID, graceStart time.Time) (*domain.User, error)
+
+	// AnonymizeTx overwrites the personal data of a deleted user within a transaction.
+	// Returns domain.ErrNotFound if the user is not deleted or already anonymised.
+	AnonymizeTx(ctx context.Context, q *db.Queries, id uuid.UUID) error
 }
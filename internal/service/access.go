@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// authorizeProject checks that a user holds a permission in a project, through
+// ownership, the project's organization or a project membership.
+// An empty permission only requires some access to the project.
+func authorizeProject(ctx context.Context, projectRepo repository.ProjectRepository, projectID, userID uuid.UUID, permission string) (*domain.ProjectAccess, error) {
+	access, err := projectRepo.GetAccess(ctx, projectID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrNotProjectOwner
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to check project access", err)
+	}
+
+	if permission != "" && !access.Can(permission) {
+		return nil, domain.ErrInsufficientRole
+	}
+	return access, nil
+}
+
+// checkApplicationQuotaTx refuses a new application once the project's
+// organization holds as many as its quota allows. It locks the organization,
+// so it must run in the transaction that creates the application.
+func checkApplicationQuotaTx(ctx context.Context, orgRepo repository.OrganizationRepository, q *db.Queries, orgID uuid.UUID) error {
+	org, err := orgRepo.LockTx(ctx, q, orgID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to get organization", err)
+	}
+	usage, err := orgRepo.GetUsageTx(ctx, q, orgID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to count organization usage", err)
+	}
+	if usage.Applications >= int64(org.Quotas.MaxApplications) {
+		return domain.NewQuotaExceededError("applications", org.Quotas.MaxApplications)
+	}
+	return nil
+}
+
+// checkProjectQuotaTx refuses one more project once the organization holds as
+// many as its quota allows. Like checkApplicationQuotaTx, it locks the organization.
+func checkProjectQuotaTx(ctx context.Context, orgRepo repository.OrganizationRepository, q *db.Queries, orgID uuid.UUID) error {
+	org, err := orgRepo.LockTx(ctx, q, orgID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to get organization", err)
+	}
+	usage, err := orgRepo.GetUsageTx(ctx, q, orgID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to count organization usage", err)
+	}
+	if usage.Projects >= int64(org.Quotas.MaxProjects) {
+		return domain.NewQuotaExceededError("projects", org.Quotas.MaxProjects)
+	}
+	return nil
+}
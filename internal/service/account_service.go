@@ -0,0 +1,623 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/mailer"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/bsrodrigue/appshare-backend/internal/storage"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// anonymizeBatchSize bounds how many accounts a single anonymiser pass processes.
+const anonymizeBatchSize = 100
+
+// AccountConfig holds the tunables of the AccountService.
+type AccountConfig struct {
+	// DeletionGracePeriod is how long a deleted account can be restored before it is anonymised.
+	DeletionGracePeriod time.Duration
+
+	// ExportURLTTL is how long the signed download link of a data export stays valid.
+	ExportURLTTL time.Duration
+
+	// RestoreURL is the frontend page the account restore token is appended to.
+	RestoreURL string
+}
+
+// AccountService handles data exports and the account deletion lifecycle:
+// a deletion request starts a restorable grace period, after which the
+// anonymiser overwrites the user's personal data.
+type AccountService struct {
+	// Repositories
+	userRepo     repository.UserRepository
+	projectRepo  repository.ProjectRepository
+	orgRepo      repository.OrganizationRepository
+	appRepo      repository.ApplicationRepository
+	releaseRepo  repository.ReleaseRepository
+	identityRepo repository.IdentityRepository
+	mfaRepo      repository.MFARepository
+	throttleRepo repository.LoginThrottleRepository
+	resetRepo    repository.PasswordResetRepository
+	emailRepo    repository.EmailChangeRepository
+	restoreRepo  repository.AccountRestoreRepository
+
+	// Services
+	authService *AuthService
+
+	// Infrastructure
+	storage   storage.Storage
+	mailer    mailer.Mailer
+	txManager *db.TxManager
+	config    AccountConfig
+}
+
+// NewAccountService creates a new AccountService.
+func NewAccountService(
+	// Repositories
+	userRepo repository.UserRepository,
+	projectRepo repository.ProjectRepository,
+	orgRepo repository.OrganizationRepository,
+	appRepo repository.ApplicationRepository,
+	releaseRepo repository.ReleaseRepository,
+	identityRepo repository.IdentityRepository,
+	mfaRepo repository.MFARepository,
+	throttleRepo repository.LoginThrottleRepository,
+	resetRepo repository.PasswordResetRepository,
+	emailRepo repository.EmailChangeRepository,
+	restoreRepo repository.AccountRestoreRepository,
+
+	// Services
+	authService *AuthService,
+
+	// Infrastructure
+	storage storage.Storage,
+	mailer mailer.Mailer,
+	txManager *db.TxManager,
+	config AccountConfig,
+) *AccountService {
+	return &AccountService{
+		userRepo:     userRepo,
+		projectRepo:  projectRepo,
+		orgRepo:      orgRepo,
+		appRepo:      appRepo,
+		releaseRepo:  releaseRepo,
+		identityRepo: identityRepo,
+		mfaRepo:      mfaRepo,
+		throttleRepo: throttleRepo,
+		resetRepo:    resetRepo,
+		emailRepo:    emailRepo,
+		restoreRepo:  restoreRepo,
+		authService:  authService,
+		storage:      storage,
+		mailer:       mailer,
+		txManager:    txManager,
+		config:       config,
+	}
+}
+
+// DataExport is a packaged copy of a user's data, downloadable until ExpiresAt.
+type DataExport struct {
+	DownloadURL string
+	ExpiresAt   time.Time
+}
+
+// DeletionConfirmation proves the user requesting a deletion controls the account.
+type DeletionConfirmation struct {
+	// Password is the current password of the account.
+	Password string
+
+	// ReauthToken, from a fresh identity provider sign-in, or else Code, a second
+	// factor code, stand in for the password of accounts with a linked identity:
+	// single sign-on generated a password they never knew.
+	ReauthToken string
+	Code        string
+}
+
+// AccountDeletion describes a scheduled account deletion.
+type AccountDeletion struct {
+	Export *DataExport
+
+	// AnonymizeAfter is when the grace period ends and the account can no longer be restored.
+	AnonymizeAfter time.Time
+}
+
+// Export packages the user's profile and the projects they can access, owned,
+// shared with them or reached through an organization, with their role and
+// releases metadata, as a ZIP archive and returns a signed link to download it.
+func (s *AccountService) Export(ctx context.Context, userID uuid.UUID) (*DataExport, error) {
+	if s.storage == nil {
+		return nil, domain.NewAppError(domain.CodeInternal, "storage is not configured")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := s.buildExport(ctx, user)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to build data export", err)
+	}
+
+	now := time.Now()
+	path := fmt.Sprintf("exports/%s/%s.zip", user.ID, now.UTC().Format("20060102T150405Z"))
+	if err := s.storage.Upload(ctx, path, bytes.NewReader(archive), "application/zip"); err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to store data export", err)
+	}
+
+	url, err := s.storage.GenerateDownloadURL(ctx, path, s.config.ExportURLTTL)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate download URL", err)
+	}
+
+	return &DataExport{DownloadURL: url, ExpiresAt: now.Add(s.config.ExportURLTTL)}, nil
+}
+
+// RequestDeletion deletes the user's account after exporting their data.
+// The user must not own any project. The account stays restorable through
+// an emailed link until the grace period ends.
+func (s *AccountService) RequestDeletion(ctx context.Context, userID uuid.UUID, confirmation DeletionConfirmation, ip string) (*AccountDeletion, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.confirmDeletion(ctx, user, confirmation, ip); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDeletable(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	export, err := s.Export(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.deleteAccount(ctx, user, export)
+}
+
+// confirmDeletion checks the password, or for accounts with a linked identity
+// a fresh identity provider sign-in or a second factor code.
+func (s *AccountService) confirmDeletion(ctx context.Context, user *domain.User, confirmation DeletionConfirmation, ip string) error {
+	if confirmation.Password != "" {
+		creds, err := s.userRepo.GetCredentialsByEmail(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(confirmation.Password)); err != nil {
+			return domain.NewAppError(domain.CodeInvalidCredentials, "current password is incorrect")
+		}
+		return nil
+	}
+
+	if confirmation.ReauthToken == "" && confirmation.Code == "" {
+		return domain.NewValidationError("current_password", "current password is required")
+	}
+	linked, err := s.identityRepo.HasAnyForUser(ctx, user.ID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve identities", err)
+	}
+	if !linked {
+		return domain.NewValidationError("current_password", "current password is required")
+	}
+	return s.authService.reauthenticateWithoutPassword(ctx, user, confirmation.ReauthToken, confirmation.Code, ip)
+}
+
+// DeleteByAdmin deletes a user's account on an administrator's behalf, with
+// the same guards and grace period as a deletion the user requests. No data
+// export is made; the user is emailed the restore link.
+func (s *AccountService) DeleteByAdmin(ctx context.Context, userID uuid.UUID) (*AccountDeletion, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDeletable(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return s.deleteAccount(ctx, user, nil)
+}
+
+// checkDeletable refuses to delete users whose projects, or organizations
+// holding projects, would be left without an owner.
+func (s *AccountService) checkDeletable(ctx context.Context, userID uuid.UUID) error {
+	owned, err := s.projectRepo.ListByOwner(ctx, userID, domain.ProjectFilter{}, domain.PageRequest{Limit: 1})
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to list owned projects", err)
+	}
+	if len(owned.Items) > 0 {
+		return domain.ErrOwnsProjects
+	}
+
+	soleOwned, err := s.orgRepo.CountSoleOwnedWithProjects(ctx, userID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to count owned organizations", err)
+	}
+	if soleOwned > 0 {
+		return domain.ErrOwnsProjects
+	}
+	return nil
+}
+
+// deleteAccount soft-deletes an account, starting its grace period, and emails
+// the user a restore link, along with their data export when one was made.
+func (s *AccountService) deleteAccount(ctx context.Context, user *domain.User, export *DataExport) (*AccountDeletion, error) {
+	rawToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate token", err)
+	}
+	anonymizeAfter := time.Now().Add(s.config.DeletionGracePeriod)
+
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.userRepo.SoftDeleteTx(ctx, q, user.ID); err != nil {
+			return err
+		}
+		if err := s.restoreRepo.InvalidateForUserTx(ctx, q, user.ID); err != nil {
+			return err
+		}
+		_, err := s.restoreRepo.CreateTx(ctx, q, user.ID, tokenHash, anonymizeAfter)
+		return err
+	})
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to delete account", err)
+	}
+
+	var exportNotice string
+	if export != nil {
+		exportNotice = fmt.Sprintf("A copy of your data is available until %s:\n%s\n\n", export.ExpiresAt.UTC().Format(time.RFC1123), export.DownloadURL)
+	}
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Your AppShare account has been deleted",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nYour AppShare account has been deleted and you have been signed out everywhere.\n\n%sChanged your mind? You can restore your account until %s:\n%s\n\nAfter that date your personal data is permanently erased.\n",
+			user.FirstName,
+			exportNotice,
+			anonymizeAfter.UTC().Format(time.RFC1123), tokenLink(s.config.RestoreURL, rawToken),
+		),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send account deletion email",
+			slog.String("user_id", user.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return &AccountDeletion{Export: export, AnonymizeAfter: anonymizeAfter}, nil
+}
+
+// Restore redeems a restore token and cancels the deletion of its account.
+func (s *AccountService) Restore(ctx context.Context, rawToken string) (*domain.User, error) {
+	token, err := s.restoreRepo.GetByHash(ctx, auth.HashOpaqueToken(rawToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTokenInvalid
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve restore token", err)
+	}
+	if token.UsedAt != nil {
+		return nil, domain.ErrTokenInvalid
+	}
+	if !token.IsUsable(time.Now()) {
+		return nil, domain.ErrTokenExpired
+	}
+
+	var user *domain.User
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		// Consuming the token first guarantees single use under concurrency
+		if err := s.restoreRepo.MarkUsedTx(ctx, q, token.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrTokenInvalid
+			}
+			return err
+		}
+
+		var err error
+		user, err = s.userRepo.RestoreTx(ctx, q, token.UserID, time.Now().Add(-s.config.DeletionGracePeriod))
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrTokenInvalid
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// AnonymizeExpired anonymises every account whose deletion grace period has
+// ended, deletes their stored files, and returns how many were processed.
+// Files that could not be deleted are retried on the next call.
+func (s *AccountService) AnonymizeExpired(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-s.config.DeletionGracePeriod)
+	total := 0
+
+	for {
+		ids, err := s.userRepo.ListPendingAnonymization(ctx, deletedBefore, anonymizeBatchSize)
+		if err != nil {
+			return total, domain.WrapError(domain.CodeInternal, "failed to list deleted accounts", err)
+		}
+
+		for _, id := range ids {
+			if err := s.anonymize(ctx, id); err != nil {
+				return total, domain.WrapError(domain.CodeInternal, "failed to anonymise account", err)
+			}
+			total++
+		}
+
+		if len(ids) < anonymizeBatchSize {
+			return total, s.purgeAnonymizedFiles(ctx)
+		}
+	}
+}
+
+// purgeAnonymizedFiles deletes the stored files of anonymised accounts, the
+// accounts anonymised just now as well as those a failed earlier pass left.
+func (s *AccountService) purgeAnonymizedFiles(ctx context.Context) error {
+	if s.storage == nil {
+		return nil
+	}
+
+	for {
+		ids, err := s.userRepo.ListPendingStoragePurge(ctx, anonymizeBatchSize)
+		if err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to list anonymised accounts", err)
+		}
+
+		for _, id := range ids {
+			// Stop at the first failure: the account stays pending until the next pass
+			if err := s.deleteUserFiles(ctx, id); err != nil {
+				return domain.WrapError(domain.CodeInternal, "failed to delete account files", err)
+			}
+			if err := s.userRepo.MarkStoragePurged(ctx, id); err != nil {
+				return domain.WrapError(domain.CodeInternal, "failed to record deleted account files", err)
+			}
+		}
+
+		if len(ids) < anonymizeBatchSize {
+			return nil
+		}
+	}
+}
+
+// deleteUserFiles deletes the data exports and uploads, such as avatars, of a user.
+func (s *AccountService) deleteUserFiles(ctx context.Context, userID uuid.UUID) error {
+	for _, prefix := range []string{
+		fmt.Sprintf("exports/%s/", userID),
+		fmt.Sprintf("users/%s/", userID),
+	} {
+		if err := s.storage.DeletePrefix(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAnonymizer calls AnonymizeExpired every interval until ctx is cancelled.
+func (s *AccountService) RunAnonymizer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.AnonymizeExpired(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "account anonymisation failed", slog.String("error", err.Error()))
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "anonymised deleted accounts", slog.Int("count", n))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// anonymize erases the personal data of a single deleted account.
+func (s *AccountService) anonymize(ctx context.Context, userID uuid.UUID) error {
+	return s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.userRepo.AnonymizeTx(ctx, q, userID); err != nil {
+			// Restored or anonymised concurrently
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := s.identityRepo.DeleteByUserTx(ctx, q, userID); err != nil {
+			return err
+		}
+		if err := s.mfaRepo.DeleteTx(ctx, q, userID); err != nil {
+			return err
+		}
+		if err := s.orgRepo.DeleteMembershipsByUserTx(ctx, q, userID); err != nil {
+			return err
+		}
+		if err := s.throttleRepo.DeleteAttemptsByUserTx(ctx, q, userID); err != nil {
+			return err
+		}
+		if err := s.resetRepo.InvalidateForUserTx(ctx, q, userID); err != nil {
+			return err
+		}
+		if err := s.emailRepo.InvalidateForUserTx(ctx, q, userID); err != nil {
+			return err
+		}
+		return s.restoreRepo.InvalidateForUserTx(ctx, q, userID)
+	})
+}
+
+// ========== Export Format ==========
+
+type exportProfile struct {
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	Username    string     `json:"username"`
+	PhoneNumber string     `json:"phone_number"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	AvatarURL   string     `json:"avatar_url,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+type exportProject struct {
+	ID           uuid.UUID           `json:"id"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	Role         string              `json:"role"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	Applications []exportApplication `json:"applications"`
+}
+
+type exportApplication struct {
+	ID          uuid.UUID       `json:"id"`
+	Title       string          `json:"title"`
+	PackageName string          `json:"package_name"`
+	Description string          `json:"description"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Releases    []exportRelease `json:"releases"`
+}
+
+type exportRelease struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	VersionCode int32     `json:"version_code"`
+	VersionName string    `json:"version_name"`
+	ReleaseNote string    `json:"release_note"`
+	Environment string    `json:"environment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// buildExport writes the user's data as a ZIP archive.
+func (s *AccountService) buildExport(ctx context.Context, user *domain.User) ([]byte, error) {
+	projects, err := listAll(func(page domain.PageRequest) (*domain.Page[*domain.ProjectSummary], error) {
+		return s.projectRepo.ListForUser(ctx, user.ID, domain.ProjectFilter{}, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]exportProject, 0, len(projects))
+	for _, p := range projects {
+		apps, err := listAll(func(page domain.PageRequest) (*domain.Page[*domain.Application], error) {
+			return s.appRepo.ListByProject(ctx, p.ID, domain.ApplicationFilter{}, page)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ep := exportProject{
+			ID:           p.ID,
+			Title:        p.Title,
+			Description:  p.Description,
+			Role:         p.Role,
+			CreatedAt:    p.CreatedAt,
+			UpdatedAt:    p.UpdatedAt,
+			Applications: make([]exportApplication, 0, len(apps)),
+		}
+		for _, a := range apps {
+			releases, err := listAll(func(page domain.PageRequest) (*domain.Page[*domain.ApplicationRelease], error) {
+				return s.releaseRepo.ListByApplication(ctx, a.ID, domain.ReleaseFilter{}, page)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			ea := exportApplication{
+				ID:          a.ID,
+				Title:       a.Title,
+				PackageName: a.PackageName,
+				Description: a.Description,
+				CreatedAt:   a.CreatedAt,
+				Releases:    make([]exportRelease, 0, len(releases)),
+			}
+			for _, r := range releases {
+				ea.Releases = append(ea.Releases, exportRelease{
+					ID:          r.ID,
+					Title:       r.Title,
+					VersionCode: r.VersionCode,
+					VersionName: r.VersionName,
+					ReleaseNote: r.ReleaseNote,
+					Environment: string(r.Environment),
+					CreatedAt:   r.CreatedAt,
+				})
+			}
+			ep.Applications = append(ep.Applications, ea)
+		}
+		exported = append(exported, ep)
+	}
+
+	profile := exportProfile{
+		ID:          user.ID,
+		Email:       user.Email,
+		Username:    user.Username,
+		PhoneNumber: user.PhoneNumber,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		AvatarURL:   user.AvatarURL,
+		CreatedAt:   user.CreatedAt,
+		LastLoginAt: user.LastLoginAt,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeZipJSON(zw, "profile.json", profile); err != nil {
+		return nil, err
+	}
+	if err := writeZipJSON(zw, "projects.json", exported); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeZipJSON adds an indented JSON document to a ZIP archive.
+func writeZipJSON(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// listAll walks every page of a listing, for exports that need the complete data.
+func listAll[T any](fetch func(page domain.PageRequest) (*domain.Page[T], error)) ([]T, error) {
+	var items []T
+	page := domain.PageRequest{Limit: domain.MaxPageLimit}
+	for {
+		result, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+		if result.NextCursor == "" {
+			return items, nil
+		}
+		if page.After, err = domain.DecodeCursor(result.NextCursor); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/bsrodrigue/appshare-backend/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// purgeUserRepository tracks which anonymised users still have stored files.
+type purgeUserRepository struct {
+	repository.UserRepository
+	pending []uuid.UUID
+}
+
+func (r *purgeUserRepository) ListPendingStoragePurge(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	return r.pending[:min(len(r.pending), int(limit))], nil
+}
+
+func (r *purgeUserRepository) MarkStoragePurged(ctx context.Context, id uuid.UUID) error {
+	for i, pending := range r.pending {
+		if pending == id {
+			r.pending = append(r.pending[:i], r.pending[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// unavailableStorage fails to delete anything.
+type unavailableStorage struct {
+	*storage.MemoryStorage
+}
+
+func (s unavailableStorage) DeletePrefix(ctx context.Context, prefix string) error {
+	return errors.New("storage unavailable")
+}
+
+func uploadAll(t *testing.T, store storage.Storage, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		require.NoError(t, store.Upload(context.Background(), path, strings.NewReader("data"), "application/octet-stream"))
+	}
+}
+
+func TestAccountService_PurgeAnonymizedFiles(t *testing.T) {
+	ctx := context.Background()
+	deleted, kept := uuid.New(), uuid.New()
+
+	store := storage.NewMemoryStorage()
+	uploadAll(t, store,
+		"exports/"+deleted.String()+"/20260301T120000Z.zip",
+		"exports/"+deleted.String()+"/20260302T120000Z.zip",
+		"users/"+deleted.String()+"/avatar/1/avatar.png",
+		"users/"+deleted.String()+"/avatar/1/thumbnail.png",
+		"exports/"+kept.String()+"/20260301T120000Z.zip",
+		"users/"+kept.String()+"/avatar/1/avatar.png",
+		"apps/"+deleted.String()+"/release.apk",
+	)
+
+	users := &purgeUserRepository{pending: []uuid.UUID{deleted}}
+	s := &AccountService{userRepo: users, storage: store}
+
+	require.NoError(t, s.purgeAnonymizedFiles(ctx))
+	assert.Equal(t, []string{
+		"apps/" + deleted.String() + "/release.apk",
+		"exports/" + kept.String() + "/20260301T120000Z.zip",
+		"users/" + kept.String() + "/avatar/1/avatar.png",
+	}, store.Paths())
+	assert.Empty(t, users.pending)
+}
+
+func TestAccountService_PurgeAnonymizedFiles_RetriesWhenStorageFails(t *testing.T) {
+	ctx := context.Background()
+	deleted := uuid.New()
+
+	store := storage.NewMemoryStorage()
+	uploadAll(t, store,
+		"exports/"+deleted.String()+"/20260301T120000Z.zip",
+		"users/"+deleted.String()+"/avatar/1/avatar.png",
+	)
+	users := &purgeUserRepository{pending: []uuid.UUID{deleted}}
+
+	s := &AccountService{userRepo: users, storage: unavailableStorage{store}}
+	require.Error(t, s.purgeAnonymizedFiles(ctx))
+	assert.Equal(t, []uuid.UUID{deleted}, users.pending, "still pending after a failure")
+	assert.Len(t, store.Paths(), 2)
+
+	s.storage = store
+	require.NoError(t, s.purgeAnonymizedFiles(ctx))
+	assert.Empty(t, users.pending)
+	assert.Empty(t, store.Paths())
+}
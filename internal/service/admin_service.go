@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// AdminActor identifies the administrator performing an action, for the audit trail.
+type AdminActor struct {
+	ID        uuid.UUID
+	IPAddress string
+}
+
+// AdminService handles system administration. Every mutation is recorded in the audit trail.
+// Callers are responsible for checking that the actor is an administrator.
+type AdminService struct {
+	userRepo       repository.UserRepository
+	auditRepo      repository.AdminAuditRepository
+	userService    *UserService
+	accountService *AccountService
+	orgService     *OrganizationService
+	authService    *AuthService
+	protection     *LoginProtectionService
+	jwtService     *auth.JWTService
+}
+
+// NewAdminService creates a new AdminService.
+func NewAdminService(
+	userRepo repository.UserRepository,
+	auditRepo repository.AdminAuditRepository,
+	userService *UserService,
+	accountService *AccountService,
+	orgService *OrganizationService,
+	authService *AuthService,
+	protection *LoginProtectionService,
+	jwtService *auth.JWTService,
+) *AdminService {
+	return &AdminService{
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		userService:    userService,
+		accountService: accountService,
+		orgService:     orgService,
+		authService:    authService,
+		protection:     protection,
+		jwtService:     jwtService,
+	}
+}
+
+// ========== User Management ==========
+
+// ListUsers retrieves a page of users, including inactive ones unless filtered out.
+func (s *AdminService) ListUsers(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	return s.userService.List(ctx, filter, page)
+}
+
+// CreateUser creates a user account on someone's behalf.
+func (s *AdminService) CreateUser(ctx context.Context, actor AdminActor, input domain.CreateUserInput) (*domain.User, error) {
+	user, err := s.userService.Create(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, actor, domain.AdminActionCreateUser, &user.ID, map[string]string{"email": user.Email})
+	return user, nil
+}
+
+// DeleteUser deletes a user account. Like a deletion the user requests, it is
+// refused while the user owns projects, and stays restorable by the user
+// until the grace period ends.
+func (s *AdminService) DeleteUser(ctx context.Context, actor AdminActor, userID uuid.UUID) error {
+	if userID == actor.ID {
+		return domain.NewAppError(domain.CodeForbidden, "administrators cannot delete their own account")
+	}
+	if _, err := s.accountService.DeleteByAdmin(ctx, userID); err != nil {
+		return err
+	}
+
+	s.record(ctx, actor, domain.AdminActionDeleteUser, &userID, nil)
+	return nil
+}
+
+// UpdateProfile changes another user's name.
+func (s *AdminService) UpdateProfile(ctx context.Context, actor AdminActor, userID uuid.UUID, firstName, lastName string) (*domain.User, error) {
+	user, err := s.userService.UpdateProfile(ctx, userID, firstName, lastName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, actor, domain.AdminActionUpdateProfile, &userID, map[string]string{
+		"first_name": firstName,
+		"last_name":  lastName,
+	})
+	return user, nil
+}
+
+// SetActive activates or deactivates a user. Deactivated users cannot log in,
+// and their sessions end at once.
+func (s *AdminService) SetActive(ctx context.Context, actor AdminActor, userID uuid.UUID, active bool) (*domain.User, error) {
+	if userID == actor.ID && !active {
+		return nil, domain.NewAppError(domain.CodeForbidden, "administrators cannot deactivate their own account")
+	}
+
+	user, err := s.userRepo.UpdateActiveStatus(ctx, userID, active)
+	if err != nil {
+		return nil, err
+	}
+
+	action := domain.AdminActionActivateUser
+	if !active {
+		action = domain.AdminActionDeactivateUser
+	}
+	s.record(ctx, actor, action, &userID, nil)
+	return user, nil
+}
+
+// SetAdmin grants or revokes system administration. The user's sessions end,
+// so that a revocation applies at once.
+func (s *AdminService) SetAdmin(ctx context.Context, actor AdminActor, userID uuid.UUID, isAdmin bool) (*domain.User, error) {
+	if userID == actor.ID && !isAdmin {
+		return nil, domain.NewAppError(domain.CodeForbidden, "administrators cannot revoke their own administration rights")
+	}
+
+	user, err := s.userRepo.UpdateAdminStatus(ctx, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	action := domain.AdminActionGrantAdmin
+	if !isAdmin {
+		action = domain.AdminActionRevokeAdmin
+	}
+	s.record(ctx, actor, action, &userID, nil)
+	return user, nil
+}
+
+// ForcePasswordReset revokes a user's sessions and password and emails them a reset link.
+func (s *AdminService) ForcePasswordReset(ctx context.Context, actor AdminActor, userID uuid.UUID) error {
+	if err := s.authService.ForcePasswordReset(ctx, userID); err != nil {
+		return err
+	}
+
+	s.record(ctx, actor, domain.AdminActionForcePasswordReset, &userID, nil)
+	return nil
+}
+
+// Impersonation is a support session acting as another user.
+type Impersonation struct {
+	User        *domain.User
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// Impersonate issues a short-lived, non-refreshable access token to act as a user.
+// The audit entry is written before the token is issued.
+func (s *AdminService) Impersonate(ctx context.Context, actor AdminActor, userID uuid.UUID, reason string) (*Impersonation, error) {
+	if userID == actor.ID {
+		return nil, domain.NewValidationError("id", "you cannot impersonate yourself")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+	if user.IsAdmin {
+		return nil, domain.NewAppError(domain.CodeForbidden, "administrators cannot be impersonated")
+	}
+
+	_, err = s.auditRepo.Create(ctx, domain.CreateAdminAuditLogInput{
+		ActorID:      actor.ID,
+		Action:       domain.AdminActionImpersonate,
+		TargetUserID: &userID,
+		Details:      map[string]string{"reason": reason},
+		IPAddress:    actor.IPAddress,
+	})
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to record impersonation", err)
+	}
+
+	token, expiresAt, err := s.jwtService.GenerateImpersonationToken(user, actor.ID)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate token", err)
+	}
+
+	slog.WarnContext(ctx, "administrator impersonating user",
+		slog.String("admin_id", actor.ID.String()),
+		slog.String("user_id", user.ID.String()),
+	)
+
+	return &Impersonation{
+		User:        user,
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// ========== Login Lockouts ==========
+
+// ListLockedLogins returns every currently locked identifier and client IP.
+func (s *AdminService) ListLockedLogins(ctx context.Context) ([]*domain.LoginThrottle, error) {
+	return s.protection.ListLocked(ctx)
+}
+
+// ListLoginAttempts returns the most recent login attempts for an identifier.
+func (s *AdminService) ListLoginAttempts(ctx context.Context, identifier string, limit int32) ([]*domain.LoginAttempt, error) {
+	return s.protection.ListAttempts(ctx, identifier, limit)
+}
+
+// UnlockLogin lifts a lockout, as listed by ListLockedLogins.
+func (s *AdminService) UnlockLogin(ctx context.Context, actor AdminActor, key string) error {
+	if err := s.protection.Unlock(ctx, key); err != nil {
+		return err
+	}
+
+	s.record(ctx, actor, domain.AdminActionUnlockLogin, nil, map[string]string{"key": key})
+	return nil
+}
+
+// ========== Organizations ==========
+
+// SetOrganizationQuotas replaces the quotas of an organization.
+func (s *AdminService) SetOrganizationQuotas(ctx context.Context, actor AdminActor, orgID uuid.UUID, quotas domain.OrganizationQuotas) (*domain.Organization, error) {
+	org, err := s.orgService.SetQuotas(ctx, orgID, quotas)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, actor, domain.AdminActionSetOrgQuotas, nil, map[string]string{
+		"organization_id":  orgID.String(),
+		"max_projects":     strconv.Itoa(int(quotas.MaxProjects)),
+		"max_members":      strconv.Itoa(int(quotas.MaxMembers)),
+		"max_applications": strconv.Itoa(int(quotas.MaxApplications)),
+	})
+	return org, nil
+}
+
+// ========== Audit Trail ==========
+
+// ListAuditLogs returns the most recent audit entries, optionally about a single user.
+func (s *AdminService) ListAuditLogs(ctx context.Context, targetUserID *uuid.UUID, limit int32) ([]*domain.AdminAuditLog, error) {
+	var (
+		logs []*domain.AdminAuditLog
+		err  error
+	)
+	if targetUserID != nil {
+		logs, err = s.auditRepo.ListByTarget(ctx, *targetUserID, limit)
+	} else {
+		logs, err = s.auditRepo.List(ctx, limit)
+	}
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list audit logs", err)
+	}
+	return logs, nil
+}
+
+// record writes an audit entry for an action that already happened.
+// Failures are logged rather than returned so the caller sees the action's real outcome.
+func (s *AdminService) record(ctx context.Context, actor AdminActor, action domain.AdminAction, targetUserID *uuid.UUID, details map[string]string) {
+	_, err := s.auditRepo.Create(ctx, domain.CreateAdminAuditLogInput{
+		ActorID:      actor.ID,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Details:      details,
+		IPAddress:    actor.IPAddress,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record admin action",
+			slog.String("action", string(action)),
+			slog.String("admin_id", actor.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+}
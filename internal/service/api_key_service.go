@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// APIKeyService handles the API keys client SDKs use to check for updates.
+type APIKeyService struct {
+	apiKeyRepo  repository.APIKeyRepository
+	appRepo     repository.ApplicationRepository
+	projectRepo repository.ProjectRepository
+}
+
+// NewAPIKeyService creates a new APIKeyService.
+func NewAPIKeyService(
+	apiKeyRepo repository.APIKeyRepository,
+	appRepo repository.ApplicationRepository,
+	projectRepo repository.ProjectRepository,
+) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo:  apiKeyRepo,
+		appRepo:     appRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// Create issues a new API key for an application. The raw key is only
+// returned here.
+func (s *APIKeyService) Create(ctx context.Context, userID, appID uuid.UUID, name string) (*domain.CreatedAPIKey, error) {
+	if _, err := s.authorize(ctx, userID, appID, domain.PermissionApplicationUpdate); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if err := domain.ValidateAPIKeyName(name); err != nil {
+		return nil, err
+	}
+
+	token, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate API key", err)
+	}
+	raw := domain.APIKeyPrefix + token
+
+	key, err := s.apiKeyRepo.Create(ctx, domain.CreateAPIKeyInput{
+		ApplicationID: appID,
+		Name:          name,
+		Prefix:        domain.APIKeyDisplayPrefix(raw),
+		KeyHash:       auth.HashOpaqueToken(raw),
+		CreatedBy:     userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "API key created",
+		slog.String("api_key_id", key.ID.String()),
+		slog.String("application_id", appID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return &domain.CreatedAPIKey{Key: key, Raw: raw}, nil
+}
+
+// List retrieves the API keys of an application, revoked ones included.
+func (s *APIKeyService) List(ctx context.Context, userID, appID uuid.UUID) ([]*domain.APIKey, error) {
+	if _, err := s.authorize(ctx, userID, appID, domain.PermissionApplicationUpdate); err != nil {
+		return nil, err
+	}
+	return s.apiKeyRepo.ListByApplication(ctx, appID)
+}
+
+// Revoke revokes an API key of an application. Clients using it are refused
+// from then on.
+func (s *APIKeyService) Revoke(ctx context.Context, userID, appID, keyID uuid.UUID) (*domain.APIKey, error) {
+	if _, err := s.authorize(ctx, userID, appID, domain.PermissionApplicationUpdate); err != nil {
+		return nil, err
+	}
+
+	key, err := s.apiKeyRepo.Revoke(ctx, appID, keyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "API key revoked",
+		slog.String("api_key_id", key.ID.String()),
+		slog.String("application_id", appID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return key, nil
+}
+
+// Authenticate resolves the application a raw API key belongs to. The key must
+// be live and issued for the application with the given package name.
+func (s *APIKeyService) Authenticate(ctx context.Context, raw, packageName string) (*domain.Application, error) {
+	if !domain.LooksLikeAPIKey(raw) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByHash(ctx, auth.HashOpaqueToken(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	app, err := s.appRepo.GetByID(ctx, key.ApplicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	// Refuse keys used for another application, without telling whether the
+	// package exists.
+	if app.PackageName != packageName {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.Touch(ctx, key.ID); err != nil {
+		slog.WarnContext(ctx, "failed to record API key use",
+			slog.String("api_key_id", key.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+	return app, nil
+}
+
+// authorize checks the user's permission on the application's project.
+func (s *APIKeyService) authorize(ctx context.Context, userID, appID uuid.UUID, perm string) (*domain.Application, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, perm); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
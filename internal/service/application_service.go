@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -18,8 +20,10 @@ type ApplicationService struct {
 	// Repositories
 	appRepo      repository.ApplicationRepository
 	projectRepo  repository.ProjectRepository
+	orgRepo      repository.OrganizationRepository
 	releaseRepo  repository.ReleaseRepository
 	artifactRepo repository.ArtifactRepository
+	channelRepo  repository.ChannelRepository
 	txManager    *db.TxManager
 }
 
@@ -27,16 +31,20 @@ type ApplicationService struct {
 func NewApplicationService(
 	appRepo repository.ApplicationRepository,
 	projectRepo repository.ProjectRepository,
+	orgRepo repository.OrganizationRepository,
 	releaseRepo repository.ReleaseRepository,
 	artifactRepo repository.ArtifactRepository,
+	channelRepo repository.ChannelRepository,
 	apkService *APKService,
 	txManager *db.TxManager,
 ) *ApplicationService {
 	return &ApplicationService{
 		appRepo:      appRepo,
 		projectRepo:  projectRepo,
+		orgRepo:      orgRepo,
 		releaseRepo:  releaseRepo,
 		artifactRepo: artifactRepo,
+		channelRepo:  channelRepo,
 		apkService:   apkService,
 		txManager:    txManager,
 	}
@@ -44,14 +52,14 @@ func NewApplicationService(
 
 // Create creates a new application within a project.
 func (s *ApplicationService) Create(ctx context.Context, userID uuid.UUID, input domain.CreateApplicationInput) (*domain.Application, error) {
-	// Verify project exists and user is the owner
+	// Verify project exists and user may add applications to it
 	project, err := s.projectRepo.GetByID(ctx, input.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
+	if _, err := authorizeProject(ctx, s.projectRepo, project.ID, userID, domain.PermissionApplicationCreate); err != nil {
+		return nil, err
 	}
 
 	// Check if package name is already taken
@@ -63,19 +71,44 @@ func (s *ApplicationService) Create(ctx context.Context, userID uuid.UUID, input
 		return nil, domain.ErrPackageNameExists
 	}
 
-	return s.appRepo.Create(ctx, input)
+	// Transaction: Create Application and its default channels
+	var app *domain.Application
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := checkApplicationQuotaTx(ctx, s.orgRepo, q, project.OrganizationID); err != nil {
+			return err
+		}
+		app, err = s.appRepo.CreateTx(ctx, q, input)
+		if err != nil {
+			return err
+		}
+		return createDefaultChannelsTx(ctx, s.channelRepo, q, app.ID, project.Settings.DefaultEnvironment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
 // Create application, release and artifact from a single first app binary
 func (s *ApplicationService) CreateFromArtifact(ctx context.Context, userId uuid.UUID, input domain.CreateApplicationFromArtifactInput) (*domain.Application, error) {
-	// Verify project exists and user is the owner
+	// Verify project exists and user may add applications to it
 	project, err := s.projectRepo.GetByID(ctx, input.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
-	if project.OwnerID != userId {
-		return nil, domain.ErrNotProjectOwner
+	if _, err := authorizeProject(ctx, s.projectRepo, project.ID, userId, domain.PermissionApplicationCreate); err != nil {
+		return nil, err
+	}
+
+	// The application starts with the default channels; the upload must target one of them
+	environment := project.Settings.EnvironmentFor(input.Environment)
+	if !slices.Contains(domain.DefaultChannelNames(project.Settings.DefaultEnvironment), environment) {
+		return nil, domain.NewValidationError("environment", fmt.Sprintf("new applications have no %q channel yet", environment))
+	}
+	if err := project.Settings.Pipeline.CheckUpload(environment); err != nil {
+		return nil, err
 	}
 
 	// Parse the APK
@@ -84,6 +117,16 @@ func (s *ApplicationService) CreateFromArtifact(ctx context.Context, userId uuid
 		return nil, err
 	}
 
+	if err := project.Settings.CheckArtifact(domain.APKContentType, metadata.FileSize); err != nil {
+		return nil, err
+	}
+	if err := project.Settings.CheckVersionName(metadata.VersionName); err != nil {
+		return nil, err
+	}
+	if err := project.Settings.CheckReleaseNote(input.ReleaseNote); err != nil {
+		return nil, err
+	}
+
 	// Check if package name is already taken
 	exists, err := s.appRepo.PackageNameExists(ctx, metadata.PackageName)
 	if err != nil {
@@ -93,9 +136,13 @@ func (s *ApplicationService) CreateFromArtifact(ctx context.Context, userId uuid
 		return nil, domain.ErrPackageNameExists
 	}
 
-	// Transaction: Create Application, Release and Artifact
+	// Transaction: Create Application, Channels, Release and Artifact
 	var app *domain.Application
 	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := checkApplicationQuotaTx(ctx, s.orgRepo, q, project.OrganizationID); err != nil {
+			return err
+		}
+
 		// 1. Create Application
 		app, err = s.appRepo.CreateTx(ctx, q, domain.CreateApplicationInput{
 			Title:       input.Title,
@@ -105,6 +152,9 @@ func (s *ApplicationService) CreateFromArtifact(ctx context.Context, userId uuid
 		if err != nil {
 			return err
 		}
+		if err := createDefaultChannelsTx(ctx, s.channelRepo, q, app.ID, project.Settings.DefaultEnvironment); err != nil {
+			return err
+		}
 
 		// 2. Create Initial Release
 		release, err := s.releaseRepo.CreateTx(ctx, q, domain.CreateReleaseInput{
@@ -112,8 +162,9 @@ func (s *ApplicationService) CreateFromArtifact(ctx context.Context, userId uuid
 			Title:         fmt.Sprintf("Release: %s (%d)", metadata.VersionName, metadata.VersionCode),
 			VersionCode:   int32(metadata.VersionCode),
 			VersionName:   metadata.VersionName,
-			ReleaseNote:   "Release initiale...",
-			Environment:   input.Environment,
+			ReleaseNote:   input.ReleaseNote,
+			Environment:   environment,
+			Status:        domain.ReleasePublished,
 		})
 		if err != nil {
 			return err
@@ -125,7 +176,8 @@ func (s *ApplicationService) CreateFromArtifact(ctx context.Context, userId uuid
 			FileURL:   input.ArtifactURL,
 			SHA256:    metadata.SHA256,
 			FileSize:  metadata.FileSize,
-			FileType:  "application/vnd.android.package-archive",
+			FileType:  domain.APKContentType,
+			Verified:  true,
 		})
 		if err != nil {
 			return err
@@ -149,16 +201,11 @@ func (s *ApplicationService) Update(ctx context.Context, userID uuid.UUID, appID
 		return nil, err
 	}
 
-	// Verify ownership through project
-	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
-	if err != nil {
+	// Verify permission through project
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionApplicationUpdate); err != nil {
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
-	}
-
 	// Update fields if provided
 	title := app.Title
 	if input.Title != nil {
@@ -181,17 +228,28 @@ func (s *ApplicationService) Delete(ctx context.Context, userID uuid.UUID, appID
 		return err
 	}
 
-	// Verify ownership
-	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
-	if err != nil {
+	// Verify permission through project
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionApplicationDelete); err != nil {
 		return err
 	}
 
-	if project.OwnerID != userID {
-		return domain.ErrNotProjectOwner
+	// Cascade to the application's releases and artifacts, with the same deleted_at
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.artifactRepo.SoftDeleteByApplicationTx(ctx, q, appID); err != nil {
+			return err
+		}
+		if err := s.releaseRepo.SoftDeleteByApplicationTx(ctx, q, appID); err != nil {
+			return err
+		}
+		return s.appRepo.SoftDeleteTx(ctx, q, appID)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrApplicationNotFound
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to delete application", err)
 	}
-
-	return s.appRepo.SoftDelete(ctx, appID)
+	return nil
 }
 
 // GetByID retrieves an application by ID.
@@ -199,7 +257,7 @@ func (s *ApplicationService) GetByID(ctx context.Context, appID uuid.UUID) (*dom
 	return s.appRepo.GetByID(ctx, appID)
 }
 
-// ListByProject lists all applications for a project.
-func (s *ApplicationService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Application, error) {
-	return s.appRepo.ListByProject(ctx, projectID)
+// ListByProject lists a page of the applications of a project.
+func (s *ApplicationService) ListByProject(ctx context.Context, projectID uuid.UUID, filter domain.ApplicationFilter, page domain.PageRequest) (*domain.Page[*domain.Application], error) {
+	return s.appRepo.ListByProject(ctx, projectID, filter, page)
 }
@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"log/slog"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -40,7 +45,11 @@ func NewArtifactService(
 
 // GetUploadURL generates a signed URL for uploading an artifact.
 func (s *ArtifactService) GetUploadURL(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID, filename string) (*domain.UploadURLResponse, error) {
-	// 1. Verify ownership
+	if s.storage == nil {
+		return nil, domain.NewAppError(domain.CodeInternal, "storage is not configured")
+	}
+
+	// 1. Verify permission
 	release, err := s.releaseRepo.GetByID(ctx, releaseID)
 	if err != nil {
 		return nil, err
@@ -51,15 +60,10 @@ func (s *ArtifactService) GetUploadURL(ctx context.Context, userID uuid.UUID, re
 		return nil, err
 	}
 
-	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
-	if err != nil {
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.WrapError(domain.CodeNotProjectOwner, fmt.Sprintf("access denied: user %s is not the owner of project %s", userID, project.ID), domain.ErrNotProjectOwner)
-	}
-
 	// 2. Generate storage path
 	// Structure: apps/{app_id}/releases/{release_id}/{timestamp}_{filename}
 	timestamp := time.Now().Unix()
@@ -81,7 +85,7 @@ func (s *ArtifactService) GetUploadURL(ctx context.Context, userID uuid.UUID, re
 
 // CreateArtifact records a new artifact in the database.
 func (s *ArtifactService) CreateArtifact(ctx context.Context, userID uuid.UUID, input domain.CreateArtifactInput) (*domain.Artifact, error) {
-	// Ownership check
+	// Permission check
 	release, err := s.releaseRepo.GetByID(ctx, input.ReleaseID)
 	if err != nil {
 		return nil, err
@@ -97,15 +101,22 @@ func (s *ArtifactService) CreateArtifact(ctx context.Context, userID uuid.UUID,
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
+	if _, err := authorizeProject(ctx, s.projectRepo, project.ID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	if err := project.Settings.CheckArtifact(input.FileType, input.FileSize); err != nil {
+		return nil, err
+	}
+	if err := checkBuildEditable(ctx, s.releaseRepo, release.ID); err != nil {
+		return nil, err
 	}
 
 	return s.artifactRepo.Create(ctx, input)
 }
 
-// ListByRelease retrieves all artifacts for a release.
-func (s *ArtifactService) ListByRelease(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) ([]*domain.Artifact, error) {
+// ListByRelease retrieves a page of the artifacts of a release.
+func (s *ArtifactService) ListByRelease(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID, filter domain.ArtifactFilter, page domain.PageRequest) (*domain.Page[*domain.Artifact], error) {
 	// 1. Verify access (can user see this release?)
 	release, err := s.releaseRepo.GetByID(ctx, releaseID)
 	if err != nil {
@@ -117,16 +128,75 @@ func (s *ArtifactService) ListByRelease(ctx context.Context, userID uuid.UUID, r
 		return nil, err
 	}
 
-	// For now, if they are project owner, they can see it.
-	// We might want to allow others later if we implement a "viewer" role.
-	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
+	// Anyone allowed to download the project's packages can see them,
+	// except the artifacts of drafts, which are for uploaders only.
+	access, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageDownload)
+	if err != nil {
+		return nil, err
+	}
+	if release.Status == domain.ReleaseDraft && !access.Can(domain.PermissionPackageUpload) {
+		return nil, domain.ErrReleaseNotFound
+	}
+
+	return s.artifactRepo.ListByRelease(ctx, releaseID, filter, page)
+}
+
+// Verify hashes the stored file of an artifact and marks the artifact verified
+// when the hash and size match what was recorded.
+func (s *ArtifactService) Verify(ctx context.Context, userID uuid.UUID, artifactID uuid.UUID) (*domain.Artifact, error) {
+	if s.storage == nil {
+		return nil, domain.NewAppError(domain.CodeInternal, "storage is not configured")
+	}
+
+	artifact, err := s.artifactRepo.GetByID(ctx, artifactID)
 	if err != nil {
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
+	release, err := s.releaseRepo.GetByID(ctx, artifact.ReleaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	if artifact.VerifiedAt != nil {
+		return artifact, nil
+	}
+
+	storagePath, isOurs := s.storage.ExtractStoragePath(artifact.FileURL)
+	if !isOurs {
+		return nil, domain.NewValidationError("file_url", "only artifacts in our storage can be verified")
+	}
+
+	reader, err := s.storage.Download(ctx, storagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	fileSize, err := io.Copy(hasher, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash artifact: %w", err)
+	}
+	sha256Hex := hex.EncodeToString(hasher.Sum(nil))
+
+	if fileSize != artifact.FileSize || !strings.EqualFold(sha256Hex, artifact.SHA256) {
+		slog.WarnContext(ctx, "artifact does not match its stored file",
+			slog.String("artifact_id", artifact.ID.String()),
+			slog.Int64("recorded_size", artifact.FileSize),
+			slog.Int64("stored_size", fileSize),
+		)
+		return nil, domain.NewAppError(domain.CodeArtifactMismatch, "the stored file does not match the artifact's recorded hash and size")
 	}
 
-	return s.artifactRepo.ListByRelease(ctx, releaseID)
+	return s.artifactRepo.MarkVerified(ctx, artifact.ID)
 }
@@ -3,41 +3,101 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/mailer"
 	"github.com/bsrodrigue/appshare-backend/internal/repository"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// AuthConfig holds the tunables of the AuthService.
+type AuthConfig struct {
+	// PasswordResetTokenTTL is how long an emailed reset token stays valid.
+	PasswordResetTokenTTL time.Duration
+
+	// PasswordResetURL is the frontend page the reset token is appended to.
+	PasswordResetURL string
+
+	// EmailChangeTokenTTL is how long a new address can be confirmed.
+	EmailChangeTokenTTL time.Duration
+
+	// EmailChangeURL is the frontend page the email change token is appended to.
+	EmailChangeURL string
+}
+
 // AuthService handles authentication business logic.
 type AuthService struct {
 	userRepo   repository.UserRepository
+	resetRepo  repository.PasswordResetRepository
+	emailRepo  repository.EmailChangeRepository
 	jwtService *auth.JWTService
+	mfaService *MFAService
+	protection *LoginProtectionService
+	policy     *auth.PasswordPolicy
+	mailer     mailer.Mailer
+	txManager  *db.TxManager
+	config     AuthConfig
 }
 
 // NewAuthService creates a new AuthService.
-func NewAuthService(userRepo repository.UserRepository, jwtService *auth.JWTService) *AuthService {
+func NewAuthService(
+	userRepo repository.UserRepository,
+	resetRepo repository.PasswordResetRepository,
+	emailRepo repository.EmailChangeRepository,
+	jwtService *auth.JWTService,
+	mfaService *MFAService,
+	protection *LoginProtectionService,
+	policy *auth.PasswordPolicy,
+	mailer mailer.Mailer,
+	txManager *db.TxManager,
+	config AuthConfig,
+) *AuthService {
 	return &AuthService{
 		userRepo:   userRepo,
+		resetRepo:  resetRepo,
+		emailRepo:  emailRepo,
 		jwtService: jwtService,
+		mfaService: mfaService,
+		protection: protection,
+		policy:     policy,
+		mailer:     mailer,
+		txManager:  txManager,
+		config:     config,
 	}
 }
 
 // LoginInput represents credentials for login.
 type LoginInput struct {
-	Email    string // Can be email or username
-	Password string
+	Email     string // Can be email or username
+	Password  string
+	IPAddress string // Client IP, used for brute-force protection
 }
 
 // LoginResult represents a successful login response.
+// When the user has two-factor authentication enabled, Tokens is nil and
+// MFAChallenge must be exchanged at VerifyMFA to complete the login.
 type LoginResult struct {
-	User   *domain.User
-	Tokens *auth.TokenPair
+	User         *domain.User
+	Tokens       *auth.TokenPair
+	MFAChallenge *MFAChallenge
+}
+
+// MFAChallenge is a short-lived proof that the password step succeeded.
+type MFAChallenge struct {
+	Token     string
+	ExpiresAt time.Time
 }
 
 // Login authenticates a user by email/username and password.
+// Repeated failures lock the identifier or the client IP (see LoginProtectionService).
 func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult, error) {
 	// Try to get credentials by email first, then by username
 	creds, err := s.userRepo.GetCredentialsByEmail(ctx, input.Email)
@@ -45,13 +105,23 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 		// Try username
 		creds, err = s.userRepo.GetCredentialsByUsername(ctx, input.Email)
 	}
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return nil, domain.ErrInvalidCredentials
-		}
+	var userID *uuid.UUID
+	switch {
+	case err == nil:
+		userID = &creds.ID
+	case !errors.Is(err, domain.ErrNotFound):
 		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve credentials", err)
 	}
 
+	// Refuse locked identifiers, accounts and IPs before doing any bcrypt work
+	if err := s.protection.Check(ctx, input.Email, input.IPAddress, userID); err != nil {
+		return nil, err
+	}
+	if userID == nil {
+		s.protection.RecordFailure(ctx, input.Email, input.IPAddress, nil)
+		return nil, domain.ErrInvalidCredentials
+	}
+
 	// Check if user is active
 	if !creds.IsActive {
 		return nil, domain.ErrUserInactive
@@ -59,8 +129,10 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(input.Password)); err != nil {
+		s.protection.RecordFailure(ctx, input.Email, input.IPAddress, &creds.ID)
 		return nil, domain.ErrInvalidCredentials
 	}
+	s.protection.RecordSuccess(ctx, input.Email, input.IPAddress, creds.ID)
 
 	// Get full user data (without password hash)
 	user, err := s.userRepo.GetByID(ctx, creds.ID)
@@ -68,6 +140,146 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
 	}
 
+	return s.beginSession(ctx, user)
+}
+
+// LoginWithIdentity logs in a user authenticated by an external identity provider.
+// Two-factor authentication still applies when enabled on the account.
+func (s *AuthService) LoginWithIdentity(ctx context.Context, user *domain.User) (*LoginResult, error) {
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+	return s.beginSession(ctx, user)
+}
+
+// beginSession issues tokens, or an MFA challenge when a second factor is required.
+func (s *AuthService) beginSession(ctx context.Context, user *domain.User) (*LoginResult, error) {
+	// Second factor required: hand out a challenge instead of tokens
+	mfaEnabled, err := s.mfaService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		challenge, expiresAt, err := s.jwtService.GenerateMFAChallengeToken(user)
+		if err != nil {
+			return nil, domain.WrapError(domain.CodeInternal, "failed to generate challenge", err)
+		}
+		return &LoginResult{
+			MFAChallenge: &MFAChallenge{Token: challenge, ExpiresAt: expiresAt},
+		}, nil
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// VerifyMFA completes a login by checking a TOTP or recovery code against an MFA challenge.
+// Wrong codes count as login failures for the account email and the client IP.
+func (s *AuthService) VerifyMFA(ctx context.Context, challengeToken, code, ip string) (*LoginResult, error) {
+	claims, err := s.jwtService.ValidateMFAChallengeToken(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTokenInvalid
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+	if claims.TokenVersion != user.TokenVersion {
+		return nil, domain.ErrTokenInvalid
+	}
+
+	if err := s.protection.Check(ctx, user.Email, ip, &user.ID); err != nil {
+		return nil, err
+	}
+	if err := s.mfaService.VerifyCode(ctx, user.ID, code); err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			s.protection.RecordFailure(ctx, user.Email, ip, &user.ID)
+		}
+		return nil, err
+	}
+	s.protection.RecordSuccess(ctx, user.Email, ip, user.ID)
+
+	return s.completeLogin(ctx, user)
+}
+
+// reauthenticate checks the password of a user, and their second factor when
+// enabled, before a sensitive change. Failures count as login failures for the
+// account email and the client IP.
+func (s *AuthService) reauthenticate(ctx context.Context, user *domain.User, password, code, ip string) error {
+	if err := s.protection.Check(ctx, user.Email, ip, &user.ID); err != nil {
+		return err
+	}
+
+	creds, err := s.userRepo.GetCredentialsByEmail(ctx, user.Email)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve credentials", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(password)); err != nil {
+		s.protection.RecordFailure(ctx, user.Email, ip, &user.ID)
+		return domain.ErrInvalidCredentials
+	}
+
+	mfaEnabled, err := s.mfaService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if mfaEnabled {
+		if code == "" {
+			return domain.NewAppError(domain.CodeInvalidMFACode, "a two-factor authentication code is required")
+		}
+		if err := s.verifyCode(ctx, user, code, ip); err != nil {
+			return err
+		}
+	}
+
+	s.protection.RecordSuccess(ctx, user.Email, ip, user.ID)
+	return nil
+}
+
+// reauthenticateWithoutPassword confirms a sensitive change for users whose
+// password was generated when single sign-on provisioned their account: with
+// a fresh sign-in at their identity provider or else a second factor code.
+func (s *AuthService) reauthenticateWithoutPassword(ctx context.Context, user *domain.User, reauthToken, code, ip string) error {
+	if reauthToken != "" {
+		claims, err := s.jwtService.ValidateReauthenticationToken(reauthToken)
+		if err != nil {
+			return err
+		}
+		if claims.UserID != user.ID || claims.TokenVersion != user.TokenVersion {
+			return domain.ErrTokenInvalid
+		}
+		return nil
+	}
+
+	if err := s.protection.Check(ctx, user.Email, ip, &user.ID); err != nil {
+		return err
+	}
+	if err := s.verifyCode(ctx, user, code, ip); err != nil {
+		return err
+	}
+	s.protection.RecordSuccess(ctx, user.Email, ip, user.ID)
+	return nil
+}
+
+// verifyCode checks a second factor code, counting wrong codes as login failures.
+func (s *AuthService) verifyCode(ctx context.Context, user *domain.User, code, ip string) error {
+	if err := s.mfaService.VerifyCode(ctx, user.ID, code); err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			s.protection.RecordFailure(ctx, user.Email, ip, &user.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// completeLogin issues tokens once every authentication factor has been verified.
+func (s *AuthService) completeLogin(ctx context.Context, user *domain.User) (*LoginResult, error) {
 	// Generate tokens
 	tokens, err := s.jwtService.GenerateTokenPair(user)
 	if err != nil {
@@ -75,7 +287,7 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 	}
 
 	// Update last login (fire and forget)
-	_ = s.userRepo.UpdateLastLogin(ctx, creds.ID)
+	_ = s.userRepo.UpdateLastLogin(ctx, user.ID)
 
 	return &LoginResult{
 		User:   user,
@@ -103,8 +315,8 @@ func (s *AuthService) Register(ctx context.Context, input domain.CreateUserInput
 	if input.Username == "" {
 		return nil, domain.NewValidationError("username", "username is required")
 	}
-	if len(input.Password) < 8 {
-		return nil, domain.NewValidationError("password", "password must be at least 8 characters")
+	if err := validatePassword(s.policy, "password", input.Password, input.Username, input.Email); err != nil {
+		return nil, err
 	}
 
 	// Check email uniqueness
@@ -190,6 +402,11 @@ func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*
 		return nil, domain.ErrUserInactive
 	}
 
+	// Reject tokens issued before the sessions were revoked (e.g. password reset)
+	if claims.TokenVersion != user.TokenVersion {
+		return nil, domain.ErrTokenInvalid
+	}
+
 	// Generate new token pair
 	tokens, err := s.jwtService.GenerateTokenPair(user)
 	if err != nil {
@@ -233,8 +450,8 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, curr
 	}
 
 	// Validate new password
-	if len(newPassword) < 8 {
-		return domain.NewValidationError("new_password", "password must be at least 8 characters")
+	if err := validatePassword(s.policy, "new_password", newPassword, credsWithHash.Username, credsWithHash.Email); err != nil {
+		return err
 	}
 
 	// Hash new password
@@ -246,3 +463,330 @@ func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, curr
 	// Update password
 	return s.userRepo.UpdatePassword(ctx, userID, string(hash))
 }
+
+// ForgotPassword issues a password reset token and emails it to the user.
+// It never reveals whether the email is registered: the token is issued and
+// sent in the background, so known and unknown accounts answer equally fast,
+// and unknown or inactive accounts, as well as failures, are only logged.
+func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	// The request may end before the email is sent
+	go s.sendPasswordReset(context.WithoutCancel(ctx), user)
+	return nil
+}
+
+// sendPasswordReset stores a new reset token for the user and emails the link.
+func (s *AuthService) sendPasswordReset(ctx context.Context, user *domain.User) {
+	var rawToken string
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		rawToken, err = s.createResetTokenTx(ctx, q, user.ID)
+		return err
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to store password reset token",
+			slog.String("user_id", user.ID.String()),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	body := fmt.Sprintf(
+		"Hello %s,\n\nUse the link below to choose a new password. It expires in %s and can only be used once.\n\n%s\n\nIf you did not request this, you can ignore this email.\n",
+		user.FirstName, s.config.PasswordResetTokenTTL, s.resetLink(rawToken),
+	)
+	if err := s.sendResetEmail(ctx, user, body); err != nil {
+		slog.ErrorContext(ctx, "failed to send password reset email",
+			slog.String("user_id", user.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// ForcePasswordReset disables a user's current password, revokes all their
+// sessions and emails them a reset link. Used by administrators.
+func (s *AuthService) ForcePasswordReset(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// An unknown random password locks the account until the link is used
+	unusable, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to generate password", err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(unusable), bcrypt.DefaultCost)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to hash password", err)
+	}
+
+	var rawToken string
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if _, err := s.userRepo.ResetPasswordTx(ctx, q, user.ID, string(hash)); err != nil {
+			return err
+		}
+		var err error
+		rawToken, err = s.createResetTokenTx(ctx, q, user.ID)
+		return err
+	})
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to reset password", err)
+	}
+
+	body := fmt.Sprintf(
+		"Hello %s,\n\nAn administrator has reset your password and signed you out everywhere. Use the link below to choose a new password. It expires in %s and can only be used once.\n\n%s\n\nIf the link has expired, request a new one from the login page.\n",
+		user.FirstName, s.config.PasswordResetTokenTTL, s.resetLink(rawToken),
+	)
+	if err := s.sendResetEmail(ctx, user, body); err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to send password reset email", err)
+	}
+
+	return nil
+}
+
+// createResetTokenTx issues a reset token for a user within a transaction and returns its raw value.
+// A new token supersedes any previously issued one.
+func (s *AuthService) createResetTokenTx(ctx context.Context, q *db.Queries, userID uuid.UUID) (string, error) {
+	rawToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.resetRepo.InvalidateForUserTx(ctx, q, userID); err != nil {
+		return "", err
+	}
+	expiresAt := time.Now().Add(s.config.PasswordResetTokenTTL)
+	if _, err := s.resetRepo.CreateTx(ctx, q, userID, tokenHash, expiresAt); err != nil {
+		return "", err
+	}
+	return rawToken, nil
+}
+
+// sendResetEmail sends a password reset email to the user.
+func (s *AuthService) sendResetEmail(ctx context.Context, user *domain.User, body string) error {
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your AppShare password",
+		Body:    body,
+	})
+}
+
+// ResetPassword redeems a reset token, sets the new password and revokes all existing sessions.
+func (s *AuthService) ResetPassword(ctx context.Context, rawToken, newPassword string) error {
+	token, err := s.resetRepo.GetByHash(ctx, auth.HashOpaqueToken(rawToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrTokenInvalid
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve reset token", err)
+	}
+	if token.UsedAt != nil {
+		return domain.ErrTokenInvalid
+	}
+	if !token.IsUsable(time.Now()) {
+		return domain.ErrTokenExpired
+	}
+
+	// Validate new password
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrTokenInvalid
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+	if err := validatePassword(s.policy, "new_password", newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to hash password", err)
+	}
+
+	return s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		// Consuming the token first guarantees single use under concurrency
+		if err := s.resetRepo.MarkUsedTx(ctx, q, token.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrTokenInvalid
+			}
+			return err
+		}
+
+		if _, err := s.userRepo.ResetPasswordTx(ctx, q, token.UserID, string(hash)); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrTokenInvalid
+			}
+			return err
+		}
+
+		// The reset link was mailed to the account's address
+		if err := s.userRepo.MarkEmailVerifiedTx(ctx, q, token.UserID, user.Email); err != nil {
+			return err
+		}
+
+		return s.resetRepo.InvalidateForUserTx(ctx, q, token.UserID)
+	})
+}
+
+// RequestEmailChange starts changing the user's email address. The current
+// address stays in place until the link mailed to the new one is confirmed.
+func (s *AuthService) RequestEmailChange(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return domain.NewValidationError("new_email", "must differ from the current email")
+	}
+
+	creds, err := s.userRepo.GetCredentialsByEmail(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(currentPassword)); err != nil {
+		return domain.NewAppError(domain.CodeInvalidCredentials, "current password is incorrect")
+	}
+
+	exists, err := s.userRepo.EmailExists(ctx, newEmail)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to check email", err)
+	}
+	if exists {
+		return domain.ErrEmailAlreadyExists
+	}
+
+	rawToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to generate token", err)
+	}
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		// A new request supersedes any pending one
+		if err := s.emailRepo.InvalidateForUserTx(ctx, q, user.ID); err != nil {
+			return err
+		}
+		expiresAt := time.Now().Add(s.config.EmailChangeTokenTTL)
+		_, err := s.emailRepo.CreateTx(ctx, q, user.ID, newEmail, tokenHash, expiresAt)
+		return err
+	})
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to store email change token", err)
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new AppShare email address",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nUse the link below to confirm this address for your AppShare account. It expires in %s and can only be used once.\n\n%s\n\nIf you did not request this, you can ignore this email.\n",
+			user.FirstName, s.config.EmailChangeTokenTTL, tokenLink(s.config.EmailChangeURL, rawToken),
+		),
+	})
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to send confirmation email", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange redeems an email change token and applies the new address.
+// The previous address is notified of the change.
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, rawToken string) (*domain.User, error) {
+	token, err := s.emailRepo.GetByHash(ctx, auth.HashOpaqueToken(rawToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTokenInvalid
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve email change token", err)
+	}
+	if token.UsedAt != nil {
+		return nil, domain.ErrTokenInvalid
+	}
+	if !token.IsUsable(time.Now()) {
+		return nil, domain.ErrTokenExpired
+	}
+
+	previous, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTokenInvalid
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+
+	var user *domain.User
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		// Consuming the token first guarantees single use under concurrency
+		if err := s.emailRepo.MarkUsedTx(ctx, q, token.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrTokenInvalid
+			}
+			return err
+		}
+
+		// The address may have been taken since the change was requested
+		exists, err := s.userRepo.EmailExistsTx(ctx, q, token.NewEmail)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrEmailAlreadyExists
+		}
+
+		user, err = s.userRepo.UpdateEmailTx(ctx, q, token.UserID, token.NewEmail)
+		if err != nil {
+			return err
+		}
+		if err := s.userRepo.MarkEmailVerifiedTx(ctx, q, token.UserID, token.NewEmail); err != nil {
+			return err
+		}
+
+		return s.emailRepo.InvalidateForUserTx(ctx, q, token.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      previous.Email,
+		Subject: "Your AppShare email address was changed",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nThe email address of your AppShare account was changed to %s. If you did not make this change, contact support immediately.\n",
+			previous.FirstName, user.Email,
+		),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send email change notice",
+			slog.String("user_id", user.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return user, nil
+}
+
+// resetLink builds the frontend URL carrying the raw reset token.
+func (s *AuthService) resetLink(rawToken string) string {
+	return tokenLink(s.config.PasswordResetURL, rawToken)
+}
+
+// tokenLink appends a raw token to a frontend URL as the "token" query parameter.
+func tokenLink(base, rawToken string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(rawToken)
+	}
+	q := u.Query()
+	q.Set("token", rawToken)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/avatar"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/bsrodrigue/appshare-backend/internal/storage"
+	"github.com/google/uuid"
+)
+
+// AvatarService handles user profile pictures.
+// Clients upload the original image through a signed URL, then ask the server
+// to validate it and publish resized renditions.
+type AvatarService struct {
+	userRepo repository.UserRepository
+	storage  storage.Storage
+}
+
+// NewAvatarService creates a new AvatarService.
+func NewAvatarService(userRepo repository.UserRepository, storage storage.Storage) *AvatarService {
+	return &AvatarService{userRepo: userRepo, storage: storage}
+}
+
+// GetUploadURL generates a signed URL for uploading the original avatar image.
+func (s *AvatarService) GetUploadURL(ctx context.Context, userID uuid.UUID) (*domain.UploadURLResponse, error) {
+	if s.storage == nil {
+		return nil, domain.NewAppError(domain.CodeInternal, "storage is not configured")
+	}
+
+	storagePath := avatarUploadPath(userID)
+	uploadURL, err := s.storage.GenerateUploadURL(ctx, storagePath, 15*time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
+	}
+
+	return &domain.UploadURLResponse{
+		UploadURL: uploadURL,
+		FileURL:   s.storage.GetPublicURL(storagePath),
+		Path:      storagePath,
+	}, nil
+}
+
+// Publish validates the uploaded image, stores its square renditions and
+// makes them the user's avatar. The previous avatar is removed.
+func (s *AvatarService) Publish(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	if s.storage == nil {
+		return nil, domain.NewAppError(domain.CodeInternal, "storage is not configured")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadPath := avatarUploadPath(userID)
+	original, err := s.readUpload(ctx, uploadPath)
+	if err != nil {
+		return nil, err
+	}
+
+	renditions, err := avatar.Process(original)
+	if err != nil {
+		if errors.Is(err, avatar.ErrUnsupportedFormat) || errors.Is(err, avatar.ErrTooLarge) || errors.Is(err, avatar.ErrTooSmall) {
+			return nil, domain.NewValidationError("avatar", err.Error())
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to process avatar", err)
+	}
+
+	// A new directory per upload keeps cached URLs of the old picture from going stale
+	dir := fmt.Sprintf("users/%s/avatar/%d", userID, time.Now().UnixNano())
+	for _, size := range avatar.Sizes {
+		if err := s.storage.Upload(ctx, renditionPath(dir, size), bytes.NewReader(renditions[size]), "image/png"); err != nil {
+			return nil, domain.WrapError(domain.CodeInternal, "failed to store avatar", err)
+		}
+	}
+
+	updated, err := s.userRepo.UpdateAvatar(ctx, userID, s.storage.GetPublicURL(renditionPath(dir, avatar.Sizes[0])))
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to update avatar", err)
+	}
+
+	s.deleteFile(ctx, uploadPath)
+	s.deleteRenditions(ctx, user)
+
+	return updated, nil
+}
+
+// Remove deletes the user's avatar, reverting to the initials placeholder.
+func (s *AvatarService) Remove(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AvatarURL == "" {
+		return user, nil
+	}
+
+	updated, err := s.userRepo.UpdateAvatar(ctx, userID, "")
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to remove avatar", err)
+	}
+
+	s.deleteRenditions(ctx, user)
+
+	return updated, nil
+}
+
+// readUpload reads the original image, refusing anything over the size limit.
+func (s *AvatarService) readUpload(ctx context.Context, uploadPath string) ([]byte, error) {
+	rc, err := s.storage.Download(ctx, uploadPath)
+	if err != nil {
+		slog.WarnContext(ctx, "avatar upload not readable",
+			slog.String("path", uploadPath),
+			slog.String("error", err.Error()),
+		)
+		return nil, domain.NewValidationError("avatar", "no uploaded image found, upload one first")
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, avatar.MaxUploadBytes+1))
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to read avatar", err)
+	}
+	return data, nil
+}
+
+// deleteRenditions removes the stored renditions of the user's current avatar.
+func (s *AvatarService) deleteRenditions(ctx context.Context, user *domain.User) {
+	if user.AvatarURL == "" || s.storage == nil {
+		return
+	}
+
+	storagePath, ok := s.storage.ExtractStoragePath(user.AvatarURL)
+	// Never delete outside the user's avatar directory
+	if !ok || !strings.HasPrefix(storagePath, fmt.Sprintf("users/%s/avatar/", user.ID)) {
+		return
+	}
+
+	dir := path.Dir(storagePath)
+	for _, size := range avatar.Sizes {
+		s.deleteFile(ctx, renditionPath(dir, size))
+	}
+}
+
+// deleteFile removes a file, only logging failures.
+func (s *AvatarService) deleteFile(ctx context.Context, storagePath string) {
+	if err := s.storage.Delete(ctx, storagePath); err != nil {
+		slog.WarnContext(ctx, "failed to delete avatar file",
+			slog.String("path", storagePath),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// avatarUploadPath is where clients upload the original image: users/{id}/avatar/upload
+func avatarUploadPath(userID uuid.UUID) string {
+	return fmt.Sprintf("users/%s/avatar/upload", userID)
+}
+
+// renditionPath is the storage path of a square rendition: {dir}/{size}.png
+func renditionPath(dir string, size int) string {
+	return fmt.Sprintf("%s/%d.png", dir, size)
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ChannelService handles the release channels of applications.
+type ChannelService struct {
+	// Repositories
+	channelRepo repository.ChannelRepository
+	appRepo     repository.ApplicationRepository
+	projectRepo repository.ProjectRepository
+	releaseRepo repository.ReleaseRepository
+
+	// Transaction Manager
+	txManager *db.TxManager
+}
+
+// NewChannelService creates a new ChannelService.
+func NewChannelService(
+	// Repositories
+	channelRepo repository.ChannelRepository,
+	appRepo repository.ApplicationRepository,
+	projectRepo repository.ProjectRepository,
+	releaseRepo repository.ReleaseRepository,
+
+	// Transaction Manager
+	txManager *db.TxManager,
+) *ChannelService {
+	return &ChannelService{
+		channelRepo: channelRepo,
+		appRepo:     appRepo,
+		projectRepo: projectRepo,
+		releaseRepo: releaseRepo,
+		txManager:   txManager,
+	}
+}
+
+// List retrieves the channels of an application. Anyone with access to the
+// project can list them, unlisted ones included.
+func (s *ChannelService) List(ctx context.Context, userID, appID uuid.UUID) ([]*domain.ReleaseChannel, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, ""); err != nil {
+		return nil, err
+	}
+	return s.channelRepo.ListByApplication(ctx, appID)
+}
+
+// Create adds a channel to an application.
+func (s *ChannelService) Create(ctx context.Context, userID uuid.UUID, input domain.CreateReleaseChannelInput) (*domain.ReleaseChannel, error) {
+	app, err := s.appRepo.GetByID(ctx, input.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionApplicationUpdate); err != nil {
+		return nil, err
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	channels, err := s.channelRepo.ListByApplication(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) >= domain.MaxChannelsPerApplication {
+		return nil, domain.NewValidationError("name", fmt.Sprintf("an application has at most %d channels", domain.MaxChannelsPerApplication))
+	}
+
+	var channel *domain.ReleaseChannel
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		isDefault := input.IsDefault
+		input.IsDefault = false
+		channel, err = s.channelRepo.CreateTx(ctx, q, input)
+		if err != nil || !isDefault {
+			return err
+		}
+		channel, err = s.channelRepo.SetDefaultTx(ctx, q, app.ID, channel.Name)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, domain.ErrChannelExists
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to create channel", err)
+	}
+
+	slog.InfoContext(ctx, "release channel created",
+		slog.String("application_id", app.ID.String()),
+		slog.String("channel", string(channel.Name)),
+		slog.String("user_id", userID.String()),
+	)
+	return channel, nil
+}
+
+// Update changes a channel's position, visibility, default flag or minimum
+// supported version code. The minimum cannot exceed the newest release of the
+// channel offered to every client.
+func (s *ChannelService) Update(ctx context.Context, userID, appID uuid.UUID, name domain.ReleaseEnvironment, input domain.UpdateReleaseChannelInput) (*domain.ReleaseChannel, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionApplicationUpdate); err != nil {
+		return nil, err
+	}
+
+	current, err := s.getChannel(ctx, appID, name)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := input.Apply(*current)
+	if err != nil {
+		return nil, err
+	}
+
+	var channel *domain.ReleaseChannel
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if input.MinSupportedVersionCode != nil {
+			// Under the channel's lock, so releases cannot be pulled meanwhile
+			if _, err := s.channelRepo.LockTx(ctx, q, appID, name); err != nil {
+				return err
+			}
+			fullyRolledOut, err := s.releaseRepo.HighestFullyRolledOutVersionCodeTx(ctx, q, appID, name)
+			if err != nil {
+				return domain.WrapError(domain.CodeInternal, "failed to get the channel's latest release", err)
+			}
+			if err := updated.CheckMinSupportedVersion(fullyRolledOut); err != nil {
+				return err
+			}
+		}
+
+		channel, err = s.channelRepo.UpdateTx(ctx, q, appID, name, updated.Position, updated.Visibility, updated.MinSupportedVersionCode)
+		if err != nil || !updated.IsDefault || current.IsDefault {
+			return err
+		}
+		channel, err = s.channelRepo.SetDefaultTx(ctx, q, appID, name)
+		return err
+	})
+	if err != nil {
+		var valErr *domain.ValidationError
+		switch {
+		case errors.As(err, &valErr):
+			return nil, err
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, domain.ErrChannelNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to update channel", err)
+	}
+
+	if channel.MinSupportedVersionCode != current.MinSupportedVersionCode {
+		slog.InfoContext(ctx, "minimum supported version changed",
+			slog.String("application_id", appID.String()),
+			slog.String("channel", string(name)),
+			slog.Int("min_supported_version_code", int(channel.MinSupportedVersionCode)),
+			slog.String("user_id", userID.String()),
+		)
+	}
+	return channel, nil
+}
+
+// Delete removes a channel. The default channel and channels still holding
+// releases, trashed ones included, cannot be removed.
+func (s *ChannelService) Delete(ctx context.Context, userID, appID uuid.UUID, name domain.ReleaseEnvironment) error {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionApplicationUpdate); err != nil {
+		return err
+	}
+
+	channel, err := s.getChannel(ctx, appID, name)
+	if err != nil {
+		return err
+	}
+	if channel.IsDefault {
+		return domain.NewValidationError("name", "the default channel cannot be deleted; make another channel the default first")
+	}
+	inUse, err := s.channelRepo.InUse(ctx, appID, name)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to check channel releases", err)
+	}
+	if inUse {
+		return domain.ErrChannelInUse
+	}
+
+	if err := s.channelRepo.Delete(ctx, appID, name); err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to delete channel", err)
+	}
+
+	slog.InfoContext(ctx, "release channel deleted",
+		slog.String("application_id", appID.String()),
+		slog.String("channel", string(name)),
+		slog.String("user_id", userID.String()),
+	)
+	return nil
+}
+
+func (s *ChannelService) getChannel(ctx context.Context, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error) {
+	channel, err := s.channelRepo.GetByName(ctx, appID, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrChannelNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get channel", err)
+	}
+	return channel, nil
+}
+
+// ========== Helpers ==========
+
+// resolveChannel returns the channel an upload goes to: the named one, or the
+// application's default when none was named.
+func resolveChannel(ctx context.Context, channelRepo repository.ChannelRepository, appID uuid.UUID, name domain.ReleaseEnvironment) (domain.ReleaseEnvironment, error) {
+	channel, err := lookupChannel(ctx, channelRepo, appID, name)
+	if err != nil {
+		return "", err
+	}
+	return channel.Name, nil
+}
+
+// lookupChannel retrieves the named channel, or the application's default when
+// none was named.
+func lookupChannel(ctx context.Context, channelRepo repository.ChannelRepository, appID uuid.UUID, name domain.ReleaseEnvironment) (*domain.ReleaseChannel, error) {
+	var channel *domain.ReleaseChannel
+	var err error
+	if name == "" {
+		channel, err = channelRepo.GetDefault(ctx, appID)
+	} else {
+		channel, err = channelRepo.GetByName(ctx, appID, name)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound) && name == "":
+			return nil, domain.NewAppError(domain.CodeChannelNotFound, "the application has no default channel")
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, domain.NewAppError(domain.CodeChannelNotFound, fmt.Sprintf("the application has no %q channel", name))
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get channel", err)
+	}
+	return channel, nil
+}
+
+// createDefaultChannelsTx gives a new application its default channels within a transaction.
+func createDefaultChannelsTx(ctx context.Context, channelRepo repository.ChannelRepository, q *db.Queries, appID uuid.UUID, defaultEnv domain.ReleaseEnvironment) error {
+	for _, input := range domain.DefaultChannels(appID, defaultEnv) {
+		if _, err := channelRepo.CreateTx(ctx, q, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
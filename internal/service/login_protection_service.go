@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// LoginProtectionConfig holds the brute-force protection thresholds.
+type LoginProtectionConfig struct {
+	// MaxFailures is the number of consecutive failures for one identifier, or
+	// for one account whichever identifier names it, before lockout.
+	MaxFailures int
+
+	// MaxFailuresPerIP is the number of consecutive failures from one client IP before lockout.
+	// Higher than MaxFailures since offices and carriers share addresses.
+	MaxFailuresPerIP int
+
+	// BaseLockout is the first lockout duration. It doubles with each further failure.
+	BaseLockout time.Duration
+
+	// MaxLockout caps the lockout duration.
+	MaxLockout time.Duration
+
+	// FailureWindow is how long a failure is remembered before the counter starts over.
+	FailureWindow time.Duration
+}
+
+// LoginProtectionService tracks failed logins per identifier, per account and
+// per client IP, applying exponential lockouts to slow down password guessing.
+type LoginProtectionService struct {
+	throttleRepo repository.LoginThrottleRepository
+	config       LoginProtectionConfig
+}
+
+// NewLoginProtectionService creates a new LoginProtectionService.
+func NewLoginProtectionService(throttleRepo repository.LoginThrottleRepository, config LoginProtectionConfig) *LoginProtectionService {
+	return &LoginProtectionService{
+		throttleRepo: throttleRepo,
+		config:       config,
+	}
+}
+
+// Throttle key prefixes
+const (
+	identifierThrottlePrefix = "identifier:"
+	userThrottlePrefix       = "user:"
+	ipThrottlePrefix         = "ip:"
+)
+
+// Check returns a domain.LockoutError if the identifier, the account it
+// resolves to (nil when none does) or the client IP is locked. Keying on the
+// account keeps a client from doubling its attempts by alternating the email
+// and username of the same user. It must run before any password comparison.
+func (s *LoginProtectionService) Check(ctx context.Context, identifier, ip string, userID *uuid.UUID) error {
+	now := time.Now()
+	for _, k := range s.keys(identifier, ip, userID) {
+		throttle, err := s.throttleRepo.Get(ctx, k.key)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			return domain.WrapError(domain.CodeInternal, "failed to check login throttle", err)
+		}
+		if throttle.IsLocked(now) {
+			return domain.NewLockoutError(*throttle.LockedUntil)
+		}
+	}
+	return nil
+}
+
+// RecordFailure records a failed attempt and locks the identifier, account or
+// IP once their threshold is reached. Errors are logged: the login already failed.
+func (s *LoginProtectionService) RecordFailure(ctx context.Context, identifier, ip string, userID *uuid.UUID) {
+	s.recordAttempt(ctx, identifier, ip, userID, false)
+
+	now := time.Now()
+	for _, k := range s.keys(identifier, ip, userID) {
+		throttle, err := s.throttleRepo.RecordFailure(ctx, k.key, now.Add(-s.config.FailureWindow))
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to record login failure", slog.String("error", err.Error()))
+			continue
+		}
+
+		excess := int(throttle.FailureCount) - k.maxFailures
+		if excess < 0 {
+			continue
+		}
+
+		until := now.Add(s.lockoutDuration(excess))
+		if err := s.throttleRepo.Lock(ctx, k.key, until); err != nil {
+			slog.ErrorContext(ctx, "failed to lock login", slog.String("error", err.Error()))
+			continue
+		}
+		slog.WarnContext(ctx, "login locked after repeated failures",
+			slog.String("key", k.key),
+			slog.Int("failures", int(throttle.FailureCount)),
+			slog.Time("locked_until", until),
+		)
+	}
+}
+
+// RecordSuccess records a successful attempt and resets the failures of the
+// identifier and account. The IP counter is left alone so one valid account
+// cannot shield a guessing spree.
+func (s *LoginProtectionService) RecordSuccess(ctx context.Context, identifier, ip string, userID uuid.UUID) {
+	s.recordAttempt(ctx, identifier, ip, &userID, true)
+
+	for _, key := range []string{identifierThrottlePrefix + normalizeIdentifier(identifier), userThrottlePrefix + userID.String()} {
+		if err := s.throttleRepo.Delete(ctx, key); err != nil {
+			slog.ErrorContext(ctx, "failed to reset login throttle", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// ListLocked returns every currently locked identifier and IP.
+func (s *LoginProtectionService) ListLocked(ctx context.Context) ([]*domain.LoginThrottle, error) {
+	throttles, err := s.throttleRepo.ListLocked(ctx)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list locked logins", err)
+	}
+	return throttles, nil
+}
+
+// ListAttempts returns the most recent login attempts for an identifier.
+func (s *LoginProtectionService) ListAttempts(ctx context.Context, identifier string, limit int32) ([]*domain.LoginAttempt, error) {
+	attempts, err := s.throttleRepo.ListAttempts(ctx, normalizeIdentifier(identifier), limit)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list login attempts", err)
+	}
+	return attempts, nil
+}
+
+// Unlock clears the failures and lockout of a throttle key, as listed by ListLocked.
+func (s *LoginProtectionService) Unlock(ctx context.Context, key string) error {
+	if err := s.throttleRepo.Delete(ctx, key); err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to unlock login", err)
+	}
+	slog.InfoContext(ctx, "login unlocked", slog.String("key", key))
+	return nil
+}
+
+// lockoutDuration doubles the base lockout for each failure past the threshold.
+func (s *LoginProtectionService) lockoutDuration(excess int) time.Duration {
+	lockout := s.config.BaseLockout
+	for i := 0; i < excess && lockout < s.config.MaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, s.config.MaxLockout)
+}
+
+// throttleKey is a throttle key and the failures it tolerates before lockout.
+type throttleKey struct {
+	key         string
+	maxFailures int
+}
+
+// keys returns the identifier key, the account key when the identifier
+// resolved to a user, then the IP key.
+func (s *LoginProtectionService) keys(identifier, ip string, userID *uuid.UUID) []throttleKey {
+	keys := []throttleKey{{identifierThrottlePrefix + normalizeIdentifier(identifier), s.config.MaxFailures}}
+	if userID != nil {
+		keys = append(keys, throttleKey{userThrottlePrefix + userID.String(), s.config.MaxFailures})
+	}
+	return append(keys, throttleKey{ipThrottlePrefix + normalizeIP(ip), s.config.MaxFailuresPerIP})
+}
+
+func (s *LoginProtectionService) recordAttempt(ctx context.Context, identifier, ip string, userID *uuid.UUID, succeeded bool) {
+	err := s.throttleRepo.RecordAttempt(ctx, domain.CreateLoginAttemptInput{
+		Identifier: normalizeIdentifier(identifier),
+		IPAddress:  normalizeIP(ip),
+		UserID:     userID,
+		Succeeded:  succeeded,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record login attempt", slog.String("error", err.Error()))
+	}
+}
+
+// normalizeIdentifier lower-cases and bounds the identifier so variants share a counter.
+func normalizeIdentifier(identifier string) string {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+	if len(identifier) > 255 {
+		identifier = identifier[:255]
+	}
+	return identifier
+}
+
+func normalizeIP(ip string) string {
+	if ip == "" {
+		return "unknown"
+	}
+	return ip
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// throttleRepository keeps throttles in memory. Elapse moves every recorded
+// time back, as if that much time had passed.
+type throttleRepository struct {
+	repository.LoginThrottleRepository
+	throttles map[string]*domain.LoginThrottle
+}
+
+func newThrottleRepository() *throttleRepository {
+	return &throttleRepository{throttles: make(map[string]*domain.LoginThrottle)}
+}
+
+func (r *throttleRepository) RecordAttempt(ctx context.Context, input domain.CreateLoginAttemptInput) error {
+	return nil
+}
+
+func (r *throttleRepository) Get(ctx context.Context, key string) (*domain.LoginThrottle, error) {
+	throttle, ok := r.throttles[key]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	copied := *throttle
+	return &copied, nil
+}
+
+func (r *throttleRepository) RecordFailure(ctx context.Context, key string, windowStart time.Time) (*domain.LoginThrottle, error) {
+	throttle, ok := r.throttles[key]
+	if !ok || throttle.LastFailureAt.Before(windowStart) {
+		throttle = &domain.LoginThrottle{Key: key}
+		r.throttles[key] = throttle
+	}
+	throttle.FailureCount++
+	throttle.LastFailureAt = time.Now()
+	copied := *throttle
+	return &copied, nil
+}
+
+func (r *throttleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.throttles[key].LockedUntil = &until
+	return nil
+}
+
+func (r *throttleRepository) Delete(ctx context.Context, key string) error {
+	delete(r.throttles, key)
+	return nil
+}
+
+func (r *throttleRepository) Elapse(d time.Duration) {
+	for _, throttle := range r.throttles {
+		throttle.LastFailureAt = throttle.LastFailureAt.Add(-d)
+		if throttle.LockedUntil != nil {
+			until := throttle.LockedUntil.Add(-d)
+			throttle.LockedUntil = &until
+		}
+	}
+}
+
+func (r *throttleRepository) lockout(key string) time.Duration {
+	throttle, ok := r.throttles[key]
+	if !ok || throttle.LockedUntil == nil {
+		return 0
+	}
+	return time.Until(*throttle.LockedUntil).Round(time.Minute)
+}
+
+var testProtectionConfig = LoginProtectionConfig{
+	MaxFailures:      3,
+	MaxFailuresPerIP: 5,
+	BaseLockout:      time.Minute,
+	MaxLockout:       8 * time.Minute,
+	FailureWindow:    15 * time.Minute,
+}
+
+func TestLoginProtectionService_LockoutDuration(t *testing.T) {
+	s := NewLoginProtectionService(nil, testProtectionConfig)
+
+	for excess, want := range []time.Duration{
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		8 * time.Minute, // Capped
+		8 * time.Minute,
+	} {
+		assert.Equal(t, want, s.lockoutDuration(excess), "excess %d", excess)
+	}
+}
+
+// loginAttempt is a login attempt in a scenario: a failure unless succeeded is
+// set, optionally after some time has passed.
+type loginAttempt struct {
+	identifier string
+	ip         string
+	account    bool // The identifier names alice's account
+	succeeded  bool
+	after      time.Duration
+}
+
+func TestLoginProtectionService(t *testing.T) {
+	alice := uuid.New()
+	const ip, otherIP = "203.0.113.7", "198.51.100.1"
+
+	repeat := func(n int, a loginAttempt) []loginAttempt {
+		attempts := make([]loginAttempt, n)
+		for i := range attempts {
+			attempts[i] = a
+		}
+		return attempts
+	}
+	concat := func(groups ...[]loginAttempt) []loginAttempt {
+		var attempts []loginAttempt
+		for _, g := range groups {
+			attempts = append(attempts, g...)
+		}
+		return attempts
+	}
+	aliceFails := loginAttempt{identifier: "alice@example.com", ip: ip, account: true}
+
+	tests := []struct {
+		name     string
+		attempts []loginAttempt
+		check    loginAttempt // The next login
+		locked   bool
+		key      string        // Throttle key whose lockout is checked
+		lockout  time.Duration // Its expected lockout, 0 for none
+	}{
+		{
+			name:     "below the threshold",
+			attempts: repeat(2, aliceFails),
+			check:    aliceFails,
+			key:      "identifier:alice@example.com",
+		},
+		{
+			name:     "threshold crossed",
+			attempts: repeat(3, aliceFails),
+			check:    aliceFails,
+			locked:   true,
+			key:      "identifier:alice@example.com",
+			lockout:  time.Minute,
+		},
+		{
+			name:     "lockout doubles past the threshold",
+			attempts: repeat(5, aliceFails),
+			check:    aliceFails,
+			locked:   true,
+			key:      "user:" + alice.String(),
+			lockout:  4 * time.Minute,
+		},
+		{
+			name:     "lockout is capped",
+			attempts: repeat(10, aliceFails),
+			check:    aliceFails,
+			locked:   true,
+			key:      "identifier:alice@example.com",
+			lockout:  8 * time.Minute,
+		},
+		{
+			name:     "identifiers are normalised",
+			attempts: repeat(3, loginAttempt{identifier: "  Alice@Example.COM", ip: ip, account: true}),
+			check:    loginAttempt{identifier: "alice@example.com", ip: otherIP},
+			locked:   true,
+		},
+		{
+			name: "email and username share the account counter",
+			attempts: []loginAttempt{
+				{identifier: "alice@example.com", ip: ip, account: true},
+				{identifier: "alice", ip: otherIP, account: true},
+				{identifier: "alice@example.com", ip: ip, account: true},
+			},
+			check:   loginAttempt{identifier: "alice", ip: "192.0.2.1", account: true},
+			locked:  true,
+			key:     "user:" + alice.String(),
+			lockout: time.Minute,
+		},
+		{
+			name:     "unknown identifiers only share the identifier counter",
+			attempts: repeat(3, loginAttempt{identifier: "nobody@example.com", ip: ip}),
+			check:    loginAttempt{identifier: "alice@example.com", ip: otherIP, account: true},
+			key:      "identifier:nobody@example.com",
+			lockout:  time.Minute,
+		},
+		{
+			name: "IP threshold across identifiers",
+			attempts: []loginAttempt{
+				{identifier: "a@example.com", ip: ip}, {identifier: "b@example.com", ip: ip},
+				{identifier: "c@example.com", ip: ip}, {identifier: "d@example.com", ip: ip},
+				{identifier: "e@example.com", ip: ip},
+			},
+			check:   loginAttempt{identifier: "alice@example.com", ip: ip, account: true},
+			locked:  true,
+			key:     "ip:" + ip,
+			lockout: time.Minute,
+		},
+		{
+			name:     "lock expires",
+			attempts: repeat(3, aliceFails),
+			check:    loginAttempt{identifier: "alice@example.com", ip: ip, account: true, after: time.Minute + time.Second},
+		},
+		{
+			name: "failure after expiry relocks for longer",
+			attempts: append(repeat(3, aliceFails),
+				loginAttempt{identifier: "alice@example.com", ip: ip, account: true, after: time.Minute + time.Second}),
+			check:   aliceFails,
+			locked:  true,
+			key:     "identifier:alice@example.com",
+			lockout: 2 * time.Minute,
+		},
+		{
+			name: "failures outside the window are forgotten",
+			attempts: append(repeat(2, aliceFails),
+				loginAttempt{identifier: "alice@example.com", ip: ip, account: true, after: 16 * time.Minute}),
+			check: aliceFails,
+			key:   "identifier:alice@example.com",
+		},
+		{
+			name: "success resets the identifier and account",
+			attempts: concat(
+				repeat(2, aliceFails),
+				[]loginAttempt{{identifier: "alice@example.com", ip: ip, account: true, succeeded: true}},
+				repeat(2, aliceFails),
+			),
+			check: aliceFails,
+			key:   "user:" + alice.String(),
+		},
+		{
+			name: "success keeps the IP counter",
+			attempts: concat(
+				repeat(4, loginAttempt{identifier: "mallory@example.com", ip: ip}),
+				[]loginAttempt{{identifier: "alice@example.com", ip: ip, account: true, succeeded: true}},
+				[]loginAttempt{{identifier: "mallory@example.com", ip: ip}},
+			),
+			check:   loginAttempt{identifier: "alice@example.com", ip: ip, account: true},
+			locked:  true,
+			key:     "ip:" + ip,
+			lockout: time.Minute,
+		},
+		{
+			name:     "locked account refuses its username from another IP",
+			attempts: repeat(3, aliceFails),
+			check:    loginAttempt{identifier: "alice", ip: otherIP, account: true},
+			locked:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newThrottleRepository()
+			s := NewLoginProtectionService(repo, testProtectionConfig)
+
+			userID := func(a loginAttempt) *uuid.UUID {
+				if a.account {
+					return &alice
+				}
+				return nil
+			}
+			for _, a := range tt.attempts {
+				repo.Elapse(a.after)
+				if a.succeeded {
+					s.RecordSuccess(ctx, a.identifier, a.ip, alice)
+				} else {
+					s.RecordFailure(ctx, a.identifier, a.ip, userID(a))
+				}
+			}
+			repo.Elapse(tt.check.after)
+
+			err := s.Check(ctx, tt.check.identifier, tt.check.ip, userID(tt.check))
+			if tt.locked {
+				var lockout *domain.LockoutError
+				require.ErrorAs(t, err, &lockout)
+				assert.ErrorIs(t, err, domain.ErrAccountLocked)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.key != "" {
+				assert.Equal(t, tt.lockout, repo.lockout(tt.key), tt.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// recoveryCodeCount is the number of recovery codes issued per enrollment.
+	recoveryCodeCount = 10
+
+	// recoveryCodeAlphabet has 32 symbols (no modulo bias) and avoids
+	// ambiguous characters (0/o, 1/l).
+	recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// MFAService handles TOTP two-factor authentication business logic.
+type MFAService struct {
+	mfaRepo   repository.MFARepository
+	userRepo  repository.UserRepository
+	txManager *db.TxManager
+	issuer    string
+}
+
+// NewMFAService creates a new MFAService.
+// issuer is the label shown in authenticator apps.
+func NewMFAService(
+	mfaRepo repository.MFARepository,
+	userRepo repository.UserRepository,
+	txManager *db.TxManager,
+	issuer string,
+) *MFAService {
+	return &MFAService{
+		mfaRepo:   mfaRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+		issuer:    issuer,
+	}
+}
+
+// MFAStatus describes the second factor state of a user.
+type MFAStatus struct {
+	Enabled                bool
+	EnabledAt              *time.Time
+	RecoveryCodesRemaining int64
+}
+
+// GetStatus returns the second factor state of a user.
+func (s *MFAService) GetStatus(ctx context.Context, userID uuid.UUID) (*MFAStatus, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &MFAStatus{}, nil
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve two-factor settings", err)
+	}
+	if !mfa.IsEnabled() {
+		return &MFAStatus{}, nil
+	}
+
+	remaining, err := s.mfaRepo.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to count recovery codes", err)
+	}
+
+	return &MFAStatus{
+		Enabled:                true,
+		EnabledAt:              mfa.EnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// IsEnabled reports whether the user has a confirmed second factor.
+func (s *MFAService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, domain.WrapError(domain.CodeInternal, "failed to retrieve two-factor settings", err)
+	}
+	return mfa.IsEnabled(), nil
+}
+
+// Enroll starts TOTP enrollment and returns the secret, otpauth URI and recovery codes.
+// The second factor is only enforced once confirmed with a valid code.
+func (s *MFAService) Enroll(ctx context.Context, userID uuid.UUID) (*domain.MFAEnrollment, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate secret", err)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate recovery codes", err)
+	}
+
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if _, err := s.mfaRepo.SaveSecretTx(ctx, q, userID, secret); err != nil {
+			return err
+		}
+		return s.mfaRepo.ReplaceRecoveryCodesTx(ctx, q, userID, hashes)
+	})
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to save two-factor settings", err)
+	}
+
+	return &domain.MFAEnrollment{
+		Secret:        secret,
+		URI:           auth.TOTPURI(s.issuer, user.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// Confirm activates a pending enrollment with a code from the authenticator app.
+func (s *MFAService) Confirm(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NewAppError(domain.CodeMFANotEnabled, "no pending two-factor enrollment")
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve two-factor settings", err)
+	}
+	if mfa.IsEnabled() {
+		return domain.ErrMFAAlreadyEnabled
+	}
+
+	step, valid := auth.ValidateTOTP(mfa.Secret, code, time.Now())
+	if !valid {
+		return domain.ErrInvalidMFACode
+	}
+
+	if _, err := s.mfaRepo.Enable(ctx, userID, step); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrMFAAlreadyEnabled
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to enable two-factor authentication", err)
+	}
+	return nil
+}
+
+// Disable removes the second factor. It requires the password and a current code.
+func (s *MFAService) Disable(ctx context.Context, userID uuid.UUID, password, code string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	creds, err := s.userRepo.GetCredentialsByEmail(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(password)); err != nil {
+		return domain.NewAppError(domain.CodeInvalidCredentials, "password is incorrect")
+	}
+
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return err
+	}
+
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		return s.mfaRepo.DeleteTx(ctx, q, userID)
+	})
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to disable two-factor authentication", err)
+	}
+	return nil
+}
+
+// VerifyCode checks a TOTP code or, failing that, consumes a recovery code.
+// Each TOTP time step and each recovery code can be used only once.
+func (s *MFAService) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := s.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrMFANotEnabled
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to retrieve two-factor settings", err)
+	}
+	if !mfa.IsEnabled() {
+		return domain.ErrMFANotEnabled
+	}
+
+	if step, valid := auth.ValidateTOTP(mfa.Secret, code, time.Now()); valid {
+		consumed, err := s.mfaRepo.ConsumeStep(ctx, userID, step)
+		if err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to record code usage", err)
+		}
+		if !consumed {
+			return domain.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := s.mfaRepo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to use recovery code", err)
+	}
+	if !used {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// generateRecoveryCodes returns plain-text recovery codes ("xxxxx-xxxxx") and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	buf := make([]byte, 10)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		var b strings.Builder
+		for j, c := range buf {
+			if j == 5 {
+				b.WriteByte('-')
+			}
+			b.WriteByte(recoveryCodeAlphabet[int(c)%len(recoveryCodeAlphabet)])
+		}
+		codes[i] = b.String()
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode normalizes a recovery code (case, separators) and hashes it.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(code)
+	normalized = strings.NewReplacer("-", "", " ", "").Replace(normalized)
+	return auth.HashOpaqueToken(normalized)
+}
@@ -0,0 +1,408 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// OrganizationService handles organizations, their members and their quotas.
+type OrganizationService struct {
+	orgRepo   repository.OrganizationRepository
+	userRepo  repository.UserRepository
+	txManager *db.TxManager
+}
+
+// NewOrganizationService creates a new OrganizationService.
+func NewOrganizationService(
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	txManager *db.TxManager,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:   orgRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+	}
+}
+
+// Create creates an organization. The creator becomes its owner.
+func (s *OrganizationService) Create(ctx context.Context, userID uuid.UUID, name string) (*domain.OrganizationMembership, error) {
+	if err := domain.ValidateOrganizationName(name); err != nil {
+		return nil, err
+	}
+
+	org, err := s.create(ctx, userID, name, nil)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to create organization", err)
+	}
+	return &domain.OrganizationMembership{Organization: *org, Role: domain.OrgRoleOwner}, nil
+}
+
+// EnsurePersonal returns the personal organization of a user, creating it on first use.
+func (s *OrganizationService) EnsurePersonal(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	org, err := s.orgRepo.GetPersonal(ctx, userID)
+	if err == nil {
+		return org, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get personal organization", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err = s.create(ctx, userID, user.Username, &userID)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		// Created concurrently by another request
+		return s.orgRepo.GetPersonal(ctx, userID)
+	}
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to create personal organization", err)
+	}
+
+	slog.InfoContext(ctx, "personal organization created",
+		slog.String("organization_id", org.ID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return org, nil
+}
+
+// create creates an organization and its first owner in one transaction.
+func (s *OrganizationService) create(ctx context.Context, ownerID uuid.UUID, name string, personalOwnerID *uuid.UUID) (*domain.Organization, error) {
+	var org *domain.Organization
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		org, err = s.orgRepo.CreateTx(ctx, q, name, personalOwnerID)
+		if err != nil {
+			return err
+		}
+		_, err = s.orgRepo.AddMemberTx(ctx, q, org.ID, ownerID, domain.OrgRoleOwner)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// ListForUser lists the organizations a user belongs to, starting with their personal one.
+func (s *OrganizationService) ListForUser(ctx context.Context, userID uuid.UUID) ([]*domain.OrganizationMembership, error) {
+	if _, err := s.EnsurePersonal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListForUser(ctx, userID)
+}
+
+// Get retrieves an organization and its usage. Only members can see it.
+func (s *OrganizationService) Get(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrganizationMembership, *domain.OrganizationUsage, error) {
+	member, err := s.authorize(ctx, orgID, userID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	org, err := s.getByID(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage, err := s.orgRepo.GetUsage(ctx, orgID)
+	if err != nil {
+		return nil, nil, domain.WrapError(domain.CodeInternal, "failed to count organization usage", err)
+	}
+	return &domain.OrganizationMembership{Organization: *org, Role: member.Role}, usage, nil
+}
+
+// Rename changes an organization's name. Only owners and admins can rename it.
+func (s *OrganizationService) Rename(ctx context.Context, orgID, userID uuid.UUID, name string) (*domain.OrganizationMembership, error) {
+	if err := domain.ValidateOrganizationName(name); err != nil {
+		return nil, err
+	}
+
+	member, err := s.authorize(ctx, orgID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.UpdateName(ctx, orgID, name)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to rename organization", err)
+	}
+	return &domain.OrganizationMembership{Organization: *org, Role: member.Role}, nil
+}
+
+// SetQuotas replaces an organization's quotas. Callers are responsible for
+// checking that the actor is an administrator.
+func (s *OrganizationService) SetQuotas(ctx context.Context, orgID uuid.UUID, quotas domain.OrganizationQuotas) (*domain.Organization, error) {
+	if err := quotas.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.UpdateQuotas(ctx, orgID, quotas)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to update organization quotas", err)
+	}
+	return org, nil
+}
+
+// ========== Members ==========
+
+// ListMembers lists the members of an organization. Only members can see them.
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID, userID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	if _, err := s.authorize(ctx, orgID, userID, false); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+// AddMember adds a user, found by email, to an organization.
+// Owners and admins can add members; only owners can add other owners.
+func (s *OrganizationService) AddMember(ctx context.Context, orgID, requesterID uuid.UUID, email string, role domain.OrganizationRole) (*domain.OrganizationMember, error) {
+	if !role.Valid() {
+		return nil, domain.NewValidationError("role", "must be owner, admin or member")
+	}
+
+	requester, err := s.authorize(ctx, orgID, requesterID, true)
+	if err != nil {
+		return nil, err
+	}
+	if role == domain.OrgRoleOwner && requester.Role != domain.OrgRoleOwner {
+		return nil, domain.ErrInsufficientRole
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewValidationError("email", "no user with this email")
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to find user", err)
+	}
+
+	// Count and insert under the organization's lock, so concurrent
+	// additions cannot both fit in the last free seat
+	var member *domain.OrganizationMember
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		org, err := s.orgRepo.LockTx(ctx, q, orgID)
+		if err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to get organization", err)
+		}
+		usage, err := s.orgRepo.GetUsageTx(ctx, q, orgID)
+		if err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to count organization usage", err)
+		}
+		if usage.Members >= int64(org.Quotas.MaxMembers) {
+			return domain.NewQuotaExceededError("members", org.Quotas.MaxMembers)
+		}
+
+		member, err = s.orgRepo.AddMemberTx(ctx, q, orgID, user.ID, role)
+		if err != nil {
+			if errors.Is(err, domain.ErrAlreadyExists) {
+				return domain.NewAppError(domain.CodeAlreadyExists, "user is already a member of this organization")
+			}
+			return domain.WrapError(domain.CodeInternal, "failed to add organization member", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// UpdateMemberRole changes a member's role. Owners and admins can change roles;
+// only owners can grant or take away the owner role. Members who can no longer
+// manage the organization hand the projects they own in it over to an owner.
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, orgID, requesterID, userID uuid.UUID, role domain.OrganizationRole) (*domain.OrganizationMember, error) {
+	if !role.Valid() {
+		return nil, domain.NewValidationError("role", "must be owner, admin or member")
+	}
+
+	requester, err := s.authorize(ctx, orgID, requesterID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.getMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if (role == domain.OrgRoleOwner || target.Role == domain.OrgRoleOwner) && requester.Role != domain.OrgRoleOwner {
+		return nil, domain.ErrInsufficientRole
+	}
+	if target.Role == domain.OrgRoleOwner && role != domain.OrgRoleOwner {
+		if err := s.checkOwnerCanLeave(ctx, orgID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var member *domain.OrganizationMember
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if target.Role.CanManage() && !role.CanManage() {
+			if err := s.handOverProjectsTx(ctx, q, orgID, requester, userID); err != nil {
+				return err
+			}
+		}
+		member, err = s.orgRepo.UpdateMemberRoleTx(ctx, q, orgID, userID, role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember removes a user from an organization. Owners and admins can remove
+// members, only owners can remove owners, and anyone can leave. The projects
+// the user owns in the organization are handed over to an owner.
+func (s *OrganizationService) RemoveMember(ctx context.Context, orgID, requesterID, userID uuid.UUID) error {
+	requester, err := s.authorize(ctx, orgID, requesterID, requesterID != userID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.getMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if target.Role == domain.OrgRoleOwner && requester.Role != domain.OrgRoleOwner {
+		return domain.ErrInsufficientRole
+	}
+	if target.Role == domain.OrgRoleOwner {
+		if err := s.checkOwnerCanLeave(ctx, orgID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.handOverProjectsTx(ctx, q, orgID, requester, userID); err != nil {
+			return err
+		}
+		return s.orgRepo.RemoveMemberTx(ctx, q, orgID, userID)
+	})
+}
+
+// handOverProjectsTx gives the projects a user owns in an organization to one
+// of its owners, the requester when they are one, so that the user keeps no
+// owner access to them once they leave or stop managing the organization.
+func (s *OrganizationService) handOverProjectsTx(ctx context.Context, q *db.Queries, orgID uuid.UUID, requester *domain.OrganizationMember, userID uuid.UUID) error {
+	successor := requester.UserID
+	if requester.Role != domain.OrgRoleOwner || requester.UserID == userID {
+		var err error
+		successor, err = s.orgRepo.GetOtherOwnerTx(ctx, q, orgID, userID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.NewAppError(domain.CodeForbidden, "the organization has no other owner to take over the member's projects")
+			}
+			return domain.WrapError(domain.CodeInternal, "failed to find an organization owner", err)
+		}
+	}
+
+	if err := s.orgRepo.HandOverProjectsTx(ctx, q, orgID, userID, successor); err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to hand over projects", err)
+	}
+	return nil
+}
+
+// checkOwnerCanLeave refuses to drop an owner who is the last one, or whose personal organization it is.
+func (s *OrganizationService) checkOwnerCanLeave(ctx context.Context, orgID, userID uuid.UUID) error {
+	org, err := s.getByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.PersonalOwnerID != nil && *org.PersonalOwnerID == userID {
+		return domain.NewAppError(domain.CodeForbidden, "you cannot leave your personal organization")
+	}
+
+	owners, err := s.orgRepo.CountOwners(ctx, orgID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to count organization owners", err)
+	}
+	if owners <= 1 {
+		return domain.ErrLastOwner
+	}
+	return nil
+}
+
+// ========== Projects ==========
+
+// AuthorizeProjectCreation resolves the organization a new project goes into,
+// defaulting to the user's personal organization, and checks that the user may
+// create projects there. The quota is checked by CheckProjectQuotaTx, in the
+// transaction that creates the project.
+func (s *OrganizationService) AuthorizeProjectCreation(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) (*domain.Organization, error) {
+	var (
+		org *domain.Organization
+		err error
+	)
+	if orgID == nil {
+		org, err = s.EnsurePersonal(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := s.authorize(ctx, *orgID, userID, true); err != nil {
+			return nil, err
+		}
+		org, err = s.getByID(ctx, *orgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return org, nil
+}
+
+// CheckProjectQuotaTx refuses a new project once the organization holds as many
+// as its quota allows. It locks the organization until the transaction ends.
+func (s *OrganizationService) CheckProjectQuotaTx(ctx context.Context, q *db.Queries, orgID uuid.UUID) error {
+	return checkProjectQuotaTx(ctx, s.orgRepo, q, orgID)
+}
+
+// ========== Helpers ==========
+
+// authorize returns the requester's membership, requiring a managing role when manage is set.
+// Non-members are told the organization does not exist.
+func (s *OrganizationService) authorize(ctx context.Context, orgID, userID uuid.UUID, manage bool) (*domain.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to check organization membership", err)
+	}
+	if manage && !member.Role.CanManage() {
+		return nil, domain.ErrInsufficientRole
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) getByID(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get organization", err)
+	}
+	return org, nil
+}
+
+func (s *OrganizationService) getMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewAppError(domain.CodeNotFound, "user is not a member of this organization")
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get organization member", err)
+	}
+	return member, nil
+}
@@ -12,21 +12,33 @@ import (
 
 // ProjectService handles project-related business logic.
 type ProjectService struct {
-	projectRepo repository.ProjectRepository
-	userRepo    repository.UserRepository
-	txManager   *db.TxManager
+	projectRepo  repository.ProjectRepository
+	userRepo     repository.UserRepository
+	appRepo      repository.ApplicationRepository
+	releaseRepo  repository.ReleaseRepository
+	artifactRepo repository.ArtifactRepository
+	orgService   *OrganizationService
+	txManager    *db.TxManager
 }
 
 // NewProjectService creates a new ProjectService.
 func NewProjectService(
 	projectRepo repository.ProjectRepository,
 	userRepo repository.UserRepository,
+	appRepo repository.ApplicationRepository,
+	releaseRepo repository.ReleaseRepository,
+	artifactRepo repository.ArtifactRepository,
+	orgService *OrganizationService,
 	txManager *db.TxManager,
 ) *ProjectService {
 	return &ProjectService{
-		projectRepo: projectRepo,
-		userRepo:    userRepo,
-		txManager:   txManager,
+		projectRepo:  projectRepo,
+		userRepo:     userRepo,
+		appRepo:      appRepo,
+		releaseRepo:  releaseRepo,
+		artifactRepo: artifactRepo,
+		orgService:   orgService,
+		txManager:    txManager,
 	}
 }
 
@@ -41,9 +53,26 @@ func (s *ProjectService) Create(ctx context.Context, input domain.CreateProjectI
 		return nil, domain.WrapError(domain.CodeInternal, "failed to verify owner", err)
 	}
 
-	project, err := s.projectRepo.Create(ctx, input)
+	// Resolve the organization, defaulting to the owner's personal one
+	org, err := s.orgService.AuthorizeProjectCreation(ctx, input.OwnerID, input.OrganizationID)
 	if err != nil {
-		return nil, domain.WrapError(domain.CodeInternal, "failed to create project", err)
+		return nil, err
+	}
+	input.OrganizationID = &org.ID
+
+	var project *domain.Project
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.orgService.CheckProjectQuotaTx(ctx, q, org.ID); err != nil {
+			return err
+		}
+		project, err = s.projectRepo.CreateTx(ctx, q, input)
+		if err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to create project", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return project, nil
@@ -61,25 +90,39 @@ func (s *ProjectService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pro
 	return project, nil
 }
 
-// ListByOwner retrieves all projects owned by a user.
-func (s *ProjectService) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
-	return s.projectRepo.ListByOwner(ctx, ownerID)
+// GetForUser retrieves a project the user has access to, with their role and permissions in it.
+func (s *ProjectService) GetForUser(ctx context.Context, id, userID uuid.UUID) (*domain.Project, *domain.ProjectAccess, error) {
+	project, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	access, err := authorizeProject(ctx, s.projectRepo, id, userID, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return project, access, nil
+}
+
+// ListByOwner retrieves a page of the projects owned by a user.
+func (s *ProjectService) ListByOwner(ctx context.Context, ownerID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.Project], error) {
+	return s.projectRepo.ListByOwner(ctx, ownerID, filter, page)
 }
 
-// Update updates a project. Only the owner can update their project.
+// ListForUser retrieves a page of the projects a user owns or is a member of,
+// with the user's role and permissions in each.
+func (s *ProjectService) ListForUser(ctx context.Context, userID uuid.UUID, filter domain.ProjectFilter, page domain.PageRequest) (*domain.Page[*domain.ProjectSummary], error) {
+	return s.projectRepo.ListForUser(ctx, userID, filter, page)
+}
+
+// Update updates a project. Requires the project.manage permission.
 func (s *ProjectService) Update(ctx context.Context, id uuid.UUID, input domain.UpdateProjectInput, requesterID uuid.UUID) (*domain.Project, error) {
-	// Get project to verify ownership
-	project, err := s.projectRepo.GetByID(ctx, id)
+	project, err := s.GetByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return nil, domain.ErrProjectNotFound
-		}
 		return nil, err
 	}
 
-	// Check ownership
-	if project.OwnerID != requesterID {
-		return nil, domain.ErrNotProjectOwner
+	if _, err := authorizeProject(ctx, s.projectRepo, id, requesterID, domain.PermissionProjectManage); err != nil {
+		return nil, err
 	}
 
 	// Apply updates
@@ -96,33 +139,87 @@ func (s *ProjectService) Update(ctx context.Context, id uuid.UUID, input domain.
 	return s.projectRepo.Update(ctx, id, title, description)
 }
 
-// Delete soft-deletes a project. Only the owner can delete.
+// Delete soft-deletes a project. Only owners, directly or through the
+// project's organization, can delete.
 func (s *ProjectService) Delete(ctx context.Context, id uuid.UUID, requesterID uuid.UUID) error {
-	// Get project to verify ownership
-	project, err := s.projectRepo.GetByID(ctx, id)
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.requireOwner(ctx, id, requesterID); err != nil {
+		return err
+	}
+
+	// Cascade to the project's applications, releases and artifacts. They all get
+	// the same deleted_at, the transaction's timestamp.
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.artifactRepo.SoftDeleteByProjectTx(ctx, q, id); err != nil {
+			return err
+		}
+		if err := s.releaseRepo.SoftDeleteByProjectTx(ctx, q, id); err != nil {
+			return err
+		}
+		if err := s.appRepo.SoftDeleteByProjectTx(ctx, q, id); err != nil {
+			return err
+		}
+		return s.projectRepo.SoftDeleteTx(ctx, q, id)
+	})
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return domain.ErrProjectNotFound
 		}
-		return err
+		return domain.WrapError(domain.CodeInternal, "failed to delete project", err)
+	}
+	return nil
+}
+
+// GetSettings retrieves a project's settings. Anyone with access to the project can read them.
+func (s *ProjectService) GetSettings(ctx context.Context, id uuid.UUID, requesterID uuid.UUID) (*domain.ProjectSettings, error) {
+	project, _, err := s.GetForUser(ctx, id, requesterID)
+	if err != nil {
+		return nil, err
+	}
+	return &project.Settings, nil
+}
+
+// UpdateSettings changes a project's settings. Requires the project.manage permission.
+func (s *ProjectService) UpdateSettings(ctx context.Context, id uuid.UUID, input domain.UpdateProjectSettingsInput, requesterID uuid.UUID) (*domain.ProjectSettings, error) {
+	project, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, id, requesterID, domain.PermissionProjectManage); err != nil {
+		return nil, err
 	}
 
-	// Check ownership
-	if project.OwnerID != requesterID {
-		return domain.ErrNotProjectOwner
+	settings := input.Apply(project.Settings)
+	if err := settings.Validate(); err != nil {
+		return nil, err
 	}
 
-	return s.projectRepo.SoftDelete(ctx, id)
+	updated, err := s.projectRepo.UpdateSettings(ctx, id, settings)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to update project settings", err)
+	}
+	return &updated.Settings, nil
 }
 
 // TransferOwnership transfers project ownership to another user.
 // This is a transactional operation as it may involve multiple updates.
+// The project stays in its organization.
 func (s *ProjectService) TransferOwnership(ctx context.Context, projectID, newOwnerID, requesterID uuid.UUID) (*domain.Project, error) {
+	if _, err := s.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if err := s.requireOwner(ctx, projectID, requesterID); err != nil {
+		return nil, err
+	}
+
 	var result *domain.Project
 
 	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
-		// Get project to verify current ownership
-		project, err := s.projectRepo.GetByIDTx(ctx, q, projectID)
+		// Re-check the project inside the transaction
+		_, err := s.projectRepo.GetByIDTx(ctx, q, projectID)
 		if err != nil {
 			if errors.Is(err, domain.ErrNotFound) {
 				return domain.ErrProjectNotFound
@@ -130,11 +227,6 @@ func (s *ProjectService) TransferOwnership(ctx context.Context, projectID, newOw
 			return err
 		}
 
-		// Only current owner can transfer
-		if project.OwnerID != requesterID {
-			return domain.ErrNotProjectOwner
-		}
-
 		// Verify new owner exists
 		_, err = s.userRepo.GetByIDTx(ctx, q, newOwnerID)
 		if err != nil {
@@ -162,3 +254,15 @@ func (s *ProjectService) TransferOwnership(ctx context.Context, projectID, newOw
 
 	return result, nil
 }
+
+// requireOwner checks that the user owns the project, directly or through its organization.
+func (s *ProjectService) requireOwner(ctx context.Context, projectID, userID uuid.UUID) error {
+	access, err := authorizeProject(ctx, s.projectRepo, projectID, userID, "")
+	if err != nil {
+		return err
+	}
+	if !access.IsOwner() {
+		return domain.ErrInsufficientRole
+	}
+	return nil
+}
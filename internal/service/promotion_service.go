@@ -0,0 +1,512 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PromotionService handles release promotions through the project's pipeline.
+type PromotionService struct {
+	// Repositories
+	releaseRepo   repository.ReleaseRepository
+	appRepo       repository.ApplicationRepository
+	projectRepo   repository.ProjectRepository
+	artifactRepo  repository.ArtifactRepository
+	promotionRepo repository.PromotionRequestRepository
+	channelRepo   repository.ChannelRepository
+	noteRepo      repository.ReleaseNoteRepository
+
+	// Transaction Manager
+	txManager *db.TxManager
+}
+
+// NewPromotionService creates a new PromotionService.
+func NewPromotionService(
+	// Repositories
+	releaseRepo repository.ReleaseRepository,
+	appRepo repository.ApplicationRepository,
+	projectRepo repository.ProjectRepository,
+	artifactRepo repository.ArtifactRepository,
+	promotionRepo repository.PromotionRequestRepository,
+	channelRepo repository.ChannelRepository,
+	noteRepo repository.ReleaseNoteRepository,
+
+	// Transaction Manager
+	txManager *db.TxManager,
+) *PromotionService {
+	return &PromotionService{
+		releaseRepo:   releaseRepo,
+		appRepo:       appRepo,
+		projectRepo:   projectRepo,
+		artifactRepo:  artifactRepo,
+		promotionRepo: promotionRepo,
+		channelRepo:   channelRepo,
+		noteRepo:      noteRepo,
+		txManager:     txManager,
+	}
+}
+
+// Promote promotes a release to the next stage of the project's pipeline.
+// Ungated stages get a copy of the release, artifacts and translated notes
+// included, right away;
+// the release stays in its own environment, so a build can live in several
+// environments at once. Stages requiring approvals get a pending request instead.
+// The copy is offered to the given percentage of clients, 0 meaning all of them.
+func (s *PromotionService) Promote(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID, env domain.ReleaseEnvironment, note string, rollout int32) (*domain.PromotionResult, error) {
+	if rollout == 0 {
+		rollout = domain.FullRollout
+	}
+	if err := domain.CheckRolloutPercentage(rollout); err != nil {
+		return nil, err
+	}
+
+	release, err := s.releaseRepo.GetByID(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	if release.Status != domain.ReleasePublished {
+		return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, fmt.Sprintf("only published releases can be promoted, this one is %s", release.Status))
+	}
+	if env == release.Environment {
+		return nil, domain.NewValidationError("environment", "release is already in this environment")
+	}
+	if _, err := resolveChannel(ctx, s.channelRepo, app.ID, env); err != nil {
+		return nil, err
+	}
+	stage, err := project.Settings.Pipeline.StageFor(release.Environment, env)
+	if err != nil {
+		return nil, err
+	}
+	if err := stage.CheckSoak(release.Environment, *release.PublishedAt, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkTargetFree(ctx, release, env); err != nil {
+		return nil, err
+	}
+	if err := checkVersionCode(ctx, s.releaseRepo, project.Settings.VersionCodePolicy, app.ID, env, release.VersionCode); err != nil {
+		return nil, err
+	}
+
+	if stage.RequiredApprovals == 0 {
+		var promoted *domain.ApplicationRelease
+		err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+			promoted, err = s.promoteTx(ctx, q, release, env, userID, note, rollout)
+			return err
+		})
+		if err != nil {
+			return nil, s.promotionError(release, env, err)
+		}
+		return &domain.PromotionResult{Release: promoted}, nil
+	}
+
+	var request *domain.PromotionRequest
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		request, err = s.promotionRepo.CreateTx(ctx, q, domain.CreatePromotionRequestInput{
+			ApplicationID:     release.ApplicationID,
+			ReleaseID:         release.ID,
+			FromEnvironment:   release.Environment,
+			ToEnvironment:     env,
+			RequestedBy:       userID,
+			Note:              note,
+			RequiredApprovals: stage.RequiredApprovals,
+			ApproverRoles:     stage.ApproverRoles,
+			RolloutPercentage: rollout,
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, domain.NewAppError(domain.CodeAlreadyExists, fmt.Sprintf("a promotion of this release to %s is already pending", env))
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to request promotion", err)
+	}
+
+	slog.InfoContext(ctx, "promotion requested",
+		slog.String("request_id", request.ID.String()),
+		slog.String("release_id", release.ID.String()),
+		slog.String("to", string(env)),
+		slog.String("user_id", userID.String()),
+	)
+	return &domain.PromotionResult{Request: request}, nil
+}
+
+// ========== Promotion Requests ==========
+
+// GetRequest retrieves a promotion request with its reviews. Anyone with access
+// to the project can read it.
+func (s *PromotionService) GetRequest(ctx context.Context, userID, requestID uuid.UUID) (*domain.PromotionRequest, error) {
+	request, _, err := s.getRequest(ctx, userID, requestID)
+	return request, err
+}
+
+// ListRequests retrieves the most recent promotion requests of an application.
+func (s *PromotionService) ListRequests(ctx context.Context, userID, appID uuid.UUID, status domain.PromotionRequestStatus, limit int32) ([]*domain.PromotionRequest, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, ""); err != nil {
+		return nil, err
+	}
+	return s.promotionRepo.ListByApplication(ctx, appID, status, limit)
+}
+
+// Approve records an approval. The release is promoted once the request holds
+// the approvals its stage requires.
+func (s *PromotionService) Approve(ctx context.Context, userID, requestID uuid.UUID, comment string) (*domain.PromotionRequest, error) {
+	return s.review(ctx, userID, requestID, true, comment)
+}
+
+// Reject records a rejection, which closes the request.
+func (s *PromotionService) Reject(ctx context.Context, userID, requestID uuid.UUID, comment string) (*domain.PromotionRequest, error) {
+	return s.review(ctx, userID, requestID, false, comment)
+}
+
+// Cancel withdraws a pending request. Only its requester or someone who can
+// manage the project may cancel it.
+func (s *PromotionService) Cancel(ctx context.Context, userID, requestID uuid.UUID) (*domain.PromotionRequest, error) {
+	request, access, err := s.getRequest(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	requester := request.RequestedBy != nil && *request.RequestedBy == userID
+	if !requester && !access.Can(domain.PermissionProjectManage) {
+		return nil, domain.ErrInsufficientRole
+	}
+
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		return s.promotionRepo.ResolveTx(ctx, q, requestID, domain.PromotionCancelled, nil)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrPromotionNotPending
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to cancel promotion request", err)
+	}
+	return s.promotionRepo.GetByID(ctx, requestID)
+}
+
+func (s *PromotionService) review(ctx context.Context, userID, requestID uuid.UUID, approved bool, comment string) (*domain.PromotionRequest, error) {
+	request, access, err := s.getRequest(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.PromotionPending {
+		return nil, domain.ErrPromotionNotPending
+	}
+	if request.RequestedBy != nil && *request.RequestedBy == userID {
+		return nil, domain.ErrSelfReview
+	}
+	if !request.CanReview(access.Role) {
+		return nil, domain.ErrInsufficientRole
+	}
+	app, err := s.appRepo.GetByID(ctx, request.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var promotedID *uuid.UUID
+	var failure error
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		// Lock the request so concurrent reviews count approvals one at a time
+		locked, err := s.promotionRepo.GetByIDForUpdateTx(ctx, q, requestID)
+		if err != nil {
+			return err
+		}
+		if locked.Status != domain.PromotionPending {
+			return domain.ErrPromotionNotPending
+		}
+
+		if _, err := s.promotionRepo.CreateReviewTx(ctx, q, requestID, userID, approved, comment); err != nil {
+			if errors.Is(err, domain.ErrAlreadyExists) {
+				return domain.ErrPromotionReviewed
+			}
+			return err
+		}
+
+		if !approved {
+			return s.promotionRepo.ResolveTx(ctx, q, requestID, domain.PromotionRejected, nil)
+		}
+		if locked.Approvals()+1 < locked.RequiredApprovals {
+			return nil
+		}
+
+		actorID := userID
+		if locked.RequestedBy != nil {
+			actorID = *locked.RequestedBy
+		}
+		promoted, err := s.promoteRequestTx(ctx, q, locked, project.Settings.VersionCodePolicy, actorID)
+		if err != nil {
+			if domain.IsPromotionFailure(err) {
+				// Recorded once this transaction is rolled back
+				failure = err
+			}
+			return err
+		}
+		promotedID = &promoted.ID
+		return s.promotionRepo.ResolveTx(ctx, q, requestID, domain.PromotionApproved, promotedID)
+	})
+	if failure != nil && errors.Is(err, failure) {
+		return s.failRequest(ctx, userID, requestID, comment, failure)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPromotionNotPending), errors.Is(err, domain.ErrPromotionReviewed),
+			errors.Is(err, domain.ErrReleaseNotFound), errors.Is(err, domain.ErrReleaseExists), errors.Is(err, domain.ErrReleaseWithdrawn), errors.Is(err, domain.ErrReleaseStatus),
+			errors.Is(err, domain.ErrVersionCode):
+			return nil, err
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to review promotion request", err)
+	}
+
+	if promotedID != nil {
+		slog.InfoContext(ctx, "promotion approved",
+			slog.String("request_id", requestID.String()),
+			slog.String("promoted_release_id", promotedID.String()),
+		)
+	}
+	return s.promotionRepo.GetByID(ctx, requestID)
+}
+
+// promoteRequestTx carries out a request that received its last approval. The
+// release and the target environment may have changed since it was opened.
+func (s *PromotionService) promoteRequestTx(ctx context.Context, q *db.Queries, request *domain.PromotionRequest, policy domain.VersionCodePolicy, actorID uuid.UUID) (*domain.ApplicationRelease, error) {
+	release, err := s.releaseRepo.GetByIDTx(ctx, q, request.ReleaseID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrReleaseNotFound
+		}
+		return nil, err
+	}
+	if release.Status != domain.ReleasePublished {
+		return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, fmt.Sprintf("the release was %s since the request was opened", release.Status))
+	}
+	if err := s.checkTargetFreeTx(ctx, q, release, request.ToEnvironment); err != nil {
+		return nil, err
+	}
+	if err := checkVersionCodeTx(ctx, s.releaseRepo, q, policy, release.ApplicationID, request.ToEnvironment, release.VersionCode); err != nil {
+		return nil, err
+	}
+
+	promoted, err := s.promoteTx(ctx, q, release, request.ToEnvironment, actorID, request.Note, request.RolloutPercentage)
+	if err != nil {
+		return nil, s.promotionError(release, request.ToEnvironment, err)
+	}
+	return promoted, nil
+}
+
+// failRequest records the approval that completed a request and closes the
+// request as failed, with the reason the release could not be promoted.
+func (s *PromotionService) failRequest(ctx context.Context, userID, requestID uuid.UUID, comment string, reason error) (*domain.PromotionRequest, error) {
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		locked, err := s.promotionRepo.GetByIDForUpdateTx(ctx, q, requestID)
+		if err != nil {
+			return err
+		}
+		if locked.Status != domain.PromotionPending {
+			return domain.ErrPromotionNotPending
+		}
+		if _, err := s.promotionRepo.CreateReviewTx(ctx, q, requestID, userID, true, comment); err != nil {
+			if errors.Is(err, domain.ErrAlreadyExists) {
+				return domain.ErrPromotionReviewed
+			}
+			return err
+		}
+		return s.promotionRepo.FailTx(ctx, q, requestID, failureReason(reason))
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrPromotionNotPending) || errors.Is(err, domain.ErrPromotionReviewed) {
+			return nil, err
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to review promotion request", err)
+	}
+
+	slog.WarnContext(ctx, "promotion failed",
+		slog.String("request_id", requestID.String()),
+		slog.String("reason", failureReason(reason)),
+	)
+	return s.promotionRepo.GetByID(ctx, requestID)
+}
+
+// failureReason is the message of a promotion failure, without its error code.
+func failureReason(err error) string {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// getRequest retrieves a promotion request and the user's access to its project.
+func (s *PromotionService) getRequest(ctx context.Context, userID, requestID uuid.UUID) (*domain.PromotionRequest, *domain.ProjectAccess, error) {
+	request, err := s.promotionRepo.GetByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrPromotionRequestNotFound
+		}
+		return nil, nil, err
+	}
+	app, err := s.appRepo.GetByID(ctx, request.ApplicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrPromotionRequestNotFound
+		}
+		return nil, nil, err
+	}
+	access, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return request, access, nil
+}
+
+// ========== History ==========
+
+// ListPromotions retrieves the most recent promotions of an application's releases.
+// Anyone with access to the project can read them.
+func (s *PromotionService) ListPromotions(ctx context.Context, userID uuid.UUID, appID uuid.UUID, limit int32) ([]*domain.ReleasePromotion, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, ""); err != nil {
+		return nil, err
+	}
+	return s.releaseRepo.ListPromotionsByApplication(ctx, appID, limit)
+}
+
+// ListReleasePromotions retrieves the promotions a release was copied from or into.
+func (s *PromotionService) ListReleasePromotions(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) ([]*domain.ReleasePromotion, error) {
+	release, err := s.releaseRepo.GetByID(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, ""); err != nil {
+		return nil, err
+	}
+	return s.releaseRepo.ListPromotionsByRelease(ctx, releaseID)
+}
+
+// ========== Helpers ==========
+
+// checkTargetFree refuses promotions into an environment already holding the version.
+func (s *PromotionService) checkTargetFree(ctx context.Context, release *domain.ApplicationRelease, env domain.ReleaseEnvironment) error {
+	exists, err := s.releaseRepo.VersionExists(ctx, release.ApplicationID, release.VersionCode, env)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to check version", err)
+	}
+	if exists {
+		return versionTakenError(release, env)
+	}
+	return nil
+}
+
+// checkTargetFreeTx is checkTargetFree within a transaction.
+func (s *PromotionService) checkTargetFreeTx(ctx context.Context, q *db.Queries, release *domain.ApplicationRelease, env domain.ReleaseEnvironment) error {
+	exists, err := s.releaseRepo.VersionExistsTx(ctx, q, release.ApplicationID, release.VersionCode, env)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to check version", err)
+	}
+	if exists {
+		return versionTakenError(release, env)
+	}
+	return nil
+}
+
+// promoteTx copies a release and its artifacts into an environment, rolled out
+// to the given percentage of clients, and records the promotion.
+//
+// A copy rather than a second channel for the same row: status, rollout,
+// withdrawal, the mandatory flag and the per-channel version code rules all
+// belong to one channel, and clients, changelogs and rollbacks look releases
+// up by channel. The build itself, i.e. the title, notes and artifacts, is
+// frozen on both sides once promoted (see checkBuildEditable), so the copies
+// never drift apart and each environment serves what was reviewed.
+func (s *PromotionService) promoteTx(ctx context.Context, q *db.Queries, release *domain.ApplicationRelease, env domain.ReleaseEnvironment, actorID uuid.UUID, note string, rollout int32) (*domain.ApplicationRelease, error) {
+	promoted, err := s.releaseRepo.CreateTx(ctx, q, domain.CreateReleaseInput{
+		Title:             release.Title,
+		VersionCode:       release.VersionCode,
+		VersionName:       release.VersionName,
+		ReleaseNote:       release.ReleaseNote,
+		Environment:       env,
+		Status:            domain.ReleasePublished,
+		ApplicationID:     release.ApplicationID,
+		RolloutPercentage: rollout,
+		Mandatory:         release.Mandatory,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.artifactRepo.CopyToReleaseTx(ctx, q, release.ID, promoted.ID); err != nil {
+		return nil, err
+	}
+	if err := s.noteRepo.CopyTx(ctx, q, release.ID, promoted.ID); err != nil {
+		return nil, err
+	}
+
+	_, err = s.releaseRepo.CreatePromotionTx(ctx, q, domain.CreateReleasePromotionInput{
+		ApplicationID:   release.ApplicationID,
+		SourceReleaseID: release.ID,
+		ReleaseID:       promoted.ID,
+		VersionCode:     release.VersionCode,
+		VersionName:     release.VersionName,
+		FromEnvironment: release.Environment,
+		ToEnvironment:   env,
+		ActorID:         actorID,
+		Note:            note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "release promoted",
+		slog.String("release_id", release.ID.String()),
+		slog.String("promoted_release_id", promoted.ID.String()),
+		slog.String("from", string(release.Environment)),
+		slog.String("to", string(env)),
+		slog.String("actor_id", actorID.String()),
+	)
+	return promoted, nil
+}
+
+// promotionError translates a failed copy.
+func (s *PromotionService) promotionError(release *domain.ApplicationRelease, env domain.ReleaseEnvironment, err error) error {
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		// Promoted concurrently
+		return versionTakenError(release, env)
+	}
+	return domain.WrapError(domain.CodeInternal, "failed to promote release", err)
+}
+
+func versionTakenError(release *domain.ApplicationRelease, env domain.ReleaseEnvironment) error {
+	return domain.NewAppError(domain.CodeReleaseExists, fmt.Sprintf("version code %d is already in %s", release.VersionCode, env))
+}
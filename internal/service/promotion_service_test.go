@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// targetReleaseRepository holds one release and the state of the target environment.
+type targetReleaseRepository struct {
+	repository.ReleaseRepository
+	release       *domain.ApplicationRelease
+	versionExists bool
+	highest       int32
+}
+
+func (r *targetReleaseRepository) GetByIDTx(ctx context.Context, q *db.Queries, id uuid.UUID) (*domain.ApplicationRelease, error) {
+	if r.release == nil || r.release.ID != id {
+		return nil, domain.ErrNotFound
+	}
+	return r.release, nil
+}
+
+func (r *targetReleaseRepository) VersionExistsTx(ctx context.Context, q *db.Queries, appID uuid.UUID, versionCode int32, env domain.ReleaseEnvironment) (bool, error) {
+	return r.versionExists, nil
+}
+
+func (r *targetReleaseRepository) HighestVersionCodeTx(ctx context.Context, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) (int32, error) {
+	return r.highest, nil
+}
+
+func TestPromotionService_PromoteRequestTx_Failures(t *testing.T) {
+	release := &domain.ApplicationRelease{
+		ID:            uuid.New(),
+		ApplicationID: uuid.New(),
+		VersionCode:   42,
+		Environment:   domain.EnvironmentStaging,
+		Status:        domain.ReleasePublished,
+	}
+	withStatus := func(status domain.ReleaseStatus) *domain.ApplicationRelease {
+		r := *release
+		r.Status = status
+		return &r
+	}
+
+	tests := []struct {
+		name   string
+		repo   *targetReleaseRepository
+		want   error
+		reason string
+	}{
+		{
+			name:   "target moved on: version already promoted",
+			repo:   &targetReleaseRepository{release: release, versionExists: true, highest: 42},
+			want:   domain.ErrReleaseExists,
+			reason: "version code 42 is already in production",
+		},
+		{
+			name:   "target moved on: higher version shipped",
+			repo:   &targetReleaseRepository{release: release, highest: 43},
+			want:   domain.ErrVersionCode,
+			reason: "version code 42 must be greater than 43, the highest used in production",
+		},
+		{
+			name:   "release withdrawn",
+			repo:   &targetReleaseRepository{release: withStatus(domain.ReleaseWithdrawn)},
+			want:   domain.ErrReleaseStatus,
+			reason: "the release was withdrawn since the request was opened",
+		},
+		{
+			name:   "release deleted",
+			repo:   &targetReleaseRepository{},
+			want:   domain.ErrReleaseNotFound,
+			reason: "release not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &PromotionService{releaseRepo: tt.repo}
+			request := &domain.PromotionRequest{
+				ID:              uuid.New(),
+				ApplicationID:   release.ApplicationID,
+				ReleaseID:       release.ID,
+				FromEnvironment: domain.EnvironmentStaging,
+				ToEnvironment:   domain.EnvironmentProduction,
+				Status:          domain.PromotionPending,
+			}
+
+			promoted, err := s.promoteRequestTx(context.Background(), nil, request, domain.VersionCodeIncreasing, uuid.New())
+			require.Error(t, err)
+			assert.Nil(t, promoted)
+			assert.ErrorIs(t, err, tt.want)
+			assert.True(t, domain.IsPromotionFailure(err), "resolves the request as failed")
+			assert.Equal(t, tt.reason, failureReason(err))
+		})
+	}
+}
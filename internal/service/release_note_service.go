@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ReleaseNoteService handles translated release notes and changelogs.
+type ReleaseNoteService struct {
+	releaseNoteRepo repository.ReleaseNoteRepository
+	releaseRepo     repository.ReleaseRepository
+	appRepo         repository.ApplicationRepository
+	projectRepo     repository.ProjectRepository
+	channelRepo     repository.ChannelRepository
+}
+
+// NewReleaseNoteService creates a new ReleaseNoteService.
+func NewReleaseNoteService(
+	releaseNoteRepo repository.ReleaseNoteRepository,
+	releaseRepo repository.ReleaseRepository,
+	appRepo repository.ApplicationRepository,
+	projectRepo repository.ProjectRepository,
+	channelRepo repository.ChannelRepository,
+) *ReleaseNoteService {
+	return &ReleaseNoteService{
+		releaseNoteRepo: releaseNoteRepo,
+		releaseRepo:     releaseRepo,
+		appRepo:         appRepo,
+		projectRepo:     projectRepo,
+		channelRepo:     channelRepo,
+	}
+}
+
+// List retrieves the translated notes of a release.
+func (s *ReleaseNoteService) List(ctx context.Context, userID, releaseID uuid.UUID) ([]*domain.ReleaseNote, error) {
+	if _, _, err := authorizeRelease(ctx, s.releaseRepo, s.appRepo, s.projectRepo, userID, releaseID, ""); err != nil {
+		return nil, err
+	}
+	return s.releaseNoteRepo.ListByRelease(ctx, releaseID)
+}
+
+// Set creates or replaces the note of a release in a locale. Translations
+// follow the same project rules as the release's default note.
+func (s *ReleaseNoteService) Set(ctx context.Context, userID, releaseID uuid.UUID, locale, note string) (*domain.ReleaseNote, error) {
+	release, _, err := authorizeRelease(ctx, s.releaseRepo, s.appRepo, s.projectRepo, userID, releaseID, domain.PermissionPackageUpload)
+	if err != nil {
+		return nil, err
+	}
+	locale, err = domain.NormalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateReleaseNoteText(note); err != nil {
+		return nil, err
+	}
+
+	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Settings.CheckReleaseNote(note); err != nil {
+		return nil, err
+	}
+	if err := checkBuildEditable(ctx, s.releaseRepo, releaseID); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.releaseNoteRepo.Upsert(ctx, releaseID, locale, note)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to save release note", err)
+	}
+
+	slog.InfoContext(ctx, "release note saved",
+		slog.String("release_id", releaseID.String()),
+		slog.String("locale", locale),
+		slog.String("user_id", userID.String()),
+	)
+	return saved, nil
+}
+
+// Delete removes the note of a release in a locale. Readers of that locale get
+// the next matching translation, or the default note.
+func (s *ReleaseNoteService) Delete(ctx context.Context, userID, releaseID uuid.UUID, locale string) error {
+	if _, _, err := authorizeRelease(ctx, s.releaseRepo, s.appRepo, s.projectRepo, userID, releaseID, domain.PermissionPackageUpload); err != nil {
+		return err
+	}
+	locale, err := domain.NormalizeLocale(locale)
+	if err != nil {
+		return err
+	}
+	if err := checkBuildEditable(ctx, s.releaseRepo, releaseID); err != nil {
+		return err
+	}
+
+	if err := s.releaseNoteRepo.Delete(ctx, releaseID, locale); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NewAppError(domain.CodeNotFound, fmt.Sprintf("the release has no %s note", locale))
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to delete release note", err)
+	}
+
+	slog.InfoContext(ctx, "release note deleted",
+		slog.String("release_id", releaseID.String()),
+		slog.String("locale", locale),
+		slog.String("user_id", userID.String()),
+	)
+	return nil
+}
+
+// Changelog gathers the notes of the releases of a channel within a range of
+// version codes, e.g. for a tester who skipped several builds. Notes are in
+// the given locale when translated, an empty locale meaning default notes.
+func (s *ReleaseNoteService) Changelog(ctx context.Context, userID, appID uuid.UUID, env domain.ReleaseEnvironment, versions domain.ChangelogRange, locale string) (*domain.Changelog, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageDownload); err != nil {
+		return nil, err
+	}
+	if err := versions.Validate(); err != nil {
+		return nil, err
+	}
+	if locale, err = normalizeReaderLocale(locale); err != nil {
+		return nil, err
+	}
+	env, err = resolveChannel(ctx, s.channelRepo, appID, env)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := s.releaseRepo.ListChangelog(ctx, appID, env, versions, domain.MaxChangelogReleases+1)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list releases", err)
+	}
+	changelog := &domain.Changelog{Environment: env, Range: versions}
+	if len(releases) > domain.MaxChangelogReleases {
+		releases = releases[:domain.MaxChangelogReleases]
+		changelog.Truncated = true
+	}
+
+	notes, err := s.notesFor(ctx, releases, locale)
+	if err != nil {
+		return nil, err
+	}
+	changelog.Entries = make([]domain.ChangelogEntry, len(releases))
+	for i, r := range releases {
+		changelog.Entries[i] = domain.ChangelogEntry{Release: r, Note: domain.LocalizeNote(r, notes, locale)}
+	}
+	return changelog, nil
+}
+
+// Localize picks the note of a release for a reader of the given locale.
+func (s *ReleaseNoteService) Localize(ctx context.Context, release *domain.ApplicationRelease, locale string) (domain.LocalizedNote, error) {
+	locale, err := normalizeReaderLocale(locale)
+	if err != nil {
+		return domain.LocalizedNote{}, err
+	}
+	notes, err := s.notesFor(ctx, []*domain.ApplicationRelease{release}, locale)
+	if err != nil {
+		return domain.LocalizedNote{}, err
+	}
+	return domain.LocalizeNote(release, notes, locale), nil
+}
+
+// notesFor retrieves the translations of releases that suit a reader of the
+// given normalized locale, none when the locale is empty.
+func (s *ReleaseNoteService) notesFor(ctx context.Context, releases []*domain.ApplicationRelease, locale string) ([]*domain.ReleaseNote, error) {
+	if locale == "" {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(releases))
+	for i, r := range releases {
+		ids[i] = r.ID
+	}
+	notes, err := s.releaseNoteRepo.ListForReleases(ctx, ids, domain.LocaleFallbacks(locale))
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get release notes", err)
+	}
+	return notes, nil
+}
+
+// normalizeReaderLocale normalizes the locale a reader asked notes in, which
+// may be empty for default notes.
+func normalizeReaderLocale(locale string) (string, error) {
+	if locale == "" {
+		return "", nil
+	}
+	return domain.NormalizeLocale(locale)
+}
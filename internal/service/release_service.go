@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/bsrodrigue/appshare-backend/internal/db"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
@@ -21,6 +23,7 @@ type ReleaseService struct {
 	appRepo      repository.ApplicationRepository
 	projectRepo  repository.ProjectRepository
 	artifactRepo repository.ArtifactRepository
+	channelRepo  repository.ChannelRepository
 
 	// Storage
 	storage storage.Storage
@@ -39,6 +42,7 @@ func NewReleaseService(
 	appRepo repository.ApplicationRepository,
 	projectRepo repository.ProjectRepository,
 	artifactRepo repository.ArtifactRepository,
+	channelRepo repository.ChannelRepository,
 
 	// Storage
 	storage storage.Storage,
@@ -55,6 +59,7 @@ func NewReleaseService(
 		appRepo:      appRepo,
 		projectRepo:  projectRepo,
 		artifactRepo: artifactRepo,
+		channelRepo:  channelRepo,
 
 		// Storage
 		storage: storage,
@@ -66,7 +71,7 @@ func NewReleaseService(
 
 // Create creates a new release for an application.
 func (s *ReleaseService) Create(ctx context.Context, userID uuid.UUID, input domain.CreateReleaseInput) (*domain.ApplicationRelease, error) {
-	// Verify application exists and user owns the project
+	// Verify application exists and user may upload to the project
 	app, err := s.appRepo.GetByID(ctx, input.ApplicationID)
 	if err != nil {
 		return nil, err
@@ -77,17 +82,41 @@ func (s *ReleaseService) Create(ctx context.Context, userID uuid.UUID, input dom
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
+	if _, err := authorizeProject(ctx, s.projectRepo, project.ID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	input.Environment, err = resolveChannel(ctx, s.channelRepo, app.ID, input.Environment)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Settings.Pipeline.CheckUpload(input.Environment); err != nil {
+		return nil, err
+	}
+	if err := project.Settings.CheckReleaseNote(input.ReleaseNote); err != nil {
+		return nil, err
+	}
+	if err := project.Settings.CheckVersionName(input.VersionName); err != nil {
+		return nil, err
+	}
+	if err := checkVersionCode(ctx, s.releaseRepo, project.Settings.VersionCodePolicy, app.ID, input.Environment, input.VersionCode); err != nil {
+		return nil, err
+	}
+	if input.RolloutPercentage != 0 {
+		if err := domain.CheckRolloutPercentage(input.RolloutPercentage); err != nil {
+			return nil, err
+		}
 	}
 
-	// Create release (DB unique constraint will handle duplicate version_code/environment)
+	// Releases start as drafts until an artifact is verified and they are published.
+	// The DB unique constraint handles duplicate version_code/environment.
+	input.Status = domain.ReleaseDraft
 	return s.releaseRepo.Create(ctx, input)
 }
 
-// Update updates a release.
+// Update updates a release's title, release notes or mandatory flag.
 func (s *ReleaseService) Update(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID, input domain.UpdateReleaseInput) (*domain.ApplicationRelease, error) {
-	// Get release and verify ownership
+	// Get release and verify permission
 	release, err := s.releaseRepo.GetByID(ctx, releaseID)
 	if err != nil {
 		return nil, err
@@ -103,8 +132,8 @@ func (s *ReleaseService) Update(ctx context.Context, userID uuid.UUID, releaseID
 		return nil, err
 	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
+	if _, err := authorizeProject(ctx, s.projectRepo, project.ID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
 	}
 
 	// Update fields if provided
@@ -116,80 +145,389 @@ func (s *ReleaseService) Update(ctx context.Context, userID uuid.UUID, releaseID
 	releaseNote := release.ReleaseNote
 	if input.ReleaseNote != nil {
 		releaseNote = *input.ReleaseNote
+		if err := project.Settings.CheckReleaseNote(releaseNote); err != nil {
+			return nil, err
+		}
+	}
+
+	mandatory := release.Mandatory
+	if input.Mandatory != nil {
+		mandatory = *input.Mandatory
+	}
+
+	// The mandatory flag belongs to the channel, the title and note to the build
+	if title != release.Title || releaseNote != release.ReleaseNote {
+		if err := checkBuildEditable(ctx, s.releaseRepo, release.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	return s.releaseRepo.Update(ctx, releaseID, title, releaseNote)
+	return s.releaseRepo.Update(ctx, releaseID, title, releaseNote, mandatory)
 }
 
-// Promote promotes a release to another environment.
-func (s *ReleaseService) Promote(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error) {
-	// Ownership check
+// Delete deletes a release.
+func (s *ReleaseService) Delete(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) error {
+	// Permission check
 	release, err := s.releaseRepo.GetByID(ctx, releaseID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
+		return err
+	}
+
+	// Cascade to the release's artifacts, with the same deleted_at
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := s.artifactRepo.SoftDeleteByReleaseTx(ctx, q, releaseID); err != nil {
+			return err
+		}
+		if err := s.releaseRepo.SoftDeleteTx(ctx, q, releaseID); err != nil {
+			return err
+		}
+		return checkChannelServedTx(ctx, s.channelRepo, s.releaseRepo, q, release.ApplicationID, release.Environment)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReleaseStatus):
+			return err
+		case errors.Is(err, domain.ErrNotFound):
+			return domain.ErrReleaseNotFound
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to delete release", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a release by ID. Drafts are only visible to users who can
+// upload to the project.
+func (s *ReleaseService) GetByID(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) (*domain.ApplicationRelease, error) {
+	release, _, err := s.getRelease(ctx, userID, releaseID, "")
+	return release, err
+}
+
+// ListByApplication lists a page of the releases of an application. Drafts are
+// only listed for users who can upload to the project.
+func (s *ReleaseService) ListByApplication(ctx context.Context, userID uuid.UUID, appID uuid.UUID, filter domain.ReleaseFilter, page domain.PageRequest) (*domain.Page[*domain.ApplicationRelease], error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	access, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, "")
 	if err != nil {
 		return nil, err
 	}
 
-	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
+	filter.IncludeDrafts = access.Can(domain.PermissionPackageUpload)
+	return s.releaseRepo.ListByApplication(ctx, appID, filter, page)
+}
+
+// Publish makes a draft visible to testers, or reinstates a withdrawn release.
+// The release needs at least one verified artifact.
+func (s *ReleaseService) Publish(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) (*domain.ApplicationRelease, error) {
+	release, _, err := s.getRelease(ctx, userID, releaseID, domain.PermissionPackageUpload)
 	if err != nil {
 		return nil, err
 	}
+	if err := release.Status.CheckTransition(domain.ReleasePublished); err != nil {
+		return nil, err
+	}
 
-	if project.OwnerID != userID {
-		return nil, domain.ErrNotProjectOwner
+	verified, err := s.artifactRepo.HasVerified(ctx, releaseID)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to check artifacts", err)
+	}
+	if !verified {
+		return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, "a release needs at least one verified artifact to be published")
 	}
 
-	return s.releaseRepo.Promote(ctx, releaseID, env)
+	return s.changeStatus(ctx, userID, release, domain.ReleasePublished, func(q *db.Queries) (*domain.ApplicationRelease, error) {
+		return s.releaseRepo.PublishTx(ctx, q, releaseID)
+	})
 }
 
-// Delete deletes a release.
-func (s *ReleaseService) Delete(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) error {
-	// Ownership check
-	release, err := s.releaseRepo.GetByID(ctx, releaseID)
+// Withdraw pulls a published release back. The build is kept, and the reason
+// is shown to anyone who looks it up. The channel must keep a release offered
+// to every client at or above its minimum supported version code.
+func (s *ReleaseService) Withdraw(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID, reason string) (*domain.ApplicationRelease, error) {
+	release, _, err := s.getRelease(ctx, userID, releaseID, domain.PermissionPackageUpload)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := release.Status.CheckTransition(domain.ReleaseWithdrawn); err != nil {
+		return nil, err
 	}
 
-	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	return s.changeStatus(ctx, userID, release, domain.ReleaseWithdrawn, func(q *db.Queries) (*domain.ApplicationRelease, error) {
+		withdrawn, err := s.releaseRepo.WithdrawTx(ctx, q, releaseID, userID, reason)
+		if err != nil {
+			return nil, err
+		}
+		return withdrawn, checkChannelServedTx(ctx, s.channelRepo, s.releaseRepo, q, release.ApplicationID, release.Environment)
+	})
+}
+
+// Archive retires a published or withdrawn release, keeping it for the record.
+// Like Withdraw, it refuses to leave the channel below its minimum supported version.
+func (s *ReleaseService) Archive(ctx context.Context, userID uuid.UUID, releaseID uuid.UUID) (*domain.ApplicationRelease, error) {
+	release, _, err := s.getRelease(ctx, userID, releaseID, domain.PermissionPackageUpload)
 	if err != nil {
+		return nil, err
+	}
+	if err := release.Status.CheckTransition(domain.ReleaseArchived); err != nil {
+		return nil, err
+	}
+
+	return s.changeStatus(ctx, userID, release, domain.ReleaseArchived, func(q *db.Queries) (*domain.ApplicationRelease, error) {
+		archived, err := s.releaseRepo.ArchiveTx(ctx, q, releaseID)
+		if err != nil {
+			return nil, err
+		}
+		return archived, checkChannelServedTx(ctx, s.channelRepo, s.releaseRepo, q, release.ApplicationID, release.Environment)
+	})
+}
+
+// getRelease retrieves a release and checks the user's permission in its project.
+// Drafts are hidden from users who cannot upload.
+func (s *ReleaseService) getRelease(ctx context.Context, userID, releaseID uuid.UUID, permission string) (*domain.ApplicationRelease, *domain.ProjectAccess, error) {
+	return authorizeRelease(ctx, s.releaseRepo, s.appRepo, s.projectRepo, userID, releaseID, permission)
+}
+
+// changeStatus applies a status change in a transaction; the update only
+// matches the statuses the change starts from, so a concurrent change makes it fail.
+func (s *ReleaseService) changeStatus(ctx context.Context, userID uuid.UUID, release *domain.ApplicationRelease, to domain.ReleaseStatus, update func(q *db.Queries) (*domain.ApplicationRelease, error)) (*domain.ApplicationRelease, error) {
+	var updated *domain.ApplicationRelease
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		updated, err = update(q)
 		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReleaseStatus):
+			return nil, err
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, "the release's status changed concurrently, check it again")
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to change release status", err)
 	}
 
-	project, err := s.projectRepo.GetByID(ctx, app.ProjectID)
+	slog.InfoContext(ctx, "release status changed",
+		slog.String("release_id", release.ID.String()),
+		slog.String("from", string(release.Status)),
+		slog.String("to", string(to)),
+		slog.String("user_id", userID.String()),
+	)
+	return updated, nil
+}
+
+// CheckUpdate returns the release of a channel offered to a user, bucketed by
+// their device when one is given and by their account otherwise.
+func (s *ReleaseService) CheckUpdate(ctx context.Context, userID, appID uuid.UUID, env domain.ReleaseEnvironment, deviceID string) (*domain.ApplicationRelease, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageDownload); err != nil {
+		return nil, err
+	}
+	env, err = resolveChannel(ctx, s.channelRepo, appID, env)
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := deviceID
+	if clientID == "" {
+		clientID = userID.String()
+	}
+	return s.GetLatestForClient(ctx, appID, env, clientID)
+}
+
+// GetLatestForClient returns the newest published release of a channel whose
+// rollout includes the client, so clients outside a staged rollout keep getting
+// the previous release.
+func (s *ReleaseService) GetLatestForClient(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment, clientID string) (*domain.ApplicationRelease, error) {
+	candidates, err := s.releaseRepo.ListRolloutCandidates(ctx, appID, env)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list releases", err)
+	}
+
+	release := domain.SelectRelease(candidates, domain.RolloutBucket(clientID, appID))
+	if release == nil {
+		return nil, domain.NewAppError(domain.CodeReleaseNotFound, fmt.Sprintf("%s has no release available", env))
+	}
+	return release, nil
+}
+
+// IncreaseRollout offers a published release to more clients. Clients already
+// offered it keep it; a halted rollout resumes.
+func (s *ReleaseService) IncreaseRollout(ctx context.Context, userID, releaseID uuid.UUID, percentage int32) (*domain.ApplicationRelease, error) {
+	release, _, err := s.getRelease(ctx, userID, releaseID, domain.PermissionPackageUpload)
+	if err != nil {
+		return nil, err
 	}
+	if release.Status != domain.ReleasePublished {
+		return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, fmt.Sprintf("only published releases are rolled out, this one is %s", release.Status))
+	}
+	if err := release.Rollout.CheckIncrease(percentage); err != nil {
+		return nil, err
+	}
+
+	return s.changeRollout(ctx, userID, release, "increased", func(q *db.Queries) (*domain.ApplicationRelease, error) {
+		return s.releaseRepo.IncreaseRolloutTx(ctx, q, releaseID, percentage)
+	})
+}
 
-	if project.OwnerID != userID {
-		return domain.ErrNotProjectOwner
+// HaltRollout stops offering a release under staged rollout to anyone; clients
+// get the previous release of the channel until the rollout is increased again.
+// The channel must keep a release offered to every client at or above its
+// minimum supported version code.
+func (s *ReleaseService) HaltRollout(ctx context.Context, userID, releaseID uuid.UUID) (*domain.ApplicationRelease, error) {
+	release, _, err := s.getRelease(ctx, userID, releaseID, domain.PermissionPackageUpload)
+	if err != nil {
+		return nil, err
+	}
+	if release.Status != domain.ReleasePublished {
+		return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, fmt.Sprintf("only published releases are rolled out, this one is %s", release.Status))
+	}
+	if err := release.Rollout.CheckHalt(); err != nil {
+		return nil, err
 	}
 
-	return s.releaseRepo.SoftDelete(ctx, releaseID)
+	return s.changeRollout(ctx, userID, release, "halted", func(q *db.Queries) (*domain.ApplicationRelease, error) {
+		halted, err := s.releaseRepo.HaltRolloutTx(ctx, q, releaseID)
+		if err != nil {
+			return nil, err
+		}
+		return halted, checkChannelServedTx(ctx, s.channelRepo, s.releaseRepo, q, release.ApplicationID, release.Environment)
+	})
 }
 
-// GetByID retrieves a release by ID.
-func (s *ReleaseService) GetByID(ctx context.Context, releaseID uuid.UUID) (*domain.ApplicationRelease, error) {
-	return s.releaseRepo.GetByID(ctx, releaseID)
+// changeRollout applies a rollout change in a transaction; the update only
+// matches releases the change still applies to, so a concurrent change makes it fail.
+func (s *ReleaseService) changeRollout(ctx context.Context, userID uuid.UUID, release *domain.ApplicationRelease, change string, update func(q *db.Queries) (*domain.ApplicationRelease, error)) (*domain.ApplicationRelease, error) {
+	var updated *domain.ApplicationRelease
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		updated, err = update(q)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReleaseStatus):
+			return nil, err
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, domain.NewAppError(domain.CodeInvalidReleaseStatus, "the release's rollout changed concurrently, check it again")
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to change rollout", err)
+	}
+
+	slog.InfoContext(ctx, "release rollout "+change,
+		slog.String("release_id", release.ID.String()),
+		slog.Int("from", int(release.Rollout.Percentage)),
+		slog.Int("to", int(updated.Rollout.Percentage)),
+		slog.String("user_id", userID.String()),
+	)
+	return updated, nil
 }
 
-// ListByApplication lists all releases for an application.
-func (s *ReleaseService) ListByApplication(ctx context.Context, appID uuid.UUID) ([]*domain.ApplicationRelease, error) {
-	return s.releaseRepo.ListByApplication(ctx, appID)
+// NextVersion computes the version code the next build of an environment
+// should use, defaulting to the application's default channel.
+func (s *ReleaseService) NextVersion(ctx context.Context, userID, appID uuid.UUID, env domain.ReleaseEnvironment) (*domain.NextVersion, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, ""); err != nil {
+		return nil, err
+	}
+	env, err = resolveChannel(ctx, s.channelRepo, appID, env)
+	if err != nil {
+		return nil, err
+	}
+
+	highest, err := s.releaseRepo.HighestVersionCode(ctx, appID, env)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get highest version code", err)
+	}
+	return domain.NewNextVersion(env, highest), nil
 }
 
-// GetLatestByEnvironment gets the latest release.
-func (s *ReleaseService) GetLatestByEnvironment(ctx context.Context, appID uuid.UUID, env domain.ReleaseEnvironment) (*domain.ApplicationRelease, error) {
-	return s.releaseRepo.GetLatestByEnvironment(ctx, appID, env)
+// Rollback withdraws the latest release of a channel so that the previous one
+// becomes its latest again. The withdrawn build is kept, with the reason. The
+// channel must keep a release offered to every client at or above its minimum
+// supported version code.
+func (s *ReleaseService) Rollback(ctx context.Context, userID, appID uuid.UUID, env domain.ReleaseEnvironment, reason string) (*domain.RollbackResult, error) {
+	app, err := s.appRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+	if _, err := resolveChannel(ctx, s.channelRepo, appID, env); err != nil {
+		return nil, err
+	}
+
+	latest, err := s.releaseRepo.GetLatestByEnvironment(ctx, appID, env)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewAppError(domain.CodeReleaseNotFound, fmt.Sprintf("%s has no release to roll back", env))
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to get latest release", err)
+	}
+
+	result := &domain.RollbackResult{}
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		// Fails when a concurrent rollback withdrew the release first
+		result.Withdrawn, err = s.releaseRepo.WithdrawTx(ctx, q, latest.ID, userID, reason)
+		if err != nil {
+			return err
+		}
+		result.Current, err = s.releaseRepo.GetLatestByEnvironmentTx(ctx, q, appID, env)
+		if err != nil {
+			return err
+		}
+		return checkChannelServedTx(ctx, s.channelRepo, s.releaseRepo, q, appID, env)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReleaseStatus):
+			return nil, err
+		case errors.Is(err, domain.ErrNotFound) && result.Withdrawn == nil:
+			return nil, domain.NewAppError(domain.CodeReleaseWithdrawn, "the release was just rolled back by someone else")
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, domain.NewValidationError("environment", fmt.Sprintf("%s has no earlier release to roll back to", env))
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to roll back", err)
+	}
+
+	slog.InfoContext(ctx, "channel rolled back",
+		slog.String("application_id", appID.String()),
+		slog.String("channel", string(env)),
+		slog.String("withdrawn_release_id", result.Withdrawn.ID.String()),
+		slog.String("current_release_id", result.Current.ID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return result, nil
 }
 
 // CreateReleaseWithArtifactURL handles the complex flow of downloading an artifact,
 // verifying it's an APK, extracting version info, and creating both release and artifact records.
-func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userID uuid.UUID, appID uuid.UUID, artifactURL string, releaseNote string, environment domain.ReleaseEnvironment) (*domain.ApplicationRelease, error) {
-	// 1. Verify ownership early
+// The release is offered to the given percentage of clients, 0 meaning all of them.
+func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userID uuid.UUID, appID uuid.UUID, artifactURL string, releaseNote string, environment domain.ReleaseEnvironment, rollout int32, mandatory bool) (*domain.ApplicationRelease, error) {
+	if rollout != 0 {
+		if err := domain.CheckRolloutPercentage(rollout); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. Verify permission early
 	app, err := s.appRepo.GetByID(ctx, appID)
 	if err != nil {
 		return nil, err
@@ -198,8 +536,19 @@ func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userI
 	if err != nil {
 		return nil, err
 	}
-	if project.OwnerID != userID {
-		return nil, domain.WrapError(domain.CodeNotProjectOwner, fmt.Sprintf("access denied: user %s is not the owner of project %s", userID, project.ID), domain.ErrNotProjectOwner)
+	if _, err := authorizeProject(ctx, s.projectRepo, project.ID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	environment, err = resolveChannel(ctx, s.channelRepo, app.ID, environment)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.Settings.Pipeline.CheckUpload(environment); err != nil {
+		return nil, err
+	}
+	if err := project.Settings.CheckReleaseNote(releaseNote); err != nil {
+		return nil, err
 	}
 
 	// 2. Download the file to a temporary location
@@ -211,6 +560,10 @@ func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userI
 		return nil, err
 	}
 
+	if err := project.Settings.CheckArtifact(domain.APKContentType, metadata.FileSize); err != nil {
+		return nil, err
+	}
+
 	// Verify package name matches
 	if app.PackageName != metadata.PackageName {
 		return nil, domain.NewValidationError(
@@ -223,6 +576,13 @@ func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userI
 		)
 	}
 
+	if err := project.Settings.CheckVersionName(metadata.VersionName); err != nil {
+		return nil, err
+	}
+	if err := checkVersionCode(ctx, s.releaseRepo, project.Settings.VersionCodePolicy, appID, environment, int32(metadata.VersionCode)); err != nil {
+		return nil, err
+	}
+
 	// Check if version already exists for this environment
 	exists, err := s.releaseRepo.VersionExists(ctx, appID, int32(metadata.VersionCode), environment)
 	if err != nil {
@@ -237,12 +597,15 @@ func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userI
 	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
 		// Create Release
 		release, err = s.releaseRepo.CreateTx(ctx, q, domain.CreateReleaseInput{
-			ApplicationID: appID,
-			Title:         fmt.Sprintf("Release %s (%d)", metadata.VersionName, metadata.VersionCode),
-			VersionCode:   int32(metadata.VersionCode),
-			VersionName:   metadata.VersionName,
-			ReleaseNote:   releaseNote,
-			Environment:   environment,
+			ApplicationID:     appID,
+			Title:             fmt.Sprintf("Release %s (%d)", metadata.VersionName, metadata.VersionCode),
+			VersionCode:       int32(metadata.VersionCode),
+			VersionName:       metadata.VersionName,
+			ReleaseNote:       releaseNote,
+			Environment:       environment,
+			Status:            domain.ReleasePublished, // The artifact was hashed and parsed above
+			RolloutPercentage: rollout,
+			Mandatory:         mandatory,
 		})
 		if err != nil {
 			return err
@@ -254,7 +617,8 @@ func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userI
 			FileURL:   artifactURL,
 			SHA256:    metadata.SHA256,
 			FileSize:  metadata.FileSize,
-			FileType:  "application/vnd.android.package-archive",
+			FileType:  domain.APKContentType,
+			Verified:  true,
 			// ABI: could extract from APK entries (lib/arm64-v8a etc.) but let's keep it simple
 		})
 		if err != nil {
@@ -270,3 +634,78 @@ func (s *ReleaseService) CreateReleaseWithArtifactURL(ctx context.Context, userI
 
 	return release, nil
 }
+
+// ========== Helpers ==========
+
+// checkVersionCode enforces the project's version code policy on a release
+// entering an environment.
+func checkVersionCode(ctx context.Context, releaseRepo repository.ReleaseRepository, policy domain.VersionCodePolicy, appID uuid.UUID, env domain.ReleaseEnvironment, code int32) error {
+	highest, err := releaseRepo.HighestVersionCode(ctx, appID, env)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to get highest version code", err)
+	}
+	return policy.CheckVersionCode(code, highest, env)
+}
+
+// checkChannelServedTx refuses a change to the releases of a channel that
+// leaves no release offered to every client at or above the channel's minimum
+// supported version code. It locks the channel, so that the check and changes
+// to the minimum are made one at a time.
+func checkChannelServedTx(ctx context.Context, channelRepo repository.ChannelRepository, releaseRepo repository.ReleaseRepository, q *db.Queries, appID uuid.UUID, env domain.ReleaseEnvironment) error {
+	channel, err := channelRepo.LockTx(ctx, q, appID, env)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to get channel", err)
+	}
+	if channel.MinSupportedVersionCode == 0 {
+		return nil
+	}
+	fullyRolledOut, err := releaseRepo.HighestFullyRolledOutVersionCodeTx(ctx, q, appID, env)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to get the channel's latest release", err)
+	}
+	return channel.CheckStillServed(fullyRolledOut)
+}
+
+// checkVersionCodeTx is checkVersionCode within a transaction.
+func checkVersionCodeTx(ctx context.Context, releaseRepo repository.ReleaseRepository, q *db.Queries, policy domain.VersionCodePolicy, appID uuid.UUID, env domain.ReleaseEnvironment, code int32) error {
+	highest, err := releaseRepo.HighestVersionCodeTx(ctx, q, appID, env)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to get highest version code", err)
+	}
+	return policy.CheckVersionCode(code, highest, env)
+}
+
+// checkBuildEditable refuses changes to the title, notes or artifacts of a
+// release that was promoted from or into another: each environment keeps the
+// exact build that was reviewed and promoted.
+func checkBuildEditable(ctx context.Context, releaseRepo repository.ReleaseRepository, releaseID uuid.UUID) error {
+	promoted, err := releaseRepo.IsPromoted(ctx, releaseID)
+	if err != nil {
+		return domain.WrapError(domain.CodeInternal, "failed to check release promotions", err)
+	}
+	if promoted {
+		return domain.ErrReleasePromoted
+	}
+	return nil
+}
+
+// authorizeRelease retrieves a release and checks the user's permission in its
+// project. Drafts are hidden from users who cannot upload.
+func authorizeRelease(ctx context.Context, releaseRepo repository.ReleaseRepository, appRepo repository.ApplicationRepository, projectRepo repository.ProjectRepository, userID, releaseID uuid.UUID, permission string) (*domain.ApplicationRelease, *domain.ProjectAccess, error) {
+	release, err := releaseRepo.GetByID(ctx, releaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	app, err := appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	access, err := authorizeProject(ctx, projectRepo, app.ProjectID, userID, permission)
+	if err != nil {
+		return nil, nil, err
+	}
+	if release.Status == domain.ReleaseDraft && !access.Can(domain.PermissionPackageUpload) {
+		return nil, nil, domain.ErrReleaseNotFound
+	}
+	return release, access, nil
+}
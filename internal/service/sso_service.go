@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/oidc"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// oauthStateTTL bounds how long a user may take to sign in at the provider.
+const oauthStateTTL = 10 * time.Minute
+
+// SSOService handles single sign-on through OpenID Connect providers.
+type SSOService struct {
+	providers    map[string]*oidc.Provider
+	stateRepo    repository.OAuthStateRepository
+	identityRepo repository.IdentityRepository
+	userRepo     repository.UserRepository
+	authService  *AuthService
+	txManager    *db.TxManager
+}
+
+// NewSSOService creates a new SSOService.
+func NewSSOService(
+	providers []*oidc.Provider,
+	stateRepo repository.OAuthStateRepository,
+	identityRepo repository.IdentityRepository,
+	userRepo repository.UserRepository,
+	authService *AuthService,
+	txManager *db.TxManager,
+) *SSOService {
+	byName := make(map[string]*oidc.Provider, len(providers))
+	for _, p := range providers {
+		byName[p.Config().Name] = p
+	}
+	return &SSOService{
+		providers:    byName,
+		stateRepo:    stateRepo,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		authService:  authService,
+		txManager:    txManager,
+	}
+}
+
+// SSOProvider describes a configured identity provider for login screens.
+type SSOProvider struct {
+	Name        string
+	DisplayName string
+}
+
+// ListProviders returns the configured identity providers.
+func (s *SSOService) ListProviders() []SSOProvider {
+	result := make([]SSOProvider, 0, len(s.providers))
+	for _, p := range s.providers {
+		cfg := p.Config()
+		result = append(result, SSOProvider{Name: cfg.Name, DisplayName: cfg.DisplayName})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// SSOAuthorization is where to send the user to sign in at the provider.
+type SSOAuthorization struct {
+	AuthorizationURL string
+	State            string
+	ExpiresAt        time.Time
+}
+
+// Authorize starts an authorization code flow with PKCE.
+func (s *SSOService) Authorize(ctx context.Context, providerName string) (*SSOAuthorization, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := oidc.RandomString(32)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate state", err)
+	}
+	nonce, err := oidc.RandomString(32)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate nonce", err)
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate code verifier", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "identity provider is unavailable", err)
+	}
+
+	// Housekeeping of abandoned attempts (fire and forget)
+	_, _ = s.stateRepo.DeleteExpired(ctx)
+
+	expiresAt := time.Now().Add(oauthStateTTL)
+	err = s.stateRepo.Create(ctx, domain.OAuthState{
+		State:        state,
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to store sign-in state", err)
+	}
+
+	return &SSOAuthorization{
+		AuthorizationURL: authURL,
+		State:            state,
+		ExpiresAt:        expiresAt,
+	}, nil
+}
+
+// Callback completes the flow: it validates the state, exchanges the code,
+// verifies the ID token and logs in the linked (or newly provisioned) user.
+func (s *SSOService) Callback(ctx context.Context, providerName, code, state string) (*LoginResult, error) {
+	provider, claims, err := s.exchange(ctx, providerName, code, state)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.resolveUser(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.LoginWithIdentity(ctx, user)
+}
+
+// Reauthentication proves a user just signed in again at a linked identity provider.
+type Reauthentication struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Reauthenticate completes a flow started by a signed-in user to confirm a
+// sensitive operation. The identity must already be linked to that user.
+func (s *SSOService) Reauthenticate(ctx context.Context, userID uuid.UUID, providerName, code, state string) (*Reauthentication, error) {
+	_, claims, err := s.exchange(ctx, providerName, code, state)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, providerName, claims.Subject)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve identity", err)
+	}
+	if err != nil || identity.UserID != userID {
+		return nil, domain.NewAppError(domain.CodeSSOFailed, "this identity is not linked to your account")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+	token, expiresAt, err := s.authService.jwtService.GenerateReauthenticationToken(user)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate reauthentication token", err)
+	}
+
+	return &Reauthentication{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// exchange validates the state of a flow, exchanges its code and returns the
+// verified claims of the ID token.
+func (s *SSOService) exchange(ctx context.Context, providerName, code, state string) (*oidc.Provider, *oidc.Claims, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := s.stateRepo.Consume(ctx, state)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.NewAppError(domain.CodeSSOFailed, "invalid or expired sign-in state")
+		}
+		return nil, nil, domain.WrapError(domain.CodeInternal, "failed to retrieve sign-in state", err)
+	}
+	if pending.Provider != providerName || time.Now().After(pending.ExpiresAt) {
+		return nil, nil, domain.NewAppError(domain.CodeSSOFailed, "invalid or expired sign-in state")
+	}
+
+	claims, err := provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		slog.WarnContext(ctx, "single sign-on exchange failed",
+			slog.String("provider", providerName),
+			slog.String("error", err.Error()),
+		)
+		return nil, nil, domain.WrapError(domain.CodeSSOFailed, "identity provider rejected the sign-in", err)
+	}
+
+	return provider, claims, nil
+}
+
+// resolveUser finds the user behind an identity, linking or provisioning one if needed.
+func (s *SSOService) resolveUser(ctx context.Context, provider *oidc.Provider, claims *oidc.Claims) (*domain.User, error) {
+	providerName := provider.Config().Name
+
+	// 1. Already linked identity
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, providerName, claims.Subject)
+	if err == nil {
+		if err := s.identityRepo.RecordLogin(ctx, identity.ID, claims.Email); err != nil {
+			slog.WarnContext(ctx, "failed to record identity login", slog.String("error", err.Error()))
+		}
+		user, err := s.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, domain.ErrUserInactive
+			}
+			return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve identity", err)
+	}
+
+	// Linking and provisioning both rely on the email being proven by the provider
+	if claims.Email == "" || !bool(claims.EmailVerified) {
+		return nil, domain.NewAppError(domain.CodeSSOFailed, "identity provider did not return a verified email")
+	}
+
+	// 2. Existing account with the same email: link it once the account has
+	// proven the address too, or else its password was set by whoever registered
+	// the email first and must be confirmed
+	user, err := s.userRepo.GetByEmail(ctx, claims.Email)
+	if err == nil {
+		verified, err := s.userRepo.IsEmailVerified(ctx, user.ID)
+		if err != nil {
+			return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+		}
+		if !verified {
+			token, _, err := s.authService.jwtService.GenerateIdentityLinkToken(user, providerName, claims.Subject)
+			if err != nil {
+				return nil, domain.WrapError(domain.CodeInternal, "failed to generate link token", err)
+			}
+			return nil, domain.NewLinkRequiredError(token)
+		}
+
+		if err := s.link(ctx, user, providerName, claims.Subject, claims.Email); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+
+	// 3. New account, only for allowed domains
+	if !provider.AllowsDomain(claims.EmailDomain()) {
+		return nil, domain.ErrDomainNotAllowed
+	}
+	return s.provisionUser(ctx, providerName, claims)
+}
+
+// Link confirms an identity provider sign-in refused with IDENTITY_LINK_REQUIRED:
+// once the account's password, and second factor when enabled, are checked, the
+// identity is linked, the email counts as verified and the user is logged in.
+func (s *SSOService) Link(ctx context.Context, linkToken, password, code, ip string) (*LoginResult, error) {
+	claims, err := s.authService.jwtService.ValidateIdentityLinkToken(linkToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.provider(claims.Provider); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTokenInvalid
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to retrieve user", err)
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+	// The provider vouched for the email the account had when the token was issued
+	if claims.TokenVersion != user.TokenVersion || claims.Email != user.Email {
+		return nil, domain.ErrTokenInvalid
+	}
+
+	if err := s.authService.reauthenticate(ctx, user, password, code, ip); err != nil {
+		return nil, err
+	}
+	if err := s.link(ctx, user, claims.Provider, claims.ProviderSubject, claims.Email); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "identity linked after password confirmation",
+		slog.String("provider", claims.Provider),
+		slog.String("user_id", user.ID.String()),
+	)
+	return s.authService.completeLogin(ctx, user)
+}
+
+// link attaches an identity to a user whose email the provider verified,
+// which proves the user owns the address.
+func (s *SSOService) link(ctx context.Context, user *domain.User, providerName, subject, email string) error {
+	err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		_, err := s.identityRepo.CreateTx(ctx, q, domain.CreateUserIdentityInput{
+			Provider: providerName,
+			Subject:  subject,
+			Email:    email,
+			UserID:   user.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return s.userRepo.MarkEmailVerifiedTx(ctx, q, user.ID, email)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return domain.NewAppError(domain.CodeSSOFailed, "this identity is already linked to an account")
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to link identity", err)
+	}
+	return nil
+}
+
+// provisionUser creates a user and links the identity in a single transaction.
+// The provider verified the email, so the account's is verified from the start.
+// The account gets an unusable random password; one can be set via password reset.
+func (s *SSOService) provisionUser(ctx context.Context, providerName string, claims *oidc.Claims) (*domain.User, error) {
+	randomPassword, err := oidcRandomHex(32)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to generate password", err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(randomPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to hash password", err)
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName = claims.Name
+	}
+	if firstName == "" {
+		firstName = strings.Split(claims.Email, "@")[0]
+	}
+
+	var user *domain.User
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		username, err := s.availableUsername(ctx, q, claims.Email)
+		if err != nil {
+			return err
+		}
+
+		user, err = s.userRepo.CreateTx(ctx, q, domain.CreateUserInput{
+			Email:     claims.Email,
+			Username:  username,
+			FirstName: firstName,
+			LastName:  lastName,
+		}, string(hash))
+		if err != nil {
+			return err
+		}
+
+		_, err = s.identityRepo.CreateTx(ctx, q, domain.CreateUserIdentityInput{
+			Provider: providerName,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+			UserID:   user.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return s.userRepo.MarkEmailVerifiedTx(ctx, q, user.ID, claims.Email)
+	})
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to provision user", err)
+	}
+
+	slog.InfoContext(ctx, "user provisioned through single sign-on",
+		slog.String("provider", providerName),
+		slog.String("user_id", user.ID.String()),
+	)
+	return user, nil
+}
+
+// availableUsername derives a free username from the local part of an email.
+func (s *SSOService) availableUsername(ctx context.Context, q *db.Queries, email string) (string, error) {
+	base := sanitizeUsername(strings.Split(email, "@")[0])
+
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		exists, err := s.userRepo.UsernameExistsTx(ctx, q, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		suffix, err := oidcRandomHex(2)
+		if err != nil {
+			return "", err
+		}
+		candidate = base + "-" + suffix
+	}
+	return "", errors.New("could not find an available username")
+}
+
+// sanitizeUsername keeps lower-case letters, digits, dots, dashes and underscores.
+func sanitizeUsername(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	username := b.String()
+	if len(username) > 24 {
+		username = username[:24]
+	}
+	if len(username) < 3 {
+		username = "user" + username
+	}
+	return username
+}
+
+// oidcRandomHex returns n random bytes, hex-encoded.
+func oidcRandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// provider looks up a configured identity provider.
+func (s *SSOService) provider(name string) (*oidc.Provider, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, domain.NewAppError(domain.CodeNotFound, "identity provider not found")
+	}
+	return p, nil
+}
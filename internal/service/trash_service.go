@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/db"
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/bsrodrigue/appshare-backend/internal/storage"
+	"github.com/google/uuid"
+)
+
+// purgeBatchSize bounds how many rows of each kind a single purger query returns.
+const purgeBatchSize = 100
+
+// TrashConfig holds the tunables of the TrashService.
+type TrashConfig struct {
+	// Retention is how long deleted projects, applications, releases and artifacts stay restorable.
+	Retention time.Duration
+}
+
+// TrashService lists and restores soft-deleted projects and their content, and purges them
+// for good, stored files included, once the retention window has passed. It
+// also moves the releases past their project's retention policy to the trash.
+type TrashService struct {
+	// Repositories
+	projectRepo  repository.ProjectRepository
+	orgRepo      repository.OrganizationRepository
+	appRepo      repository.ApplicationRepository
+	releaseRepo  repository.ReleaseRepository
+	artifactRepo repository.ArtifactRepository
+
+	// Infrastructure
+	storage   storage.Storage
+	txManager *db.TxManager
+	config    TrashConfig
+}
+
+// NewTrashService creates a new TrashService.
+func NewTrashService(
+	// Repositories
+	projectRepo repository.ProjectRepository,
+	orgRepo repository.OrganizationRepository,
+	appRepo repository.ApplicationRepository,
+	releaseRepo repository.ReleaseRepository,
+	artifactRepo repository.ArtifactRepository,
+
+	// Infrastructure
+	storage storage.Storage,
+	txManager *db.TxManager,
+	config TrashConfig,
+) *TrashService {
+	return &TrashService{
+		projectRepo:  projectRepo,
+		orgRepo:      orgRepo,
+		appRepo:      appRepo,
+		releaseRepo:  releaseRepo,
+		artifactRepo: artifactRepo,
+		storage:      storage,
+		txManager:    txManager,
+		config:       config,
+	}
+}
+
+// List retrieves the most recently deleted applications, releases and artifacts
+// of a project, up to limit of each. Anyone with access to the project can see it.
+func (s *TrashService) List(ctx context.Context, userID, projectID uuid.UUID, limit int32) (*domain.Trash, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, projectID, userID, ""); err != nil {
+		return nil, err
+	}
+
+	apps, err := s.appRepo.ListDeletedByProject(ctx, projectID, limit)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list deleted applications", err)
+	}
+	releases, err := s.releaseRepo.ListDeletedByProject(ctx, projectID, limit)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list deleted releases", err)
+	}
+	artifacts, err := s.artifactRepo.ListDeletedByProject(ctx, projectID, limit)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list deleted artifacts", err)
+	}
+
+	return &domain.Trash{Applications: apps, Releases: releases, Artifacts: artifacts}, nil
+}
+
+// ListProjects retrieves the most recently deleted projects the user may
+// restore: the ones they own and those of the organizations they own.
+func (s *TrashService) ListProjects(ctx context.Context, userID uuid.UUID, limit int32) ([]*domain.Project, error) {
+	projects, err := s.projectRepo.ListDeletedForUser(ctx, userID, limit)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to list deleted projects", err)
+	}
+	return projects, nil
+}
+
+// ========== Restore ==========
+
+// RestoreProject undoes the deletion of a project, along with the applications,
+// releases and artifacts that were deleted with it. Only those who could delete
+// it, its owner and the owners of its organization, can restore it, and only
+// while its organization has room for one more project.
+func (s *TrashService) RestoreProject(ctx context.Context, userID, projectID uuid.UUID) (*domain.Project, error) {
+	project, err := s.projectRepo.GetDeletedByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	if err := s.authorizeDeletedProject(ctx, project, userID); err != nil {
+		return nil, err
+	}
+
+	var restored *domain.Project
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		if err := checkProjectQuotaTx(ctx, s.orgRepo, q, project.OrganizationID); err != nil {
+			return err
+		}
+
+		var err error
+		restored, err = s.projectRepo.RestoreTx(ctx, q, projectID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				// Restored concurrently
+				return domain.ErrProjectNotFound
+			}
+			return domain.WrapError(domain.CodeInternal, "failed to restore project", err)
+		}
+
+		if err := s.appRepo.RestoreDeletedWithProjectTx(ctx, q, projectID, *project.DeletedAt); err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to restore project applications", err)
+		}
+		if err := s.releaseRepo.RestoreDeletedWithProjectTx(ctx, q, projectID, *project.DeletedAt); err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to restore project releases", err)
+		}
+		if err := s.artifactRepo.RestoreDeletedWithProjectTx(ctx, q, projectID, *project.DeletedAt); err != nil {
+			return domain.WrapError(domain.CodeInternal, "failed to restore project artifacts", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "project restored",
+		slog.String("project_id", projectID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return restored, nil
+}
+
+// RestoreApplication undoes the deletion of an application, along with the
+// releases and artifacts that were deleted with it.
+func (s *TrashService) RestoreApplication(ctx context.Context, userID, appID uuid.UUID) (*domain.Application, error) {
+	app, err := s.appRepo.GetDeletedByID(ctx, appID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.projectRepo.GetByID(ctx, app.ProjectID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionApplicationDelete); err != nil {
+		return nil, err
+	}
+
+	var restored *domain.Application
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		restored, err = s.appRepo.RestoreTx(ctx, q, appID)
+		if err != nil {
+			return err
+		}
+		if err := s.releaseRepo.RestoreDeletedWithApplicationTx(ctx, q, appID, *app.DeletedAt); err != nil {
+			return err
+		}
+		return s.artifactRepo.RestoreDeletedWithApplicationTx(ctx, q, appID, *app.DeletedAt)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Restored concurrently
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to restore application", err)
+	}
+
+	slog.InfoContext(ctx, "application restored",
+		slog.String("application_id", appID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return restored, nil
+}
+
+// RestoreRelease undoes the deletion of a release, along with the artifacts
+// that were deleted with it. Its application must not be deleted.
+func (s *TrashService) RestoreRelease(ctx context.Context, userID, releaseID uuid.UUID) (*domain.ApplicationRelease, error) {
+	release, err := s.releaseRepo.GetDeletedByID(ctx, releaseID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrReleaseNotFound
+		}
+		return nil, err
+	}
+
+	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewAppError(domain.CodeInvalidInput, "the release's application is deleted, restore it first")
+		}
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	var restored *domain.ApplicationRelease
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		restored, err = s.releaseRepo.RestoreTx(ctx, q, releaseID)
+		if err != nil {
+			return err
+		}
+		return s.artifactRepo.RestoreDeletedWithReleaseTx(ctx, q, releaseID, *release.DeletedAt)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrReleaseNotFound
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to restore release", err)
+	}
+
+	slog.InfoContext(ctx, "release restored",
+		slog.String("release_id", releaseID.String()),
+		slog.String("user_id", userID.String()),
+	)
+	return restored, nil
+}
+
+// RestoreArtifact undoes the deletion of an artifact. Its release must not be deleted.
+func (s *TrashService) RestoreArtifact(ctx context.Context, userID, artifactID uuid.UUID) (*domain.Artifact, error) {
+	artifact, err := s.artifactRepo.GetDeletedByID(ctx, artifactID)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.releaseRepo.GetByID(ctx, artifact.ReleaseID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewAppError(domain.CodeInvalidInput, "the artifact's release is deleted, restore it first")
+		}
+		return nil, err
+	}
+	app, err := s.appRepo.GetByID(ctx, release.ApplicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewAppError(domain.CodeInvalidInput, "the artifact's application is deleted, restore it first")
+		}
+		return nil, err
+	}
+	if _, err := authorizeProject(ctx, s.projectRepo, app.ProjectID, userID, domain.PermissionPackageUpload); err != nil {
+		return nil, err
+	}
+
+	var restored *domain.Artifact
+	err = s.txManager.WithTx(ctx, func(q *db.Queries) error {
+		var err error
+		restored, err = s.artifactRepo.RestoreTx(ctx, q, artifactID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		return nil, domain.WrapError(domain.CodeInternal, "failed to restore artifact", err)
+	}
+	return restored, nil
+}
+
+// ========== Purge ==========
+
+// PurgeExpired permanently removes the projects, applications, releases and
+// artifacts deleted longer than the retention window ago, children first, and
+// returns how many rows were removed. Artifact files are deleted from storage
+// once no other artifact points to them; a row that cannot be removed, such as
+// an artifact whose file cannot be deleted, is kept for the next run.
+func (s *TrashService) PurgeExpired(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-s.config.Retention)
+	total := 0
+
+	// 1. Artifacts and their files
+	for {
+		artifacts, err := s.artifactRepo.ListPurgeable(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return total, domain.WrapError(domain.CodeInternal, "failed to list purgeable artifacts", err)
+		}
+
+		purged := 0
+		for _, artifact := range artifacts {
+			if err := s.purgeArtifact(ctx, artifact); err != nil {
+				slog.WarnContext(ctx, "failed to purge artifact",
+					slog.String("artifact_id", artifact.ID.String()),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			purged++
+		}
+		total += purged
+
+		// Stop when done, or when only failing artifacts are left
+		if len(artifacts) < purgeBatchSize || purged == 0 {
+			break
+		}
+	}
+
+	// 2. Releases left without artifacts
+	n, err := s.purgeRows(ctx, "release", deletedBefore, s.releaseRepo.ListPurgeable, s.releaseRepo.HardDelete)
+	total += n
+	if err != nil {
+		return total, domain.WrapError(domain.CodeInternal, "failed to purge releases", err)
+	}
+
+	// 3. Applications left without releases
+	n, err = s.purgeRows(ctx, "application", deletedBefore, s.appRepo.ListPurgeable, s.appRepo.HardDelete)
+	total += n
+	if err != nil {
+		return total, domain.WrapError(domain.CodeInternal, "failed to purge applications", err)
+	}
+
+	// 4. Projects left without applications
+	n, err = s.purgeRows(ctx, "project", deletedBefore, s.projectRepo.ListPurgeable, s.projectRepo.HardDelete)
+	total += n
+	if err != nil {
+		return total, domain.WrapError(domain.CodeInternal, "failed to purge projects", err)
+	}
+
+	return total, nil
+}
+
+// purgeArtifact deletes an artifact's stored file, unless it is shared, then its row.
+func (s *TrashService) purgeArtifact(ctx context.Context, artifact *domain.Artifact) error {
+	others, err := s.artifactRepo.CountOthersWithFileURL(ctx, artifact.FileURL, artifact.ID)
+	if err != nil {
+		return err
+	}
+
+	if others == 0 {
+		if s.storage == nil {
+			return errors.New("storage is not configured")
+		}
+		// Files outside our bucket are not ours to delete
+		if storagePath, ok := s.storage.ExtractStoragePath(artifact.FileURL); ok {
+			if err := s.storage.Delete(ctx, storagePath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.artifactRepo.HardDelete(ctx, artifact.ID)
+}
+
+// purgeRows hard-deletes batches of rows until none are left. A row that
+// cannot be deleted is logged and skipped until the next run.
+func (s *TrashService) purgeRows(
+	ctx context.Context,
+	kind string,
+	deletedBefore time.Time,
+	list func(context.Context, time.Time, int32) ([]uuid.UUID, error),
+	hardDelete func(context.Context, uuid.UUID) error,
+) (int, error) {
+	total := 0
+	for {
+		ids, err := list(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		purged := 0
+		for _, id := range ids {
+			if err := hardDelete(ctx, id); err != nil {
+				slog.WarnContext(ctx, "failed to purge "+kind,
+					slog.String(kind+"_id", id.String()),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			purged++
+		}
+		total += purged
+
+		// Stop when done, or when only failing rows are left
+		if len(ids) < purgeBatchSize || purged == 0 {
+			return total, nil
+		}
+	}
+}
+
+// authorizeDeletedProject checks that a user could have deleted a project: its
+// owner or an owner of its organization. Others are told it does not exist.
+func (s *TrashService) authorizeDeletedProject(ctx context.Context, project *domain.Project, userID uuid.UUID) error {
+	if project.OwnerID == userID {
+		return nil
+	}
+	member, err := s.orgRepo.GetMember(ctx, project.OrganizationID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrProjectNotFound
+		}
+		return domain.WrapError(domain.CodeInternal, "failed to check organization membership", err)
+	}
+	if member.Role != domain.OrgRoleOwner {
+		return domain.ErrProjectNotFound
+	}
+	return nil
+}
+
+// ApplyRetention moves the releases their project's retention policy no
+// longer keeps to the trash, artifacts included, and returns how many were
+// moved. They stay restorable until the trash retention window passes.
+func (s *TrashService) ApplyRetention(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		ids, err := s.releaseRepo.ListPastRetention(ctx, purgeBatchSize)
+		if err != nil {
+			return total, domain.WrapError(domain.CodeInternal, "failed to list releases past retention", err)
+		}
+
+		trashed := 0
+		for _, id := range ids {
+			// Cascade to the release's artifacts, with the same deleted_at
+			err := s.txManager.WithTx(ctx, func(q *db.Queries) error {
+				if err := s.artifactRepo.SoftDeleteByReleaseTx(ctx, q, id); err != nil {
+					return err
+				}
+				return s.releaseRepo.SoftDeleteTx(ctx, q, id)
+			})
+			if err != nil {
+				slog.WarnContext(ctx, "failed to apply retention to release",
+					slog.String("release_id", id.String()),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			trashed++
+		}
+		total += trashed
+
+		// Stop when done, or when only failing releases are left
+		if len(ids) < purgeBatchSize || trashed == 0 {
+			return total, nil
+		}
+	}
+}
+
+// RunPurger calls ApplyRetention then PurgeExpired every interval until ctx is cancelled.
+func (s *TrashService) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.ApplyRetention(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "release retention failed", slog.String("error", err.Error()))
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "moved releases past retention to the trash", slog.Int("count", n))
+		}
+
+		n, err = s.PurgeExpired(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "trash purge failed", slog.String("error", err.Error()))
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "purged deleted project content", slog.Int("count", n))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsrodrigue/appshare-backend/internal/domain"
+	"github.com/bsrodrigue/appshare-backend/internal/repository"
+	"github.com/bsrodrigue/appshare-backend/internal/storage"
+)
+
+// updateDownloadURLTTL is how long the signed download link of an update stays valid.
+const updateDownloadURLTTL = 15 * time.Minute
+
+// UpdateService answers the update checks of client SDKs.
+type UpdateService struct {
+	apiKeyService  *APIKeyService
+	releaseService *ReleaseService
+	noteService    *ReleaseNoteService
+	releaseRepo    repository.ReleaseRepository
+	artifactRepo   repository.ArtifactRepository
+	channelRepo    repository.ChannelRepository
+	storage        storage.Storage
+}
+
+// NewUpdateService creates a new UpdateService.
+func NewUpdateService(
+	apiKeyService *APIKeyService,
+	releaseService *ReleaseService,
+	noteService *ReleaseNoteService,
+	releaseRepo repository.ReleaseRepository,
+	artifactRepo repository.ArtifactRepository,
+	channelRepo repository.ChannelRepository,
+	storage storage.Storage,
+) *UpdateService {
+	return &UpdateService{
+		apiKeyService:  apiKeyService,
+		releaseService: releaseService,
+		noteService:    noteService,
+		releaseRepo:    releaseRepo,
+		artifactRepo:   artifactRepo,
+		channelRepo:    channelRepo,
+		storage:        storage,
+	}
+}
+
+// Check tells a client whether a newer build than the installed one is
+// offered to it, with its notes in the client's locale, and where to download
+// the artifact matching its ABI. The
+// update is mandatory when the installed build is below the channel's minimum
+// supported version code, or when the update is or skips a mandatory release.
+func (s *UpdateService) Check(ctx context.Context, rawKey string, input domain.UpdateCheckInput) (*domain.UpdateInfo, error) {
+	app, err := s.apiKeyService.Authenticate(ctx, rawKey, input.PackageName)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := lookupChannel(ctx, s.channelRepo, app.ID, input.Environment)
+	if err != nil {
+		return nil, err
+	}
+	env := channel.Name
+	info := &domain.UpdateInfo{
+		Environment:             env,
+		MinSupportedVersionCode: channel.MinSupportedVersionCode,
+		Supported:               channel.Supports(input.VersionCode),
+	}
+
+	release, err := s.releaseService.GetLatestForClient(ctx, app.ID, env, input.DeviceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrReleaseNotFound) {
+			return info, nil
+		}
+		return nil, err
+	}
+	if release.VersionCode <= input.VersionCode {
+		return info, nil
+	}
+
+	artifact, err := s.artifactRepo.GetForABI(ctx, release.ID, input.ABI)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return info, nil
+		}
+		return nil, err
+	}
+	info.Release = release
+	info.Artifact = artifact
+	info.DownloadURL = artifact.FileURL
+
+	info.Note, err = s.noteService.Localize(ctx, release, input.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Mandatory = !info.Supported || release.Mandatory
+	if !info.Mandatory {
+		info.Mandatory, err = s.releaseRepo.HasMandatoryBetween(ctx, app.ID, env, input.VersionCode, release.VersionCode)
+		if err != nil {
+			return nil, domain.WrapError(domain.CodeInternal, "failed to check mandatory releases", err)
+		}
+	}
+
+	if s.storage != nil {
+		if storagePath, isOurs := s.storage.ExtractStoragePath(artifact.FileURL); isOurs {
+			url, err := s.storage.GenerateDownloadURL(ctx, storagePath, updateDownloadURLTTL)
+			if err != nil {
+				return nil, domain.WrapError(domain.CodeInternal, "failed to sign download URL", err)
+			}
+			expiresAt := time.Now().Add(updateDownloadURLTTL)
+			info.DownloadURL = url
+			info.DownloadExpiresAt = &expiresAt
+		}
+	}
+
+	return info, nil
+}
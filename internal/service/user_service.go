@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/bsrodrigue/appshare-backend/internal/auth"
 	"github.com/bsrodrigue/appshare-backend/internal/domain"
 	"github.com/bsrodrigue/appshare-backend/internal/repository"
 	"github.com/google/uuid"
@@ -11,17 +12,22 @@ import (
 
 // UserService handles user-related business logic.
 type UserService struct {
-	repo repository.UserRepository
+	repo   repository.UserRepository
+	policy *auth.PasswordPolicy
 }
 
 // NewUserService creates a new UserService.
-func NewUserService(repo repository.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo repository.UserRepository, policy *auth.PasswordPolicy) *UserService {
+	return &UserService{repo: repo, policy: policy}
 }
 
 // Create creates a new user with the given input.
 // It validates uniqueness and hashes the password.
 func (s *UserService) Create(ctx context.Context, input domain.CreateUserInput) (*domain.User, error) {
+	if err := validatePassword(s.policy, "password", input.Password, input.Username, input.Email); err != nil {
+		return nil, err
+	}
+
 	// Check email uniqueness
 	exists, err := s.repo.EmailExists(ctx, input.Email)
 	if err != nil {
@@ -65,9 +71,9 @@ func (s *UserService) GetByEmail(ctx context.Context, email string) (*domain.Use
 	return s.repo.GetByEmail(ctx, email)
 }
 
-// List retrieves all active users.
-func (s *UserService) List(ctx context.Context) ([]*domain.User, error) {
-	return s.repo.List(ctx)
+// List retrieves a page of users.
+func (s *UserService) List(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) (*domain.Page[*domain.User], error) {
+	return s.repo.List(ctx, filter, page)
 }
 
 // UpdateEmail updates a user's email after checking uniqueness.
@@ -86,6 +92,14 @@ func (s *UserService) UpdateEmail(ctx context.Context, id uuid.UUID, email strin
 
 // UpdateUsername updates a user's username after checking uniqueness.
 func (s *UserService) UpdateUsername(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Username == username {
+		return user, nil
+	}
+
 	exists, err := s.repo.UsernameExists(ctx, username)
 	if err != nil {
 		return nil, domain.WrapError(domain.CodeInternal, "failed to check username", err)
@@ -97,8 +111,37 @@ func (s *UserService) UpdateUsername(ctx context.Context, id uuid.UUID, username
 	return s.repo.UpdateUsername(ctx, id, username)
 }
 
+// UpdatePhoneNumber updates a user's phone number after checking uniqueness.
+func (s *UserService) UpdatePhoneNumber(ctx context.Context, id uuid.UUID, phoneNumber string) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.PhoneNumber == phoneNumber {
+		return user, nil
+	}
+
+	exists, err := s.repo.PhoneNumberExists(ctx, phoneNumber)
+	if err != nil {
+		return nil, domain.WrapError(domain.CodeInternal, "failed to check phone number", err)
+	}
+	if exists {
+		return nil, domain.ErrPhoneAlreadyExists
+	}
+
+	return s.repo.UpdatePhoneNumber(ctx, id, phoneNumber)
+}
+
 // UpdatePassword updates a user's password.
 func (s *UserService) UpdatePassword(ctx context.Context, id uuid.UUID, newPassword string) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := validatePassword(s.policy, "new_password", newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return domain.WrapError(domain.CodeInternal, "failed to hash password", err)
@@ -111,7 +154,10 @@ func (s *UserService) UpdateProfile(ctx context.Context, id uuid.UUID, firstName
 	return s.repo.UpdateProfile(ctx, id, firstName, lastName)
 }
 
-// Delete soft-deletes a user.
-func (s *UserService) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.repo.SoftDelete(ctx, id)
+// validatePassword applies the password policy, reporting violations on the given field.
+func validatePassword(policy *auth.PasswordPolicy, field, password string, personal ...string) error {
+	if err := policy.Validate(password, personal...); err != nil {
+		return domain.NewValidationError(field, err.Error())
+	}
+	return nil
 }
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryURLPrefix is the scheme of the URLs handed out by MemoryStorage.
+const memoryURLPrefix = "memory://"
+
+// MemoryStorage implements the Storage interface in memory.
+// This is useful for testing services that store files.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+// NewMemoryStorage creates a new empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{objects: make(map[string][]byte)}
+}
+
+// GenerateUploadURL returns a fake URL for the path.
+func (s *MemoryStorage) GenerateUploadURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	return memoryURLPrefix + path, nil
+}
+
+// GenerateDownloadURL returns a fake URL for the path.
+func (s *MemoryStorage) GenerateDownloadURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	return memoryURLPrefix + path, nil
+}
+
+// Upload stores the file contents under the path.
+func (s *MemoryStorage) Upload(ctx context.Context, path string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to upload object %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[path] = data
+	return nil
+}
+
+// Delete removes the file at the path, if any.
+func (s *MemoryStorage) Delete(ctx context.Context, path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, path)
+	return nil
+}
+
+// DeletePrefix removes every file under the prefix.
+func (s *MemoryStorage) DeletePrefix(ctx context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for path := range s.objects {
+		if strings.HasPrefix(path, prefix) {
+			delete(s.objects, path)
+		}
+	}
+	return nil
+}
+
+// GetPublicURL returns a fake URL for the path.
+func (s *MemoryStorage) GetPublicURL(path string) string {
+	return memoryURLPrefix + path
+}
+
+// Download returns the contents of the file at the path.
+func (s *MemoryStorage) Download(ctx context.Context, path string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.objects[path]
+	if !ok {
+		return nil, fmt.Errorf("failed to download object %s: not found", path)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// ExtractStoragePath extracts the path from a URL handed out by this storage.
+func (s *MemoryStorage) ExtractStoragePath(url string) (string, bool) {
+	path, ok := strings.CutPrefix(url, memoryURLPrefix)
+	return path, ok
+}
+
+// Paths returns the paths of every stored file, sorted.
+func (s *MemoryStorage) Paths() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	paths := make([]string, 0, len(s.objects))
+	for path := range s.objects {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
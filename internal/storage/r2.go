@@ -12,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 // R2Storage implements the Storage interface using Cloudflare R2 (S3-compatible).
@@ -63,6 +64,34 @@ func (s *R2Storage) GenerateUploadURL(ctx context.Context, path string, expires
 	return request.URL, nil
 }
 
+// GenerateDownloadURL generates a signed URL for downloading a file via GET.
+func (s *R2Storage) GenerateDownloadURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	request, err := s.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(path),
+	}, s3.WithPresignExpires(expires))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signed URL: %w", err)
+	}
+
+	return request.URL, nil
+}
+
+// Upload writes a file to the bucket.
+func (s *R2Storage) Upload(ctx context.Context, path string, body io.Reader, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(path),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object %s: %w", path, err)
+	}
+	return nil
+}
+
 // Delete removes a file from the bucket.
 func (s *R2Storage) Delete(ctx context.Context, path string) error {
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -75,6 +104,39 @@ func (s *R2Storage) Delete(ctx context.Context, path string) error {
 	return nil
 }
 
+// DeletePrefix removes every object under a prefix, a page of keys at a time.
+func (s *R2Storage) DeletePrefix(ctx context.Context, prefix string) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucketName),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+		if len(page.Contents) == 0 {
+			continue
+		}
+
+		objects := make([]types.ObjectIdentifier, len(page.Contents))
+		for i, obj := range page.Contents {
+			objects[i] = types.ObjectIdentifier{Key: obj.Key}
+		}
+		output, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucketName),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete objects under %s: %w", prefix, err)
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("failed to delete object %s: %s", aws.ToString(output.Errors[0].Key), aws.ToString(output.Errors[0].Message))
+		}
+	}
+	return nil
+}
+
 // GetPublicURL returns the public URL of the object.
 // If publicDomain is provided, it uses that. Otherwise, it returns the standard R2 dev domain if enabled,
 // though usually R2 buckets are not public by default.
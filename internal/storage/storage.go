@@ -12,9 +12,18 @@ type Storage interface {
 	// This usually returns a PUT URL that the client can use to upload the file directly.
 	GenerateUploadURL(ctx context.Context, path string, expires time.Duration) (string, error)
 
+	// GenerateDownloadURL generates a signed URL for downloading a private file via GET.
+	GenerateDownloadURL(ctx context.Context, path string, expires time.Duration) (string, error)
+
+	// Upload writes a file to the given path from the server side.
+	Upload(ctx context.Context, path string, body io.Reader, contentType string) error
+
 	// Delete deletes a file from the given path.
 	Delete(ctx context.Context, path string) error
 
+	// DeletePrefix deletes every file whose path starts with the given prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+
 	// GetPublicURL returns the public URL for a file at the given path.
 	GetPublicURL(path string) string
 
@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- Incremented whenever all sessions of a user must be revoked (e.g. password reset).
+-- Embedded in issued JWTs and compared on refresh.
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE password_reset_tokens (
+    -- Identification
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 hex digest, the raw token is never stored
+
+    -- Relations
+    user_id UUID NOT NULL,
+
+    -- Timestamps
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id) WHERE used_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+DROP TABLE IF EXISTS password_reset_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
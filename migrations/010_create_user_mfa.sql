@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE user_mfa (
+    -- Identification
+    user_id UUID PRIMARY KEY,
+
+    -- Credentials
+    secret VARCHAR(64) NOT NULL, -- base32 TOTP secret
+
+    -- Information
+    enabled_at TIMESTAMP, -- NULL while enrollment is pending confirmation
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- last accepted TOTP time step, prevents replay
+
+    -- Timestamps
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE TABLE user_recovery_codes (
+    -- Identification
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code_hash VARCHAR(64) NOT NULL, -- SHA-256 hex digest of the normalized code
+
+    -- Relations
+    user_id UUID NOT NULL,
+
+    -- Timestamps
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE,
+
+    -- Constraints
+    CONSTRAINT unique_user_recovery_code UNIQUE (user_id, code_hash)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
@@ -0,0 +1,55 @@
+-- +goose Up
+
+-- Accounts provisioned through single sign-on have no phone number
+ALTER TABLE users ALTER COLUMN phone_number DROP NOT NULL;
+
+-- The address the user proved to own (password reset, email change, identity
+-- provider). The email is verified while it matches; only then may an identity
+-- with the same email be linked without the account's password.
+ALTER TABLE users ADD COLUMN verified_email VARCHAR(255);
+
+-- External (OIDC) identities linked to local users
+CREATE TABLE user_identities (
+    -- Identification
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL, -- "sub" claim, stable per provider
+
+    -- Information
+    email VARCHAR(255) NOT NULL, -- email at the provider when last seen
+
+    -- Relations
+    user_id UUID NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE,
+
+    -- Constraints
+    CONSTRAINT unique_user_identity UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- Pending authorization requests (state, nonce and PKCE verifier), single use
+CREATE TABLE oauth_states (
+    state VARCHAR(64) PRIMARY KEY,
+    provider VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS oauth_states;
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP TABLE IF EXISTS user_identities;
+ALTER TABLE users DROP COLUMN IF EXISTS verified_email;
+-- Fails if SSO-provisioned users without phone numbers remain
+ALTER TABLE users ALTER COLUMN phone_number SET NOT NULL;
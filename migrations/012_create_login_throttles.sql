@@ -0,0 +1,33 @@
+-- +goose Up
+
+-- Audit trail of login attempts, used to investigate and unlock accounts
+CREATE TABLE login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    identifier VARCHAR(255) NOT NULL, -- email or username as typed, lower-cased
+    ip_address VARCHAR(45) NOT NULL,
+    user_id UUID, -- set when the identifier matched an account
+    succeeded BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_login_attempts_identifier ON login_attempts(identifier, created_at DESC);
+CREATE INDEX idx_login_attempts_ip_address ON login_attempts(ip_address, created_at DESC);
+
+-- Consecutive failure counters and lockouts, keyed by "identifier:<value>" or "ip:<value>"
+CREATE TABLE login_throttles (
+    key VARCHAR(300) PRIMARY KEY,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS login_throttles;
+DROP INDEX IF EXISTS idx_login_attempts_ip_address;
+DROP INDEX IF EXISTS idx_login_attempts_identifier;
+DROP TABLE IF EXISTS login_attempts;
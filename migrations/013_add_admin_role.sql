@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- Global administrators manage every account.
+-- Grant the first one manually: UPDATE users SET is_admin = TRUE WHERE email = '...';
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Audit trail of administrative actions
+CREATE TABLE admin_audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID, -- administrator who acted
+    action VARCHAR(64) NOT NULL,
+    target_user_id UUID,
+    details JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(45) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys (entries outlive the accounts they mention)
+    FOREIGN KEY(actor_id)
+    REFERENCES users(id)
+    ON DELETE SET NULL,
+
+    FOREIGN KEY(target_user_id)
+    REFERENCES users(id)
+    ON DELETE SET NULL
+);
+
+CREATE INDEX idx_admin_audit_logs_created_at ON admin_audit_logs(created_at DESC);
+CREATE INDEX idx_admin_audit_logs_target_user_id ON admin_audit_logs(target_user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_admin_audit_logs_target_user_id;
+DROP INDEX IF EXISTS idx_admin_audit_logs_created_at;
+DROP TABLE IF EXISTS admin_audit_logs;
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
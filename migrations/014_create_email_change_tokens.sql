@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Pending email address changes, applied once the new address is confirmed.
+CREATE TABLE email_change_tokens (
+    -- Identification
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 hex digest, the raw token is never stored
+
+    -- Relations
+    user_id UUID NOT NULL,
+
+    -- Requested change
+    new_email VARCHAR(255) NOT NULL,
+
+    -- Timestamps
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_change_tokens_user_id ON email_change_tokens(user_id) WHERE used_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_email_change_tokens_user_id;
+DROP TABLE IF EXISTS email_change_tokens;
@@ -0,0 +1,41 @@
+-- +goose Up
+
+-- Deleted accounts stay restorable (deleted_at set) for a grace period, then
+-- their personal data is overwritten so email, username and phone number can be reused.
+ALTER TABLE users ADD COLUMN anonymized_at TIMESTAMP;
+
+-- Files under exports/{id}/ and users/{id}/ are deleted right after anonymisation;
+-- until that succeeds the anonymiser keeps retrying.
+ALTER TABLE users ADD COLUMN storage_purged_at TIMESTAMP;
+
+CREATE INDEX idx_users_pending_anonymization ON users(deleted_at) WHERE deleted_at IS NOT NULL AND anonymized_at IS NULL;
+CREATE INDEX idx_users_pending_storage_purge ON users(anonymized_at) WHERE anonymized_at IS NOT NULL AND storage_purged_at IS NULL;
+
+CREATE TABLE account_restore_tokens (
+    -- Identification
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 hex digest, the raw token is never stored
+
+    -- Relations
+    user_id UUID NOT NULL,
+
+    -- Timestamps
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Foreign Keys
+    FOREIGN KEY(user_id)
+    REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_account_restore_tokens_user_id ON account_restore_tokens(user_id) WHERE used_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_account_restore_tokens_user_id;
+DROP TABLE IF EXISTS account_restore_tokens;
+DROP INDEX IF EXISTS idx_users_pending_storage_purge;
+DROP INDEX IF EXISTS idx_users_pending_anonymization;
+ALTER TABLE users DROP COLUMN IF EXISTS storage_purged_at;
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
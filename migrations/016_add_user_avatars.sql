@@ -0,0 +1,7 @@
+-- +goose Up
+
+-- Public URL of the largest avatar rendition; NULL falls back to an initials placeholder.
+ALTER TABLE users ADD COLUMN avatar_url VARCHAR(1024);
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Project settings document (release policy, artifact limits, retention, sharing).
+-- Keys missing from the document fall back to the application defaults.
+ALTER TABLE projects ADD COLUMN settings JSONB NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE projects DROP COLUMN IF EXISTS settings;
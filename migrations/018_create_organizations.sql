@@ -0,0 +1,99 @@
+-- +goose Up
+
+-- Organizations own projects so they outlive the people who created them.
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(256) NOT NULL,
+
+    -- Set on a user's personal organization, at most one per user
+    personal_owner_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Quotas, independent of any billing plan
+    max_projects INTEGER NOT NULL DEFAULT 50,
+    max_members INTEGER NOT NULL DEFAULT 50,
+    max_applications INTEGER NOT NULL DEFAULT 200,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    role VARCHAR(32) NOT NULL, -- 'owner', 'admin' or 'member'
+
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+ALTER TABLE projects ADD COLUMN organization_id UUID REFERENCES organizations(id);
+
+-- Move existing projects into a personal organization of their owner
+INSERT INTO organizations (name, personal_owner_id)
+SELECT u.username, u.id
+FROM users u
+WHERE EXISTS (SELECT 1 FROM projects p WHERE p.owner_id = u.id);
+
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT id, personal_owner_id, 'owner'
+FROM organizations
+WHERE personal_owner_id IS NOT NULL;
+
+UPDATE projects p SET organization_id = o.id
+FROM organizations o
+WHERE o.personal_owner_id = p.owner_id;
+
+ALTER TABLE projects ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX idx_projects_organization_id ON projects(organization_id) WHERE deleted_at IS NULL;
+
+-- Project settings and membership management
+INSERT INTO permissions (key, description) VALUES
+    ('project.manage', 'Edit project details and settings')
+ON CONFLICT (key) DO NOTHING;
+
+-- Effective access of users to projects, one row per granted permission.
+-- Project owners and organization owners/admins hold every permission,
+-- organization members can read, and project members hold their own grants.
+-- rank orders the roles: the lowest rank is the user's effective role.
+CREATE VIEW project_access AS
+    SELECT p.id AS project_id, p.owner_id AS user_id, 'owner'::text AS role, 1 AS rank, perm.key AS permission
+    FROM projects p
+    CROSS JOIN permissions perm
+    WHERE p.deleted_at IS NULL
+UNION ALL
+    SELECT p.id, om.user_id, om.role::text,
+        CASE om.role WHEN 'owner' THEN 1 ELSE 2 END,
+        perm.key
+    FROM projects p
+    JOIN organization_members om ON om.organization_id = p.organization_id AND om.role IN ('owner', 'admin')
+    CROSS JOIN permissions perm
+    WHERE p.deleted_at IS NULL
+UNION ALL
+    SELECT p.id, om.user_id, 'member', 3, perm.key
+    FROM projects p
+    JOIN organization_members om ON om.organization_id = p.organization_id AND om.role = 'member'
+    JOIN permissions perm ON perm.key IN ('package.download', 'notification.list', 'notification.view')
+    WHERE p.deleted_at IS NULL
+UNION ALL
+    SELECT m.project_id, m.user_id, m.role::text,
+        CASE m.role WHEN 'owner' THEN 1 WHEN 'admin' THEN 2 ELSE 3 END,
+        perm.key
+    FROM project_memberships m
+    JOIN projects p ON p.id = m.project_id AND p.deleted_at IS NULL
+    LEFT JOIN membership_permissions mp ON mp.membership_id = m.id
+    LEFT JOIN permissions perm ON perm.id = mp.permission_id
+    WHERE m.deleted_at IS NULL;
+
+-- +goose Down
+DROP VIEW IF EXISTS project_access;
+DELETE FROM permissions WHERE key = 'project.manage';
+DROP INDEX IF EXISTS idx_projects_organization_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
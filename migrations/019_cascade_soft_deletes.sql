@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Deletes now cascade from projects to applications, releases and artifacts.
+-- Bring rows left live under an already deleted parent in line, giving them
+-- the parent's deleted_at so that restoring the parent restores them too.
+UPDATE applications a SET deleted_at = p.deleted_at
+FROM projects p
+WHERE p.id = a.project_id AND p.deleted_at IS NOT NULL AND a.deleted_at IS NULL;
+
+UPDATE application_releases r SET deleted_at = a.deleted_at
+FROM applications a
+WHERE a.id = r.application_id AND a.deleted_at IS NOT NULL AND r.deleted_at IS NULL;
+
+UPDATE artifacts ar SET deleted_at = r.deleted_at
+FROM application_releases r
+WHERE r.id = ar.release_id AND r.deleted_at IS NOT NULL AND ar.deleted_at IS NULL;
+
+-- +goose Down
+-- Data only; cascaded rows cannot be told apart from rows deleted on their own.
@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- Promoting a release copies it, artifacts included, into the target environment
+-- so that a build can live in several environments at once. Each promotion is
+-- recorded here; version fields are copied so the history survives purges.
+CREATE TABLE release_promotions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+
+    source_release_id UUID REFERENCES application_releases(id) ON DELETE SET NULL,
+    release_id UUID REFERENCES application_releases(id) ON DELETE SET NULL, -- The copy
+    version_code INTEGER NOT NULL,
+    version_name VARCHAR(256) NOT NULL,
+    from_environment release_environment NOT NULL,
+    to_environment release_environment NOT NULL,
+
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_release_promotions_application ON release_promotions(application_id, created_at DESC);
+CREATE INDEX idx_release_promotions_source ON release_promotions(source_release_id);
+CREATE INDEX idx_release_promotions_release ON release_promotions(release_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS release_promotions;
@@ -0,0 +1,46 @@
+-- +goose Up
+
+-- 'failed': the last approval came in but the promotion could no longer be made
+CREATE TYPE promotion_request_status AS ENUM ('pending', 'approved', 'rejected', 'cancelled', 'failed');
+
+-- Promotions into gated pipeline stages wait here for approvals. The stage's
+-- gate is copied on creation so later pipeline changes don't affect it.
+CREATE TABLE promotion_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    release_id UUID NOT NULL REFERENCES application_releases(id) ON DELETE CASCADE,
+    from_environment release_environment NOT NULL,
+    to_environment release_environment NOT NULL,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+
+    status promotion_request_status NOT NULL DEFAULT 'pending',
+    required_approvals INTEGER NOT NULL,
+    approver_roles TEXT[] NOT NULL DEFAULT '{}', -- Empty allows any project role
+    promoted_release_id UUID REFERENCES application_releases(id) ON DELETE SET NULL,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one open request per release and target environment
+CREATE UNIQUE INDEX idx_promotion_requests_pending ON promotion_requests(release_id, to_environment) WHERE status = 'pending';
+CREATE INDEX idx_promotion_requests_application ON promotion_requests(application_id, created_at DESC);
+
+CREATE TABLE promotion_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    request_id UUID NOT NULL REFERENCES promotion_requests(id) ON DELETE CASCADE,
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved BOOLEAN NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_promotion_review UNIQUE (request_id, reviewer_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS promotion_reviews;
+DROP TABLE IF EXISTS promotion_requests;
+DROP TYPE IF EXISTS promotion_request_status;
@@ -0,0 +1,60 @@
+-- +goose Up
+
+-- Release channels replace the fixed release_environment enum: each application
+-- defines its own channels (e.g. beta, internal-qa, customer-acme). Columns keep
+-- their "environment" names; they now hold a channel name.
+CREATE TABLE release_channels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    name VARCHAR(40) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0, -- Display order, lowest first
+    visibility VARCHAR(16) NOT NULL DEFAULT 'listed' CHECK (visibility IN ('listed', 'unlisted')),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE, -- Channel of uploads that name none
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_release_channel UNIQUE (application_id, name)
+);
+
+-- At most one default channel per application
+CREATE UNIQUE INDEX idx_release_channels_default ON release_channels(application_id) WHERE is_default;
+
+-- Existing applications get the former enum values, uploads defaulting to the
+-- project's default environment.
+INSERT INTO release_channels (application_id, name, position, is_default)
+SELECT a.id, c.name, c.position, c.name = COALESCE(p.settings->>'default_environment', 'development')
+FROM applications a
+JOIN projects p ON p.id = a.project_id
+CROSS JOIN (VALUES ('development', 0), ('staging', 1), ('production', 2)) AS c(name, position);
+
+ALTER TABLE application_releases ALTER COLUMN environment DROP DEFAULT;
+ALTER TABLE application_releases ALTER COLUMN environment TYPE VARCHAR(40) USING environment::text;
+ALTER TABLE release_promotions
+    ALTER COLUMN from_environment TYPE VARCHAR(40) USING from_environment::text,
+    ALTER COLUMN to_environment TYPE VARCHAR(40) USING to_environment::text;
+ALTER TABLE promotion_requests
+    ALTER COLUMN from_environment TYPE VARCHAR(40) USING from_environment::text,
+    ALTER COLUMN to_environment TYPE VARCHAR(40) USING to_environment::text;
+DROP TYPE release_environment;
+
+-- Releases can only live in a channel of their application. The promotion
+-- history keeps plain names so it survives channel removal.
+ALTER TABLE application_releases ADD CONSTRAINT fk_release_channel
+    FOREIGN KEY (application_id, environment) REFERENCES release_channels(application_id, name);
+
+-- +goose Down
+-- Fails while releases live in channels other than the former enum values.
+ALTER TABLE application_releases DROP CONSTRAINT IF EXISTS fk_release_channel;
+
+CREATE TYPE release_environment AS ENUM ('development', 'staging', 'production');
+ALTER TABLE promotion_requests
+    ALTER COLUMN from_environment TYPE release_environment USING from_environment::release_environment,
+    ALTER COLUMN to_environment TYPE release_environment USING to_environment::release_environment;
+ALTER TABLE release_promotions
+    ALTER COLUMN from_environment TYPE release_environment USING from_environment::release_environment,
+    ALTER COLUMN to_environment TYPE release_environment USING to_environment::release_environment;
+ALTER TABLE application_releases ALTER COLUMN environment TYPE release_environment USING environment::release_environment;
+ALTER TABLE application_releases ALTER COLUMN environment SET DEFAULT 'development';
+
+DROP TABLE IF EXISTS release_channels;
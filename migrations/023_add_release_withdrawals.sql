@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Rolling back a channel withdraws its latest release: the build is kept, but
+-- the previous release becomes the channel's latest again.
+ALTER TABLE application_releases
+    ADD COLUMN withdrawn_at TIMESTAMP,
+    ADD COLUMN withdrawn_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN withdrawal_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_releases_latest ON application_releases(application_id, environment, version_code DESC)
+    WHERE deleted_at IS NULL AND withdrawn_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_releases_latest;
+ALTER TABLE application_releases
+    DROP COLUMN IF EXISTS withdrawal_reason,
+    DROP COLUMN IF EXISTS withdrawn_by,
+    DROP COLUMN IF EXISTS withdrawn_at;
@@ -0,0 +1,32 @@
+-- +goose Up
+
+-- Releases move through draft -> published -> withdrawn / archived. Drafts are
+-- only visible to uploaders and are published once an artifact is verified.
+CREATE TYPE release_status AS ENUM ('draft', 'published', 'withdrawn', 'archived');
+
+ALTER TABLE application_releases
+    ADD COLUMN status release_status NOT NULL DEFAULT 'draft',
+    ADD COLUMN published_at TIMESTAMP;
+
+-- Existing releases were visible as soon as they were created
+UPDATE application_releases SET
+    status = CASE WHEN withdrawn_at IS NOT NULL THEN 'withdrawn' ELSE 'published' END::release_status,
+    published_at = created_at;
+
+-- Set once the server has hashed the stored file and found it matches the record
+ALTER TABLE artifacts ADD COLUMN verified_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_releases_latest;
+CREATE INDEX idx_releases_latest ON application_releases(application_id, environment, version_code DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_releases_latest;
+CREATE INDEX idx_releases_latest ON application_releases(application_id, environment, version_code DESC)
+    WHERE deleted_at IS NULL AND withdrawn_at IS NULL;
+
+ALTER TABLE artifacts DROP COLUMN IF EXISTS verified_at;
+ALTER TABLE application_releases
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status;
+DROP TYPE IF EXISTS release_status;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- Staged rollouts: a published release is only offered to the share of clients
+-- whose bucket, derived from their user or device ID, falls under its percentage.
+-- Other clients keep getting the previous release of the channel.
+ALTER TABLE application_releases
+    ADD COLUMN rollout_percentage INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN rollout_halted_at TIMESTAMP, -- Halted rollouts are offered to no one
+    ADD CONSTRAINT check_rollout_percentage CHECK (rollout_percentage BETWEEN 1 AND 100);
+
+-- Promotions waiting for approval start their rollout once approved
+ALTER TABLE promotion_requests
+    ADD COLUMN rollout_percentage INTEGER NOT NULL DEFAULT 100,
+    ADD CONSTRAINT check_rollout_percentage CHECK (rollout_percentage BETWEEN 1 AND 100);
+
+-- +goose Down
+ALTER TABLE promotion_requests
+    DROP CONSTRAINT IF EXISTS check_rollout_percentage,
+    DROP COLUMN IF EXISTS rollout_percentage;
+ALTER TABLE application_releases
+    DROP CONSTRAINT IF EXISTS check_rollout_percentage,
+    DROP COLUMN IF EXISTS rollout_halted_at,
+    DROP COLUMN IF EXISTS rollout_percentage;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- API keys let client SDKs shipped inside an application check for updates
+-- without a user account. Keys are scoped to one application and read-only;
+-- only their hash is stored, the raw key is shown once on creation.
+CREATE TABLE application_api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL, -- Start of the raw key, to tell keys apart
+    key_hash TEXT NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_application_api_keys_application ON application_api_keys(application_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS application_api_keys;
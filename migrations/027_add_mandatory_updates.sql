@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Clients offered a mandatory release, or running a build below their channel's
+-- minimum supported version code, are told they must update.
+ALTER TABLE application_releases
+    ADD COLUMN mandatory BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 0 supports every build. The minimum cannot exceed the newest release of the
+-- channel offered to every client, so unsupported clients always have an update.
+ALTER TABLE release_channels
+    ADD COLUMN min_supported_version_code INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT check_min_supported_version_code CHECK (min_supported_version_code >= 0);
+
+-- +goose Down
+ALTER TABLE release_channels
+    DROP CONSTRAINT IF EXISTS check_min_supported_version_code,
+    DROP COLUMN IF EXISTS min_supported_version_code;
+ALTER TABLE application_releases
+    DROP COLUMN IF EXISTS mandatory;
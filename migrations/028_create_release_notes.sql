@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- Translations of release notes, in Markdown. The release_note column of a
+-- release stays its default note, shown when no translation matches the
+-- reader's locale.
+CREATE TABLE release_notes (
+    release_id UUID NOT NULL REFERENCES application_releases(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL, -- BCP 47 tag, e.g. fr or pt-BR
+    note TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (release_id, locale)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS release_notes;